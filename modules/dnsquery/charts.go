@@ -12,12 +12,17 @@ import (
 const (
 	prioDNSQueryStatus = module.Priority + iota
 	prioDNSQueryTime
+	prioDNSQueryRcode
+	prioDNSQueryDNSSECAuthenticated
+	prioDNSQueryDNSSECSignatureExpiry
+	prioDNSQueryExpectations
 )
 
 var (
 	dnsChartsTmpl = module.Charts{
 		dnsQueryStatusChartTmpl.Copy(),
 		dnsQueryTimeChartTmpl.Copy(),
+		dnsQueryRcodeChartTmpl.Copy(),
 	}
 	dnsQueryStatusChartTmpl = module.Chart{
 		ID:       "server_%s_record_%s_query_status",
@@ -45,20 +50,98 @@ var (
 	}
 )
 
-func newDNSServerCharts(server, network, rtype string) *module.Charts {
-	charts := dnsChartsTmpl.Copy()
+var dnsQueryRcodeChartTmpl = module.Chart{
+	ID:       "server_%s_record_%s_query_rcode",
+	Title:    "DNS Query Response Code",
+	Units:    "status",
+	Fam:      "query status",
+	Ctx:      "dns_query.query_rcode",
+	Priority: prioDNSQueryRcode,
+	Dims: module.Dims{
+		{ID: "server_%s_record_%s_rcode_noerror", Name: "NOERROR"},
+		{ID: "server_%s_record_%s_rcode_formerr", Name: "FORMERR"},
+		{ID: "server_%s_record_%s_rcode_servfail", Name: "SERVFAIL"},
+		{ID: "server_%s_record_%s_rcode_nxdomain", Name: "NXDOMAIN"},
+		{ID: "server_%s_record_%s_rcode_notimp", Name: "NOTIMP"},
+		{ID: "server_%s_record_%s_rcode_refused", Name: "REFUSED"},
+		{ID: "server_%s_record_%s_rcode_other", Name: "other"},
+	},
+}
 
+var (
+	dnssecChartsTmpl = module.Charts{
+		dnsQueryDNSSECAuthenticatedChartTmpl.Copy(),
+		dnsQueryDNSSECSignatureExpiryChartTmpl.Copy(),
+	}
+	dnsQueryDNSSECAuthenticatedChartTmpl = module.Chart{
+		ID:       "server_%s_record_%s_dnssec_authenticated",
+		Title:    "DNS Query DNSSEC Authenticated Data",
+		Units:    "boolean",
+		Fam:      "dnssec",
+		Ctx:      "dns_query.dnssec_authenticated",
+		Priority: prioDNSQueryDNSSECAuthenticated,
+		Dims: module.Dims{
+			{ID: "server_%s_record_%s_dnssec_authenticated", Name: "authenticated"},
+		},
+	}
+	dnsQueryDNSSECSignatureExpiryChartTmpl = module.Chart{
+		ID:       "server_%s_record_%s_dnssec_signature_expiry",
+		Title:    "DNS Query DNSSEC Signature Time Until Expiration",
+		Units:    "seconds",
+		Fam:      "dnssec",
+		Ctx:      "dns_query.dnssec_signature_expiry",
+		Priority: prioDNSQueryDNSSECSignatureExpiry,
+		Dims: module.Dims{
+			{ID: "server_%s_record_%s_dnssec_signature_expiry", Name: "expiry"},
+		},
+	}
+)
+
+var dnsQueryExpectationsChartTmpl = module.Chart{
+	ID:       "server_%s_record_%s_expectations",
+	Title:    "DNS Query Failed Answer Expectations",
+	Units:    "boolean",
+	Fam:      "answer",
+	Ctx:      "dns_query.expectations",
+	Priority: prioDNSQueryExpectations,
+	Dims: module.Dims{
+		{ID: "server_%s_record_%s_expect_answers_failed", Name: "answers"},
+		{ID: "server_%s_record_%s_expect_min_ttl_failed", Name: "min_ttl"},
+	},
+}
+
+func newDNSSECCharts(server, network, rtype string) *module.Charts {
+	charts := dnssecChartsTmpl.Copy()
 	for _, chart := range *charts {
-		chart.ID = fmt.Sprintf(chart.ID, strings.ReplaceAll(server, ".", "_"), rtype)
-		chart.Labels = []module.Label{
-			{Key: "server", Value: server},
-			{Key: "network", Value: network},
-			{Key: "record_type", Value: rtype},
-		}
-		for _, d := range chart.Dims {
-			d.ID = fmt.Sprintf(d.ID, server, rtype)
-		}
+		formatChart(chart, server, network, rtype)
 	}
+	return charts
+}
 
+func newExpectationsChart(server, network, rtype string) *module.Chart {
+	chart := dnsQueryExpectationsChartTmpl.Copy()
+	formatChart(chart, server, network, rtype)
+	return chart
+}
+
+func newDNSServerCharts(server, network, rtype string) *module.Charts {
+	charts := dnsChartsTmpl.Copy()
+	for _, chart := range *charts {
+		formatChart(chart, server, network, rtype)
+	}
 	return charts
 }
+
+func formatChart(chart *module.Chart, server, network, rtype string) {
+	chart.ID = fmt.Sprintf(chart.ID, cleanChartID.Replace(server), rtype)
+	chart.Labels = []module.Label{
+		{Key: "server", Value: server},
+		{Key: "network", Value: network},
+		{Key: "record_type", Value: rtype},
+	}
+	for _, d := range chart.Dims {
+		d.ID = fmt.Sprintf(d.ID, server, rtype)
+	}
+}
+
+var cleanChartID = strings.NewReplacer(".", "_", ":", "_", "/", "_")
@@ -4,17 +4,25 @@ package dnsquery
 
 import (
 	"math/rand"
-	"net"
-	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/miekg/dns"
 )
 
+var rcodes = map[int]string{
+	dns.RcodeSuccess:        "noerror",
+	dns.RcodeFormatError:    "formerr",
+	dns.RcodeServerFailure:  "servfail",
+	dns.RcodeNameError:      "nxdomain",
+	dns.RcodeNotImplemented: "notimp",
+	dns.RcodeRefused:        "refused",
+}
+
 func (d *DNSQuery) collect() (map[string]int64, error) {
 	if d.dnsClient == nil {
-		d.dnsClient = d.newDNSClient(d.Network, d.Timeout.Duration)
+		d.dnsClient = d.newDNSClient(d.Network, d.Timeout.Duration, d.tlsConfig)
 	}
 
 	mx := make(map[string]int64)
@@ -29,41 +37,181 @@ func (d *DNSQuery) collect() (map[string]int64, error) {
 			go func(srv, rtypeName string, rtype uint16, wg *sync.WaitGroup) {
 				defer wg.Done()
 
-				msg := new(dns.Msg)
-				msg.SetQuestion(dns.Fqdn(domain), rtype)
-				address := net.JoinHostPort(srv, strconv.Itoa(d.Port))
+				px := "server_" + srv + "_record_" + rtypeName + "_"
+				qmx := make(map[string]int64)
 
-				resp, rtt, err := d.dnsClient.Exchange(msg, address)
+				d.collectQuery(qmx, px, srv, domain, rtypeName, rtype)
 
 				mux.Lock()
 				defer mux.Unlock()
+				for k, v := range qmx {
+					mx[k] = v
+				}
+			}(srv, rtypeName, rtype, &wg)
+		}
+	}
+	wg.Wait()
 
-				px := "server_" + srv + "_record_" + rtypeName + "_"
+	return mx, nil
+}
 
-				mx[px+"query_status_success"] = 0
-				mx[px+"query_status_network_error"] = 0
-				mx[px+"query_status_dns_error"] = 0
+func (d *DNSQuery) collectQuery(mx map[string]int64, px, srv, domain, rtypeName string, rtype uint16) {
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(domain), rtype)
+	if d.DNSSEC {
+		msg.SetEdns0(4096, true)
+		msg.AuthenticatedData = true
+	}
 
-				if err != nil {
-					d.Debugf("error on querying %s after %s query for %s : %s", srv, rtypeName, domain, err)
-					mx[px+"query_status_network_error"] = 1
-					return
-				}
+	resp, rtt, err := d.dnsClient.Exchange(msg, d.serverAddress(srv))
 
-				if resp != nil && resp.Rcode != dns.RcodeSuccess {
-					d.Debugf("invalid answer from %s after %s query for %s (rcode %d)", srv, rtypeName, domain, resp.Rcode)
-					mx[px+"query_status_dns_error"] = 1
-				} else {
-					mx[px+"query_status_success"] = 1
-				}
-				mx["server_"+srv+"_record_"+rtypeName+"_query_time"] = rtt.Nanoseconds()
+	mx[px+"query_status_success"] = 0
+	mx[px+"query_status_network_error"] = 0
+	mx[px+"query_status_dns_error"] = 0
 
-			}(srv, rtypeName, rtype, &wg)
+	if err != nil {
+		d.Debugf("error on querying %s after %s query for %s : %s", srv, rtypeName, domain, err)
+		mx[px+"query_status_network_error"] = 1
+		return
+	}
+
+	if resp != nil && resp.Rcode != dns.RcodeSuccess {
+		d.Debugf("invalid answer from %s after %s query for %s (rcode %d)", srv, rtypeName, domain, resp.Rcode)
+		mx[px+"query_status_dns_error"] = 1
+	} else {
+		mx[px+"query_status_success"] = 1
+	}
+	mx[px+"query_time"] = rtt.Nanoseconds()
+
+	if resp == nil {
+		return
+	}
+
+	for _, name := range rcodes {
+		mx[px+"rcode_"+name] = 0
+	}
+	mx[px+"rcode_other"] = 0
+	if name, ok := rcodes[resp.Rcode]; ok {
+		mx[px+"rcode_"+name] = 1
+	} else {
+		mx[px+"rcode_other"] = 1
+	}
+
+	if d.DNSSEC {
+		d.collectDNSSEC(mx, px, resp)
+	}
+	if d.hasExpectations(rtypeName) {
+		d.collectExpectations(mx, px, domain, rtypeName, resp)
+	}
+}
+
+func (d *DNSQuery) collectDNSSEC(mx map[string]int64, px string, resp *dns.Msg) {
+	mx[px+"dnssec_authenticated"] = boolToInt(resp.AuthenticatedData)
+
+	var expiry int64
+	var found bool
+	now := time.Now().Unix()
+	for _, rr := range resp.Answer {
+		sig, ok := rr.(*dns.RRSIG)
+		if !ok {
+			continue
+		}
+		if v := int64(sig.Expiration) - now; !found || v < expiry {
+			expiry, found = v, true
 		}
 	}
-	wg.Wait()
+	if found {
+		mx[px+"dnssec_signature_expiry"] = expiry
+	}
+}
 
-	return mx, nil
+// collectExpectations sets the expectation dimensions only if there is an expectation for the queried domain,
+// otherwise they are left unset and the chart shows a gap.
+func (d *DNSQuery) collectExpectations(mx map[string]int64, px, domain, rtypeName string, resp *dns.Msg) {
+	answers := make(map[string]bool)
+	for _, rr := range resp.Answer {
+		answers[normalizeAnswer(answerValue(rr))] = true
+	}
+
+	for _, e := range d.Expect {
+		if !e.matches(domain, rtypeName) {
+			continue
+		}
+		if _, ok := mx[px+"expect_answers_failed"]; !ok && len(e.Answers) > 0 {
+			mx[px+"expect_answers_failed"] = 0
+		}
+		for _, v := range e.Answers {
+			if !answers[normalizeAnswer(v)] {
+				d.Debugf("expected answer '%s' not found in %s response for %s", v, rtypeName, domain)
+				mx[px+"expect_answers_failed"] = 1
+			}
+		}
+		if e.MinTTL == 0 {
+			continue
+		}
+		if _, ok := mx[px+"expect_min_ttl_failed"]; !ok {
+			mx[px+"expect_min_ttl_failed"] = 0
+		}
+		for _, rr := range resp.Answer {
+			if rr.Header().Rrtype == dns.TypeRRSIG {
+				continue
+			}
+			if ttl := rr.Header().Ttl; ttl < e.MinTTL {
+				d.Debugf("answer '%s' TTL %d is less than %d", rr, ttl, e.MinTTL)
+				mx[px+"expect_min_ttl_failed"] = 1
+			}
+		}
+	}
+}
+
+func (d *DNSQuery) hasExpectations(rtypeName string) bool {
+	for _, e := range d.Expect {
+		if e.RecordType == "" || e.RecordType == rtypeName {
+			return true
+		}
+	}
+	return false
+}
+
+func (e ExpectConfig) matches(domain, rtypeName string) bool {
+	if e.RecordType != "" && e.RecordType != rtypeName {
+		return false
+	}
+	return e.Domain == "" || normalizeAnswer(e.Domain) == normalizeAnswer(domain)
+}
+
+func answerValue(rr dns.RR) string {
+	switch v := rr.(type) {
+	case *dns.A:
+		return v.A.String()
+	case *dns.AAAA:
+		return v.AAAA.String()
+	case *dns.CNAME:
+		return v.Target
+	case *dns.MX:
+		return v.Mx
+	case *dns.NS:
+		return v.Ns
+	case *dns.PTR:
+		return v.Ptr
+	case *dns.SRV:
+		return v.Target
+	case *dns.TXT:
+		return strings.Join(v.Txt, "")
+	default:
+		return strings.TrimPrefix(rr.String(), rr.Header().String())
+	}
+}
+
+func normalizeAnswer(v string) string {
+	return strings.TrimSuffix(strings.ToLower(v), ".")
+}
+
+func boolToInt(v bool) int64 {
+	if v {
+		return 1
+	}
+	return 0
 }
 
 func randomDomain(domains []string) string {
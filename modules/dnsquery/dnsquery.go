@@ -3,9 +3,11 @@
 package dnsquery
 
 import (
+	"crypto/tls"
 	"time"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/tlscfg"
 	"github.com/netdata/go.d.plugin/pkg/web"
 
 	"github.com/miekg/dns"
@@ -26,26 +28,40 @@ func New() *DNSQuery {
 			Timeout:     web.Duration{Duration: time.Second * 2},
 			Network:     "udp",
 			RecordTypes: []string{"A"},
-			Port:        53,
 		},
-		newDNSClient: func(network string, timeout time.Duration) dnsClient {
+		newDNSClient: func(network string, timeout time.Duration, tlsConfig *tls.Config) dnsClient {
+			if network == networkHTTPS {
+				return newDoHClient(timeout, tlsConfig)
+			}
 			return &dns.Client{
 				Net:         network,
 				ReadTimeout: timeout,
+				TLSConfig:   tlsConfig,
 			}
 		},
 	}
 }
 
-type Config struct {
-	Domains     []string
-	Servers     []string
-	Network     string
-	RecordType  string   `yaml:"record_type"`
-	RecordTypes []string `yaml:"record_types"`
-	Port        int
-	Timeout     web.Duration
-}
+type (
+	Config struct {
+		tlscfg.TLSConfig `yaml:",inline"`
+		Domains          []string
+		Servers          []string
+		Network          string
+		RecordType       string   `yaml:"record_type"`
+		RecordTypes      []string `yaml:"record_types"`
+		Port             int
+		Timeout          web.Duration
+		DNSSEC           bool           `yaml:"dnssec"`
+		Expect           []ExpectConfig `yaml:"expect"`
+	}
+	ExpectConfig struct {
+		Domain     string   `yaml:"domain"`
+		RecordType string   `yaml:"record_type"`
+		Answers    []string `yaml:"answers"`
+		MinTTL     uint32   `yaml:"min_ttl"`
+	}
+)
 
 type (
 	DNSQuery struct {
@@ -55,8 +71,9 @@ type (
 
 		charts *module.Charts
 
-		newDNSClient func(network string, duration time.Duration) dnsClient
+		newDNSClient func(network string, duration time.Duration, tlsConfig *tls.Config) dnsClient
 		recordTypes  map[string]uint16
+		tlsConfig    *tls.Config
 
 		dnsClient dnsClient
 	}
@@ -79,6 +96,13 @@ func (d *DNSQuery) Init() bool {
 	}
 	d.recordTypes = rt
 
+	tlsConfig, err := tlscfg.NewTLSConfig(d.TLSConfig)
+	if err != nil {
+		d.Errorf("init TLS config: %v", err)
+		return false
+	}
+	d.tlsConfig = tlsConfig
+
 	charts, err := d.initCharts()
 	if err != nil {
 		d.Errorf("init charts: %v", err)
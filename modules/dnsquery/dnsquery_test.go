@@ -3,7 +3,12 @@
 package dnsquery
 
 import (
+	"crypto/tls"
 	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -83,6 +88,17 @@ func TestDNSQuery_Init(t *testing.T) {
 				Timeout:     web.Duration{Duration: time.Second},
 			},
 		},
+		"fail when expect has nothing to check": {
+			wantFail: true,
+			config: Config{
+				Domains:     []string{"example.com"},
+				Servers:     []string{"192.0.2.0"},
+				Network:     "udp",
+				RecordTypes: []string{"A"},
+				Timeout:     web.Duration{Duration: time.Second},
+				Expect:      []ExpectConfig{{Domain: "example.com"}},
+			},
+		},
 		"fail when record_type is invalid": {
 			wantFail: true,
 			config: Config{
@@ -195,11 +211,183 @@ func TestDNSQuery_Collect(t *testing.T) {
 	}
 }
 
+func TestDNSQuery_Collect_InProcessServer(t *testing.T) {
+	tests := map[string]struct {
+		network string
+		domain  string
+		prepare func(t *testing.T, dq *DNSQuery) (server string, cleanup func())
+		check   func(t *testing.T, mx map[string]int64, px string)
+	}{
+		"udp with dnssec and expectations": {
+			network: "udp",
+			domain:  "example.com",
+			prepare: prepareUDPServer,
+			check: func(t *testing.T, mx map[string]int64, px string) {
+				assert.Equal(t, int64(1), mx[px+"query_status_success"])
+				assert.Equal(t, int64(1), mx[px+"rcode_noerror"])
+				assert.Equal(t, int64(0), mx[px+"rcode_nxdomain"])
+				assert.Equal(t, int64(1), mx[px+"dnssec_authenticated"])
+				assert.InDelta(t, 3600, mx[px+"dnssec_signature_expiry"], 10)
+				assert.Equal(t, int64(0), mx[px+"expect_answers_failed"])
+				assert.Equal(t, int64(1), mx[px+"expect_min_ttl_failed"])
+			},
+		},
+		"udp nxdomain": {
+			network: "udp",
+			domain:  "nxdomain.example",
+			prepare: prepareUDPServer,
+			check: func(t *testing.T, mx map[string]int64, px string) {
+				assert.Equal(t, int64(1), mx[px+"query_status_dns_error"])
+				assert.Equal(t, int64(0), mx[px+"rcode_noerror"])
+				assert.Equal(t, int64(1), mx[px+"rcode_nxdomain"])
+				assert.Equal(t, int64(0), mx[px+"dnssec_authenticated"])
+				assert.NotContains(t, mx, px+"dnssec_signature_expiry")
+			},
+		},
+		"dns-over-tls": {
+			network: "tcp-tls",
+			domain:  "example.com",
+			prepare: prepareDoTServer,
+			check: func(t *testing.T, mx map[string]int64, px string) {
+				assert.Equal(t, int64(1), mx[px+"query_status_success"])
+				assert.Equal(t, int64(1), mx[px+"rcode_noerror"])
+			},
+		},
+		"dns-over-https": {
+			network: "https",
+			domain:  "servfail.example",
+			prepare: prepareDoHServer,
+			check: func(t *testing.T, mx map[string]int64, px string) {
+				assert.Equal(t, int64(1), mx[px+"query_status_dns_error"])
+				assert.Equal(t, int64(1), mx[px+"rcode_servfail"])
+				// the expectations are for another domain
+				assert.NotContains(t, mx, px+"expect_answers_failed")
+				assert.NotContains(t, mx, px+"expect_min_ttl_failed")
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dq := New()
+			dq.Network = test.network
+			dq.Domains = []string{test.domain}
+			dq.DNSSEC = true
+			dq.InsecureSkipVerify = true
+			dq.Expect = []ExpectConfig{
+				{Domain: "example.com", RecordType: "A", Answers: []string{"192.0.2.10", "192.0.2.11"}, MinTTL: 60},
+			}
+
+			srv, cleanup := test.prepare(t, dq)
+			defer cleanup()
+			dq.Servers = []string{srv}
+
+			require.True(t, dq.Init())
+
+			mx := dq.Collect()
+			require.NotNil(t, mx)
+
+			test.check(t, mx, "server_"+srv+"_record_A_")
+		})
+	}
+}
+
+func prepareUDPServer(t *testing.T, dq *DNSQuery) (string, func()) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := &dns.Server{PacketConn: pc, Handler: dns.HandlerFunc(testDNSHandler)}
+	startDNSServer(t, srv)
+	dq.Port = pc.LocalAddr().(*net.UDPAddr).Port
+
+	return "127.0.0.1", func() { _ = srv.Shutdown() }
+}
+
+func prepareDoTServer(t *testing.T, dq *DNSQuery) (string, func()) {
+	certSrv := httptest.NewTLSServer(http.NotFoundHandler())
+	certSrv.Close()
+
+	l, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: certSrv.TLS.Certificates})
+	require.NoError(t, err)
+
+	srv := &dns.Server{Listener: l, Net: "tcp-tls", Handler: dns.HandlerFunc(testDNSHandler)}
+	startDNSServer(t, srv)
+	dq.Port = l.Addr().(*net.TCPAddr).Port
+
+	return "127.0.0.1", func() { _ = srv.Shutdown() }
+}
+
+func prepareDoHServer(t *testing.T, _ *DNSQuery) (string, func()) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bs, _ := io.ReadAll(r.Body)
+		req := new(dns.Msg)
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/dns-message" || req.Unpack(bs) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		resp, _ := testDNSAnswer(req).Pack()
+		w.Header().Set("Content-Type", "application/dns-message")
+		_, _ = w.Write(resp)
+	}))
+
+	return srv.URL + "/dns-query", srv.Close
+}
+
+func startDNSServer(t *testing.T, srv *dns.Server) {
+	started := make(chan struct{})
+	srv.NotifyStartedFunc = func() { close(started) }
+	go func() { _ = srv.ActivateAndServe() }()
+
+	select {
+	case <-started:
+	case <-time.After(time.Second * 5):
+		t.Fatal("DNS server did not start")
+	}
+}
+
+func testDNSHandler(w dns.ResponseWriter, r *dns.Msg) {
+	_ = w.WriteMsg(testDNSAnswer(r))
+}
+
+func testDNSAnswer(r *dns.Msg) *dns.Msg {
+	m := new(dns.Msg)
+	m.SetReply(r)
+
+	q := r.Question[0]
+	switch q.Name {
+	case "example.com.":
+		a1, _ := dns.NewRR("example.com. 300 IN A 192.0.2.10")
+		a2, _ := dns.NewRR("example.com. 30 IN A 192.0.2.11")
+		m.Answer = append(m.Answer, a1, a2)
+		if opt := r.IsEdns0(); opt != nil && opt.Do() {
+			now := time.Now().Unix()
+			m.AuthenticatedData = true
+			m.Answer = append(m.Answer, &dns.RRSIG{
+				Hdr:         dns.RR_Header{Name: q.Name, Rrtype: dns.TypeRRSIG, Class: dns.ClassINET, Ttl: 300},
+				TypeCovered: dns.TypeA,
+				Algorithm:   dns.ECDSAP256SHA256,
+				Labels:      2,
+				OrigTtl:     300,
+				Expiration:  uint32(now + 3600),
+				Inception:   uint32(now - 3600),
+				KeyTag:      12345,
+				SignerName:  q.Name,
+				Signature:   "dGVzdA==",
+			})
+		}
+	case "servfail.example.":
+		m.Rcode = dns.RcodeServerFailure
+	default:
+		m.Rcode = dns.RcodeNameError
+	}
+	return m
+}
+
 func caseDNSClientOK() *DNSQuery {
 	dq := New()
 	dq.Domains = []string{"example.com"}
 	dq.Servers = []string{"192.0.2.0", "192.0.2.1"}
-	dq.newDNSClient = func(_ string, _ time.Duration) dnsClient {
+	dq.newDNSClient = func(_ string, _ time.Duration, _ *tls.Config) dnsClient {
 		return mockDNSClient{errOnExchange: false}
 	}
 	return dq
@@ -209,7 +397,7 @@ func caseDNSClientErr() *DNSQuery {
 	dq := New()
 	dq.Domains = []string{"example.com"}
 	dq.Servers = []string{"192.0.2.0", "192.0.2.1"}
-	dq.newDNSClient = func(_ string, _ time.Duration) dnsClient {
+	dq.newDNSClient = func(_ string, _ time.Duration, _ *tls.Config) dnsClient {
 		return mockDNSClient{errOnExchange: true}
 	}
 	return dq
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package dnsquery
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	networkUDP   = "udp"
+	networkTCP   = "tcp"
+	networkTLS   = "tcp-tls"
+	networkHTTPS = "https"
+)
+
+var defaultPorts = map[string]int{
+	networkUDP:   53,
+	networkTCP:   53,
+	networkTLS:   853,
+	networkHTTPS: 443,
+}
+
+const dnsMessageContentType = "application/dns-message"
+
+// dohClient is a DNS-over-HTTPS (RFC 8484) client, queries are sent using the POST method.
+type dohClient struct {
+	httpClient *http.Client
+}
+
+func newDoHClient(timeout time.Duration, tlsConfig *tls.Config) *dohClient {
+	return &dohClient{
+		httpClient: &http.Client{
+			Timeout:   timeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig, ForceAttemptHTTP2: true},
+		},
+	}
+}
+
+func (c *dohClient) Exchange(msg *dns.Msg, url string) (*dns.Msg, time.Duration, error) {
+	// RFC 8484, 4.1: the DNS ID should be 0 to be more cache friendly
+	msg.Id = 0
+
+	bs, err := msg.Pack()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(bs))
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Content-Type", dnsMessageContentType)
+	req.Header.Set("Accept", dnsMessageContentType)
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	rtt := time.Since(start)
+	if err != nil {
+		return nil, rtt, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, rtt, fmt.Errorf("'%s' returned HTTP status code %d", url, resp.StatusCode)
+	}
+
+	answer := new(dns.Msg)
+	if err := answer.Unpack(body); err != nil {
+		return nil, rtt, err
+	}
+	return answer, rtt, nil
+}
@@ -5,6 +5,10 @@ package dnsquery
 import (
 	"errors"
 	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
 	"github.com/netdata/go.d.plugin/agent/module"
 
 	"github.com/miekg/dns"
@@ -19,7 +23,7 @@ func (d *DNSQuery) verifyConfig() error {
 		return errors.New("no servers specified")
 	}
 
-	if !(d.Network == "" || d.Network == "udp" || d.Network == "tcp" || d.Network == "tcp-tls") {
+	if _, ok := defaultPorts[d.Network]; !ok && d.Network != "" {
 		return fmt.Errorf("wrong network transport : %s", d.Network)
 	}
 
@@ -32,9 +36,40 @@ func (d *DNSQuery) verifyConfig() error {
 		return errors.New("no record types specified")
 	}
 
+	for i, e := range d.Expect {
+		if len(e.Answers) == 0 && e.MinTTL == 0 {
+			return fmt.Errorf("expect %d: 'answers' or 'min_ttl' must be set", i+1)
+		}
+		if e.RecordType != "" {
+			if _, err := parseRecordType(e.RecordType); err != nil {
+				return fmt.Errorf("expect %d: %v", i+1, err)
+			}
+		}
+	}
+
 	return nil
 }
 
+// serverAddress returns the address the query is sent to: "host:port", or the URL for DNS-over-HTTPS.
+func (d *DNSQuery) serverAddress(server string) string {
+	network := d.Network
+	if network == "" {
+		network = networkUDP
+	}
+	port := d.Port
+	if port == 0 {
+		port = defaultPorts[network]
+	}
+
+	if network == networkHTTPS {
+		if strings.HasPrefix(server, "https://") {
+			return server
+		}
+		return fmt.Sprintf("https://%s/dns-query", net.JoinHostPort(server, strconv.Itoa(port)))
+	}
+	return net.JoinHostPort(server, strconv.Itoa(port))
+}
+
 func (d *DNSQuery) initRecordTypes() (map[string]uint16, error) {
 	types := make(map[string]uint16)
 	for _, v := range d.RecordTypes {
@@ -55,6 +90,16 @@ func (d *DNSQuery) initCharts() (*module.Charts, error) {
 	for _, srv := range d.Servers {
 		for _, rtype := range d.RecordTypes {
 			cs := newDNSServerCharts(srv, d.Network, rtype)
+			if d.DNSSEC {
+				if err := cs.Add(*newDNSSECCharts(srv, d.Network, rtype)...); err != nil {
+					return nil, err
+				}
+			}
+			if d.hasExpectations(rtype) {
+				if err := cs.Add(newExpectationsChart(srv, d.Network, rtype)); err != nil {
+					return nil, err
+				}
+			}
 			if err := charts.Add(*cs...); err != nil {
 				return nil, err
 			}
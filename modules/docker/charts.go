@@ -4,6 +4,7 @@ package docker
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/netdata/go.d.plugin/agent/module"
@@ -16,6 +17,13 @@ const (
 	prioContainerState
 	prioContainerHealthStatus
 	prioContainerWritableLayerSize
+	prioContainerCPUUsage
+	prioContainerCPUThrottledPeriods
+	prioContainerCPUThrottledTime
+	prioContainerMemUsage
+	prioContainerMemUtilization
+	prioContainerNetTraffic
+	prioContainerBlkio
 
 	prioImagesCount
 	prioImagesSize
@@ -140,18 +148,136 @@ var (
 	}
 )
 
-func (d *Docker) addContainerCharts(name, image string) {
+var (
+	containerStatsChartsTmpl = module.Charts{
+		containerCPUUsageChartTmpl.Copy(),
+		containerCPUThrottledPeriodsChartTmpl.Copy(),
+		containerCPUThrottledTimeChartTmpl.Copy(),
+		containerMemUsageChartTmpl.Copy(),
+		containerMemUtilizationChartTmpl.Copy(),
+		containerNetTrafficChartTmpl.Copy(),
+		containerBlkioChartTmpl.Copy(),
+	}
+
+	containerCPUUsageChartTmpl = module.Chart{
+		ID:       "container_%s_cpu_usage",
+		Title:    "Docker container CPU usage",
+		Units:    "percentage",
+		Fam:      "containers",
+		Ctx:      "docker.container_cpu_usage",
+		Priority: prioContainerCPUUsage,
+		Type:     module.Stacked,
+		Dims: module.Dims{
+			{ID: "container_%s_cpu_usage_user", Name: "user", Algo: module.Incremental, Div: 1e7},
+			{ID: "container_%s_cpu_usage_system", Name: "system", Algo: module.Incremental, Div: 1e7},
+		},
+	}
+	containerCPUThrottledPeriodsChartTmpl = module.Chart{
+		ID:       "container_%s_cpu_throttled_periods",
+		Title:    "Docker container CPU throttled periods",
+		Units:    "periods/s",
+		Fam:      "containers",
+		Ctx:      "docker.container_cpu_throttled_periods",
+		Priority: prioContainerCPUThrottledPeriods,
+		Dims: module.Dims{
+			{ID: "container_%s_cpu_throttling_periods", Name: "periods", Algo: module.Incremental},
+			{ID: "container_%s_cpu_throttling_throttled_periods", Name: "throttled", Algo: module.Incremental},
+		},
+	}
+	containerCPUThrottledTimeChartTmpl = module.Chart{
+		ID:       "container_%s_cpu_throttled_time",
+		Title:    "Docker container CPU throttled time",
+		Units:    "ms",
+		Fam:      "containers",
+		Ctx:      "docker.container_cpu_throttled_time",
+		Priority: prioContainerCPUThrottledTime,
+		Dims: module.Dims{
+			{ID: "container_%s_cpu_throttling_throttled_time", Name: "throttled", Algo: module.Incremental, Div: 1e6},
+		},
+	}
+	containerMemUsageChartTmpl = module.Chart{
+		ID:       "container_%s_mem_usage",
+		Title:    "Docker container memory usage",
+		Units:    "bytes",
+		Fam:      "containers",
+		Ctx:      "docker.container_mem_usage",
+		Priority: prioContainerMemUsage,
+		Dims: module.Dims{
+			{ID: "container_%s_mem_usage", Name: "usage"},
+			{ID: "container_%s_mem_cache", Name: "cache"},
+			{ID: "container_%s_mem_limit", Name: "limit"},
+		},
+	}
+	containerMemUtilizationChartTmpl = module.Chart{
+		ID:       "container_%s_mem_utilization",
+		Title:    "Docker container memory utilization",
+		Units:    "percentage",
+		Fam:      "containers",
+		Ctx:      "docker.container_mem_utilization",
+		Priority: prioContainerMemUtilization,
+		Dims: module.Dims{
+			{ID: "container_%s_mem_utilization", Name: "used", Div: 100},
+		},
+	}
+	containerNetTrafficChartTmpl = module.Chart{
+		ID:       "container_%s_net_traffic",
+		Title:    "Docker container network traffic",
+		Units:    "kilobits/s",
+		Fam:      "containers",
+		Ctx:      "docker.container_net_traffic",
+		Priority: prioContainerNetTraffic,
+		Type:     module.Area,
+		Dims: module.Dims{
+			{ID: "container_%s_net_rx_bytes", Name: "received", Algo: module.Incremental, Mul: 8, Div: 1000},
+			{ID: "container_%s_net_tx_bytes", Name: "sent", Algo: module.Incremental, Mul: -8, Div: 1000},
+		},
+	}
+	containerBlkioChartTmpl = module.Chart{
+		ID:       "container_%s_blkio",
+		Title:    "Docker container block I/O",
+		Units:    "bytes/s",
+		Fam:      "containers",
+		Ctx:      "docker.container_blkio",
+		Priority: prioContainerBlkio,
+		Type:     module.Area,
+		Dims: module.Dims{
+			{ID: "container_%s_blkio_read_bytes", Name: "read", Algo: module.Incremental},
+			{ID: "container_%s_blkio_write_bytes", Name: "write", Algo: module.Incremental, Mul: -1},
+		},
+	}
+)
+
+func (d *Docker) addContainerCharts(name, image string, labels map[string]string) {
 	charts := containerChartsTmpl.Copy()
 	if !d.CollectContainerSize {
 		_ = charts.Remove(containerWritableLayerSizeChartTmpl.ID)
 	}
 
+	d.addContainerChartsFromTmpl(charts, name, image, labels)
+}
+
+func (d *Docker) addContainerStatsCharts(name, image string, labels map[string]string) {
+	d.addContainerChartsFromTmpl(containerStatsChartsTmpl.Copy(), name, image, labels)
+}
+
+func (d *Docker) addContainerChartsFromTmpl(charts *module.Charts, name, image string, labels map[string]string) {
+	var keys []string
+	for k := range labels {
+		if k != "container_name" && k != "image" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
 	for _, chart := range *charts {
 		chart.ID = fmt.Sprintf(chart.ID, name)
 		chart.Labels = []module.Label{
 			{Key: "container_name", Value: name},
 			{Key: "image", Value: image},
 		}
+		for _, k := range keys {
+			chart.Labels = append(chart.Labels, module.Label{Key: k, Value: labels[k]})
+		}
 		for _, dim := range chart.Dims {
 			dim.ID = fmt.Sprintf(dim.ID, name)
 		}
@@ -163,7 +289,7 @@ func (d *Docker) addContainerCharts(name, image string) {
 }
 
 func (d *Docker) removeContainerCharts(name string) {
-	px := fmt.Sprintf("container_%s", name)
+	px := fmt.Sprintf("container_%s_", name)
 
 	for _, chart := range *d.Charts() {
 		if strings.HasPrefix(chart.ID, px) {
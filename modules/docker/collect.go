@@ -126,6 +126,7 @@ func (d *Docker) collectContainers(mx map[string]int64) error {
 	}
 
 	seen := make(map[string]bool)
+	var running []types.Container
 
 	for _, s := range containerHealthStatuses {
 		mx["containers_health_status_"+s] = 0
@@ -152,11 +153,18 @@ func (d *Docker) collectContainers(mx map[string]int64) error {
 
 			name := strings.TrimPrefix(cntr.Names[0], "/")
 
+			if !d.containerSr.MatchLabels(containerSelectorLabels(name, cntr)) {
+				continue
+			}
+
 			seen[name] = true
 
 			if !d.containers[name] {
 				d.containers[name] = true
-				d.addContainerCharts(name, cntr.Image)
+				d.addContainerCharts(name, cntr.Image, cntr.Labels)
+			}
+			if cntr.State == "running" {
+				running = append(running, cntr)
 			}
 
 			px := fmt.Sprintf("container_%s_", name)
@@ -180,9 +188,14 @@ func (d *Docker) collectContainers(mx map[string]int64) error {
 		}
 	}
 
+	if d.CollectContainerStats {
+		d.collectContainersStats(mx, running)
+	}
+
 	for name := range d.containers {
 		if !seen[name] {
 			delete(d.containers, name)
+			delete(d.containersStats, name)
 			d.removeContainerCharts(name)
 		}
 	}
@@ -190,6 +203,16 @@ func (d *Docker) collectContainers(mx map[string]int64) error {
 	return nil
 }
 
+func containerSelectorLabels(name string, cntr types.Container) map[string]string {
+	labels := make(map[string]string, len(cntr.Labels)+2)
+	for k, v := range cntr.Labels {
+		labels["label."+k] = v
+	}
+	labels["name"] = name
+	labels["image"] = cntr.Image
+	return labels
+}
+
 func (d *Docker) negotiateAPIVersion() {
 	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout.Duration)
 	defer cancel()
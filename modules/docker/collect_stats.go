@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+)
+
+type containerStats struct {
+	name   string
+	image  string
+	labels map[string]string
+	stats  *types.StatsJSON
+}
+
+func (d *Docker) collectContainersStats(mx map[string]int64, containers []types.Container) {
+	if len(containers) == 0 {
+		return
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results []containerStats
+		sem     = make(chan struct{}, d.StatsWorkers)
+	)
+
+	for _, cntr := range containers {
+		cntr := cntr
+
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+
+			name := strings.TrimPrefix(cntr.Names[0], "/")
+
+			stats, err := d.containerStats(cntr.ID)
+			if err != nil {
+				d.Warningf("error on querying container '%s' stats: %v", name, err)
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			results = append(results, containerStats{name: name, image: cntr.Image, labels: cntr.Labels, stats: stats})
+		}()
+	}
+
+	wg.Wait()
+
+	for _, v := range results {
+		if !d.containersStats[v.name] {
+			d.containersStats[v.name] = true
+			d.addContainerStatsCharts(v.name, v.image, v.labels)
+		}
+		writeContainerStats(mx, fmt.Sprintf("container_%s_", v.name), v.stats)
+	}
+}
+
+func (d *Docker) containerStats(id string) (*types.StatsJSON, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout.Duration)
+	defer cancel()
+
+	resp, err := d.client.ContainerStatsOneShot(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var stats types.StatsJSON
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		return nil, fmt.Errorf("decode stats: %v", err)
+	}
+
+	return &stats, nil
+}
+
+func writeContainerStats(mx map[string]int64, px string, stats *types.StatsJSON) {
+	cpu := stats.CPUStats
+	mx[px+"cpu_usage_user"] = int64(cpu.CPUUsage.UsageInUsermode)
+	mx[px+"cpu_usage_system"] = int64(cpu.CPUUsage.UsageInKernelmode)
+	mx[px+"cpu_throttling_periods"] = int64(cpu.ThrottlingData.Periods)
+	mx[px+"cpu_throttling_throttled_periods"] = int64(cpu.ThrottlingData.ThrottledPeriods)
+	mx[px+"cpu_throttling_throttled_time"] = int64(cpu.ThrottlingData.ThrottledTime)
+
+	// Same as the docker CLI: cgroup v1 reports 'total_inactive_file' and 'cache',
+	// cgroup v2 reports 'inactive_file' and 'file'.
+	mem := stats.MemoryStats
+	var inactive, cache uint64
+	if v, ok := mem.Stats["total_inactive_file"]; ok {
+		inactive, cache = v, mem.Stats["cache"]
+	} else {
+		inactive, cache = mem.Stats["inactive_file"], mem.Stats["file"]
+	}
+	usage := mem.Usage
+	if inactive < usage {
+		usage -= inactive
+	}
+	mx[px+"mem_usage"] = int64(usage)
+	mx[px+"mem_cache"] = int64(cache)
+	mx[px+"mem_limit"] = int64(mem.Limit)
+	mx[px+"mem_utilization"] = 0
+	if mem.Limit > 0 {
+		mx[px+"mem_utilization"] = int64(float64(usage) * 100 * 100 / float64(mem.Limit))
+	}
+
+	mx[px+"net_rx_bytes"] = 0
+	mx[px+"net_tx_bytes"] = 0
+	for _, v := range stats.Networks {
+		mx[px+"net_rx_bytes"] += int64(v.RxBytes)
+		mx[px+"net_tx_bytes"] += int64(v.TxBytes)
+	}
+
+	mx[px+"blkio_read_bytes"] = 0
+	mx[px+"blkio_write_bytes"] = 0
+	for _, v := range stats.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(v.Op) {
+		case "read":
+			mx[px+"blkio_read_bytes"] += int64(v.Value)
+		case "write":
+			mx[px+"blkio_write_bytes"] += int64(v.Value)
+		}
+	}
+}
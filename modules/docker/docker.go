@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/matcher"
 	"github.com/netdata/go.d.plugin/pkg/web"
 
 	"github.com/docker/docker/api/types"
@@ -22,23 +23,37 @@ func init() {
 func New() *Docker {
 	return &Docker{
 		Config: Config{
-			Address:              docker.DefaultDockerHost,
-			Timeout:              web.Duration{Duration: time.Second * 5},
-			CollectContainerSize: false,
+			Address:               docker.DefaultDockerHost,
+			Timeout:               web.Duration{Duration: time.Second * 5},
+			CollectContainerSize:  false,
+			CollectContainerStats: false,
+			StatsWorkers:          10,
 		},
 
 		charts: summaryCharts.Copy(),
 		newClient: func(cfg Config) (dockerClient, error) {
 			return docker.NewClientWithOpts(docker.WithHost(cfg.Address))
 		},
-		containers: make(map[string]bool),
+		containers:      make(map[string]bool),
+		containersStats: make(map[string]bool),
 	}
 }
 
 type Config struct {
-	Timeout              web.Duration `yaml:"timeout"`
-	Address              string       `yaml:"address"`
-	CollectContainerSize bool         `yaml:"collect_container_size"`
+	Timeout               web.Duration      `yaml:"timeout"`
+	Address               string            `yaml:"address"`
+	CollectContainerSize  bool              `yaml:"collect_container_size"`
+	CollectContainerStats bool              `yaml:"collect_container_stats"`
+	StatsWorkers          int               `yaml:"stats_workers"`
+	ContainerSelector     ContainerSelector `yaml:"container_selector"`
+}
+
+// ContainerSelector selects containers for the per-container charts.
+// Include and exclude are label expressions (see matcher.ParseLabelExpr) evaluated against
+// the container "name", "image" and "label.<key>" for every container label.
+type ContainerSelector struct {
+	Include string `yaml:"include"`
+	Exclude string `yaml:"exclude"`
 }
 
 type (
@@ -52,18 +67,33 @@ type (
 		client        dockerClient
 		verNegotiated bool
 
-		containers map[string]bool
+		containerSr matcher.LabelMatcher
+
+		containers      map[string]bool
+		containersStats map[string]bool
 	}
 	dockerClient interface {
 		NegotiateAPIVersion(context.Context)
 		Info(context.Context) (types.Info, error)
 		ImageList(context.Context, types.ImageListOptions) ([]types.ImageSummary, error)
 		ContainerList(context.Context, types.ContainerListOptions) ([]types.Container, error)
+		ContainerStatsOneShot(context.Context, string) (types.ContainerStats, error)
 		Close() error
 	}
 )
 
 func (d *Docker) Init() bool {
+	sr, err := d.initContainerSelector()
+	if err != nil {
+		d.Errorf("init container selector: %v", err)
+		return false
+	}
+	d.containerSr = sr
+
+	if d.StatsWorkers <= 0 {
+		d.StatsWorkers = 1
+	}
+
 	return true
 }
 
@@ -3,10 +3,17 @@
 package docker
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
+	"sort"
+	"sync"
 	"testing"
 
+	"github.com/netdata/go.d.plugin/agent/module"
+
 	"github.com/docker/docker/api/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -27,6 +34,27 @@ func TestDocker_Init(t *testing.T) {
 				Address: "",
 			},
 		},
+		"container selector": {
+			wantFail: false,
+			config: Config{
+				ContainerSelector: ContainerSelector{
+					Include: `image="example/example:v1" || label[team]=backend`,
+					Exclude: `name=container3`,
+				},
+			},
+		},
+		"invalid container selector include": {
+			wantFail: true,
+			config: Config{
+				ContainerSelector: ContainerSelector{Include: `name=~"["`},
+			},
+		},
+		"invalid container selector exclude": {
+			wantFail: true,
+			config: Config{
+				ContainerSelector: ContainerSelector{Exclude: `(name=container3`},
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -682,6 +710,75 @@ func TestDocker_Collect(t *testing.T) {
 	}
 }
 
+func TestDocker_Collect_ContainerStats(t *testing.T) {
+	d := New()
+	d.CollectContainerStats = true
+	d.StatsWorkers = 2
+	d.ContainerSelector.Include = `image="example/example:v1" || label[team]=backend`
+	d.ContainerSelector.Exclude = `name=container3`
+	m := &mockClient{}
+	d.newClient = prepareNewClientFunc(m)
+
+	require.True(t, d.Init())
+
+	mx := d.Collect()
+	require.NotNil(t, mx)
+
+	expected := map[string]int64{
+		"cpu_usage_user":                   2000000000,
+		"cpu_usage_system":                 1000000000,
+		"cpu_throttling_periods":           100,
+		"cpu_throttling_throttled_periods": 10,
+		"cpu_throttling_throttled_time":    50000000,
+		"mem_usage":                        800,
+		"mem_cache":                        300,
+		"mem_limit":                        4000,
+		"mem_utilization":                  2000,
+		"net_rx_bytes":                     3000,
+		"net_tx_bytes":                     1500,
+		"blkio_read_bytes":                 4096,
+		"blkio_write_bytes":                8192,
+	}
+	for _, name := range []string{"container2", "container5"} {
+		for k, v := range expected {
+			key := "container_" + name + "_" + k
+			assert.Equalf(t, v, mx[key], "metric '%s'", key)
+		}
+		assert.NotNilf(t, d.Charts().Get("container_"+name+"_cpu_usage"), "container '%s' stats charts", name)
+	}
+
+	// selected, but not running
+	assert.NotNil(t, d.Charts().Get("container_container1_state"))
+	assert.Nil(t, d.Charts().Get("container_container1_cpu_usage"))
+	assert.NotContains(t, mx, "container_container1_cpu_usage_user")
+
+	// not selected
+	for _, name := range []string{"container3", "container4", "container11"} {
+		assert.Nilf(t, d.Charts().Get("container_"+name+"_state"), "container '%s' charts", name)
+		assert.NotContains(t, mx, "container_"+name+"_state_running")
+	}
+	assert.Equal(t, []string{"id2", "id5"}, m.sortedStatsIDs())
+
+	chart := d.Charts().Get("container_container5_mem_usage")
+	require.NotNil(t, chart)
+	assert.Contains(t, chart.Labels, module.Label{Key: "team", Value: "backend"})
+}
+
+func TestDocker_Collect_ContainerStatsError(t *testing.T) {
+	d := New()
+	d.CollectContainerStats = true
+	d.newClient = prepareNewClientFunc(&mockClient{errOnContainerStats: true})
+
+	require.True(t, d.Init())
+
+	mx := d.Collect()
+	require.NotNil(t, mx)
+
+	assert.Contains(t, mx, "container_container2_state_running")
+	assert.NotContains(t, mx, "container_container2_cpu_usage_user")
+	assert.Nil(t, d.Charts().Get("container_container2_cpu_usage"))
+}
+
 func prepareCaseSuccess() *Docker {
 	d := New()
 	d.CollectContainerSize = true
@@ -731,8 +828,12 @@ type mockClient struct {
 	errOnInfo                 bool
 	errOnImageList            bool
 	errOnContainerList        bool
+	errOnContainerStats       bool
 	negotiateAPIVersionCalled bool
 	closeCalled               bool
+
+	mu       sync.Mutex
+	statsIDs []string
 }
 
 func (m *mockClient) Info(_ context.Context) (types.Info, error) {
@@ -764,13 +865,13 @@ func (m *mockClient) ContainerList(_ context.Context, opts types.ContainerListOp
 	case types.Healthy:
 		containers = []types.Container{
 			{Names: []string{"container1"}, State: "created", Image: "example/example:v1"},
-			{Names: []string{"container2"}, State: "running", Image: "example/example:v1"},
-			{Names: []string{"container3"}, State: "running", Image: "example/example:v1"},
+			{ID: "id2", Names: []string{"container2"}, State: "running", Image: "example/example:v1"},
+			{ID: "id3", Names: []string{"container3"}, State: "running", Image: "example/example:v1"},
 		}
 	case types.Unhealthy:
 		containers = []types.Container{
 			{Names: []string{"container4"}, State: "created", Image: "example/example:v2"},
-			{Names: []string{"container5"}, State: "running", Image: "example/example:v2"},
+			{ID: "id5", Names: []string{"container5"}, State: "running", Image: "example/example:v2", Labels: map[string]string{"team": "backend"}},
 			{Names: []string{"container6"}, State: "paused", Image: "example/example:v2"},
 			{Names: []string{"container7"}, State: "restarting", Image: "example/example:v2"},
 			{Names: []string{"container8"}, State: "removing", Image: "example/example:v2"},
@@ -801,6 +902,39 @@ func (m *mockClient) ContainerList(_ context.Context, opts types.ContainerListOp
 	return containers, nil
 }
 
+func (m *mockClient) ContainerStatsOneShot(_ context.Context, id string) (types.ContainerStats, error) {
+	m.mu.Lock()
+	m.statsIDs = append(m.statsIDs, id)
+	m.mu.Unlock()
+
+	if m.errOnContainerStats {
+		return types.ContainerStats{}, errors.New("mockClient.ContainerStatsOneShot() error")
+	}
+
+	var stats types.StatsJSON
+	stats.CPUStats.CPUUsage.UsageInUsermode = 2000000000
+	stats.CPUStats.CPUUsage.UsageInKernelmode = 1000000000
+	stats.CPUStats.ThrottlingData = types.ThrottlingData{Periods: 100, ThrottledPeriods: 10, ThrottledTime: 50000000}
+	stats.MemoryStats.Usage = 1000
+	stats.MemoryStats.Limit = 4000
+	stats.MemoryStats.Stats = map[string]uint64{"inactive_file": 200, "file": 300}
+	stats.Networks = map[string]types.NetworkStats{
+		"eth0": {RxBytes: 1000, TxBytes: 500},
+		"eth1": {RxBytes: 2000, TxBytes: 1000},
+	}
+	stats.BlkioStats.IoServiceBytesRecursive = []types.BlkioStatEntry{
+		{Op: "read", Value: 4096},
+		{Op: "write", Value: 8192},
+	}
+
+	bs, err := json.Marshal(stats)
+	if err != nil {
+		return types.ContainerStats{}, err
+	}
+
+	return types.ContainerStats{Body: io.NopCloser(bytes.NewReader(bs))}, nil
+}
+
 func (m *mockClient) ImageList(_ context.Context, _ types.ImageListOptions) ([]types.ImageSummary, error) {
 	if m.errOnImageList {
 		return nil, errors.New("mockClient.ImageList() error")
@@ -826,3 +960,11 @@ func (m *mockClient) Close() error {
 	m.closeCalled = true
 	return nil
 }
+
+func (m *mockClient) sortedStatsIDs() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	calls := append([]string(nil), m.statsIDs...)
+	sort.Strings(calls)
+	return calls
+}
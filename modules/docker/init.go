@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package docker
+
+import (
+	"fmt"
+
+	"github.com/netdata/go.d.plugin/pkg/matcher"
+)
+
+func (d *Docker) initContainerSelector() (matcher.LabelMatcher, error) {
+	include, err := matcher.ParseLabelExpr(d.ContainerSelector.Include)
+	if err != nil {
+		return nil, fmt.Errorf("include: %v", err)
+	}
+	if d.ContainerSelector.Exclude == "" {
+		return include, nil
+	}
+
+	exclude, err := matcher.ParseLabelExpr(d.ContainerSelector.Exclude)
+	if err != nil {
+		return nil, fmt.Errorf("exclude: %v", err)
+	}
+
+	return containerSelector{include: include, exclude: exclude}, nil
+}
+
+type containerSelector struct {
+	include matcher.LabelMatcher
+	exclude matcher.LabelMatcher
+}
+
+func (s containerSelector) MatchLabels(labels map[string]string) bool {
+	return s.include.MatchLabels(labels) && !s.exclude.MatchLabels(labels)
+}
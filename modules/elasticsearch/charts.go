@@ -42,12 +42,24 @@ const (
 	prioNodeHTTPConnections
 	prioNodeBreakersTrips
 
+	prioNodeIngestPipelineDocuments
+	prioNodeIngestPipelineFailures
+	prioNodeIngestPipelineCurrent
+	prioNodeIngestPipelineTime
+
 	prioClusterStatus
 	prioClusterNodesCount
 	prioClusterShardsCount
 	prioClusterPendingTasks
 	prioClusterInFlightFetchesCount
 
+	prioClusterShardsByState
+	prioClusterUnassignedShardsByType
+	prioClusterUnassignedShardsByReason
+
+	prioClusterPendingTasksByPriority
+	prioClusterPendingTasksMaxTimeInQueue
+
 	prioClusterIndicesCount
 	prioClusterIndicesShardsCount
 	prioClusterIndicesDocsCount
@@ -55,6 +67,9 @@ const (
 	prioClusterIndicesQueryCache
 	prioClusterNodesByRoleCount
 
+	prioSnapshotRepositorySnapshotsByState
+	prioSnapshotRepositoryLastSuccessfulSnapshotAge
+
 	prioNodeIndexHealth
 	prioNodeIndexShardsCount
 	prioNodeIndexDocsCount
@@ -700,6 +715,197 @@ var (
 	}
 )
 
+var shardsAllocationChartsTmpl = module.Charts{
+	clusterShardsByStateChartTmpl.Copy(),
+	clusterUnassignedShardsByTypeChartTmpl.Copy(),
+	clusterUnassignedShardsByReasonChartTmpl.Copy(),
+}
+
+var (
+	clusterShardsByStateChartTmpl = module.Chart{
+		ID:       "cluster_%s_shards_by_state",
+		Title:    "Cluster Shards By State",
+		Units:    "shards",
+		Fam:      "shards allocation",
+		Ctx:      "elasticsearch.cluster_shards_by_state",
+		Type:     module.Stacked,
+		Priority: prioClusterShardsByState,
+		Dims: module.Dims{
+			{ID: "cluster_shards_state_started", Name: "started"},
+			{ID: "cluster_shards_state_initializing", Name: "initializing"},
+			{ID: "cluster_shards_state_relocating", Name: "relocating"},
+			{ID: "cluster_shards_state_unassigned", Name: "unassigned"},
+		},
+	}
+	clusterUnassignedShardsByTypeChartTmpl = module.Chart{
+		ID:       "cluster_%s_unassigned_shards_by_type",
+		Title:    "Cluster Unassigned Shards By Type",
+		Units:    "shards",
+		Fam:      "shards allocation",
+		Ctx:      "elasticsearch.cluster_unassigned_shards_by_type",
+		Type:     module.Stacked,
+		Priority: prioClusterUnassignedShardsByType,
+		Dims: module.Dims{
+			{ID: "cluster_unassigned_shards_primary", Name: "primary"},
+			{ID: "cluster_unassigned_shards_replica", Name: "replica"},
+		},
+	}
+	clusterUnassignedShardsByReasonChartTmpl = module.Chart{
+		ID:       "cluster_%s_unassigned_shards_by_reason",
+		Title:    "Cluster Unassigned Shards By Reason",
+		Units:    "shards",
+		Fam:      "shards allocation",
+		Ctx:      "elasticsearch.cluster_unassigned_shards_by_reason",
+		Type:     module.Stacked,
+		Priority: prioClusterUnassignedShardsByReason,
+		Dims: module.Dims{
+			{ID: "cluster_unassigned_shards_reason_index_created", Name: "index_created"},
+			{ID: "cluster_unassigned_shards_reason_cluster_recovered", Name: "cluster_recovered"},
+			{ID: "cluster_unassigned_shards_reason_index_reopened", Name: "index_reopened"},
+			{ID: "cluster_unassigned_shards_reason_dangling_index_imported", Name: "dangling_index_imported"},
+			{ID: "cluster_unassigned_shards_reason_new_index_restored", Name: "new_index_restored"},
+			{ID: "cluster_unassigned_shards_reason_existing_index_restored", Name: "existing_index_restored"},
+			{ID: "cluster_unassigned_shards_reason_replica_added", Name: "replica_added"},
+			{ID: "cluster_unassigned_shards_reason_allocation_failed", Name: "allocation_failed"},
+			{ID: "cluster_unassigned_shards_reason_node_left", Name: "node_left"},
+			{ID: "cluster_unassigned_shards_reason_reroute_cancelled", Name: "reroute_cancelled"},
+			{ID: "cluster_unassigned_shards_reason_reinitialized", Name: "reinitialized"},
+			{ID: "cluster_unassigned_shards_reason_reallocated_replica", Name: "reallocated_replica"},
+			{ID: "cluster_unassigned_shards_reason_primary_failed", Name: "primary_failed"},
+			{ID: "cluster_unassigned_shards_reason_forced_empty_primary", Name: "forced_empty_primary"},
+			{ID: "cluster_unassigned_shards_reason_manual_allocation", Name: "manual_allocation"},
+			{ID: "cluster_unassigned_shards_reason_index_closed", Name: "index_closed"},
+			{ID: "cluster_unassigned_shards_reason_node_restarting", Name: "node_restarting"},
+			{ID: "cluster_unassigned_shards_reason_other", Name: "other"},
+		},
+	}
+)
+
+var pendingTasksChartsTmpl = module.Charts{
+	clusterPendingTasksByPriorityChartTmpl.Copy(),
+	clusterPendingTasksMaxTimeInQueueChartTmpl.Copy(),
+}
+
+var (
+	clusterPendingTasksByPriorityChartTmpl = module.Chart{
+		ID:       "cluster_%s_pending_tasks_by_priority",
+		Title:    "Cluster Pending Tasks By Priority",
+		Units:    "tasks",
+		Fam:      "pending tasks",
+		Ctx:      "elasticsearch.cluster_pending_tasks_by_priority",
+		Type:     module.Stacked,
+		Priority: prioClusterPendingTasksByPriority,
+		Dims: module.Dims{
+			{ID: "cluster_pending_tasks_priority_immediate", Name: "immediate"},
+			{ID: "cluster_pending_tasks_priority_urgent", Name: "urgent"},
+			{ID: "cluster_pending_tasks_priority_high", Name: "high"},
+			{ID: "cluster_pending_tasks_priority_normal", Name: "normal"},
+			{ID: "cluster_pending_tasks_priority_low", Name: "low"},
+			{ID: "cluster_pending_tasks_priority_languid", Name: "languid"},
+		},
+	}
+	clusterPendingTasksMaxTimeInQueueChartTmpl = module.Chart{
+		ID:       "cluster_%s_pending_tasks_max_time_in_queue",
+		Title:    "Cluster Pending Tasks Max Time In Queue",
+		Units:    "milliseconds",
+		Fam:      "pending tasks",
+		Ctx:      "elasticsearch.cluster_pending_tasks_max_time_in_queue",
+		Priority: prioClusterPendingTasksMaxTimeInQueue,
+		Dims: module.Dims{
+			{ID: "cluster_pending_tasks_time_in_queue_max", Name: "max"},
+		},
+	}
+)
+
+var snapshotRepositoryChartsTmpl = module.Charts{
+	snapshotRepositorySnapshotsByStateChartTmpl.Copy(),
+	snapshotRepositoryLastSuccessfulSnapshotAgeChartTmpl.Copy(),
+}
+
+var (
+	snapshotRepositorySnapshotsByStateChartTmpl = module.Chart{
+		ID:       "snapshot_repository_%s_cluster_%s_snapshots_by_state",
+		Title:    "Snapshot Repository Snapshots By State",
+		Units:    "snapshots",
+		Fam:      "snapshots",
+		Ctx:      "elasticsearch.snapshot_repository_snapshots_by_state",
+		Type:     module.Stacked,
+		Priority: prioSnapshotRepositorySnapshotsByState,
+		Dims: module.Dims{
+			{ID: "snapshot_repository_%s_snapshots_state_success", Name: "success"},
+			{ID: "snapshot_repository_%s_snapshots_state_in_progress", Name: "in_progress"},
+			{ID: "snapshot_repository_%s_snapshots_state_failed", Name: "failed"},
+			{ID: "snapshot_repository_%s_snapshots_state_partial", Name: "partial"},
+			{ID: "snapshot_repository_%s_snapshots_state_incompatible", Name: "incompatible"},
+		},
+	}
+	snapshotRepositoryLastSuccessfulSnapshotAgeChartTmpl = module.Chart{
+		ID:       "snapshot_repository_%s_cluster_%s_last_successful_snapshot_age",
+		Title:    "Snapshot Repository Time Since Last Successful Snapshot",
+		Units:    "seconds",
+		Fam:      "snapshots",
+		Ctx:      "elasticsearch.snapshot_repository_last_successful_snapshot_age",
+		Priority: prioSnapshotRepositoryLastSuccessfulSnapshotAge,
+		Dims: module.Dims{
+			{ID: "snapshot_repository_%s_last_successful_snapshot_age", Name: "age"},
+		},
+	}
+)
+
+var nodeIngestPipelineChartsTmpl = module.Charts{
+	nodeIngestPipelineDocumentsChartTmpl.Copy(),
+	nodeIngestPipelineFailuresChartTmpl.Copy(),
+	nodeIngestPipelineCurrentChartTmpl.Copy(),
+	nodeIngestPipelineTimeChartTmpl.Copy(),
+}
+
+var (
+	nodeIngestPipelineDocumentsChartTmpl = module.Chart{
+		ID:       "node_%s_ingest_pipeline_%s_cluster_%s_documents",
+		Title:    "Ingest Pipeline Ingested Documents",
+		Units:    "documents/s",
+		Fam:      "ingest pipelines",
+		Ctx:      "elasticsearch.node_ingest_pipeline_documents",
+		Priority: prioNodeIngestPipelineDocuments,
+		Dims: module.Dims{
+			{ID: "node_%s_ingest_pipeline_%s_count", Name: "ingested", Algo: module.Incremental},
+		},
+	}
+	nodeIngestPipelineFailuresChartTmpl = module.Chart{
+		ID:       "node_%s_ingest_pipeline_%s_cluster_%s_failures",
+		Title:    "Ingest Pipeline Failed Documents",
+		Units:    "documents/s",
+		Fam:      "ingest pipelines",
+		Ctx:      "elasticsearch.node_ingest_pipeline_failures",
+		Priority: prioNodeIngestPipelineFailures,
+		Dims: module.Dims{
+			{ID: "node_%s_ingest_pipeline_%s_failed", Name: "failed", Algo: module.Incremental},
+		},
+	}
+	nodeIngestPipelineCurrentChartTmpl = module.Chart{
+		ID:       "node_%s_ingest_pipeline_%s_cluster_%s_current",
+		Title:    "Ingest Pipeline Documents Currently Being Ingested",
+		Units:    "documents",
+		Fam:      "ingest pipelines",
+		Ctx:      "elasticsearch.node_ingest_pipeline_current",
+		Priority: prioNodeIngestPipelineCurrent,
+		Dims: module.Dims{
+			{ID: "node_%s_ingest_pipeline_%s_current", Name: "current"},
+		},
+	}
+	nodeIngestPipelineTimeChartTmpl = module.Chart{
+		ID:       "node_%s_ingest_pipeline_%s_cluster_%s_time",
+		Title:    "Ingest Pipeline Processing Time",
+		Units:    "milliseconds",
+		Fam:      "ingest pipelines",
+		Ctx:      "elasticsearch.node_ingest_pipeline_time",
+		Priority: prioNodeIngestPipelineTime,
+		Dims: module.Dims{
+			{ID: "node_%s_ingest_pipeline_%s_time_in_millis", Name: "time", Algo: module.Incremental},
+		},
+	}
+)
+
 var nodeIndexChartsTmpl = module.Charts{
 	nodeIndexHealthChartTmpl.Copy(),
 	nodeIndexShardsCountChartTmpl.Copy(),
@@ -786,6 +992,77 @@ func (es *Elasticsearch) addClusterHealthCharts() {
 	}
 }
 
+func (es *Elasticsearch) addShardsAllocationCharts() {
+	es.addClusterCharts(shardsAllocationChartsTmpl.Copy())
+}
+
+func (es *Elasticsearch) addPendingTasksCharts() {
+	es.addClusterCharts(pendingTasksChartsTmpl.Copy())
+}
+
+func (es *Elasticsearch) addClusterCharts(charts *module.Charts) {
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, es.clusterName)
+		chart.Labels = []module.Label{
+			{Key: "cluster_name", Value: es.clusterName},
+		}
+	}
+
+	if err := es.charts.Add(*charts...); err != nil {
+		es.Warning(err)
+	}
+}
+
+func (es *Elasticsearch) addSnapshotRepositoryCharts(repo string) {
+	charts := snapshotRepositoryChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, repo, es.clusterName)
+		chart.Labels = []module.Label{
+			{Key: "cluster_name", Value: es.clusterName},
+			{Key: "repository", Value: repo},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, repo)
+		}
+	}
+
+	if err := es.Charts().Add(*charts...); err != nil {
+		es.Warning(err)
+	}
+}
+
+func (es *Elasticsearch) removeSnapshotRepositoryCharts(repo string) {
+	px := fmt.Sprintf("snapshot_repository_%s_cluster_%s_", repo, es.clusterName)
+	es.removeCharts(px)
+}
+
+func (es *Elasticsearch) addIngestPipelineCharts(p ingestPipeline, node *esNodeIngestStats) {
+	charts := nodeIngestPipelineChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, p.nodeID, p.name, es.clusterName)
+		chart.Labels = []module.Label{
+			{Key: "cluster_name", Value: es.clusterName},
+			{Key: "node_name", Value: node.Name},
+			{Key: "host", Value: node.Host},
+			{Key: "pipeline", Value: p.name},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, p.nodeID, p.name)
+		}
+	}
+
+	if err := es.Charts().Add(*charts...); err != nil {
+		es.Warning(err)
+	}
+}
+
+func (es *Elasticsearch) removeIngestPipelineCharts(p ingestPipeline) {
+	px := fmt.Sprintf("node_%s_ingest_pipeline_%s_cluster_%s_", p.nodeID, p.name, es.clusterName)
+	es.removeCharts(px)
+}
+
 func (es *Elasticsearch) addNodeCharts(nodeID string, node *esNodeStats) {
 	charts := nodeChartsTmpl.Copy()
 
@@ -9,6 +9,7 @@ import (
 	"io"
 	"math"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"sync"
@@ -23,6 +24,40 @@ const (
 	urlPathIndicesStats   = "/_cat/indices"
 	urlPathClusterHealth  = "/_cluster/health"
 	urlPathClusterStats   = "/_cluster/stats"
+
+	urlPathShards               = "/_cat/shards"
+	urlPathPendingTasks         = "/_cluster/pending_tasks"
+	urlPathSnapshotRepositories = "/_snapshot"
+	urlPathCatSnapshots         = "/_cat/snapshots"
+	urlPathLocalNodeIngestStats = "/_nodes/_local/stats/ingest"
+	urlPathNodesIngestStats     = "/_nodes/stats/ingest"
+)
+
+var (
+	shardStates = []string{"started", "initializing", "relocating", "unassigned"}
+	// https://www.elastic.co/guide/en/elasticsearch/reference/current/cat-shards.html#cat-shards-query-params
+	// (see 'unassigned.reason')
+	shardUnassignedReasons = []string{
+		"index_created",
+		"cluster_recovered",
+		"index_reopened",
+		"dangling_index_imported",
+		"new_index_restored",
+		"existing_index_restored",
+		"replica_added",
+		"allocation_failed",
+		"node_left",
+		"reroute_cancelled",
+		"reinitialized",
+		"reallocated_replica",
+		"primary_failed",
+		"forced_empty_primary",
+		"manual_allocation",
+		"index_closed",
+		"node_restarting",
+	}
+	pendingTaskPriorities = []string{"immediate", "urgent", "high", "normal", "low", "languid"}
+	snapshotStates        = []string{"success", "in_progress", "failed", "partial", "incompatible"}
 )
 
 func (es *Elasticsearch) collect() (map[string]int64, error) {
@@ -45,6 +80,10 @@ func (es *Elasticsearch) collect() (map[string]int64, error) {
 	es.collectClusterHealth(mx, ms)
 	es.collectClusterStats(mx, ms)
 	es.collectLocalIndicesStats(mx, ms)
+	es.collectShardsAllocation(mx, ms)
+	es.collectPendingTasks(mx, ms)
+	es.collectSnapshots(mx, ms)
+	es.collectNodesIngestStats(mx, ms)
 
 	return mx, nil
 }
@@ -132,6 +171,153 @@ func (es *Elasticsearch) collectLocalIndicesStats(mx map[string]int64, ms *esMet
 	}
 }
 
+func (es *Elasticsearch) collectShardsAllocation(mx map[string]int64, ms *esMetrics) {
+	if !ms.hasShardsAllocation() {
+		return
+	}
+
+	es.addShardsAllocationChartsOnce.Do(es.addShardsAllocationCharts)
+
+	for _, v := range shardStates {
+		mx["cluster_shards_state_"+v] = 0
+	}
+	for _, v := range shardUnassignedReasons {
+		mx["cluster_unassigned_shards_reason_"+v] = 0
+	}
+	mx["cluster_unassigned_shards_reason_other"] = 0
+	mx["cluster_unassigned_shards_primary"] = 0
+	mx["cluster_unassigned_shards_replica"] = 0
+
+	for _, v := range ms.ShardsAllocation {
+		state := strings.ToLower(v.State)
+		if _, ok := mx["cluster_shards_state_"+state]; ok {
+			mx["cluster_shards_state_"+state]++
+		}
+		if state != "unassigned" {
+			continue
+		}
+
+		if v.PriRep == "p" {
+			mx["cluster_unassigned_shards_primary"]++
+		} else {
+			mx["cluster_unassigned_shards_replica"]++
+		}
+
+		reason := strings.ToLower(v.UnassignedReason)
+		if _, ok := mx["cluster_unassigned_shards_reason_"+reason]; ok && reason != "other" {
+			mx["cluster_unassigned_shards_reason_"+reason]++
+		} else {
+			mx["cluster_unassigned_shards_reason_other"]++
+		}
+	}
+}
+
+func (es *Elasticsearch) collectPendingTasks(mx map[string]int64, ms *esMetrics) {
+	if !ms.hasPendingTasks() {
+		return
+	}
+
+	es.addPendingTasksChartsOnce.Do(es.addPendingTasksCharts)
+
+	for _, v := range pendingTaskPriorities {
+		mx["cluster_pending_tasks_priority_"+v] = 0
+	}
+	mx["cluster_pending_tasks_time_in_queue_max"] = 0
+
+	for _, task := range ms.PendingTasks.Tasks {
+		priority := strings.ToLower(task.Priority)
+		if _, ok := mx["cluster_pending_tasks_priority_"+priority]; ok {
+			mx["cluster_pending_tasks_priority_"+priority]++
+		}
+		if v := int64(task.TimeInQueueMillis); v > mx["cluster_pending_tasks_time_in_queue_max"] {
+			mx["cluster_pending_tasks_time_in_queue_max"] = v
+		}
+	}
+}
+
+func (es *Elasticsearch) collectSnapshots(mx map[string]int64, ms *esMetrics) {
+	if !ms.hasSnapshots() {
+		return
+	}
+
+	now := es.now()
+
+	for repo, snapshots := range ms.Snapshots {
+		if !es.snapshotRepos[repo] {
+			es.snapshotRepos[repo] = true
+			es.addSnapshotRepositoryCharts(repo)
+		}
+
+		px := fmt.Sprintf("snapshot_repository_%s_", repo)
+
+		for _, v := range snapshotStates {
+			mx[px+"snapshots_state_"+v] = 0
+		}
+
+		var lastSuccess int64
+		for _, v := range snapshots {
+			state := strings.ToLower(v.Status)
+			if _, ok := mx[px+"snapshots_state_"+state]; ok {
+				mx[px+"snapshots_state_"+state]++
+			}
+			if end, err := strconv.ParseInt(v.EndEpoch, 10, 64); err == nil && state == "success" && end > lastSuccess {
+				lastSuccess = end
+			}
+		}
+
+		// no successful snapshots yet
+		if lastSuccess > 0 {
+			mx[px+"last_successful_snapshot_age"] = now.Unix() - lastSuccess
+		}
+	}
+
+	for repo := range es.snapshotRepos {
+		if _, ok := ms.Snapshots[repo]; !ok {
+			delete(es.snapshotRepos, repo)
+			es.removeSnapshotRepositoryCharts(repo)
+		}
+	}
+}
+
+func (es *Elasticsearch) collectNodesIngestStats(mx map[string]int64, ms *esMetrics) {
+	if !ms.hasNodesIngestStats() {
+		return
+	}
+
+	seen := make(map[ingestPipeline]bool)
+
+	for nodeID, node := range ms.NodesIngestStats.Nodes {
+		for name, p := range node.Ingest.Pipelines {
+			key := ingestPipeline{nodeID: nodeID, name: name}
+			seen[key] = true
+
+			if !es.ingestPipelines[key] {
+				es.ingestPipelines[key] = true
+				es.addIngestPipelineCharts(key, node)
+			}
+
+			px := fmt.Sprintf("node_%s_ingest_pipeline_%s_", nodeID, name)
+
+			mx[px+"count"] = p.Count
+			mx[px+"failed"] = p.Failed
+			mx[px+"current"] = p.Current
+			mx[px+"time_in_millis"] = p.TimeInMillis
+		}
+	}
+
+	for key := range es.ingestPipelines {
+		if !seen[key] {
+			delete(es.ingestPipelines, key)
+			es.removeIngestPipelineCharts(key)
+		}
+	}
+}
+
+type ingestPipeline struct {
+	nodeID string
+	name   string
+}
+
 func (es *Elasticsearch) scrapeElasticsearch() *esMetrics {
 	ms := &esMetrics{}
 	wg := &sync.WaitGroup{}
@@ -152,6 +338,22 @@ func (es *Elasticsearch) scrapeElasticsearch() *esMetrics {
 		wg.Add(1)
 		go func() { defer wg.Done(); es.scrapeLocalIndicesStats(ms) }()
 	}
+	if es.DoShardsAllocation {
+		wg.Add(1)
+		go func() { defer wg.Done(); es.scrapeShardsAllocation(ms) }()
+	}
+	if es.DoPendingTasks {
+		wg.Add(1)
+		go func() { defer wg.Done(); es.scrapePendingTasks(ms) }()
+	}
+	if es.DoSnapshots {
+		wg.Add(1)
+		go func() { defer wg.Done(); es.scrapeSnapshots(ms) }()
+	}
+	if es.DoIngestStats {
+		wg.Add(1)
+		go func() { defer wg.Done(); es.scrapeNodesIngestStats(ms) }()
+	}
 	wg.Wait()
 
 	return ms
@@ -214,6 +416,89 @@ func (es *Elasticsearch) scrapeLocalIndicesStats(ms *esMetrics) {
 	ms.LocalIndicesStats = removeSystemIndices(stats)
 }
 
+func (es *Elasticsearch) scrapeShardsAllocation(ms *esMetrics) {
+	req, _ := web.NewHTTPRequest(es.Request)
+	req.URL.Path = urlPathShards
+	req.URL.RawQuery = "format=json&h=index,shard,prirep,state,unassigned.reason"
+
+	var stats []esShardAllocation
+	if err := es.doOKDecode(req, &stats); err != nil {
+		es.Warning(err)
+		return
+	}
+	if stats == nil {
+		stats = []esShardAllocation{}
+	}
+
+	ms.ShardsAllocation = stats
+}
+
+func (es *Elasticsearch) scrapePendingTasks(ms *esMetrics) {
+	req, _ := web.NewHTTPRequest(es.Request)
+	req.URL.Path = urlPathPendingTasks
+
+	var tasks esPendingTasks
+	if err := es.doOKDecode(req, &tasks); err != nil {
+		es.Warning(err)
+		return
+	}
+
+	ms.PendingTasks = &tasks
+}
+
+func (es *Elasticsearch) scrapeSnapshots(ms *esMetrics) {
+	req, _ := web.NewHTTPRequest(es.Request)
+	req.URL.Path = urlPathSnapshotRepositories
+
+	var repos map[string]interface{}
+	if err := es.doOKDecode(req, &repos); err != nil {
+		es.Warning(err)
+		return
+	}
+
+	snapshots := make(map[string][]esSnapshot)
+
+	for repo := range repos {
+		// '_cat/snapshots' is much cheaper than '_snapshot/<repo>/_all' that returns the details of every snapshot
+		req, _ := web.NewHTTPRequest(es.Request)
+		req.URL.Path = urlPathCatSnapshots + "/" + repo
+		req.URL.RawPath = urlPathCatSnapshots + "/" + url.PathEscape(repo)
+		req.URL.RawQuery = "format=json&h=id,status,end_epoch"
+
+		var resp []esSnapshot
+		if err := es.doOKDecode(req, &resp); err != nil {
+			es.Warning(err)
+			// keep the previous state to not remove the repository charts on a temporary failure
+			if prev, ok := es.snapshots[repo]; ok {
+				snapshots[repo] = prev
+			}
+			continue
+		}
+
+		snapshots[repo] = resp
+	}
+
+	es.snapshots = snapshots
+	ms.Snapshots = snapshots
+}
+
+func (es *Elasticsearch) scrapeNodesIngestStats(ms *esMetrics) {
+	req, _ := web.NewHTTPRequest(es.Request)
+	if es.ClusterMode {
+		req.URL.Path = urlPathNodesIngestStats
+	} else {
+		req.URL.Path = urlPathLocalNodeIngestStats
+	}
+
+	var stats esNodesIngestStats
+	if err := es.doOKDecode(req, &stats); err != nil {
+		es.Warning(err)
+		return
+	}
+
+	ms.NodesIngestStats = &stats
+}
+
 func (es *Elasticsearch) getClusterName() (string, error) {
 	req, _ := web.NewHTTPRequest(es.Request)
 
@@ -38,13 +38,24 @@ func New() *Elasticsearch {
 			DoClusterStats:  true,
 			DoClusterHealth: true,
 			DoIndicesStats:  false,
+
+			DoShardsAllocation: false,
+			DoPendingTasks:     false,
+			DoSnapshots:        false,
+			DoIngestStats:      false,
 		},
 
-		charts:                     &module.Charts{},
-		addClusterHealthChartsOnce: &sync.Once{},
-		addClusterStatsChartsOnce:  &sync.Once{},
-		nodes:                      make(map[string]bool),
-		indices:                    make(map[string]bool),
+		charts:                        &module.Charts{},
+		addClusterHealthChartsOnce:    &sync.Once{},
+		addClusterStatsChartsOnce:     &sync.Once{},
+		addShardsAllocationChartsOnce: &sync.Once{},
+		addPendingTasksChartsOnce:     &sync.Once{},
+		nodes:                         make(map[string]bool),
+		indices:                       make(map[string]bool),
+		snapshotRepos:                 make(map[string]bool),
+		snapshots:                     make(map[string][]esSnapshot),
+		ingestPipelines:               make(map[ingestPipeline]bool),
+		now:                           time.Now,
 	}
 }
 
@@ -55,6 +66,11 @@ type Config struct {
 	DoClusterHealth bool `yaml:"collect_cluster_health"`
 	DoClusterStats  bool `yaml:"collect_cluster_stats"`
 	DoIndicesStats  bool `yaml:"collect_indices_stats"`
+
+	DoShardsAllocation bool `yaml:"collect_shards_allocation"`
+	DoPendingTasks     bool `yaml:"collect_pending_tasks"`
+	DoSnapshots        bool `yaml:"collect_snapshots"`
+	DoIngestStats      bool `yaml:"collect_ingest_stats"`
 }
 
 type Elasticsearch struct {
@@ -69,8 +85,16 @@ type Elasticsearch struct {
 	addClusterHealthChartsOnce *sync.Once
 	addClusterStatsChartsOnce  *sync.Once
 
-	nodes   map[string]bool
-	indices map[string]bool
+	addShardsAllocationChartsOnce *sync.Once
+	addPendingTasksChartsOnce     *sync.Once
+
+	nodes           map[string]bool
+	indices         map[string]bool
+	snapshotRepos   map[string]bool
+	snapshots       map[string][]esSnapshot // the last successfully fetched snapshots per repository
+	ingestPipelines map[ingestPipeline]bool
+
+	now func() time.Time
 }
 
 func (es *Elasticsearch) Init() bool {
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/netdata/go.d.plugin/pkg/tlscfg"
 	"github.com/netdata/go.d.plugin/pkg/web"
@@ -22,6 +23,17 @@ var (
 	v842ClusterStats, _    = os.ReadFile("testdata/v8.4.2/cluster_stats.json")
 	v842CatIndicesStats, _ = os.ReadFile("testdata/v8.4.2/cat_indices_stats.json")
 	v842Info, _            = os.ReadFile("testdata/v8.4.2/info.json")
+
+	v842CatShards, _             = os.ReadFile("testdata/v8.4.2/cat_shards.json")
+	v842ClusterPendingTasks, _   = os.ReadFile("testdata/v8.4.2/cluster_pending_tasks.json")
+	v842SnapshotRepositories, _  = os.ReadFile("testdata/v8.4.2/snapshot_repositories.json")
+	v842CatSnapshots, _          = os.ReadFile("testdata/v8.4.2/cat_snapshots.json")
+	v842NodesLocalIngestStats, _ = os.ReadFile("testdata/v8.4.2/nodes_local_ingest_stats.json")
+
+	os2111Info, _                = os.ReadFile("testdata/opensearch-v2.11.1/info.json")
+	os2111CatShards, _           = os.ReadFile("testdata/opensearch-v2.11.1/cat_shards.json")
+	os2111ClusterPendingTasks, _ = os.ReadFile("testdata/opensearch-v2.11.1/cluster_pending_tasks.json")
+	os2111NodesIngestStats, _    = os.ReadFile("testdata/opensearch-v2.11.1/nodes_ingest_stats.json")
 )
 
 func Test_testDataIsCorrectlyReadAndValid(t *testing.T) {
@@ -32,6 +44,17 @@ func Test_testDataIsCorrectlyReadAndValid(t *testing.T) {
 		"v842ClusterStats":    v842ClusterStats,
 		"v842CatIndicesStats": v842CatIndicesStats,
 		"v842Info":            v842Info,
+
+		"v842CatShards":             v842CatShards,
+		"v842ClusterPendingTasks":   v842ClusterPendingTasks,
+		"v842SnapshotRepositories":  v842SnapshotRepositories,
+		"v842CatSnapshots":          v842CatSnapshots,
+		"v842NodesLocalIngestStats": v842NodesLocalIngestStats,
+
+		"os2111Info":                os2111Info,
+		"os2111CatShards":           os2111CatShards,
+		"os2111ClusterPendingTasks": os2111ClusterPendingTasks,
+		"os2111NodesIngestStats":    os2111NodesIngestStats,
 	} {
 		require.NotNilf(t, data, name)
 	}
@@ -67,6 +90,14 @@ func TestElasticsearch_Init(t *testing.T) {
 				DoIndicesStats:  false,
 			},
 		},
+		"only shards_allocation": {
+			config: Config{
+				HTTP: web.HTTP{
+					Request: web.Request{URL: "http://127.0.0.1:38001"},
+				},
+				DoShardsAllocation: true,
+			},
+		},
 		"URL not set": {
 			wantFail: true,
 			config: Config{
@@ -614,6 +645,72 @@ func TestElasticsearch_Collect(t *testing.T) {
 				"node_index_my-index-000003_stats_store_size_in_bytes": 208,
 			},
 		},
+		"v842: only shards allocation, pending tasks, snapshots and ingest stats": {
+			prepare: func() *Elasticsearch {
+				es := New()
+				es.DoNodeStats = false
+				es.DoClusterHealth = false
+				es.DoClusterStats = false
+				es.DoIndicesStats = false
+				es.DoShardsAllocation = true
+				es.DoPendingTasks = true
+				es.DoSnapshots = true
+				es.DoIngestStats = true
+				es.now = func() time.Time { return time.Unix(1664877610+3600, 0) }
+				return es
+			},
+			wantCharts: len(shardsAllocationChartsTmpl) +
+				len(pendingTasksChartsTmpl) +
+				len(snapshotRepositoryChartsTmpl) +
+				len(nodeIngestPipelineChartsTmpl)*2,
+			wantCollected: map[string]int64{
+				"cluster_pending_tasks_priority_high":                                         2,
+				"cluster_pending_tasks_priority_immediate":                                    0,
+				"cluster_pending_tasks_priority_languid":                                      0,
+				"cluster_pending_tasks_priority_low":                                          0,
+				"cluster_pending_tasks_priority_normal":                                       0,
+				"cluster_pending_tasks_priority_urgent":                                       1,
+				"cluster_pending_tasks_time_in_queue_max":                                     858,
+				"cluster_shards_state_initializing":                                           1,
+				"cluster_shards_state_relocating":                                             1,
+				"cluster_shards_state_started":                                                2,
+				"cluster_shards_state_unassigned":                                             4,
+				"cluster_unassigned_shards_primary":                                           1,
+				"cluster_unassigned_shards_reason_allocation_failed":                          1,
+				"cluster_unassigned_shards_reason_cluster_recovered":                          0,
+				"cluster_unassigned_shards_reason_dangling_index_imported":                    0,
+				"cluster_unassigned_shards_reason_existing_index_restored":                    0,
+				"cluster_unassigned_shards_reason_forced_empty_primary":                       0,
+				"cluster_unassigned_shards_reason_index_closed":                               0,
+				"cluster_unassigned_shards_reason_index_created":                              1,
+				"cluster_unassigned_shards_reason_index_reopened":                             0,
+				"cluster_unassigned_shards_reason_manual_allocation":                          0,
+				"cluster_unassigned_shards_reason_new_index_restored":                         0,
+				"cluster_unassigned_shards_reason_node_left":                                  1,
+				"cluster_unassigned_shards_reason_node_restarting":                            0,
+				"cluster_unassigned_shards_reason_other":                                      1,
+				"cluster_unassigned_shards_reason_primary_failed":                             0,
+				"cluster_unassigned_shards_reason_reallocated_replica":                        0,
+				"cluster_unassigned_shards_reason_reinitialized":                              0,
+				"cluster_unassigned_shards_reason_replica_added":                              0,
+				"cluster_unassigned_shards_reason_reroute_cancelled":                          0,
+				"cluster_unassigned_shards_replica":                                           3,
+				"node_Klg1CjgMTouentQcJlRGuA_ingest_pipeline_logs-pipeline_count":             1000,
+				"node_Klg1CjgMTouentQcJlRGuA_ingest_pipeline_logs-pipeline_current":           2,
+				"node_Klg1CjgMTouentQcJlRGuA_ingest_pipeline_logs-pipeline_failed":            3,
+				"node_Klg1CjgMTouentQcJlRGuA_ingest_pipeline_logs-pipeline_time_in_millis":    450,
+				"node_Klg1CjgMTouentQcJlRGuA_ingest_pipeline_metrics-pipeline_count":          25,
+				"node_Klg1CjgMTouentQcJlRGuA_ingest_pipeline_metrics-pipeline_current":        0,
+				"node_Klg1CjgMTouentQcJlRGuA_ingest_pipeline_metrics-pipeline_failed":         0,
+				"node_Klg1CjgMTouentQcJlRGuA_ingest_pipeline_metrics-pipeline_time_in_millis": 30,
+				"snapshot_repository_my_repository_last_successful_snapshot_age":              3600,
+				"snapshot_repository_my_repository_snapshots_state_failed":                    0,
+				"snapshot_repository_my_repository_snapshots_state_in_progress":               1,
+				"snapshot_repository_my_repository_snapshots_state_incompatible":              0,
+				"snapshot_repository_my_repository_snapshots_state_partial":                   1,
+				"snapshot_repository_my_repository_snapshots_state_success":                   1,
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -644,6 +741,103 @@ func TestElasticsearch_Collect(t *testing.T) {
 	}
 }
 
+func TestElasticsearch_Collect_OpenSearch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case urlPathShards:
+				_, _ = w.Write(os2111CatShards)
+			case urlPathPendingTasks:
+				_, _ = w.Write(os2111ClusterPendingTasks)
+			case urlPathNodesIngestStats:
+				_, _ = w.Write(os2111NodesIngestStats)
+			case "/":
+				_, _ = w.Write(os2111Info)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	defer srv.Close()
+
+	es := New()
+	es.URL = srv.URL
+	es.ClusterMode = true
+	es.DoNodeStats = false
+	es.DoClusterHealth = false
+	es.DoClusterStats = false
+	es.DoShardsAllocation = true
+	es.DoPendingTasks = true
+	es.DoIngestStats = true
+	require.True(t, es.Init())
+	require.True(t, es.Check())
+
+	mx := es.Collect()
+
+	expected := map[string]int64{
+		"cluster_shards_state_started":                                     3,
+		"cluster_shards_state_initializing":                                1,
+		"cluster_shards_state_relocating":                                  0,
+		"cluster_shards_state_unassigned":                                  2,
+		"cluster_unassigned_shards_primary":                                0,
+		"cluster_unassigned_shards_replica":                                2,
+		"cluster_unassigned_shards_reason_index_created":                   1,
+		"cluster_unassigned_shards_reason_node_left":                       1,
+		"cluster_pending_tasks_priority_urgent":                            1,
+		"cluster_pending_tasks_priority_normal":                            1,
+		"cluster_pending_tasks_time_in_queue_max":                          120,
+		"node_vH4Zr2aMQjmS3nDq8f0gTw_ingest_pipeline_logs-pipeline_count":  540,
+		"node_vH4Zr2aMQjmS3nDq8f0gTw_ingest_pipeline_logs-pipeline_failed": 2,
+		"node_nQ3p8WJxRzK0dUe7bVf1hA_ingest_pipeline_logs-pipeline_count":  300,
+	}
+	for k, v := range expected {
+		assert.Equalf(t, v, mx[k], "metric '%s'", k)
+	}
+	assert.Len(t, *es.Charts(), len(shardsAllocationChartsTmpl)+len(pendingTasksChartsTmpl)+len(nodeIngestPipelineChartsTmpl)*2)
+	ensureCollectedHasAllChartsDimsVarsIDs(t, es, mx)
+}
+
+func TestElasticsearch_Collect_SnapshotsKeepPreviousStateOnFailure(t *testing.T) {
+	var failSnapshots bool
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case urlPathSnapshotRepositories:
+				_, _ = w.Write(v842SnapshotRepositories)
+			case urlPathCatSnapshots + "/my_repository":
+				if failSnapshots {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				_, _ = w.Write(v842CatSnapshots)
+			case "/":
+				_, _ = w.Write(v842Info)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	defer srv.Close()
+
+	es := New()
+	es.URL = srv.URL
+	es.DoNodeStats = false
+	es.DoClusterHealth = false
+	es.DoClusterStats = false
+	es.DoSnapshots = true
+	require.True(t, es.Init())
+
+	mx := es.Collect()
+	require.Equal(t, int64(1), mx["snapshot_repository_my_repository_snapshots_state_success"])
+
+	failSnapshots = true
+	mx = es.Collect()
+
+	assert.Equal(t, int64(1), mx["snapshot_repository_my_repository_snapshots_state_success"])
+	assert.Equal(t, int64(1), mx["snapshot_repository_my_repository_snapshots_state_in_progress"])
+	for _, chart := range *es.Charts() {
+		assert.Falsef(t, chart.Obsolete, "chart '%s' is obsolete", chart.ID)
+	}
+}
+
 func ensureCollectedHasAllChartsDimsVarsIDs(t *testing.T, es *Elasticsearch, collected map[string]int64) {
 	for _, chart := range *es.Charts() {
 		if chart.Obsolete {
@@ -724,6 +918,16 @@ func prepareElasticsearchEndpoint() *httptest.Server {
 				_, _ = w.Write(v842ClusterStats)
 			case urlPathIndicesStats:
 				_, _ = w.Write(v842CatIndicesStats)
+			case urlPathShards:
+				_, _ = w.Write(v842CatShards)
+			case urlPathPendingTasks:
+				_, _ = w.Write(v842ClusterPendingTasks)
+			case urlPathSnapshotRepositories:
+				_, _ = w.Write(v842SnapshotRepositories)
+			case urlPathCatSnapshots + "/my_repository":
+				_, _ = w.Write(v842CatSnapshots)
+			case urlPathLocalNodeIngestStats, urlPathNodesIngestStats:
+				_, _ = w.Write(v842NodesLocalIngestStats)
 			case "/":
 				_, _ = w.Write(v842Info)
 			default:
@@ -13,7 +13,8 @@ func (es *Elasticsearch) validateConfig() error {
 	if es.URL == "" {
 		return errors.New("URL not set")
 	}
-	if !(es.DoNodeStats || es.DoClusterHealth || es.DoClusterStats || es.DoIndicesStats) {
+	if !(es.DoNodeStats || es.DoClusterHealth || es.DoClusterStats || es.DoIndicesStats ||
+		es.DoShardsAllocation || es.DoPendingTasks || es.DoSnapshots || es.DoIngestStats) {
 		return errors.New("all API calls are disabled")
 	}
 	if _, err := web.NewHTTPRequest(es.Request); err != nil {
@@ -13,11 +13,21 @@ type esMetrics struct {
 	ClusterStats *esClusterStats
 	// https://www.elastic.co/guide/en/elasticsearch/reference/current/cat-indices.html
 	LocalIndicesStats []esIndexStats
+	// https://www.elastic.co/guide/en/elasticsearch/reference/current/cat-shards.html
+	ShardsAllocation []esShardAllocation
+	// https://www.elastic.co/guide/en/elasticsearch/reference/current/cluster-pending.html
+	PendingTasks *esPendingTasks
+	// https://www.elastic.co/guide/en/elasticsearch/reference/current/get-snapshot-api.html
+	// repository name => snapshots
+	Snapshots map[string][]esSnapshot
+	// https://www.elastic.co/guide/en/elasticsearch/reference/current/cluster-nodes-stats.html (the 'ingest' metric)
+	NodesIngestStats *esNodesIngestStats
 }
 
 func (m esMetrics) empty() bool {
 	switch {
-	case m.hasNodesStats(), m.hasClusterHealth(), m.hasClusterStats(), m.hasLocalIndicesStats():
+	case m.hasNodesStats(), m.hasClusterHealth(), m.hasClusterStats(), m.hasLocalIndicesStats(),
+		m.hasShardsAllocation(), m.hasPendingTasks(), m.hasSnapshots(), m.hasNodesIngestStats():
 		return false
 	}
 	return true
@@ -27,6 +37,12 @@ func (m esMetrics) hasNodesStats() bool        { return m.NodesStats != nil && l
 func (m esMetrics) hasClusterHealth() bool     { return m.ClusterHealth != nil }
 func (m esMetrics) hasClusterStats() bool      { return m.ClusterStats != nil }
 func (m esMetrics) hasLocalIndicesStats() bool { return len(m.LocalIndicesStats) > 0 }
+func (m esMetrics) hasShardsAllocation() bool  { return m.ShardsAllocation != nil }
+func (m esMetrics) hasPendingTasks() bool      { return m.PendingTasks != nil }
+func (m esMetrics) hasSnapshots() bool         { return m.Snapshots != nil }
+func (m esMetrics) hasNodesIngestStats() bool {
+	return m.NodesIngestStats != nil && len(m.NodesIngestStats.Nodes) > 0
+}
 
 type (
 	esNodesStats struct {
@@ -275,3 +291,43 @@ type esIndexStats struct {
 	DocsCount string `json:"docs.count"`
 	StoreSize string `json:"store.size"`
 }
+
+type esShardAllocation struct {
+	Index            string
+	Shard            string
+	PriRep           string `json:"prirep"`
+	State            string
+	UnassignedReason string `json:"unassigned.reason"`
+}
+
+type esPendingTasks struct {
+	Tasks []struct {
+		Priority          string
+		TimeInQueueMillis float64 `json:"time_in_queue_millis"`
+	}
+}
+
+type esSnapshot struct {
+	ID       string `json:"id"`
+	Status   string `json:"status"`
+	EndEpoch string `json:"end_epoch"`
+}
+
+type (
+	esNodesIngestStats struct {
+		ClusterName string                        `json:"cluster_name"`
+		Nodes       map[string]*esNodeIngestStats `json:"nodes"`
+	}
+	esNodeIngestStats struct {
+		Name   string
+		Host   string
+		Ingest struct {
+			Pipelines map[string]struct {
+				Count        int64
+				TimeInMillis int64 `json:"time_in_millis"`
+				Current      int64
+				Failed       int64
+			}
+		}
+	}
+)
@@ -3,6 +3,8 @@
 package httpcheck
 
 import (
+	"fmt"
+
 	"github.com/netdata/go.d.plugin/agent/module"
 )
 
@@ -11,6 +13,11 @@ const (
 	prioResponseLength
 	prioResponseStatus
 	prioResponseInStatusDuration
+	prioResponseTimePhases
+	prioHTTPVersion
+	prioRedirects
+	prioTLSVersion
+	prioCertExpiry
 )
 
 var httpCheckCharts = module.Charts{
@@ -18,6 +25,14 @@ var httpCheckCharts = module.Charts{
 	responseLengthChart.Copy(),
 	responseStatusChart.Copy(),
 	responseInStatusDurationChart.Copy(),
+	responseTimePhasesChart.Copy(),
+	httpVersionChart.Copy(),
+	redirectsChart.Copy(),
+}
+
+var tlsCharts = module.Charts{
+	tlsVersionChart.Copy(),
+	certExpiryChart.Copy(),
 }
 
 var responseTimeChart = module.Chart{
@@ -71,3 +86,230 @@ var responseInStatusDurationChart = module.Chart{
 		{ID: "in_state", Name: "time"},
 	},
 }
+
+var (
+	httpVersions = []string{"1_0", "1_1", "2"}
+	tlsVersions  = []string{"1_0", "1_1", "1_2", "1_3"}
+)
+
+var responseTimePhasesChart = module.Chart{
+	ID:       "response_time_phases",
+	Title:    "HTTP Response Time Phases",
+	Units:    "ms",
+	Fam:      "response",
+	Ctx:      "httpcheck.response_time_phases",
+	Priority: prioResponseTimePhases,
+	Type:     module.Stacked,
+	Dims: module.Dims{
+		{ID: "time_dns_lookup", Name: "dns_lookup", Div: 1000},
+		{ID: "time_connect", Name: "connect", Div: 1000},
+		{ID: "time_tls_handshake", Name: "tls_handshake", Div: 1000},
+		{ID: "time_server_processing", Name: "server_processing", Div: 1000},
+		{ID: "time_body_transfer", Name: "body_transfer", Div: 1000},
+	},
+}
+
+var httpVersionChart = module.Chart{
+	ID:       "http_version",
+	Title:    "HTTP Negotiated Protocol Version",
+	Units:    "boolean",
+	Fam:      "protocol",
+	Ctx:      "httpcheck.http_version",
+	Priority: prioHTTPVersion,
+	Dims: module.Dims{
+		{ID: "http_version_1_0", Name: "HTTP/1.0"},
+		{ID: "http_version_1_1", Name: "HTTP/1.1"},
+		{ID: "http_version_2", Name: "HTTP/2"},
+	},
+}
+
+var redirectsChart = module.Chart{
+	ID:       "redirects",
+	Title:    "HTTP Redirects",
+	Units:    "redirects",
+	Fam:      "response",
+	Ctx:      "httpcheck.redirects",
+	Priority: prioRedirects,
+	Dims: module.Dims{
+		{ID: "redirects"},
+	},
+}
+
+var tlsVersionChart = module.Chart{
+	ID:       "tls_version",
+	Title:    "HTTP Negotiated TLS Version",
+	Units:    "boolean",
+	Fam:      "protocol",
+	Ctx:      "httpcheck.tls_version",
+	Priority: prioTLSVersion,
+	Dims: module.Dims{
+		{ID: "tls_version_1_0", Name: "TLSv1.0"},
+		{ID: "tls_version_1_1", Name: "TLSv1.1"},
+		{ID: "tls_version_1_2", Name: "TLSv1.2"},
+		{ID: "tls_version_1_3", Name: "TLSv1.3"},
+	},
+}
+
+var certExpiryChart = module.Chart{
+	ID:       "cert_expiry",
+	Title:    "HTTP Server Certificate Time Until Expiration",
+	Units:    "days",
+	Fam:      "protocol",
+	Ctx:      "httpcheck.cert_expiry",
+	Priority: prioCertExpiry,
+	Dims: module.Dims{
+		{ID: "cert_expiry", Name: "expiry", Div: 86400},
+	},
+}
+
+func (hc *HTTPCheck) addTLSCharts() {
+	charts := tlsCharts.Copy()
+
+	for _, chart := range *charts {
+		chart.Labels = []module.Label{
+			{Key: "url", Value: hc.URL},
+		}
+	}
+
+	if err := hc.Charts().Add(*charts...); err != nil {
+		hc.Warning(err)
+	}
+}
+
+// updateFinalURL keeps the redirects chart "final_url" label in sync with the last URL in the redirect chain.
+func (hc *HTTPCheck) updateFinalURL(url string) {
+	if hc.finalURL == url {
+		return
+	}
+	hc.finalURL = url
+
+	chart := hc.Charts().Get(redirectsChart.ID)
+	if chart == nil {
+		return
+	}
+	chart.Labels = []module.Label{
+		{Key: "url", Value: hc.URL},
+		{Key: "final_url", Value: url},
+	}
+	chart.MarkNotCreated()
+}
+
+const (
+	prioStepsStatus = module.Priority + 10 + iota
+	prioStepsTime
+	prioStepResponseTime
+	prioStepStatus
+	prioStepStatusCode
+	prioStepAssertions
+)
+
+var stepsCharts = module.Charts{
+	stepsStatusChart.Copy(),
+	stepsTimeChart.Copy(),
+}
+
+var (
+	stepsStatusChart = module.Chart{
+		ID:       "steps_status",
+		Title:    "HTTP Steps Check Status",
+		Units:    "boolean",
+		Fam:      "steps",
+		Ctx:      "httpcheck.steps_status",
+		Priority: prioStepsStatus,
+		Dims: module.Dims{
+			{ID: "steps_success", Name: "success"},
+			{ID: "steps_failed", Name: "failed"},
+		},
+	}
+	stepsTimeChart = module.Chart{
+		ID:       "steps_time",
+		Title:    "HTTP Steps Total Response Time",
+		Units:    "ms",
+		Fam:      "steps",
+		Ctx:      "httpcheck.steps_time",
+		Priority: prioStepsTime,
+		Dims: module.Dims{
+			{ID: "steps_time", Name: "time"},
+		},
+	}
+)
+
+var stepChartsTmpl = module.Charts{
+	stepResponseTimeChartTmpl.Copy(),
+	stepStatusChartTmpl.Copy(),
+	stepStatusCodeChartTmpl.Copy(),
+}
+
+var (
+	stepResponseTimeChartTmpl = module.Chart{
+		ID:       "step_%s_response_time",
+		Title:    "HTTP Step Response Time",
+		Units:    "ms",
+		Fam:      "steps",
+		Ctx:      "httpcheck.step_response_time",
+		Priority: prioStepResponseTime,
+		Dims: module.Dims{
+			{ID: "step_%s_time", Name: "time"},
+		},
+	}
+	stepStatusChartTmpl = module.Chart{
+		ID:       "step_%s_status",
+		Title:    "HTTP Step Status",
+		Units:    "boolean",
+		Fam:      "steps",
+		Ctx:      "httpcheck.step_status",
+		Priority: prioStepStatus,
+		Dims: module.Dims{
+			{ID: "step_%s_success", Name: "success"},
+			{ID: "step_%s_no_connection", Name: "no_connection"},
+			{ID: "step_%s_timeout", Name: "timeout"},
+			{ID: "step_%s_bad_content", Name: "bad_content"},
+			{ID: "step_%s_bad_status", Name: "bad_status"},
+			{ID: "step_%s_skipped", Name: "skipped"},
+		},
+	}
+	stepStatusCodeChartTmpl = module.Chart{
+		ID:       "step_%s_status_code",
+		Title:    "HTTP Step Response Status Code",
+		Units:    "code",
+		Fam:      "steps",
+		Ctx:      "httpcheck.step_status_code",
+		Priority: prioStepStatusCode,
+		Dims: module.Dims{
+			{ID: "step_%s_status_code", Name: "code"},
+		},
+	}
+	stepAssertionsChartTmpl = module.Chart{
+		ID:       "step_%s_assertions",
+		Title:    "HTTP Step Failed Assertions",
+		Units:    "boolean",
+		Fam:      "steps",
+		Ctx:      "httpcheck.step_assertions",
+		Priority: prioStepAssertions,
+	}
+)
+
+func newStepCharts(step *httpStep) *module.Charts {
+	charts := stepChartsTmpl.Copy()
+
+	if names := step.assertionNames(); len(names) > 0 {
+		chart := stepAssertionsChartTmpl.Copy()
+		for _, name := range names {
+			chart.Dims = append(chart.Dims, &module.Dim{ID: "step_%s_assertion_" + name + "_failed", Name: name})
+		}
+		_ = charts.Add(chart)
+	}
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, step.Name)
+		chart.Labels = []module.Label{
+			{Key: "step", Value: step.Name},
+			{Key: "url", Value: step.URL},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, step.Name)
+		}
+	}
+
+	return charts
+}
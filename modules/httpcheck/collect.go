@@ -7,6 +7,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptrace"
 	"os"
 	"time"
 
@@ -22,41 +23,68 @@ const (
 )
 
 func (hc *HTTPCheck) collect() (map[string]int64, error) {
+	mx := make(map[string]int64)
+
+	if hc.URL != "" {
+		if err := hc.collectRequest(mx); err != nil {
+			return nil, err
+		}
+	}
+	if len(hc.steps) > 0 {
+		hc.collectSteps(mx)
+	}
+
+	return mx, nil
+}
+
+func (hc *HTTPCheck) collectRequest(mx map[string]int64) error {
 	req, err := web.NewHTTPRequest(hc.Request)
 	if err != nil {
-		return nil, fmt.Errorf("error on creating HTTP requests to %s : %v", hc.Request.URL, err)
+		return fmt.Errorf("error on creating HTTP requests to %s : %v", hc.Request.URL, err)
 	}
 
 	if hc.CookieFile != "" {
 		if err := hc.readCookieFile(); err != nil {
-			return nil, fmt.Errorf("error on reading cookie file '%s': %v", hc.CookieFile, err)
+			return fmt.Errorf("error on reading cookie file '%s': %v", hc.CookieFile, err)
 		}
 	}
 
+	trace := &requestTrace{}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace.clientTrace()))
+
 	start := time.Now()
 	resp, err := hc.httpClient.Do(req)
 	dur := time.Since(start)
 
 	defer closeBody(resp)
 
-	var mx metrics
+	var m metrics
 
 	if err != nil {
 		hc.Warning(err)
-		hc.collectErrResponse(&mx, err)
+		hc.collectErrResponse(&m, err)
 	} else {
-		mx.ResponseTime = durationToMs(dur)
-		hc.collectOKResponse(&mx, resp)
+		m.ResponseTime = durationToMs(dur)
+		hc.collectOKResponse(&m, resp, trace)
 	}
 
-	if hc.metrics.Status != mx.Status {
-		mx.InState = hc.UpdateEvery
+	if hc.metrics.Status != m.Status {
+		m.InState = hc.UpdateEvery
 	} else {
-		mx.InState = hc.metrics.InState + hc.UpdateEvery
+		m.InState = hc.metrics.InState + hc.UpdateEvery
+	}
+	hc.metrics = m
+
+	for k, v := range stm.ToMap(m) {
+		mx[k] = v
+	}
+	if resp != nil {
+		hc.collectTrace(mx, resp, trace)
+	} else if err != nil {
+		hc.collectUnverifiedCertExpiry(mx, err)
 	}
-	hc.metrics = mx
 
-	return stm.ToMap(mx), nil
+	return nil
 }
 
 func (hc *HTTPCheck) collectErrResponse(mx *metrics, err error) {
@@ -70,7 +98,7 @@ func (hc *HTTPCheck) collectErrResponse(mx *metrics, err error) {
 	}
 }
 
-func (hc *HTTPCheck) collectOKResponse(mx *metrics, resp *http.Response) {
+func (hc *HTTPCheck) collectOKResponse(mx *metrics, resp *http.Response, trace *requestTrace) {
 	hc.Debugf("endpoint '%s' returned %d (%s) HTTP status code", hc.URL, resp.StatusCode, resp.Status)
 
 	if !hc.acceptedStatuses[resp.StatusCode] {
@@ -79,6 +107,9 @@ func (hc *HTTPCheck) collectOKResponse(mx *metrics, resp *http.Response) {
 	}
 
 	bs, err := io.ReadAll(resp.Body)
+	trace.mu.Lock()
+	trace.bodyDone = time.Now()
+	trace.mu.Unlock()
 	if err != nil && err != io.EOF {
 		hc.Warningf("error on reading body : %v", err)
 		mx.Status.BadContent = true
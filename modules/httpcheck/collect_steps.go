@@ -0,0 +1,202 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package httpcheck
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"strings"
+	"time"
+
+	"github.com/netdata/go.d.plugin/pkg/web"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+const (
+	stepStatusSuccess      = "success"
+	stepStatusNoConnection = "no_connection"
+	stepStatusTimeout      = "timeout"
+	stepStatusBadStatus    = "bad_status"
+	stepStatusBadContent   = "bad_content"
+	stepStatusSkipped      = "skipped"
+)
+
+var stepStatuses = []string{
+	stepStatusSuccess,
+	stepStatusNoConnection,
+	stepStatusTimeout,
+	stepStatusBadStatus,
+	stepStatusBadContent,
+	stepStatusSkipped,
+}
+
+// collectSteps runs the steps in order, they share a cookie jar and the extracted variables.
+// A failed step stops the sequence, the remaining steps are reported as skipped.
+func (hc *HTTPCheck) collectSteps(mx map[string]int64) {
+	jar, _ := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
+	client := *hc.httpClient
+	client.Jar = jar
+
+	vars := make(map[string]string)
+	var total time.Duration
+	failed := false
+
+	for _, step := range hc.steps {
+		px := "step_" + step.Name + "_"
+
+		for _, st := range stepStatuses {
+			mx[px+st] = 0
+		}
+		for _, name := range step.assertionNames() {
+			mx[px+"assertion_"+name+"_failed"] = 0
+		}
+		mx[px+"time"] = 0
+		mx[px+"status_code"] = 0
+
+		if failed {
+			mx[px+stepStatusSkipped] = 1
+			continue
+		}
+
+		dur, status := hc.runStep(&client, step, vars, mx, px)
+		total += dur
+		mx[px+status] = 1
+		failed = status != stepStatusSuccess
+	}
+
+	mx["steps_time"] = int64(durationToMs(total))
+	mx["steps_success"] = boolToInt(!failed)
+	mx["steps_failed"] = boolToInt(failed)
+}
+
+func (hc *HTTPCheck) runStep(client *http.Client, step *httpStep, vars map[string]string, mx map[string]int64, px string) (time.Duration, string) {
+	req, err := web.NewHTTPRequest(expandRequest(step.Request, vars))
+	if err != nil {
+		hc.Warningf("step '%s': error on creating HTTP request: %v", step.Name, err)
+		return 0, stepStatusNoConnection
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	dur := time.Since(start)
+
+	defer closeBody(resp)
+
+	if err != nil {
+		hc.Warningf("step '%s': %v", step.Name, err)
+		if decodeReqError(err) == codeTimeout {
+			return dur, stepStatusTimeout
+		}
+		return dur, stepStatusNoConnection
+	}
+
+	mx[px+"time"] = int64(durationToMs(dur))
+	mx[px+"status_code"] = int64(resp.StatusCode)
+
+	hc.Debugf("step '%s': endpoint '%s' returned %d (%s) HTTP status code", step.Name, req.URL, resp.StatusCode, resp.Status)
+
+	if !step.acceptedStatuses[resp.StatusCode] {
+		return dur, stepStatusBadStatus
+	}
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil && err != io.EOF {
+		hc.Warningf("step '%s': error on reading body : %v", step.Name, err)
+		return dur, stepStatusBadContent
+	}
+
+	var doc interface{}
+	if json.Valid(bs) {
+		_ = json.Unmarshal(bs, &doc)
+	}
+
+	value := func(jsonPath, header string) (string, bool) {
+		switch {
+		case jsonPath != "":
+			return lookupJSONPath(doc, jsonPath)
+		case header != "":
+			vs := resp.Header.Values(header)
+			return strings.Join(vs, ", "), len(vs) > 0
+		default:
+			return string(bs), true
+		}
+	}
+
+	ok := true
+
+	if step.MaxResponseTime.Duration > 0 && dur > step.MaxResponseTime.Duration {
+		hc.Debugf("step '%s': response time %s exceeds %s", step.Name, dur, step.MaxResponseTime)
+		mx[px+"assertion_response_time_failed"] = 1
+		ok = false
+	}
+
+	for _, a := range step.assertions {
+		if v, found := value(a.jsonPath, a.header); !found || !a.check(v) {
+			hc.Debugf("step '%s': assertion '%s' failed (value '%s')", step.Name, a.name, v)
+			mx[px+"assertion_"+a.name+"_failed"] = 1
+			ok = false
+		}
+	}
+
+	for _, ex := range step.extractors {
+		v, found := value(ex.jsonPath, ex.header)
+		if found && ex.re != nil {
+			v, found = extractRegexp(ex, v)
+		}
+		if !found {
+			hc.Warningf("step '%s': failed to extract '%s'", step.Name, ex.name)
+			ok = false
+			continue
+		}
+		vars[ex.name] = v
+	}
+
+	if !ok {
+		return dur, stepStatusBadContent
+	}
+	return dur, stepStatusSuccess
+}
+
+// extractRegexp returns the first capturing group, or the whole match if the expression has no groups.
+func extractRegexp(ex *stepExtractor, s string) (string, bool) {
+	m := ex.re.FindStringSubmatch(s)
+	switch len(m) {
+	case 0:
+		return "", false
+	case 1:
+		return m[0], true
+	default:
+		return m[1], true
+	}
+}
+
+// expandRequest substitutes ${name} references to the extracted variables in the URL, body and header values.
+func expandRequest(req web.Request, vars map[string]string) web.Request {
+	req = req.Copy()
+	if len(vars) == 0 {
+		return req
+	}
+
+	var pairs []string
+	for k, v := range vars {
+		pairs = append(pairs, "${"+k+"}", v)
+	}
+	r := strings.NewReplacer(pairs...)
+
+	req.URL = r.Replace(req.URL)
+	req.Body = r.Replace(req.Body)
+	for k, v := range req.Headers {
+		req.Headers[k] = r.Replace(v)
+	}
+	return req
+}
+
+func boolToInt(v bool) int64 {
+	if v {
+		return 1
+	}
+	return 0
+}
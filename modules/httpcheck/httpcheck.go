@@ -5,6 +5,7 @@ package httpcheck
 import (
 	"net/http"
 	"regexp"
+	"sync"
 	"time"
 
 	"github.com/netdata/go.d.plugin/pkg/web"
@@ -32,15 +33,17 @@ func New() *HTTPCheck {
 			AcceptedStatuses: []int{200},
 		},
 		acceptedStatuses: make(map[int]bool),
+		addTLSChartsOnce: &sync.Once{},
 	}
 }
 
 type Config struct {
 	web.HTTP         `yaml:",inline"`
-	UpdateEvery      int    `yaml:"update_every"`
-	AcceptedStatuses []int  `yaml:"status_accepted"`
-	ResponseMatch    string `yaml:"response_match"`
-	CookieFile       string `yaml:"cookie_file"`
+	UpdateEvery      int          `yaml:"update_every"`
+	AcceptedStatuses []int        `yaml:"status_accepted"`
+	ResponseMatch    string       `yaml:"response_match"`
+	CookieFile       string       `yaml:"cookie_file"`
+	Steps            []StepConfig `yaml:"steps"`
 }
 
 type HTTPCheck struct {
@@ -54,8 +57,13 @@ type HTTPCheck struct {
 	acceptedStatuses map[int]bool
 	reResponse       *regexp.Regexp
 
+	steps []*httpStep
+
 	cookieFileModTime time.Time
 
+	addTLSChartsOnce *sync.Once
+	finalURL         string
+
 	metrics metrics
 }
 
@@ -65,6 +73,13 @@ func (hc *HTTPCheck) Init() bool {
 		return false
 	}
 
+	steps, err := newHTTPSteps(hc.Steps)
+	if err != nil {
+		hc.Errorf("init steps: %v", err)
+		return false
+	}
+	hc.steps = steps
+
 	hc.charts = hc.initCharts()
 
 	httpClient, err := hc.initHTTPClient()
@@ -85,12 +100,17 @@ func (hc *HTTPCheck) Init() bool {
 		hc.acceptedStatuses[v] = true
 	}
 
-	hc.Debugf("using URL %s", hc.URL)
+	if hc.URL != "" {
+		hc.Debugf("using URL %s", hc.URL)
+	}
 	hc.Debugf("using HTTP timeout %s", hc.Timeout.Duration)
 	hc.Debugf("using accepted HTTP statuses %v", hc.AcceptedStatuses)
 	if hc.reResponse != nil {
 		hc.Debugf("using response match regexp %s", hc.reResponse)
 	}
+	for _, step := range hc.steps {
+		hc.Debugf("using step '%s': %s %s", step.Name, step.Method, step.URL)
+	}
 
 	return true
 }
@@ -5,9 +5,11 @@ package httpcheck
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/netdata/go.d.plugin/agent/module"
 	"github.com/netdata/go.d.plugin/pkg/web"
 
 	"github.com/stretchr/testify/assert"
@@ -39,6 +41,69 @@ func TestHTTPCheck_Init(t *testing.T) {
 				},
 			},
 		},
+		"success if only steps set": {
+			wantFail: false,
+			config: Config{
+				Steps: []StepConfig{
+					{Name: "login", Request: web.Request{URL: "http://127.0.0.1:38001/login"}},
+				},
+			},
+		},
+		"fail if step name not set": {
+			wantFail: true,
+			config: Config{
+				Steps: []StepConfig{
+					{Request: web.Request{URL: "http://127.0.0.1:38001/login"}},
+				},
+			},
+		},
+		"success if step extract has json_path and regexp": {
+			config: Config{
+				Steps: []StepConfig{
+					{
+						Name:    "login",
+						Request: web.Request{URL: "http://127.0.0.1:38001/login"},
+						Extract: []ExtractConfig{{Name: "token", JSONPath: "token", Regexp: "^Bearer (.+)$"}},
+					},
+				},
+			},
+		},
+		"fail if step extract has no source": {
+			wantFail: true,
+			config: Config{
+				Steps: []StepConfig{
+					{
+						Name:    "login",
+						Request: web.Request{URL: "http://127.0.0.1:38001/login"},
+						Extract: []ExtractConfig{{Name: "token"}},
+					},
+				},
+			},
+		},
+		"fail if step extract has several sources": {
+			wantFail: true,
+			config: Config{
+				Steps: []StepConfig{
+					{
+						Name:    "login",
+						Request: web.Request{URL: "http://127.0.0.1:38001/login"},
+						Extract: []ExtractConfig{{Name: "token", JSONPath: "token", Header: "X-Token"}},
+					},
+				},
+			},
+		},
+		"fail if step assertion has no condition": {
+			wantFail: true,
+			config: Config{
+				Steps: []StepConfig{
+					{
+						Name:       "login",
+						Request:    web.Request{URL: "http://127.0.0.1:38001/login"},
+						Assertions: []AssertionConfig{{JSONPath: "status"}},
+					},
+				},
+			},
+		},
 		"fail if wrong response regex": {
 			wantFail: true,
 			config: Config{
@@ -147,14 +212,23 @@ func TestHTTPCheck_Collect(t *testing.T) {
 		"success case": {
 			prepare: prepareSuccessCase,
 			wantMetrics: map[string]int64{
-				"bad_content":   0,
-				"bad_status":    0,
-				"in_state":      2,
-				"length":        5,
-				"no_connection": 0,
-				"success":       1,
-				"time":          0,
-				"timeout":       0,
+				"http_version_1_0":       0,
+				"http_version_1_1":       1,
+				"http_version_2":         0,
+				"redirects":              0,
+				"time_body_transfer":     0,
+				"time_connect":           0,
+				"time_dns_lookup":        0,
+				"time_server_processing": 0,
+				"time_tls_handshake":     0,
+				"bad_content":            0,
+				"bad_status":             0,
+				"in_state":               2,
+				"length":                 5,
+				"no_connection":          0,
+				"success":                1,
+				"time":                   0,
+				"timeout":                0,
 			},
 		},
 		"timeout case": {
@@ -173,27 +247,45 @@ func TestHTTPCheck_Collect(t *testing.T) {
 		"bad status case": {
 			prepare: prepareBadStatusCase,
 			wantMetrics: map[string]int64{
-				"bad_content":   0,
-				"bad_status":    1,
-				"in_state":      2,
-				"length":        0,
-				"no_connection": 0,
-				"success":       0,
-				"time":          0,
-				"timeout":       0,
+				"http_version_1_0":       0,
+				"http_version_1_1":       1,
+				"http_version_2":         0,
+				"redirects":              0,
+				"time_body_transfer":     0,
+				"time_connect":           0,
+				"time_dns_lookup":        0,
+				"time_server_processing": 0,
+				"time_tls_handshake":     0,
+				"bad_content":            0,
+				"bad_status":             1,
+				"in_state":               2,
+				"length":                 0,
+				"no_connection":          0,
+				"success":                0,
+				"time":                   0,
+				"timeout":                0,
 			},
 		},
 		"bad content case": {
 			prepare: prepareBadContentCase,
 			wantMetrics: map[string]int64{
-				"bad_content":   1,
-				"bad_status":    0,
-				"in_state":      2,
-				"length":        17,
-				"no_connection": 0,
-				"success":       0,
-				"time":          0,
-				"timeout":       0,
+				"http_version_1_0":       0,
+				"http_version_1_1":       1,
+				"http_version_2":         0,
+				"redirects":              0,
+				"time_body_transfer":     0,
+				"time_connect":           0,
+				"time_dns_lookup":        0,
+				"time_server_processing": 0,
+				"time_tls_handshake":     0,
+				"bad_content":            1,
+				"bad_status":             0,
+				"in_state":               2,
+				"length":                 17,
+				"no_connection":          0,
+				"success":                0,
+				"time":                   0,
+				"timeout":                0,
 			},
 		},
 		"no connection case": {
@@ -212,14 +304,23 @@ func TestHTTPCheck_Collect(t *testing.T) {
 		"cookie auth case": {
 			prepare: prepareCookieAuthCase,
 			wantMetrics: map[string]int64{
-				"bad_content":   0,
-				"bad_status":    0,
-				"in_state":      2,
-				"length":        0,
-				"no_connection": 0,
-				"success":       1,
-				"time":          0,
-				"timeout":       0,
+				"http_version_1_0":       0,
+				"http_version_1_1":       1,
+				"http_version_2":         0,
+				"redirects":              0,
+				"time_body_transfer":     0,
+				"time_connect":           0,
+				"time_dns_lookup":        0,
+				"time_server_processing": 0,
+				"time_tls_handshake":     0,
+				"bad_content":            0,
+				"bad_status":             0,
+				"in_state":               2,
+				"length":                 0,
+				"no_connection":          0,
+				"success":                1,
+				"time":                   0,
+				"timeout":                0,
 			},
 		},
 	}
@@ -245,6 +346,214 @@ func TestHTTPCheck_Collect(t *testing.T) {
 	}
 }
 
+func TestHTTPCheck_Collect_TLSAndRedirects(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/old" {
+				http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+				return
+			}
+			_, _ = w.Write([]byte("ok"))
+		}))
+	defer srv.Close()
+
+	httpCheck := New()
+	httpCheck.URL = srv.URL + "/old"
+	httpCheck.TLSConfig.InsecureSkipVerify = true
+	require.True(t, httpCheck.Init())
+
+	mx := httpCheck.Collect()
+	require.NotNil(t, mx)
+
+	assert.Equal(t, int64(1), mx["success"])
+	assert.Equal(t, int64(1), mx["redirects"])
+	assert.Equal(t, int64(1), mx["http_version_1_1"])
+	assert.Equal(t, int64(1), mx["tls_version_1_3"])
+	assert.Greater(t, mx["cert_expiry"], int64(0))
+	assert.Greater(t, mx["time_tls_handshake"], int64(0))
+
+	require.NotNil(t, httpCheck.Charts().Get(tlsVersionChart.ID))
+	require.NotNil(t, httpCheck.Charts().Get(certExpiryChart.ID))
+
+	chart := httpCheck.Charts().Get(redirectsChart.ID)
+	require.NotNil(t, chart)
+	assert.Contains(t, chart.Labels, module.Label{Key: "final_url", Value: srv.URL + "/new"})
+}
+
+func TestHTTPCheck_Collect_CertExpiryOnVerificationError(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		}))
+	defer srv.Close()
+
+	httpCheck := New()
+	httpCheck.URL = srv.URL
+	require.True(t, httpCheck.Init())
+
+	mx := httpCheck.Collect()
+	require.NotNil(t, mx)
+
+	assert.Equal(t, int64(1), mx["no_connection"])
+	assert.Greater(t, mx["cert_expiry"], int64(0))
+	assert.NotNil(t, httpCheck.Charts().Get(certExpiryChart.ID))
+}
+
+func TestHTTPCheck_Collect_TimingsOnEveryRun(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		}))
+	defer srv.Close()
+
+	httpCheck := New()
+	// use a hostname, the DNS lookup is skipped for IP addresses
+	httpCheck.URL = strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)
+	httpCheck.TLSConfig.InsecureSkipVerify = true
+	require.True(t, httpCheck.Init())
+
+	for i := 0; i < 2; i++ {
+		mx := httpCheck.Collect()
+		require.NotNil(t, mx)
+
+		assert.Equalf(t, int64(1), mx["success"], "run %d", i+1)
+		assert.Greaterf(t, mx["time_dns_lookup"], int64(0), "run %d", i+1)
+		assert.Greaterf(t, mx["time_connect"], int64(0), "run %d", i+1)
+		assert.Greaterf(t, mx["time_tls_handshake"], int64(0), "run %d", i+1)
+	}
+}
+
+func TestHTTPCheck_Collect_Steps(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/login":
+				if r.Method != http.MethodPost || r.FormValue("user") != "netdata" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				http.SetCookie(w, &http.Cookie{Name: "session", Value: "s1"})
+				w.Header().Set("X-Request-Id", "req-42")
+				_, _ = w.Write([]byte(`{"data":{"token":"abc","session":"ttl=60s"}}`))
+			case "/api/items":
+				q := r.URL.Query()
+				if r.Header.Get("Authorization") != "Bearer abc" || q.Get("req") != "42" || q.Get("ttl") != "60" {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				if c, err := r.Cookie("session"); err != nil || c.Value != "s1" {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"status":"ok","items":[{"id":1,"enabled":true}]}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	defer srv.Close()
+
+	steps := []StepConfig{
+		{
+			Name: "login",
+			Request: web.Request{
+				URL:     srv.URL + "/login",
+				Method:  http.MethodPost,
+				Headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+				Body:    "user=netdata",
+			},
+			Extract: []ExtractConfig{
+				{Name: "token", JSONPath: "data.token"},
+				{Name: "req_id", Header: "X-Request-Id", Regexp: `^req-(\d+)$`},
+				{Name: "ttl", JSONPath: "data.session", Regexp: `ttl=(\d+)s`},
+			},
+		},
+		{
+			Name: "items",
+			Request: web.Request{
+				URL:     srv.URL + "/api/items?req=${req_id}&ttl=${ttl}",
+				Headers: map[string]string{"Authorization": "Bearer ${token}"},
+			},
+			MaxResponseTime: web.Duration{Duration: time.Second * 5},
+			Assertions: []AssertionConfig{
+				{Name: "status_ok", JSONPath: "status", Equals: "ok"},
+				{Name: "first_enabled", JSONPath: "items.0.enabled", Equals: "true"},
+				{Name: "json", Header: "Content-Type", Match: "^application/json"},
+				{Name: "has_id", Match: `"id":\d+`},
+			},
+		},
+		{
+			Name:       "missing",
+			Request:    web.Request{URL: srv.URL + "/missing"},
+			Assertions: []AssertionConfig{{Name: "never", Equals: "x"}},
+		},
+		{
+			Name:    "after",
+			Request: web.Request{URL: srv.URL + "/login"},
+		},
+	}
+
+	httpCheck := New()
+	httpCheck.Steps = steps
+	require.True(t, httpCheck.Init())
+
+	mx := httpCheck.Collect()
+
+	// response times are not deterministic
+	for _, name := range []string{"login", "items", "missing", "after"} {
+		delete(mx, "step_"+name+"_time")
+	}
+	delete(mx, "steps_time")
+
+	expected := map[string]int64{
+		"steps_success": 0,
+		"steps_failed":  1,
+
+		"step_login_status_code":   200,
+		"step_login_success":       1,
+		"step_login_no_connection": 0,
+		"step_login_timeout":       0,
+		"step_login_bad_status":    0,
+		"step_login_bad_content":   0,
+		"step_login_skipped":       0,
+
+		"step_items_status_code":                    200,
+		"step_items_success":                        1,
+		"step_items_no_connection":                  0,
+		"step_items_timeout":                        0,
+		"step_items_bad_status":                     0,
+		"step_items_bad_content":                    0,
+		"step_items_skipped":                        0,
+		"step_items_assertion_response_time_failed": 0,
+		"step_items_assertion_status_ok_failed":     0,
+		"step_items_assertion_first_enabled_failed": 0,
+		"step_items_assertion_json_failed":          0,
+		"step_items_assertion_has_id_failed":        0,
+
+		"step_missing_status_code":            404,
+		"step_missing_success":                0,
+		"step_missing_no_connection":          0,
+		"step_missing_timeout":                0,
+		"step_missing_bad_status":             1,
+		"step_missing_bad_content":            0,
+		"step_missing_skipped":                0,
+		"step_missing_assertion_never_failed": 0,
+
+		"step_after_status_code":   0,
+		"step_after_success":       0,
+		"step_after_no_connection": 0,
+		"step_after_timeout":       0,
+		"step_after_bad_status":    0,
+		"step_after_bad_content":   0,
+		"step_after_skipped":       1,
+	}
+
+	assert.Equal(t, expected, mx)
+	assert.NotNil(t, httpCheck.Charts().Get("step_items_assertions"))
+	assert.Nil(t, httpCheck.Charts().Get("step_login_assertions"))
+	assert.Nil(t, httpCheck.Charts().Get("request_status"))
+}
+
 func prepareSuccessCase() (*HTTPCheck, func()) {
 	httpCheck := New()
 	httpCheck.UpdateEvery = 1
@@ -334,9 +643,9 @@ func prepareCookieAuthCase() (*HTTPCheck, func()) {
 }
 
 func copyResponseTime(dst, src map[string]int64) {
-	if v, ok := src["time"]; ok {
-		if _, ok := dst["time"]; ok {
-			dst["time"] = v
+	for k, v := range src {
+		if _, ok := dst[k]; ok && (k == "time" || strings.HasPrefix(k, "time_")) {
+			dst[k] = v
 		}
 	}
 }
@@ -12,14 +12,23 @@ import (
 )
 
 func (hc *HTTPCheck) validateConfig() error {
-	if hc.URL == "" {
-		return errors.New("'url' not set")
+	if hc.URL == "" && len(hc.Steps) == 0 {
+		return errors.New("'url' or 'steps' not set")
 	}
 	return nil
 }
 
 func (hc *HTTPCheck) initHTTPClient() (*http.Client, error) {
-	return web.NewHTTPClient(hc.Client)
+	client, err := web.NewHTTPClient(hc.Client)
+	if err != nil {
+		return nil, err
+	}
+	// A reused connection skips the DNS lookup, connect and TLS handshake phases,
+	// so every check opens a new one to keep the timings meaningful.
+	if tr, ok := client.Transport.(*http.Transport); ok {
+		tr.DisableKeepAlives = true
+	}
+	return client, nil
 }
 
 func (hc *HTTPCheck) initResponseMatchRegexp() (*regexp.Regexp, error) {
@@ -30,11 +39,29 @@ func (hc *HTTPCheck) initResponseMatchRegexp() (*regexp.Regexp, error) {
 }
 
 func (hc *HTTPCheck) initCharts() *module.Charts {
-	charts := httpCheckCharts.Copy()
+	charts := &module.Charts{}
 
-	for _, chart := range *charts {
-		chart.Labels = []module.Label{
-			{Key: "url", Value: hc.URL},
+	if hc.URL != "" {
+		charts = httpCheckCharts.Copy()
+		for _, chart := range *charts {
+			chart.Labels = []module.Label{
+				{Key: "url", Value: hc.URL},
+			}
+		}
+		if chart := charts.Get(redirectsChart.ID); chart != nil {
+			chart.Labels = append(chart.Labels, module.Label{Key: "final_url", Value: hc.URL})
+		}
+		hc.finalURL = hc.URL
+	}
+
+	if len(hc.steps) > 0 {
+		if err := charts.Add(*stepsCharts.Copy()...); err != nil {
+			hc.Warning(err)
+		}
+		for _, step := range hc.steps {
+			if err := charts.Add(*newStepCharts(step)...); err != nil {
+				hc.Warning(err)
+			}
 		}
 	}
 
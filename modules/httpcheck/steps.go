@@ -0,0 +1,213 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package httpcheck
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/netdata/go.d.plugin/pkg/web"
+)
+
+type (
+	StepConfig struct {
+		web.Request      `yaml:",inline"`
+		Name             string            `yaml:"name"`
+		AcceptedStatuses []int             `yaml:"status_accepted"`
+		MaxResponseTime  web.Duration      `yaml:"max_response_time"`
+		Extract          []ExtractConfig   `yaml:"extract"`
+		Assertions       []AssertionConfig `yaml:"assertions"`
+	}
+	ExtractConfig struct {
+		Name     string `yaml:"name"`
+		JSONPath string `yaml:"json_path"`
+		Header   string `yaml:"header"`
+		Regexp   string `yaml:"regexp"`
+	}
+	AssertionConfig struct {
+		Name     string `yaml:"name"`
+		JSONPath string `yaml:"json_path"`
+		Header   string `yaml:"header"`
+		Equals   string `yaml:"equals"`
+		Match    string `yaml:"match"`
+	}
+)
+
+var reStepName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+type (
+	httpStep struct {
+		StepConfig
+		acceptedStatuses map[int]bool
+		extractors       []*stepExtractor
+		assertions       []*stepAssertion
+	}
+	stepExtractor struct {
+		name     string
+		jsonPath string
+		header   string
+		re       *regexp.Regexp
+	}
+	stepAssertion struct {
+		name     string
+		jsonPath string
+		header   string
+		equals   string
+		re       *regexp.Regexp
+	}
+)
+
+func newHTTPSteps(configs []StepConfig) ([]*httpStep, error) {
+	var steps []*httpStep
+	seen := make(map[string]bool)
+
+	for i, cfg := range configs {
+		if cfg.Name == "" {
+			return nil, fmt.Errorf("step %d: 'name' not set", i+1)
+		}
+		if !reStepName.MatchString(cfg.Name) {
+			return nil, fmt.Errorf("step '%s': 'name' must contain only letters, digits, '_' and '-'", cfg.Name)
+		}
+		if seen[cfg.Name] {
+			return nil, fmt.Errorf("step '%s': duplicate name", cfg.Name)
+		}
+		seen[cfg.Name] = true
+
+		step, err := newHTTPStep(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("step '%s': %v", cfg.Name, err)
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+func newHTTPStep(cfg StepConfig) (*httpStep, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("'url' not set")
+	}
+
+	step := &httpStep{StepConfig: cfg, acceptedStatuses: make(map[int]bool)}
+
+	statuses := cfg.AcceptedStatuses
+	if len(statuses) == 0 {
+		statuses = []int{200}
+	}
+	for _, v := range statuses {
+		step.acceptedStatuses[v] = true
+	}
+
+	for _, ec := range cfg.Extract {
+		if ec.Name == "" {
+			return nil, errors.New("extract: 'name' not set")
+		}
+		if ec.JSONPath != "" && ec.Header != "" {
+			return nil, fmt.Errorf("extract '%s': only one of 'json_path' or 'header' can be set", ec.Name)
+		}
+		if countSet(ec.JSONPath, ec.Header, ec.Regexp) == 0 {
+			return nil, fmt.Errorf("extract '%s': one of 'json_path', 'header' or 'regexp' must be set", ec.Name)
+		}
+		ex := &stepExtractor{name: ec.Name, jsonPath: ec.JSONPath, header: ec.Header}
+		if ec.Regexp != "" {
+			re, err := regexp.Compile(ec.Regexp)
+			if err != nil {
+				return nil, fmt.Errorf("extract '%s': %v", ec.Name, err)
+			}
+			ex.re = re
+		}
+		step.extractors = append(step.extractors, ex)
+	}
+
+	for i, ac := range cfg.Assertions {
+		name := ac.Name
+		if name == "" {
+			name = "assertion" + strconv.Itoa(i+1)
+		}
+		if !reStepName.MatchString(name) {
+			return nil, fmt.Errorf("assertion '%s': 'name' must contain only letters, digits, '_' and '-'", name)
+		}
+		if name == "response_time" {
+			return nil, fmt.Errorf("assertion '%s': the name is reserved for 'max_response_time'", name)
+		}
+		if ac.JSONPath != "" && ac.Header != "" {
+			return nil, fmt.Errorf("assertion '%s': only one of 'json_path' or 'header' can be set", name)
+		}
+		if countSet(ac.Equals, ac.Match) != 1 {
+			return nil, fmt.Errorf("assertion '%s': exactly one of 'equals' or 'match' must be set", name)
+		}
+		as := &stepAssertion{name: name, jsonPath: ac.JSONPath, header: ac.Header, equals: ac.Equals}
+		if ac.Match != "" {
+			re, err := regexp.Compile(ac.Match)
+			if err != nil {
+				return nil, fmt.Errorf("assertion '%s': %v", name, err)
+			}
+			as.re = re
+		}
+		step.assertions = append(step.assertions, as)
+	}
+
+	return step, nil
+}
+
+func (s *httpStep) assertionNames() []string {
+	var names []string
+	if s.MaxResponseTime.Duration > 0 {
+		names = append(names, "response_time")
+	}
+	for _, a := range s.assertions {
+		names = append(names, a.name)
+	}
+	return names
+}
+
+func (a *stepAssertion) check(value string) bool {
+	if a.re != nil {
+		return a.re.MatchString(value)
+	}
+	return value == a.equals
+}
+
+// lookupJSONPath resolves a dot-separated path (array elements are addressed by index, e.g. "data.items.0.id")
+// and returns the value as a string: strings as is, everything else JSON encoded.
+func lookupJSONPath(doc interface{}, path string) (string, bool) {
+	v := doc
+	for _, key := range strings.Split(path, ".") {
+		switch node := v.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if v, ok = node[key]; !ok {
+				return "", false
+			}
+		case []interface{}:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return "", false
+			}
+			v = node[idx]
+		default:
+			return "", false
+		}
+	}
+
+	if s, ok := v.(string); ok {
+		return s, true
+	}
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return "", false
+	}
+	return string(bs), true
+}
+
+func countSet(values ...string) (n int) {
+	for _, v := range values {
+		if v != "" {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package httpcheck
+
+import (
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// requestTrace accumulates the request phases durations over all the hops if the request is redirected.
+type requestTrace struct {
+	mu sync.Mutex
+
+	dnsStart     time.Time
+	connectStart time.Time
+	tlsStart     time.Time
+	wroteRequest time.Time
+	firstByte    time.Time
+	bodyDone     time.Time
+
+	dnsLookup        time.Duration
+	connect          time.Duration
+	tlsHandshake     time.Duration
+	serverProcessing time.Duration
+}
+
+func (t *requestTrace) clientTrace() *httptrace.ClientTrace {
+	start := func(ts *time.Time) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		// dual-stack dialing may start several connections, only the first one is taken into account
+		if ts.IsZero() {
+			*ts = time.Now()
+		}
+	}
+	done := func(ts *time.Time, d *time.Duration) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if !ts.IsZero() {
+			*d += time.Since(*ts)
+			*ts = time.Time{}
+		}
+	}
+
+	return &httptrace.ClientTrace{
+		DNSStart:          func(httptrace.DNSStartInfo) { start(&t.dnsStart) },
+		DNSDone:           func(httptrace.DNSDoneInfo) { done(&t.dnsStart, &t.dnsLookup) },
+		ConnectStart:      func(string, string) { start(&t.connectStart) },
+		ConnectDone:       func(string, string, error) { done(&t.connectStart, &t.connect) },
+		TLSHandshakeStart: func() { start(&t.tlsStart) },
+		TLSHandshakeDone:  func(tls.ConnectionState, error) { done(&t.tlsStart, &t.tlsHandshake) },
+		WroteRequest:      func(httptrace.WroteRequestInfo) { start(&t.wroteRequest) },
+		GotFirstResponseByte: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.firstByte = time.Now()
+			if !t.wroteRequest.IsZero() {
+				t.serverProcessing += t.firstByte.Sub(t.wroteRequest)
+				t.wroteRequest = time.Time{}
+			}
+		},
+	}
+}
+
+func (hc *HTTPCheck) collectTrace(mx map[string]int64, resp *http.Response, t *requestTrace) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	mx["time_dns_lookup"] = t.dnsLookup.Microseconds()
+	mx["time_connect"] = t.connect.Microseconds()
+	mx["time_tls_handshake"] = t.tlsHandshake.Microseconds()
+	mx["time_server_processing"] = t.serverProcessing.Microseconds()
+	// body of the last hop only, the redirect responses bodies are not read
+	mx["time_body_transfer"] = 0
+	if !t.firstByte.IsZero() && t.bodyDone.After(t.firstByte) {
+		mx["time_body_transfer"] = t.bodyDone.Sub(t.firstByte).Microseconds()
+	}
+
+	for _, v := range httpVersions {
+		mx["http_version_"+v] = 0
+	}
+	switch {
+	case resp.ProtoMajor == 1 && resp.ProtoMinor == 0:
+		mx["http_version_1_0"] = 1
+	case resp.ProtoMajor == 1:
+		mx["http_version_1_1"] = 1
+	case resp.ProtoMajor == 2:
+		mx["http_version_2"] = 1
+	}
+
+	var redirects int64
+	for r := resp.Request; r != nil && r.Response != nil; r = r.Response.Request {
+		redirects++
+	}
+	mx["redirects"] = redirects
+	hc.updateFinalURL(resp.Request.URL.String())
+
+	if resp.TLS == nil {
+		return
+	}
+
+	hc.addTLSChartsOnce.Do(hc.addTLSCharts)
+
+	for _, v := range tlsVersions {
+		mx["tls_version_"+v] = 0
+	}
+	switch resp.TLS.Version {
+	case tls.VersionTLS10:
+		mx["tls_version_1_0"] = 1
+	case tls.VersionTLS11:
+		mx["tls_version_1_1"] = 1
+	case tls.VersionTLS12:
+		mx["tls_version_1_2"] = 1
+	case tls.VersionTLS13:
+		mx["tls_version_1_3"] = 1
+	}
+
+	if certs := resp.TLS.PeerCertificates; len(certs) > 0 {
+		mx["cert_expiry"] = int64(time.Until(certs[0].NotAfter).Seconds())
+	}
+}
+
+// collectUnverifiedCertExpiry reports the certificate expiry when the request failed
+// because the certificate didn't pass verification (expired, self-signed, etc.).
+func (hc *HTTPCheck) collectUnverifiedCertExpiry(mx map[string]int64, err error) {
+	var verifyErr *tls.CertificateVerificationError
+	if !errors.As(err, &verifyErr) || len(verifyErr.UnverifiedCertificates) == 0 {
+		return
+	}
+
+	hc.addTLSChartsOnce.Do(hc.addTLSCharts)
+
+	mx["cert_expiry"] = int64(time.Until(verifyErr.UnverifiedCertificates[0].NotAfter).Seconds())
+}
@@ -37,6 +37,7 @@ import (
 	_ "github.com/netdata/go.d.plugin/modules/k8s_state"
 	_ "github.com/netdata/go.d.plugin/modules/lighttpd"
 	_ "github.com/netdata/go.d.plugin/modules/logind"
+	_ "github.com/netdata/go.d.plugin/modules/logs"
 	_ "github.com/netdata/go.d.plugin/modules/logstash"
 	_ "github.com/netdata/go.d.plugin/modules/mongodb"
 	_ "github.com/netdata/go.d.plugin/modules/mysql"
@@ -44,6 +45,7 @@ import (
 	_ "github.com/netdata/go.d.plugin/modules/nginxplus"
 	_ "github.com/netdata/go.d.plugin/modules/nginxvts"
 	_ "github.com/netdata/go.d.plugin/modules/ntpd"
+	_ "github.com/netdata/go.d.plugin/modules/ntpquery"
 	_ "github.com/netdata/go.d.plugin/modules/nvidia_smi"
 	_ "github.com/netdata/go.d.plugin/modules/nvme"
 	_ "github.com/netdata/go.d.plugin/modules/openvpn"
@@ -65,12 +67,15 @@ import (
 	_ "github.com/netdata/go.d.plugin/modules/redis"
 	_ "github.com/netdata/go.d.plugin/modules/scaleio"
 	_ "github.com/netdata/go.d.plugin/modules/snmp"
+	_ "github.com/netdata/go.d.plugin/modules/snmp_traps"
 	_ "github.com/netdata/go.d.plugin/modules/solr"
 	_ "github.com/netdata/go.d.plugin/modules/springboot2"
+	_ "github.com/netdata/go.d.plugin/modules/sql"
 	_ "github.com/netdata/go.d.plugin/modules/squidlog"
 	_ "github.com/netdata/go.d.plugin/modules/supervisord"
 	_ "github.com/netdata/go.d.plugin/modules/systemdunits"
 	_ "github.com/netdata/go.d.plugin/modules/tengine"
+	_ "github.com/netdata/go.d.plugin/modules/traceroute"
 	_ "github.com/netdata/go.d.plugin/modules/traefik"
 	_ "github.com/netdata/go.d.plugin/modules/unbound"
 	_ "github.com/netdata/go.d.plugin/modules/vcsa"
@@ -54,6 +54,34 @@ const (
 	prioPodContainerTerminatedStateReason
 )
 
+const (
+	prioDeploymentReplicas = 50500 + iota
+	prioDeploymentGenerationLag
+	prioDeploymentConditions
+	prioReplicaSetReplicas
+	prioReplicaSetGenerationLag
+	prioStatefulSetReplicas
+	prioStatefulSetGenerationLag
+	prioDaemonSetPods
+	prioDaemonSetGenerationLag
+	prioCronJobJobs
+	prioCronJobLastJobStatus
+	prioCronJobSuspended
+	prioCronJobTimeSinceLastRun
+	prioJobPods
+	prioJobConditions
+	prioHPAReplicas
+	prioPVCPhase
+	prioPVCStorage
+)
+
+const (
+	prioEventsType = 50700 + iota
+	prioEventsReason
+	prioNamespaceEventsType
+	prioNamespaceEventsReason
+)
+
 const (
 	labelKeyPrefix = "k8s_"
 	//labelKeyLabelPrefix      = labelKeyPrefix + "label_"
@@ -70,6 +98,9 @@ const (
 	labelKeyContainerName  = labelKeyPrefix + "container_name"
 	labelKeyContainerID    = labelKeyPrefix + "container_id"
 	labelKeyQoSClass       = labelKeyPrefix + "qos_class"
+	labelKeyStorageClass   = labelKeyPrefix + "storage_class"
+	labelKeyTargetKind     = labelKeyPrefix + "target_kind"
+	labelKeyTargetName     = labelKeyPrefix + "target_name"
 )
 
 var baseCharts = module.Charts{
@@ -764,6 +795,453 @@ func (ks *KubeState) addContainerTerminatedStateReasonToChart(ps *podState, cs *
 	c.MarkNotCreated()
 }
 
+var (
+	deploymentChartsTmpl = module.Charts{
+		deploymentReplicasChartTmpl.Copy(),
+		deploymentGenerationLagChartTmpl.Copy(),
+		deploymentConditionsChartTmpl.Copy(),
+	}
+	replicaSetChartsTmpl = module.Charts{
+		replicaSetReplicasChartTmpl.Copy(),
+		replicaSetGenerationLagChartTmpl.Copy(),
+	}
+	statefulSetChartsTmpl = module.Charts{
+		statefulSetReplicasChartTmpl.Copy(),
+		statefulSetGenerationLagChartTmpl.Copy(),
+	}
+	daemonSetChartsTmpl = module.Charts{
+		daemonSetPodsChartTmpl.Copy(),
+		daemonSetGenerationLagChartTmpl.Copy(),
+	}
+	jobChartsTmpl = module.Charts{
+		jobPodsChartTmpl.Copy(),
+		jobConditionsChartTmpl.Copy(),
+	}
+	cronJobChartsTmpl = module.Charts{
+		cronJobJobsChartTmpl.Copy(),
+		cronJobLastJobStatusChartTmpl.Copy(),
+		cronJobSuspendedChartTmpl.Copy(),
+		cronJobTimeSinceLastRunChartTmpl.Copy(),
+	}
+	pvcChartsTmpl = module.Charts{
+		pvcPhaseChartTmpl.Copy(),
+		pvcStorageChartTmpl.Copy(),
+	}
+	hpaChartsTmpl = module.Charts{
+		hpaReplicasChartTmpl.Copy(),
+	}
+)
+
+var (
+	deploymentReplicasChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "deployment_%s.replicas",
+		Title:    "Deployment replicas",
+		Units:    "replicas",
+		Fam:      "deployment replicas",
+		Ctx:      "k8s_state.deployment_replicas",
+		Priority: prioDeploymentReplicas,
+		Dims: module.Dims{
+			{ID: "deployment_%s_replicas_desired", Name: "desired"},
+			{ID: "deployment_%s_replicas_current", Name: "current"},
+			{ID: "deployment_%s_replicas_updated", Name: "updated"},
+			{ID: "deployment_%s_replicas_ready", Name: "ready"},
+			{ID: "deployment_%s_replicas_available", Name: "available"},
+			{ID: "deployment_%s_replicas_unavailable", Name: "unavailable"},
+		},
+	}
+	deploymentGenerationLagChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "deployment_%s.generation_lag",
+		Title:    "Deployment rollout generation lag",
+		Units:    "generations",
+		Fam:      "deployment rollout",
+		Ctx:      "k8s_state.deployment_generation_lag",
+		Priority: prioDeploymentGenerationLag,
+		Dims: module.Dims{
+			{ID: "deployment_%s_generation_lag", Name: "lag"},
+		},
+	}
+	deploymentConditionsChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "deployment_%s.conditions",
+		Title:    "Deployment conditions",
+		Units:    "status",
+		Fam:      "deployment rollout",
+		Ctx:      "k8s_state.deployment_conditions",
+		Priority: prioDeploymentConditions,
+		Dims: module.Dims{
+			{ID: "deployment_%s_cond_available", Name: "available"},
+			{ID: "deployment_%s_cond_progressing", Name: "progressing"},
+			{ID: "deployment_%s_cond_replicafailure", Name: "replica_failure"},
+		},
+	}
+)
+
+var (
+	replicaSetReplicasChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "replicaset_%s.replicas",
+		Title:    "ReplicaSet replicas",
+		Units:    "replicas",
+		Fam:      "replicaset replicas",
+		Ctx:      "k8s_state.replicaset_replicas",
+		Priority: prioReplicaSetReplicas,
+		Dims: module.Dims{
+			{ID: "replicaset_%s_replicas_desired", Name: "desired"},
+			{ID: "replicaset_%s_replicas_current", Name: "current"},
+			{ID: "replicaset_%s_replicas_ready", Name: "ready"},
+			{ID: "replicaset_%s_replicas_available", Name: "available"},
+		},
+	}
+	replicaSetGenerationLagChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "replicaset_%s.generation_lag",
+		Title:    "ReplicaSet generation lag",
+		Units:    "generations",
+		Fam:      "replicaset rollout",
+		Ctx:      "k8s_state.replicaset_generation_lag",
+		Priority: prioReplicaSetGenerationLag,
+		Dims: module.Dims{
+			{ID: "replicaset_%s_generation_lag", Name: "lag"},
+		},
+	}
+)
+
+var (
+	statefulSetReplicasChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "statefulset_%s.replicas",
+		Title:    "StatefulSet replicas",
+		Units:    "replicas",
+		Fam:      "statefulset replicas",
+		Ctx:      "k8s_state.statefulset_replicas",
+		Priority: prioStatefulSetReplicas,
+		Dims: module.Dims{
+			{ID: "statefulset_%s_replicas_desired", Name: "desired"},
+			{ID: "statefulset_%s_replicas_current", Name: "current"},
+			{ID: "statefulset_%s_replicas_updated", Name: "updated"},
+			{ID: "statefulset_%s_replicas_ready", Name: "ready"},
+			{ID: "statefulset_%s_replicas_available", Name: "available"},
+		},
+	}
+	statefulSetGenerationLagChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "statefulset_%s.generation_lag",
+		Title:    "StatefulSet rollout generation lag",
+		Units:    "generations",
+		Fam:      "statefulset rollout",
+		Ctx:      "k8s_state.statefulset_generation_lag",
+		Priority: prioStatefulSetGenerationLag,
+		Dims: module.Dims{
+			{ID: "statefulset_%s_generation_lag", Name: "lag"},
+		},
+	}
+)
+
+var (
+	daemonSetPodsChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "daemonset_%s.pods",
+		Title:    "DaemonSet pods",
+		Units:    "pods",
+		Fam:      "daemonset pods",
+		Ctx:      "k8s_state.daemonset_pods",
+		Priority: prioDaemonSetPods,
+		Dims: module.Dims{
+			{ID: "daemonset_%s_replicas_desired", Name: "desired"},
+			{ID: "daemonset_%s_replicas_current", Name: "current"},
+			{ID: "daemonset_%s_replicas_updated", Name: "updated"},
+			{ID: "daemonset_%s_replicas_ready", Name: "ready"},
+			{ID: "daemonset_%s_replicas_available", Name: "available"},
+			{ID: "daemonset_%s_replicas_unavailable", Name: "unavailable"},
+			{ID: "daemonset_%s_replicas_misscheduled", Name: "misscheduled"},
+		},
+	}
+	daemonSetGenerationLagChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "daemonset_%s.generation_lag",
+		Title:    "DaemonSet rollout generation lag",
+		Units:    "generations",
+		Fam:      "daemonset rollout",
+		Ctx:      "k8s_state.daemonset_generation_lag",
+		Priority: prioDaemonSetGenerationLag,
+		Dims: module.Dims{
+			{ID: "daemonset_%s_generation_lag", Name: "lag"},
+		},
+	}
+)
+
+var (
+	jobPodsChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "job_%s.pods",
+		Title:    "Job pods",
+		Units:    "pods",
+		Fam:      "job pods",
+		Ctx:      "k8s_state.job_pods",
+		Priority: prioJobPods,
+		Dims: module.Dims{
+			{ID: "job_%s_pods_active", Name: "active"},
+			{ID: "job_%s_pods_succeeded", Name: "succeeded"},
+			{ID: "job_%s_pods_failed", Name: "failed"},
+		},
+	}
+	jobConditionsChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "job_%s.conditions",
+		Title:    "Job conditions",
+		Units:    "status",
+		Fam:      "job status",
+		Ctx:      "k8s_state.job_conditions",
+		Priority: prioJobConditions,
+		Dims: module.Dims{
+			{ID: "job_%s_cond_complete", Name: "complete"},
+			{ID: "job_%s_cond_failed", Name: "failed"},
+			{ID: "job_%s_suspended", Name: "suspended"},
+		},
+	}
+)
+
+var (
+	cronJobJobsChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "cronjob_%s.jobs",
+		Title:    "CronJob jobs",
+		Units:    "jobs",
+		Fam:      "cronjob jobs",
+		Ctx:      "k8s_state.cronjob_jobs",
+		Priority: prioCronJobJobs,
+		Type:     module.Stacked,
+		Dims: module.Dims{
+			{ID: "cronjob_%s_jobs_active", Name: "active"},
+			{ID: "cronjob_%s_jobs_succeeded", Name: "succeeded"},
+			{ID: "cronjob_%s_jobs_failed", Name: "failed"},
+		},
+	}
+	cronJobLastJobStatusChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "cronjob_%s.last_job_status",
+		Title:    "CronJob last job status",
+		Units:    "status",
+		Fam:      "cronjob jobs",
+		Ctx:      "k8s_state.cronjob_last_job_status",
+		Priority: prioCronJobLastJobStatus,
+		Dims: module.Dims{
+			{ID: "cronjob_%s_last_job_status_active", Name: "active"},
+			{ID: "cronjob_%s_last_job_status_succeeded", Name: "succeeded"},
+			{ID: "cronjob_%s_last_job_status_failed", Name: "failed"},
+		},
+	}
+	cronJobSuspendedChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "cronjob_%s.suspended",
+		Title:    "CronJob suspend status",
+		Units:    "status",
+		Fam:      "cronjob status",
+		Ctx:      "k8s_state.cronjob_suspended",
+		Priority: prioCronJobSuspended,
+		Dims: module.Dims{
+			{ID: "cronjob_%s_suspended", Name: "suspended"},
+		},
+	}
+	cronJobTimeSinceLastRunChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "cronjob_%s.time_since_last_run",
+		Title:    "CronJob time since last run",
+		Units:    "seconds",
+		Fam:      "cronjob status",
+		Ctx:      "k8s_state.cronjob_time_since_last_run",
+		Priority: prioCronJobTimeSinceLastRun,
+		Dims: module.Dims{
+			{ID: "cronjob_%s_last_schedule_seconds_ago", Name: "last_schedule"},
+			{ID: "cronjob_%s_last_successful_seconds_ago", Name: "last_successful"},
+		},
+	}
+)
+
+var (
+	pvcPhaseChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "pvc_%s.phase",
+		Title:    "PersistentVolumeClaim phase",
+		Units:    "state",
+		Fam:      "pvc phase",
+		Ctx:      "k8s_state.pvc_phase",
+		Priority: prioPVCPhase,
+		Dims: module.Dims{
+			{ID: "pvc_%s_phase_pending", Name: "pending"},
+			{ID: "pvc_%s_phase_bound", Name: "bound"},
+			{ID: "pvc_%s_phase_lost", Name: "lost"},
+		},
+	}
+	pvcStorageChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "pvc_%s.storage",
+		Title:    "PersistentVolumeClaim storage",
+		Units:    "bytes",
+		Fam:      "pvc storage",
+		Ctx:      "k8s_state.pvc_storage",
+		Priority: prioPVCStorage,
+		Dims: module.Dims{
+			{ID: "pvc_%s_storage_requested", Name: "requested"},
+			{ID: "pvc_%s_storage_capacity", Name: "capacity"},
+		},
+	}
+)
+
+var (
+	hpaReplicasChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "hpa_%s.replicas",
+		Title:    "HorizontalPodAutoscaler replicas",
+		Units:    "replicas",
+		Fam:      "hpa replicas",
+		Ctx:      "k8s_state.hpa_replicas",
+		Priority: prioHPAReplicas,
+		Dims: module.Dims{
+			{ID: "hpa_%s_replicas_min", Name: "min"},
+			{ID: "hpa_%s_replicas_max", Name: "max"},
+			{ID: "hpa_%s_replicas_current", Name: "current"},
+			{ID: "hpa_%s_replicas_desired", Name: "desired"},
+		},
+	}
+)
+
+func (ks *KubeState) newWorkloadCharts(tmpl module.Charts, kind kubeResourceKind, wm workloadMeta, labels ...module.Label) *module.Charts {
+	charts := tmpl.Copy()
+	for _, c := range *charts {
+		c.ID = fmt.Sprintf(c.ID, replaceDots(wm.id()))
+		c.Labels = append(ks.newWorkloadChartLabels(kind, wm), labels...)
+		for _, d := range c.Dims {
+			d.ID = fmt.Sprintf(d.ID, wm.id())
+		}
+	}
+	return charts
+}
+
+func (ks *KubeState) newWorkloadChartLabels(kind kubeResourceKind, wm workloadMeta) []module.Label {
+	labels := []module.Label{
+		{Key: labelKeyNamespace, Value: wm.namespace, Source: module.LabelSourceK8s},
+		{Key: labelKeyPrefix + kind.String() + "_name", Value: wm.name, Source: module.LabelSourceK8s},
+		{Key: labelKeyClusterID, Value: ks.kubeClusterID, Source: module.LabelSourceK8s},
+		{Key: labelKeyClusterName, Value: ks.kubeClusterName, Source: module.LabelSourceK8s},
+	}
+	return labels
+}
+
+func (ks *KubeState) addWorkloadCharts(tmpl module.Charts, kind kubeResourceKind, wm workloadMeta, labels ...module.Label) {
+	charts := ks.newWorkloadCharts(tmpl, kind, wm, labels...)
+	if err := ks.Charts().Add(*charts...); err != nil {
+		ks.Warning(err)
+	}
+}
+
+func (ks *KubeState) removeWorkloadCharts(kind kubeResourceKind, wm workloadMeta) {
+	prefix := fmt.Sprintf("%s_%s.", kind, replaceDots(wm.id()))
+	for _, c := range *ks.Charts() {
+		if strings.HasPrefix(c.ID, prefix) {
+			c.MarkRemove()
+			c.MarkNotCreated()
+		}
+	}
+}
+
+var eventsCharts = module.Charts{
+	eventsTypeChart.Copy(),
+	eventsReasonChart.Copy(),
+}
+
+var namespaceEventsChartsTmpl = module.Charts{
+	namespaceEventsTypeChartTmpl.Copy(),
+	namespaceEventsReasonChartTmpl.Copy(),
+}
+
+var (
+	eventsTypeChart = module.Chart{
+		ID:       "events_type",
+		Title:    "Events by type",
+		Units:    "events/s",
+		Fam:      "events",
+		Ctx:      "k8s_state.events_type",
+		Priority: prioEventsType,
+		Type:     module.Stacked,
+		Dims: module.Dims{
+			{ID: "events_type_normal", Name: "normal", Algo: module.Incremental},
+			{ID: "events_type_warning", Name: "warning", Algo: module.Incremental},
+		},
+	}
+	eventsReasonChart = module.Chart{
+		ID:       "events_reason",
+		Title:    "Events by reason",
+		Units:    "events/s",
+		Fam:      "events",
+		Ctx:      "k8s_state.events_reason",
+		Priority: prioEventsReason,
+		Type:     module.Stacked,
+	}
+	namespaceEventsTypeChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "events_ns_%s.type",
+		Title:    "Namespace events by type",
+		Units:    "events/s",
+		Fam:      "namespace events",
+		Ctx:      "k8s_state.namespace_events_type",
+		Priority: prioNamespaceEventsType,
+		Type:     module.Stacked,
+		Dims: module.Dims{
+			{ID: "events_ns_%s_type_normal", Name: "normal", Algo: module.Incremental},
+			{ID: "events_ns_%s_type_warning", Name: "warning", Algo: module.Incremental},
+		},
+	}
+	namespaceEventsReasonChartTmpl = module.Chart{
+		IDSep:    true,
+		ID:       "events_ns_%s.reason",
+		Title:    "Namespace events by reason",
+		Units:    "events/s",
+		Fam:      "namespace events",
+		Ctx:      "k8s_state.namespace_events_reason",
+		Priority: prioNamespaceEventsReason,
+		Type:     module.Stacked,
+	}
+)
+
+func (ks *KubeState) addNamespaceEventsCharts(namespace string) {
+	charts := namespaceEventsChartsTmpl.Copy()
+	for _, c := range *charts {
+		c.ID = fmt.Sprintf(c.ID, replaceDots(namespace))
+		c.Labels = []module.Label{
+			{Key: labelKeyNamespace, Value: namespace, Source: module.LabelSourceK8s},
+			{Key: labelKeyClusterID, Value: ks.kubeClusterID, Source: module.LabelSourceK8s},
+			{Key: labelKeyClusterName, Value: ks.kubeClusterName, Source: module.LabelSourceK8s},
+		}
+		for _, d := range c.Dims {
+			d.ID = fmt.Sprintf(d.ID, namespace)
+		}
+	}
+	if err := ks.Charts().Add(*charts...); err != nil {
+		ks.Warning(err)
+	}
+}
+
+func (ks *KubeState) addEventReasonToChart(chartID, dimID, reason string) {
+	c := ks.Charts().Get(chartID)
+	if c == nil {
+		ks.Warningf("chart '%s' does not exist", chartID)
+		return
+	}
+	dim := &module.Dim{
+		ID:   dimID,
+		Name: reason,
+		Algo: module.Incremental,
+	}
+	if err := c.AddDim(dim); err != nil {
+		ks.Warning(err)
+		return
+	}
+	c.MarkNotCreated()
+}
+
 var discoveryStatusChart = module.Chart{
 	ID:       "discovery_discoverers_state",
 	Title:    "Running discoverers state",
@@ -63,6 +63,14 @@ func (ks *KubeState) collectKubeState(mx map[string]int64) {
 	}
 	ks.collectPodsState(mx)
 	ks.collectNodesState(mx)
+	ks.collectDeploymentsState(mx)
+	ks.collectReplicaSetsState(mx)
+	ks.collectStatefulSetsState(mx)
+	ks.collectDaemonSetsState(mx)
+	ks.collectJobsAndCronJobsState(mx)
+	ks.collectPVCsState(mx)
+	ks.collectHPAsState(mx)
+	ks.collectEventsState(mx)
 }
 
 func (ks *KubeState) collectPodsState(mx map[string]int64) {
@@ -236,6 +244,268 @@ func (ks *KubeState) collectNodesState(mx map[string]int64) {
 	}
 }
 
+func (ks *KubeState) collectDeploymentsState(mx map[string]int64) {
+	for src, ds := range ks.state.deployments {
+		if ds.deleted {
+			delete(ks.state.deployments, src)
+			ks.removeWorkloadCharts(kubeResourceDeployment, ds.workloadMeta)
+			continue
+		}
+		if ds.new {
+			ds.new = false
+			ks.addWorkloadCharts(deploymentChartsTmpl, kubeResourceDeployment, ds.workloadMeta)
+		}
+
+		px := fmt.Sprintf("deployment_%s_", ds.id())
+
+		writeWorkloadReplicas(mx, px, ds.replicas)
+		mx[px+"generation_lag"] = ds.generationLag
+		mx[px+"cond_available"] = condStatusToInt(ds.condAvailable)
+		mx[px+"cond_progressing"] = condStatusToInt(ds.condProgressing)
+		mx[px+"cond_replicafailure"] = condStatusToInt(ds.condReplicaFailure)
+	}
+}
+
+func (ks *KubeState) collectReplicaSetsState(mx map[string]int64) {
+	for src, rs := range ks.state.replicaSets {
+		if rs.deleted {
+			delete(ks.state.replicaSets, src)
+			ks.removeWorkloadCharts(kubeResourceReplicaSet, rs.workloadMeta)
+			continue
+		}
+		if rs.new {
+			rs.new = false
+			ks.addWorkloadCharts(replicaSetChartsTmpl, kubeResourceReplicaSet, rs.workloadMeta)
+		}
+
+		px := fmt.Sprintf("replicaset_%s_", rs.id())
+
+		mx[px+"replicas_desired"] = rs.replicas.desired
+		mx[px+"replicas_current"] = rs.replicas.current
+		mx[px+"replicas_ready"] = rs.replicas.ready
+		mx[px+"replicas_available"] = rs.replicas.available
+		mx[px+"generation_lag"] = rs.generationLag
+	}
+}
+
+func (ks *KubeState) collectStatefulSetsState(mx map[string]int64) {
+	for src, ss := range ks.state.statefulSets {
+		if ss.deleted {
+			delete(ks.state.statefulSets, src)
+			ks.removeWorkloadCharts(kubeResourceStatefulSet, ss.workloadMeta)
+			continue
+		}
+		if ss.new {
+			ss.new = false
+			ks.addWorkloadCharts(statefulSetChartsTmpl, kubeResourceStatefulSet, ss.workloadMeta)
+		}
+
+		px := fmt.Sprintf("statefulset_%s_", ss.id())
+
+		mx[px+"replicas_desired"] = ss.replicas.desired
+		mx[px+"replicas_current"] = ss.replicas.current
+		mx[px+"replicas_updated"] = ss.replicas.updated
+		mx[px+"replicas_ready"] = ss.replicas.ready
+		mx[px+"replicas_available"] = ss.replicas.available
+		mx[px+"generation_lag"] = ss.generationLag
+	}
+}
+
+func (ks *KubeState) collectDaemonSetsState(mx map[string]int64) {
+	for src, ds := range ks.state.daemonSets {
+		if ds.deleted {
+			delete(ks.state.daemonSets, src)
+			ks.removeWorkloadCharts(kubeResourceDaemonSet, ds.workloadMeta)
+			continue
+		}
+		if ds.new {
+			ds.new = false
+			ks.addWorkloadCharts(daemonSetChartsTmpl, kubeResourceDaemonSet, ds.workloadMeta)
+		}
+
+		px := fmt.Sprintf("daemonset_%s_", ds.id())
+
+		writeWorkloadReplicas(mx, px, ds.replicas)
+		mx[px+"replicas_misscheduled"] = ds.replicas.misscheduled
+		mx[px+"generation_lag"] = ds.generationLag
+	}
+}
+
+func (ks *KubeState) collectJobsAndCronJobsState(mx map[string]int64) {
+	for _, cs := range ks.state.cronJobs {
+		cs.resetStats()
+	}
+
+	for src, js := range ks.state.jobs {
+		if js.deleted {
+			delete(ks.state.jobs, src)
+			ks.removeWorkloadCharts(kubeResourceJob, js.workloadMeta)
+			continue
+		}
+		if js.new {
+			js.new = false
+			var labels []module.Label
+			if js.cronJobName != "" {
+				labels = append(labels,
+					module.Label{Key: labelKeyControllerKind, Value: "CronJob", Source: module.LabelSourceK8s},
+					module.Label{Key: labelKeyControllerName, Value: js.cronJobName, Source: module.LabelSourceK8s},
+				)
+			}
+			ks.addWorkloadCharts(jobChartsTmpl, kubeResourceJob, js.workloadMeta, labels...)
+		}
+
+		if js.cronJobName != "" {
+			if cs := ks.state.cronJobs[workloadSource(kubeResourceCronJob, js.namespace, js.cronJobName)]; cs != nil {
+				cs.stats.jobsActive += boolToInt(!js.finished())
+				cs.stats.jobsSucceeded += boolToInt(js.condComplete)
+				cs.stats.jobsFailed += boolToInt(js.condFailed)
+				if cs.stats.lastJob == nil || js.creationTime.After(cs.stats.lastJob.creationTime) {
+					cs.stats.lastJob = js
+				}
+			}
+		}
+
+		px := fmt.Sprintf("job_%s_", js.id())
+
+		mx[px+"pods_active"] = js.podsActive
+		mx[px+"pods_succeeded"] = js.podsSucceeded
+		mx[px+"pods_failed"] = js.podsFailed
+		mx[px+"cond_complete"] = boolToInt(js.condComplete)
+		mx[px+"cond_failed"] = boolToInt(js.condFailed)
+		mx[px+"suspended"] = boolToInt(js.suspended)
+	}
+
+	now := time.Now()
+	for src, cs := range ks.state.cronJobs {
+		if cs.deleted {
+			delete(ks.state.cronJobs, src)
+			ks.removeWorkloadCharts(kubeResourceCronJob, cs.workloadMeta)
+			continue
+		}
+		if cs.new {
+			cs.new = false
+			ks.addWorkloadCharts(cronJobChartsTmpl, kubeResourceCronJob, cs.workloadMeta)
+		}
+
+		px := fmt.Sprintf("cronjob_%s_", cs.id())
+
+		// the Job discoverer may be disabled, the CronJob status still has the list of running jobs
+		mx[px+"jobs_active"] = max(cs.stats.jobsActive, cs.activeJobs)
+		mx[px+"jobs_succeeded"] = cs.stats.jobsSucceeded
+		mx[px+"jobs_failed"] = cs.stats.jobsFailed
+		mx[px+"last_job_status_active"] = 0
+		mx[px+"last_job_status_succeeded"] = 0
+		mx[px+"last_job_status_failed"] = 0
+		if js := cs.stats.lastJob; js != nil {
+			mx[px+"last_job_status_active"] = boolToInt(!js.finished())
+			mx[px+"last_job_status_succeeded"] = boolToInt(js.condComplete)
+			mx[px+"last_job_status_failed"] = boolToInt(js.condFailed)
+		}
+		mx[px+"suspended"] = boolToInt(cs.suspended)
+		if !cs.lastScheduleTime.IsZero() {
+			mx[px+"last_schedule_seconds_ago"] = int64(now.Sub(cs.lastScheduleTime).Seconds())
+		}
+		if !cs.lastSuccessfulTime.IsZero() {
+			mx[px+"last_successful_seconds_ago"] = int64(now.Sub(cs.lastSuccessfulTime).Seconds())
+		}
+	}
+}
+
+func (ks *KubeState) collectPVCsState(mx map[string]int64) {
+	for src, ps := range ks.state.pvcs {
+		if ps.deleted {
+			delete(ks.state.pvcs, src)
+			ks.removeWorkloadCharts(kubeResourcePVC, ps.workloadMeta)
+			continue
+		}
+		if ps.new {
+			ps.new = false
+			ks.addWorkloadCharts(pvcChartsTmpl, kubeResourcePVC, ps.workloadMeta,
+				module.Label{Key: labelKeyStorageClass, Value: ps.storageClass, Source: module.LabelSourceK8s},
+			)
+		}
+
+		px := fmt.Sprintf("pvc_%s_", ps.id())
+
+		mx[px+"phase_pending"] = boolToInt(ps.phase == corev1.ClaimPending)
+		mx[px+"phase_bound"] = boolToInt(ps.phase == corev1.ClaimBound)
+		mx[px+"phase_lost"] = boolToInt(ps.phase == corev1.ClaimLost)
+		mx[px+"storage_requested"] = ps.requested
+		mx[px+"storage_capacity"] = ps.capacity
+	}
+}
+
+func (ks *KubeState) collectHPAsState(mx map[string]int64) {
+	for src, hs := range ks.state.hpas {
+		if hs.deleted {
+			delete(ks.state.hpas, src)
+			ks.removeWorkloadCharts(kubeResourceHPA, hs.workloadMeta)
+			continue
+		}
+		if hs.new {
+			hs.new = false
+			ks.addWorkloadCharts(hpaChartsTmpl, kubeResourceHPA, hs.workloadMeta,
+				module.Label{Key: labelKeyTargetKind, Value: hs.targetKind, Source: module.LabelSourceK8s},
+				module.Label{Key: labelKeyTargetName, Value: hs.targetName, Source: module.LabelSourceK8s},
+			)
+		}
+
+		px := fmt.Sprintf("hpa_%s_", hs.id())
+
+		mx[px+"replicas_min"] = hs.minReplicas
+		mx[px+"replicas_max"] = hs.maxReplicas
+		mx[px+"replicas_current"] = hs.currentReplicas
+		mx[px+"replicas_desired"] = hs.desiredReplicas
+	}
+}
+
+func (ks *KubeState) collectEventsState(mx map[string]int64) {
+	es := ks.state.events
+	if es == nil {
+		return
+	}
+
+	mx["events_type_normal"] = es.cluster.typeNormal
+	mx["events_type_warning"] = es.cluster.typeWarning
+	for reason, r := range es.cluster.reasons {
+		id := "events_reason_" + reason
+		if r.new {
+			r.new = false
+			ks.addEventReasonToChart(eventsReasonChart.ID, id, reason)
+		}
+		mx[id] = r.count
+	}
+
+	for namespace, ns := range es.namespaces {
+		if ns.new {
+			ns.new = false
+			ks.addNamespaceEventsCharts(namespace)
+		}
+
+		px := fmt.Sprintf("events_ns_%s_", namespace)
+
+		mx[px+"type_normal"] = ns.typeNormal
+		mx[px+"type_warning"] = ns.typeWarning
+		for reason, r := range ns.reasons {
+			id := px + "reason_" + reason
+			if r.new {
+				r.new = false
+				ks.addEventReasonToChart(fmt.Sprintf(namespaceEventsReasonChartTmpl.ID, replaceDots(namespace)), id, reason)
+			}
+			mx[id] = r.count
+		}
+	}
+}
+
+func writeWorkloadReplicas(mx map[string]int64, px string, r workloadReplicas) {
+	mx[px+"replicas_desired"] = r.desired
+	mx[px+"replicas_current"] = r.current
+	mx[px+"replicas_updated"] = r.updated
+	mx[px+"replicas_ready"] = r.ready
+	mx[px+"replicas_available"] = r.available
+	mx[px+"replicas_unavailable"] = r.unavailable
+}
+
 func boolToInt(v bool) int64 {
 	if v {
 		return 1
@@ -10,6 +10,9 @@ import (
 
 	"github.com/netdata/go.d.plugin/logger"
 
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -32,8 +35,10 @@ type kubeDiscovery struct {
 	*logger.Logger
 	client      kubernetes.Interface
 	discoverers []discoverer
-	readyCh     chan struct{}
-	stopCh      chan struct{}
+	// events are not collected if empty
+	eventNamespaces []string
+	readyCh         chan struct{}
+	stopCh          chan struct{}
 }
 
 func (d *kubeDiscovery) run(ctx context.Context, in chan<- resource) {
@@ -126,10 +131,88 @@ func (d *kubeDiscovery) setupDiscoverers(ctx context.Context) []discoverer {
 		},
 	}
 
-	return []discoverer{
+	discoverers := []discoverer{
 		newNodeDiscoverer(cache.NewSharedInformer(nodeWatcher, &corev1.Node{}, resyncPeriod), d.Logger),
 		newPodDiscoverer(cache.NewSharedInformer(podWatcher, &corev1.Pod{}, resyncPeriod), d.Logger),
 	}
+
+	watchers := d.workloadWatchers(ctx)
+	for _, ns := range d.eventNamespaces {
+		watchers = append(watchers, d.eventWatcher(ctx, ns))
+	}
+
+	for _, w := range watchers {
+		// A discoverer whose informer can't list (no RBAC permissions, API not served) never becomes ready
+		// and would block collection of everything else.
+		if _, err := w.watcher.List(metav1.ListOptions{Limit: 1}); err != nil {
+			d.Warningf("%s_discoverer is disabled: %v", w.kind, err)
+			continue
+		}
+		si := cache.NewSharedInformer(w.watcher, w.obj, resyncPeriod)
+		discoverers = append(discoverers, newWorkloadDiscoverer(w.kind, si, d.Logger))
+	}
+
+	return discoverers
+}
+
+type workloadWatcher struct {
+	kind    kubeResourceKind
+	obj     runtime.Object
+	watcher *cache.ListWatch
+}
+
+func (d *kubeDiscovery) workloadWatchers(ctx context.Context) []workloadWatcher {
+	deploy := d.client.AppsV1().Deployments(corev1.NamespaceAll)
+	rs := d.client.AppsV1().ReplicaSets(corev1.NamespaceAll)
+	sts := d.client.AppsV1().StatefulSets(corev1.NamespaceAll)
+	ds := d.client.AppsV1().DaemonSets(corev1.NamespaceAll)
+	job := d.client.BatchV1().Jobs(corev1.NamespaceAll)
+	cronJob := d.client.BatchV1().CronJobs(corev1.NamespaceAll)
+	pvc := d.client.CoreV1().PersistentVolumeClaims(corev1.NamespaceAll)
+	hpa := d.client.AutoscalingV2().HorizontalPodAutoscalers(corev1.NamespaceAll)
+
+	return []workloadWatcher{
+		{kind: kubeResourceDeployment, obj: &appsv1.Deployment{}, watcher: &cache.ListWatch{
+			ListFunc:  func(options metav1.ListOptions) (runtime.Object, error) { return deploy.List(ctx, options) },
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) { return deploy.Watch(ctx, options) },
+		}},
+		{kind: kubeResourceReplicaSet, obj: &appsv1.ReplicaSet{}, watcher: &cache.ListWatch{
+			ListFunc:  func(options metav1.ListOptions) (runtime.Object, error) { return rs.List(ctx, options) },
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) { return rs.Watch(ctx, options) },
+		}},
+		{kind: kubeResourceStatefulSet, obj: &appsv1.StatefulSet{}, watcher: &cache.ListWatch{
+			ListFunc:  func(options metav1.ListOptions) (runtime.Object, error) { return sts.List(ctx, options) },
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) { return sts.Watch(ctx, options) },
+		}},
+		{kind: kubeResourceDaemonSet, obj: &appsv1.DaemonSet{}, watcher: &cache.ListWatch{
+			ListFunc:  func(options metav1.ListOptions) (runtime.Object, error) { return ds.List(ctx, options) },
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) { return ds.Watch(ctx, options) },
+		}},
+		{kind: kubeResourceJob, obj: &batchv1.Job{}, watcher: &cache.ListWatch{
+			ListFunc:  func(options metav1.ListOptions) (runtime.Object, error) { return job.List(ctx, options) },
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) { return job.Watch(ctx, options) },
+		}},
+		{kind: kubeResourceCronJob, obj: &batchv1.CronJob{}, watcher: &cache.ListWatch{
+			ListFunc:  func(options metav1.ListOptions) (runtime.Object, error) { return cronJob.List(ctx, options) },
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) { return cronJob.Watch(ctx, options) },
+		}},
+		{kind: kubeResourcePVC, obj: &corev1.PersistentVolumeClaim{}, watcher: &cache.ListWatch{
+			ListFunc:  func(options metav1.ListOptions) (runtime.Object, error) { return pvc.List(ctx, options) },
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) { return pvc.Watch(ctx, options) },
+		}},
+		{kind: kubeResourceHPA, obj: &autoscalingv2.HorizontalPodAutoscaler{}, watcher: &cache.ListWatch{
+			ListFunc:  func(options metav1.ListOptions) (runtime.Object, error) { return hpa.List(ctx, options) },
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) { return hpa.Watch(ctx, options) },
+		}},
+	}
+}
+
+func (d *kubeDiscovery) eventWatcher(ctx context.Context, namespace string) workloadWatcher {
+	event := d.client.CoreV1().Events(namespace)
+	return workloadWatcher{kind: kubeResourceEvent, obj: &corev1.Event{}, watcher: &cache.ListWatch{
+		ListFunc:  func(options metav1.ListOptions) (runtime.Object, error) { return event.List(ctx, options) },
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) { return event.Watch(ctx, options) },
+	}}
 }
 
 func enqueue(queue *workqueue.Type, obj interface{}) {
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package k8s_state
+
+import (
+	"context"
+
+	"github.com/netdata/go.d.plugin/logger"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// workloadDiscoverer is a namespaced resource discoverer used for
+// Deployments, ReplicaSets, StatefulSets, DaemonSets, Jobs, CronJobs, PVCs and HPAs.
+func newWorkloadDiscoverer(kind kubeResourceKind, si cache.SharedInformer, l *logger.Logger) *workloadDiscoverer {
+	if si == nil {
+		panic("nil " + kind.String() + " shared informer")
+	}
+
+	queue := workqueue.NewNamed(kind.String())
+	si.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { enqueue(queue, obj) },
+		UpdateFunc: func(_, obj interface{}) { enqueue(queue, obj) },
+		DeleteFunc: func(obj interface{}) { enqueue(queue, obj) },
+	})
+
+	return &workloadDiscoverer{
+		Logger:   l,
+		kind:     kind,
+		informer: si,
+		queue:    queue,
+		readyCh:  make(chan struct{}),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+type workloadResource struct {
+	src string
+	knd kubeResourceKind
+	val interface{}
+}
+
+func (r workloadResource) source() string         { return r.src }
+func (r workloadResource) kind() kubeResourceKind { return r.knd }
+func (r workloadResource) value() interface{}     { return r.val }
+
+type workloadDiscoverer struct {
+	*logger.Logger
+	kind     kubeResourceKind
+	informer cache.SharedInformer
+	queue    *workqueue.Type
+	readyCh  chan struct{}
+	stopCh   chan struct{}
+}
+
+func (d *workloadDiscoverer) run(ctx context.Context, in chan<- resource) {
+	d.Infof("%s_discoverer is started", d.kind)
+	defer func() { close(d.stopCh); d.Infof("%s_discoverer is stopped", d.kind) }()
+
+	defer d.queue.ShutDown()
+
+	go d.informer.Run(ctx.Done())
+
+	if !cache.WaitForCacheSync(ctx.Done(), d.informer.HasSynced) {
+		return
+	}
+
+	go d.runDiscover(ctx, in)
+	close(d.readyCh)
+
+	<-ctx.Done()
+}
+
+func (d *workloadDiscoverer) ready() bool   { return isChanClosed(d.readyCh) }
+func (d *workloadDiscoverer) stopped() bool { return isChanClosed(d.stopCh) }
+
+func (d *workloadDiscoverer) runDiscover(ctx context.Context, in chan<- resource) {
+	for {
+		item, shutdown := d.queue.Get()
+		if shutdown {
+			return
+		}
+
+		func() {
+			defer d.queue.Done(item)
+
+			key := item.(string)
+			ns, name, err := cache.SplitMetaNamespaceKey(key)
+			if err != nil {
+				return
+			}
+
+			item, exists, err := d.informer.GetStore().GetByKey(key)
+			if err != nil {
+				return
+			}
+
+			r := &workloadResource{src: workloadSource(d.kind, ns, name), knd: d.kind}
+			if exists {
+				r.val = item
+			}
+			send(ctx, in, r)
+		}()
+	}
+}
+
+func workloadSource(kind kubeResourceKind, namespace, name string) string {
+	return "k8s/" + kind.String() + "/" + namespace + "/" + name
+}
@@ -3,6 +3,7 @@
 package k8s_state
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -11,5 +12,12 @@ func (ks KubeState) initClient() (kubernetes.Interface, error) {
 }
 
 func (ks *KubeState) initDiscoverer(client kubernetes.Interface) discoverer {
-	return newKubeDiscovery(client, ks.Logger)
+	d := newKubeDiscovery(client, ks.Logger)
+	if ks.Events.Enabled {
+		d.eventNamespaces = ks.Events.Namespaces
+		if len(d.eventNamespaces) == 0 {
+			d.eventNamespaces = []string{corev1.NamespaceAll}
+		}
+	}
+	return d
 }
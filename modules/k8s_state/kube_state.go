@@ -23,6 +23,12 @@ func init() {
 
 func New() *KubeState {
 	return &KubeState{
+		Config: Config{
+			Events: EventsConfig{
+				MaxNamespaces: 100,
+				MaxReasons:    50,
+			},
+		},
 		initDelay:     time.Second * 3,
 		newKubeClient: newKubeClient,
 		charts:        baseCharts.Copy(),
@@ -32,6 +38,19 @@ func New() *KubeState {
 	}
 }
 
+type (
+	Config struct {
+		Events EventsConfig `yaml:"events"`
+	}
+	EventsConfig struct {
+		Enabled       bool     `yaml:"enabled"`
+		Namespaces    []string `yaml:"namespaces"`
+		InvolvedKinds []string `yaml:"involved_kinds"`
+		MaxNamespaces int      `yaml:"max_namespaces"`
+		MaxReasons    int      `yaml:"max_reasons"`
+	}
+)
+
 type (
 	discoverer interface {
 		run(ctx context.Context, in chan<- resource)
@@ -41,6 +60,7 @@ type (
 
 	KubeState struct {
 		module.Base
+		Config `yaml:",inline"`
 
 		newKubeClient func() (kubernetes.Interface, error)
 
@@ -74,6 +94,13 @@ func (ks *KubeState) Init() bool {
 
 	ks.discoverer = ks.initDiscoverer(ks.client)
 
+	if ks.Events.Enabled {
+		ks.state.events = newEventsState(ks.Events.MaxNamespaces, ks.Events.MaxReasons)
+		if err := ks.charts.Add(*eventsCharts.Copy()...); err != nil {
+			ks.Warning(err)
+		}
+	}
+
 	return true
 }
 
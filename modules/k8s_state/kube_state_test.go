@@ -14,9 +14,13 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes"
@@ -27,6 +31,10 @@ func TestNew(t *testing.T) {
 	assert.Implements(t, (*module.Module)(nil), New())
 }
 
+func TestNew_EventsCollectionIsOptIn(t *testing.T) {
+	assert.False(t, New().Events.Enabled)
+}
+
 func TestKubeState_Init(t *testing.T) {
 	tests := map[string]struct {
 		wantFail bool
@@ -168,6 +176,7 @@ func TestKubeState_Collect(t *testing.T) {
 		testCaseStep func(t *testing.T, ks *KubeState)
 		testCase     struct {
 			client kubernetes.Interface
+			events *EventsConfig // events collection is disabled if nil
 			steps  []testCaseStep
 		}
 	)
@@ -654,6 +663,234 @@ func TestKubeState_Collect(t *testing.T) {
 				}
 			},
 		},
+		"Workloads": {
+			create: func(t *testing.T) testCase {
+				deploy := newDeployment("deploy01")
+				client := fake.NewSimpleClientset(
+					deploy,
+					newReplicaSet("rs01", nil),
+					newReplicaSet("deploy01-5d8c7b9f4", deploy),
+					newStatefulSet("sts01"),
+					newDaemonSet("ds01"),
+					newCronJob("cronjob01"),
+					newJob("cronjob01-1", "cronjob01", batchv1.JobComplete, time.Now().Add(-time.Hour)),
+					newJob("cronjob01-2", "cronjob01", batchv1.JobFailed, time.Now().Add(-time.Minute)),
+					newJob("job01", "", "", time.Now()),
+					newPVC("pvc01"),
+					newHPA("hpa01", deploy.Name),
+				)
+
+				step1 := func(t *testing.T, ks *KubeState) {
+					mx := ks.Collect()
+					expected := map[string]int64{
+						"discovery_node_discoverer_state":                     1,
+						"discovery_pod_discoverer_state":                      1,
+						"deployment_default_deploy01_cond_available":          1,
+						"deployment_default_deploy01_cond_progressing":        1,
+						"deployment_default_deploy01_cond_replicafailure":     0,
+						"deployment_default_deploy01_generation_lag":          1,
+						"deployment_default_deploy01_replicas_available":      2,
+						"deployment_default_deploy01_replicas_current":        3,
+						"deployment_default_deploy01_replicas_desired":        3,
+						"deployment_default_deploy01_replicas_ready":          2,
+						"deployment_default_deploy01_replicas_unavailable":    1,
+						"deployment_default_deploy01_replicas_updated":        3,
+						"replicaset_default_rs01_generation_lag":              0,
+						"replicaset_default_rs01_replicas_available":          1,
+						"replicaset_default_rs01_replicas_current":            2,
+						"replicaset_default_rs01_replicas_desired":            2,
+						"replicaset_default_rs01_replicas_ready":              1,
+						"statefulset_default_sts01_generation_lag":            0,
+						"statefulset_default_sts01_replicas_available":        2,
+						"statefulset_default_sts01_replicas_current":          3,
+						"statefulset_default_sts01_replicas_desired":          3,
+						"statefulset_default_sts01_replicas_ready":            2,
+						"statefulset_default_sts01_replicas_updated":          1,
+						"daemonset_default_ds01_generation_lag":               0,
+						"daemonset_default_ds01_replicas_available":           4,
+						"daemonset_default_ds01_replicas_current":             5,
+						"daemonset_default_ds01_replicas_desired":             5,
+						"daemonset_default_ds01_replicas_misscheduled":        1,
+						"daemonset_default_ds01_replicas_ready":               4,
+						"daemonset_default_ds01_replicas_unavailable":         1,
+						"daemonset_default_ds01_replicas_updated":             5,
+						"job_default_cronjob01-1_cond_complete":               1,
+						"job_default_cronjob01-1_cond_failed":                 0,
+						"job_default_cronjob01-1_pods_active":                 0,
+						"job_default_cronjob01-1_pods_failed":                 0,
+						"job_default_cronjob01-1_pods_succeeded":              1,
+						"job_default_cronjob01-1_suspended":                   0,
+						"job_default_cronjob01-2_cond_complete":               0,
+						"job_default_cronjob01-2_cond_failed":                 1,
+						"job_default_cronjob01-2_pods_active":                 0,
+						"job_default_cronjob01-2_pods_failed":                 1,
+						"job_default_cronjob01-2_pods_succeeded":              0,
+						"job_default_cronjob01-2_suspended":                   0,
+						"job_default_job01_cond_complete":                     0,
+						"job_default_job01_cond_failed":                       0,
+						"job_default_job01_pods_active":                       1,
+						"job_default_job01_pods_failed":                       0,
+						"job_default_job01_pods_succeeded":                    0,
+						"job_default_job01_suspended":                         0,
+						"cronjob_default_cronjob01_jobs_active":               0,
+						"cronjob_default_cronjob01_jobs_failed":               1,
+						"cronjob_default_cronjob01_jobs_succeeded":            1,
+						"cronjob_default_cronjob01_last_job_status_active":    0,
+						"cronjob_default_cronjob01_last_job_status_failed":    1,
+						"cronjob_default_cronjob01_last_job_status_succeeded": 0,
+						"cronjob_default_cronjob01_suspended":                 0,
+						"pvc_default_pvc01_phase_bound":                       1,
+						"pvc_default_pvc01_phase_lost":                        0,
+						"pvc_default_pvc01_phase_pending":                     0,
+						"pvc_default_pvc01_storage_capacity":                  2147483648,
+						"pvc_default_pvc01_storage_requested":                 1073741824,
+						"hpa_default_hpa01_replicas_current":                  3,
+						"hpa_default_hpa01_replicas_desired":                  4,
+						"hpa_default_hpa01_replicas_max":                      10,
+						"hpa_default_hpa01_replicas_min":                      2,
+					}
+
+					assert.Equal(t, expected, mx)
+					assert.Equal(t,
+						len(deploymentChartsTmpl)+
+							len(replicaSetChartsTmpl)+
+							len(statefulSetChartsTmpl)+
+							len(daemonSetChartsTmpl)+
+							len(jobChartsTmpl)*3+
+							len(cronJobChartsTmpl)+
+							len(pvcChartsTmpl)+
+							len(hpaChartsTmpl)+
+							len(baseCharts),
+						len(*ks.Charts()),
+					)
+
+					chart := ks.Charts().Get("job_default_cronjob01-1.pods")
+					require.NotNil(t, chart)
+					assert.True(t, isLabelValueSet(chart, labelKeyControllerName))
+					chart = ks.Charts().Get("hpa_default_hpa01.replicas")
+					require.NotNil(t, chart)
+					assert.True(t, isLabelValueSet(chart, labelKeyTargetName))
+				}
+
+				return testCase{
+					client: client,
+					steps:  []testCaseStep{step1},
+				}
+			},
+		},
+		"delete a Deployment in runtime": {
+			create: func(t *testing.T) testCase {
+				ctx := context.Background()
+				deploy := newDeployment("deploy01")
+				client := fake.NewSimpleClientset(
+					deploy,
+				)
+				step1 := func(t *testing.T, ks *KubeState) {
+					_ = ks.Collect()
+					_ = client.AppsV1().Deployments(deploy.Namespace).Delete(ctx, deploy.Name, metav1.DeleteOptions{})
+				}
+
+				step2 := func(t *testing.T, ks *KubeState) {
+					mx := ks.Collect()
+					expected := map[string]int64{
+						"discovery_node_discoverer_state": 1,
+						"discovery_pod_discoverer_state":  1,
+					}
+
+					assert.Equal(t, expected, mx)
+					assert.Equal(t,
+						len(deploymentChartsTmpl)+len(baseCharts),
+						len(*ks.Charts()),
+					)
+					assert.Equal(t,
+						len(deploymentChartsTmpl),
+						calcObsoleteCharts(*ks.Charts()),
+					)
+				}
+
+				return testCase{
+					client: client,
+					steps:  []testCaseStep{step1, step2},
+				}
+			},
+		},
+		"Events": {
+			create: func(t *testing.T) testCase {
+				ctx := context.Background()
+				oldEvent := newEvent("default", "pod01.old", "Pod", corev1.EventTypeWarning, "BackOff", 5, time.Now().Add(-time.Hour))
+				client := fake.NewSimpleClientset(
+					oldEvent,
+				)
+
+				step1 := func(t *testing.T, ks *KubeState) {
+					mx := ks.Collect()
+					expected := map[string]int64{
+						"discovery_node_discoverer_state": 1,
+						"discovery_pod_discoverer_state":  1,
+						"events_type_normal":              0,
+						"events_type_warning":             0,
+					}
+					assert.Equal(t, expected, mx)
+
+					events := []*corev1.Event{
+						newEvent("default", "pod01.1", "Pod", corev1.EventTypeWarning, "FailedScheduling", 1, time.Now()),
+						newEvent("kube-system", "pod02.1", "Pod", corev1.EventTypeNormal, "Scheduled", 1, time.Now()),
+						newEvent("default", "node01.1", "Node", corev1.EventTypeNormal, "NodeReady", 1, time.Now()),
+					}
+					for _, ev := range events {
+						_, _ = client.CoreV1().Events(ev.Namespace).Create(ctx, ev, metav1.CreateOptions{})
+					}
+					oldEvent.Count = 7
+					oldEvent.LastTimestamp = metav1.Now()
+					_, _ = client.CoreV1().Events(oldEvent.Namespace).Update(ctx, oldEvent, metav1.UpdateOptions{})
+				}
+
+				step2 := func(t *testing.T, ks *KubeState) {
+					mx := ks.Collect()
+					expected := map[string]int64{
+						"discovery_node_discoverer_state":           1,
+						"discovery_pod_discoverer_state":            1,
+						"events_type_normal":                        1,
+						"events_type_warning":                       3,
+						"events_reason_BackOff":                     2,
+						"events_reason_FailedScheduling":            1,
+						"events_reason_Scheduled":                   1,
+						"events_ns_default_type_normal":             0,
+						"events_ns_default_type_warning":            3,
+						"events_ns_default_reason_BackOff":          2,
+						"events_ns_default_reason_FailedScheduling": 1,
+						"events_ns_kube-system_type_normal":         1,
+						"events_ns_kube-system_type_warning":        0,
+						"events_ns_kube-system_reason_Scheduled":    1,
+					}
+					assert.Equal(t, expected, mx)
+					assert.Equal(t,
+						len(eventsCharts)+len(namespaceEventsChartsTmpl)*2+len(baseCharts),
+						len(*ks.Charts()),
+					)
+
+					// resync (no count change) and a repeated event
+					ev := newEvent("default", "pod01.1", "Pod", corev1.EventTypeWarning, "FailedScheduling", 1, time.Now())
+					_, _ = client.CoreV1().Events(ev.Namespace).Update(ctx, ev, metav1.UpdateOptions{})
+					ev = newEvent("default", "pod01.1", "Pod", corev1.EventTypeWarning, "FailedScheduling", 3, time.Now())
+					_, _ = client.CoreV1().Events(ev.Namespace).Update(ctx, ev, metav1.UpdateOptions{})
+				}
+
+				step3 := func(t *testing.T, ks *KubeState) {
+					mx := ks.Collect()
+
+					assert.Equal(t, int64(5), mx["events_type_warning"])
+					assert.Equal(t, int64(3), mx["events_reason_FailedScheduling"])
+					assert.Equal(t, int64(3), mx["events_ns_default_reason_FailedScheduling"])
+				}
+
+				return testCase{
+					client: client,
+					events: &EventsConfig{Enabled: true, InvolvedKinds: []string{"pod"}, MaxNamespaces: 10, MaxReasons: 10},
+					steps:  []testCaseStep{step1, step2, step3},
+				}
+			},
+		},
 	}
 
 	for name, creator := range tests {
@@ -662,6 +899,10 @@ func TestKubeState_Collect(t *testing.T) {
 
 			ks := New()
 			ks.newKubeClient = func() (kubernetes.Interface, error) { return test.client, nil }
+			ks.Events = EventsConfig{}
+			if test.events != nil {
+				ks.Events = *test.events
+			}
 
 			require.True(t, ks.Init())
 			require.True(t, ks.Check())
@@ -790,6 +1031,183 @@ func newPod(nodeName, name string) *corev1.Pod {
 	}
 }
 
+func newDeployment(name string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         corev1.NamespaceDefault,
+			Generation:        3,
+			CreationTimestamp: metav1.Time{Time: time.Now()},
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: ptrInt32(3)},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration:  2,
+			Replicas:            3,
+			UpdatedReplicas:     3,
+			ReadyReplicas:       2,
+			AvailableReplicas:   2,
+			UnavailableReplicas: 1,
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue},
+				{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+}
+
+func newReplicaSet(name string, owner *appsv1.Deployment) *appsv1.ReplicaSet {
+	rs := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         corev1.NamespaceDefault,
+			CreationTimestamp: metav1.Time{Time: time.Now()},
+		},
+		Spec: appsv1.ReplicaSetSpec{Replicas: ptrInt32(2)},
+		Status: appsv1.ReplicaSetStatus{
+			Replicas:          2,
+			ReadyReplicas:     1,
+			AvailableReplicas: 1,
+		},
+	}
+	if owner != nil {
+		rs.OwnerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(owner, appsv1.SchemeGroupVersion.WithKind("Deployment")),
+		}
+	}
+	return rs
+}
+
+func newStatefulSet(name string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         corev1.NamespaceDefault,
+			CreationTimestamp: metav1.Time{Time: time.Now()},
+		},
+		Spec: appsv1.StatefulSetSpec{Replicas: ptrInt32(3)},
+		Status: appsv1.StatefulSetStatus{
+			CurrentReplicas:   3,
+			UpdatedReplicas:   1,
+			ReadyReplicas:     2,
+			AvailableReplicas: 2,
+		},
+	}
+}
+
+func newDaemonSet(name string) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         corev1.NamespaceDefault,
+			CreationTimestamp: metav1.Time{Time: time.Now()},
+		},
+		Status: appsv1.DaemonSetStatus{
+			DesiredNumberScheduled: 5,
+			CurrentNumberScheduled: 5,
+			UpdatedNumberScheduled: 5,
+			NumberReady:            4,
+			NumberAvailable:        4,
+			NumberUnavailable:      1,
+			NumberMisscheduled:     1,
+		},
+	}
+}
+
+func newCronJob(name string) *batchv1.CronJob {
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         corev1.NamespaceDefault,
+			UID:               "cronjob-" + types.UID(name),
+			CreationTimestamp: metav1.Time{Time: time.Now()},
+		},
+		Spec: batchv1.CronJobSpec{Schedule: "*/5 * * * *"},
+	}
+}
+
+func newJob(name, cronJobName string, cond batchv1.JobConditionType, created time.Time) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         corev1.NamespaceDefault,
+			CreationTimestamp: metav1.Time{Time: created},
+		},
+	}
+	if cronJobName != "" {
+		job.OwnerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(newCronJob(cronJobName), batchv1.SchemeGroupVersion.WithKind("CronJob")),
+		}
+	}
+	switch cond {
+	case batchv1.JobComplete:
+		job.Status.Succeeded = 1
+		job.Status.Conditions = []batchv1.JobCondition{{Type: cond, Status: corev1.ConditionTrue}}
+	case batchv1.JobFailed:
+		job.Status.Failed = 1
+		job.Status.Conditions = []batchv1.JobCondition{{Type: cond, Status: corev1.ConditionTrue}}
+	default:
+		job.Status.Active = 1
+	}
+	return job
+}
+
+func newPVC(name string) *corev1.PersistentVolumeClaim {
+	storageClass := "standard"
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         corev1.NamespaceDefault,
+			CreationTimestamp: metav1.Time{Time: time.Now()},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: &storageClass,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: mustQuantity("1Gi")},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Phase:    corev1.ClaimBound,
+			Capacity: corev1.ResourceList{corev1.ResourceStorage: mustQuantity("2Gi")},
+		},
+	}
+}
+
+func newHPA(name, deployName string) *autoscalingv2.HorizontalPodAutoscaler {
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         corev1.NamespaceDefault,
+			CreationTimestamp: metav1.Time{Time: time.Now()},
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: deployName},
+			MinReplicas:    ptrInt32(2),
+			MaxReplicas:    10,
+		},
+		Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+			CurrentReplicas: 3,
+			DesiredReplicas: 4,
+		},
+	}
+}
+
+func newEvent(namespace, name, kind, typ, reason string, count int32, firstTime time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{Kind: kind, Namespace: namespace, Name: name},
+		Type:           typ,
+		Reason:         reason,
+		Count:          count,
+		FirstTimestamp: metav1.Time{Time: firstTime},
+		LastTimestamp:  metav1.Time{Time: firstTime},
+	}
+}
+
+func ptrInt32(v int32) *int32 { return &v }
+
 type brokenInfoKubeClient struct {
 	kubernetes.Interface
 }
@@ -842,3 +1260,24 @@ func isLabelValueSet(c *module.Chart, name string) bool {
 	}
 	return false
 }
+
+func TestEventsState_add(t *testing.T) {
+	es := newEventsState(1, 2)
+
+	es.add("ns1", corev1.EventTypeWarning, "BackOff", 1)
+	es.add("ns1", corev1.EventTypeWarning, "FailedMount", 1)
+	es.add("ns1", corev1.EventTypeWarning, "FailedScheduling", 2)
+	es.add("ns1", corev1.EventTypeWarning, "Unhealthy", 1)
+	es.add("ns1", corev1.EventTypeWarning, "BackOff", 1)
+	es.add("ns2", corev1.EventTypeNormal, "Pulled", 1)
+
+	assert.Equal(t, int64(6), es.cluster.typeWarning)
+	assert.Equal(t, int64(1), es.cluster.typeNormal)
+	assert.Len(t, es.cluster.reasons, 3)
+	assert.Equal(t, int64(2), es.cluster.reasons["BackOff"].count)
+	assert.Equal(t, int64(4), es.cluster.reasons[eventReasonOther].count)
+
+	require.Len(t, es.namespaces, 1)
+	require.Contains(t, es.namespaces, "ns1")
+	assert.Equal(t, int64(6), es.namespaces["ns1"].typeWarning)
+}
@@ -5,6 +5,9 @@ package k8s_state
 import (
 	"fmt"
 
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -19,8 +22,46 @@ type kubeResourceKind uint8
 const (
 	kubeResourceNode kubeResourceKind = iota + 1
 	kubeResourcePod
+	kubeResourceDeployment
+	kubeResourceReplicaSet
+	kubeResourceStatefulSet
+	kubeResourceDaemonSet
+	kubeResourceJob
+	kubeResourceCronJob
+	kubeResourcePVC
+	kubeResourceHPA
+	kubeResourceEvent
 )
 
+func (k kubeResourceKind) String() string {
+	switch k {
+	case kubeResourceNode:
+		return "node"
+	case kubeResourcePod:
+		return "pod"
+	case kubeResourceDeployment:
+		return "deployment"
+	case kubeResourceReplicaSet:
+		return "replicaset"
+	case kubeResourceStatefulSet:
+		return "statefulset"
+	case kubeResourceDaemonSet:
+		return "daemonset"
+	case kubeResourceJob:
+		return "job"
+	case kubeResourceCronJob:
+		return "cronjob"
+	case kubeResourcePVC:
+		return "pvc"
+	case kubeResourceHPA:
+		return "hpa"
+	case kubeResourceEvent:
+		return "event"
+	default:
+		return "unknown"
+	}
+}
+
 func toNode(i interface{}) (*corev1.Node, error) {
 	switch v := i.(type) {
 	case *corev1.Node:
@@ -42,3 +83,102 @@ func toPod(i interface{}) (*corev1.Pod, error) {
 		return nil, fmt.Errorf("unexpected type: %T (expected %T or %T)", v, &corev1.Pod{}, resource(nil))
 	}
 }
+
+func toDeployment(i interface{}) (*appsv1.Deployment, error) {
+	switch v := i.(type) {
+	case *appsv1.Deployment:
+		return v, nil
+	case resource:
+		return toDeployment(v.value())
+	default:
+		return nil, fmt.Errorf("unexpected type: %T (expected %T or %T)", v, &appsv1.Deployment{}, resource(nil))
+	}
+}
+
+func toReplicaSet(i interface{}) (*appsv1.ReplicaSet, error) {
+	switch v := i.(type) {
+	case *appsv1.ReplicaSet:
+		return v, nil
+	case resource:
+		return toReplicaSet(v.value())
+	default:
+		return nil, fmt.Errorf("unexpected type: %T (expected %T or %T)", v, &appsv1.ReplicaSet{}, resource(nil))
+	}
+}
+
+func toStatefulSet(i interface{}) (*appsv1.StatefulSet, error) {
+	switch v := i.(type) {
+	case *appsv1.StatefulSet:
+		return v, nil
+	case resource:
+		return toStatefulSet(v.value())
+	default:
+		return nil, fmt.Errorf("unexpected type: %T (expected %T or %T)", v, &appsv1.StatefulSet{}, resource(nil))
+	}
+}
+
+func toDaemonSet(i interface{}) (*appsv1.DaemonSet, error) {
+	switch v := i.(type) {
+	case *appsv1.DaemonSet:
+		return v, nil
+	case resource:
+		return toDaemonSet(v.value())
+	default:
+		return nil, fmt.Errorf("unexpected type: %T (expected %T or %T)", v, &appsv1.DaemonSet{}, resource(nil))
+	}
+}
+
+func toJob(i interface{}) (*batchv1.Job, error) {
+	switch v := i.(type) {
+	case *batchv1.Job:
+		return v, nil
+	case resource:
+		return toJob(v.value())
+	default:
+		return nil, fmt.Errorf("unexpected type: %T (expected %T or %T)", v, &batchv1.Job{}, resource(nil))
+	}
+}
+
+func toCronJob(i interface{}) (*batchv1.CronJob, error) {
+	switch v := i.(type) {
+	case *batchv1.CronJob:
+		return v, nil
+	case resource:
+		return toCronJob(v.value())
+	default:
+		return nil, fmt.Errorf("unexpected type: %T (expected %T or %T)", v, &batchv1.CronJob{}, resource(nil))
+	}
+}
+
+func toPVC(i interface{}) (*corev1.PersistentVolumeClaim, error) {
+	switch v := i.(type) {
+	case *corev1.PersistentVolumeClaim:
+		return v, nil
+	case resource:
+		return toPVC(v.value())
+	default:
+		return nil, fmt.Errorf("unexpected type: %T (expected %T or %T)", v, &corev1.PersistentVolumeClaim{}, resource(nil))
+	}
+}
+
+func toHPA(i interface{}) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	switch v := i.(type) {
+	case *autoscalingv2.HorizontalPodAutoscaler:
+		return v, nil
+	case resource:
+		return toHPA(v.value())
+	default:
+		return nil, fmt.Errorf("unexpected type: %T (expected %T or %T)", v, &autoscalingv2.HorizontalPodAutoscaler{}, resource(nil))
+	}
+}
+
+func toEvent(i interface{}) (*corev1.Event, error) {
+	switch v := i.(type) {
+	case *corev1.Event:
+		return v, nil
+	case resource:
+		return toEvent(v.value())
+	default:
+		return nil, fmt.Errorf("unexpected type: %T (expected %T or %T)", v, &corev1.Event{}, resource(nil))
+	}
+}
@@ -11,9 +11,17 @@ import (
 
 func newKubeState() *kubeState {
 	return &kubeState{
-		Mutex: &sync.Mutex{},
-		nodes: make(map[string]*nodeState),
-		pods:  make(map[string]*podState),
+		Mutex:        &sync.Mutex{},
+		nodes:        make(map[string]*nodeState),
+		pods:         make(map[string]*podState),
+		deployments:  make(map[string]*deploymentState),
+		replicaSets:  make(map[string]*replicaSetState),
+		statefulSets: make(map[string]*statefulSetState),
+		daemonSets:   make(map[string]*daemonSetState),
+		jobs:         make(map[string]*jobState),
+		cronJobs:     make(map[string]*cronJobState),
+		pvcs:         make(map[string]*pvcState),
+		hpas:         make(map[string]*hpaState),
 	}
 }
 
@@ -44,8 +52,17 @@ func newContainerState() *containerState {
 
 type kubeState struct {
 	*sync.Mutex
-	nodes map[string]*nodeState
-	pods  map[string]*podState
+	nodes        map[string]*nodeState
+	pods         map[string]*podState
+	deployments  map[string]*deploymentState
+	replicaSets  map[string]*replicaSetState
+	statefulSets map[string]*statefulSetState
+	daemonSets   map[string]*daemonSetState
+	jobs         map[string]*jobState
+	cronJobs     map[string]*cronJobState
+	pvcs         map[string]*pvcState
+	hpas         map[string]*hpaState
+	events       *eventsState // nil if events collection is disabled
 }
 
 type (
@@ -163,3 +180,185 @@ type (
 		active bool
 	}
 )
+
+type (
+	workloadMeta struct {
+		new     bool
+		deleted bool
+
+		name         string
+		namespace    string
+		creationTime time.Time
+	}
+	workloadReplicas struct {
+		desired      int64
+		current      int64
+		updated      int64
+		ready        int64
+		available    int64
+		unavailable  int64
+		misscheduled int64
+	}
+)
+
+func (wm workloadMeta) id() string { return wm.namespace + "_" + wm.name }
+
+type (
+	deploymentState struct {
+		workloadMeta
+		replicas      workloadReplicas
+		generationLag int64
+		// https://kubernetes.io/docs/concepts/workloads/controllers/deployment/#deployment-status
+		condAvailable      corev1.ConditionStatus
+		condProgressing    corev1.ConditionStatus
+		condReplicaFailure corev1.ConditionStatus
+	}
+	replicaSetState struct {
+		workloadMeta
+		replicas      workloadReplicas
+		generationLag int64
+	}
+	statefulSetState struct {
+		workloadMeta
+		replicas      workloadReplicas
+		generationLag int64
+	}
+	daemonSetState struct {
+		workloadMeta
+		replicas      workloadReplicas
+		generationLag int64
+	}
+)
+
+type (
+	jobState struct {
+		workloadMeta
+		cronJobName   string
+		podsActive    int64
+		podsSucceeded int64
+		podsFailed    int64
+		// https://kubernetes.io/docs/concepts/workloads/controllers/job/#terminal-job-conditions
+		condComplete bool
+		condFailed   bool
+		suspended    bool
+	}
+	cronJobState struct {
+		workloadMeta
+		suspended          bool
+		activeJobs         int64
+		lastScheduleTime   time.Time
+		lastSuccessfulTime time.Time
+
+		stats cronJobStateStats
+	}
+	cronJobStateStats struct {
+		jobsActive    int64
+		jobsSucceeded int64
+		jobsFailed    int64
+		lastJob       *jobState
+	}
+)
+
+func (js jobState) finished() bool   { return js.condComplete || js.condFailed }
+func (cs *cronJobState) resetStats() { cs.stats = cronJobStateStats{} }
+
+type (
+	pvcState struct {
+		workloadMeta
+		storageClass string
+		phase        corev1.PersistentVolumeClaimPhase
+		requested    int64
+		capacity     int64
+	}
+	hpaState struct {
+		workloadMeta
+		targetKind      string
+		targetName      string
+		minReplicas     int64
+		maxReplicas     int64
+		currentReplicas int64
+		desiredReplicas int64
+	}
+)
+
+func newEventsState(maxNamespaces, maxReasons int) *eventsState {
+	return &eventsState{
+		maxNamespaces: maxNamespaces,
+		maxReasons:    maxReasons,
+		seen:          make(map[string]int64),
+		cluster:       newEventCounters(),
+		namespaces:    make(map[string]*eventCounters),
+	}
+}
+
+func newEventCounters() *eventCounters {
+	return &eventCounters{
+		new:     true,
+		reasons: make(map[string]*eventReasonCounter),
+	}
+}
+
+const eventReasonOther = "other"
+
+type (
+	eventsState struct {
+		maxNamespaces int
+		maxReasons    int
+
+		// last seen occurrences count per event source, events are aggregated by the API server
+		// (count/series.count) and resent on every resync
+		seen map[string]int64
+
+		cluster    *eventCounters
+		namespaces map[string]*eventCounters
+	}
+	eventCounters struct {
+		new bool
+
+		typeNormal  int64
+		typeWarning int64
+		reasons     map[string]*eventReasonCounter
+	}
+	eventReasonCounter struct {
+		new   bool
+		count int64
+	}
+)
+
+func (es *eventsState) add(namespace, typ, reason string, delta int64) {
+	es.cluster.add(typ, reason, delta, es.maxReasons)
+
+	ns, ok := es.namespaces[namespace]
+	if !ok {
+		if len(es.namespaces) >= es.maxNamespaces {
+			return
+		}
+		ns = newEventCounters()
+		es.namespaces[namespace] = ns
+	}
+	ns.add(typ, reason, delta, es.maxReasons)
+}
+
+func (ec *eventCounters) add(typ, reason string, delta int64, maxReasons int) {
+	switch typ {
+	case corev1.EventTypeNormal:
+		ec.typeNormal += delta
+	case corev1.EventTypeWarning:
+		ec.typeWarning += delta
+	}
+
+	if reason == "" {
+		reason = eventReasonOther
+	}
+	r, ok := ec.reasons[reason]
+	if !ok {
+		if len(ec.reasons) >= maxReasons {
+			reason = eventReasonOther
+		}
+		if r, ok = ec.reasons[reason]; !ok {
+			r = &eventReasonCounter{new: true}
+			ec.reasons[reason] = r
+		}
+	}
+	r.count += delta
+}
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package k8s_state
+
+import (
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func (ks *KubeState) updateEventState(r resource) {
+	es := ks.state.events
+	if es == nil {
+		return
+	}
+
+	if r.value() == nil {
+		delete(es.seen, r.source())
+		return
+	}
+
+	event, err := toEvent(r)
+	if err != nil {
+		ks.Warning(err)
+		return
+	}
+
+	if !ks.isEventInvolvedKindSelected(event.InvolvedObject.Kind) {
+		return
+	}
+
+	count := eventCount(event)
+	prev, ok := es.seen[r.source()]
+	es.seen[r.source()] = count
+
+	var delta int64
+	switch {
+	case ok:
+		// resync and unrelated updates don't change the count
+		delta = count - prev
+	case !eventFirstTime(event).Before(ks.startTime):
+		delta = count
+	case !eventLastTime(event).Before(ks.startTime):
+		// the event was first seen before the start, we don't know how many times it happened since then
+		delta = 1
+	}
+
+	if delta > 0 {
+		es.add(event.Namespace, event.Type, event.Reason, delta)
+	}
+}
+
+func (ks *KubeState) isEventInvolvedKindSelected(kind string) bool {
+	if len(ks.Events.InvolvedKinds) == 0 {
+		return true
+	}
+	for _, v := range ks.Events.InvolvedKinds {
+		if strings.EqualFold(v, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+func eventCount(event *corev1.Event) int64 {
+	switch {
+	case event.Series != nil && event.Series.Count > 0:
+		return int64(event.Series.Count)
+	case event.Count > 0:
+		return int64(event.Count)
+	default:
+		return 1
+	}
+}
+
+func eventFirstTime(event *corev1.Event) time.Time {
+	if !event.FirstTimestamp.IsZero() {
+		return event.FirstTimestamp.Time
+	}
+	return event.EventTime.Time
+}
+
+func eventLastTime(event *corev1.Event) time.Time {
+	switch {
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	default:
+		return eventFirstTime(event)
+	}
+}
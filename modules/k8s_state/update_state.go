@@ -14,6 +14,24 @@ func (ks *KubeState) runUpdateState(in <-chan resource) {
 				ks.updateNodeState(r)
 			case kubeResourcePod:
 				ks.updatePodState(r)
+			case kubeResourceDeployment:
+				ks.updateDeploymentState(r)
+			case kubeResourceReplicaSet:
+				ks.updateReplicaSetState(r)
+			case kubeResourceStatefulSet:
+				ks.updateStatefulSetState(r)
+			case kubeResourceDaemonSet:
+				ks.updateDaemonSetState(r)
+			case kubeResourceJob:
+				ks.updateJobState(r)
+			case kubeResourceCronJob:
+				ks.updateCronJobState(r)
+			case kubeResourcePVC:
+				ks.updatePVCState(r)
+			case kubeResourceHPA:
+				ks.updateHPAState(r)
+			case kubeResourceEvent:
+				ks.updateEventState(r)
 			}
 			ks.state.Unlock()
 		}
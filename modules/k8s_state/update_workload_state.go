@@ -0,0 +1,303 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package k8s_state
+
+import (
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (ks *KubeState) updateDeploymentState(r resource) {
+	if r.value() == nil {
+		if ds, ok := ks.state.deployments[r.source()]; ok {
+			ds.deleted = true
+		}
+		return
+	}
+
+	deploy, err := toDeployment(r)
+	if err != nil {
+		ks.Warning(err)
+		return
+	}
+
+	ds, ok := ks.state.deployments[r.source()]
+	if !ok {
+		ds = &deploymentState{workloadMeta: newWorkloadMeta(deploy.ObjectMeta)}
+		ks.state.deployments[r.source()] = ds
+	}
+
+	ds.generationLag = generationLag(deploy.Generation, deploy.Status.ObservedGeneration)
+	ds.replicas = workloadReplicas{
+		desired:     int32PtrToInt(deploy.Spec.Replicas, 1),
+		current:     int64(deploy.Status.Replicas),
+		updated:     int64(deploy.Status.UpdatedReplicas),
+		ready:       int64(deploy.Status.ReadyReplicas),
+		available:   int64(deploy.Status.AvailableReplicas),
+		unavailable: int64(deploy.Status.UnavailableReplicas),
+	}
+
+	ds.condAvailable, ds.condProgressing, ds.condReplicaFailure = "", "", ""
+	for _, c := range deploy.Status.Conditions {
+		switch c.Type {
+		case appsv1.DeploymentAvailable:
+			ds.condAvailable = c.Status
+		case appsv1.DeploymentProgressing:
+			ds.condProgressing = c.Status
+		case appsv1.DeploymentReplicaFailure:
+			ds.condReplicaFailure = c.Status
+		}
+	}
+}
+
+func (ks *KubeState) updateReplicaSetState(r resource) {
+	if r.value() == nil {
+		if rs, ok := ks.state.replicaSets[r.source()]; ok {
+			rs.deleted = true
+		}
+		return
+	}
+
+	replicaSet, err := toReplicaSet(r)
+	if err != nil {
+		ks.Warning(err)
+		return
+	}
+
+	// ReplicaSets managed by Deployments are covered by the Deployment charts.
+	if metav1.GetControllerOf(replicaSet) != nil {
+		return
+	}
+
+	rs, ok := ks.state.replicaSets[r.source()]
+	if !ok {
+		rs = &replicaSetState{workloadMeta: newWorkloadMeta(replicaSet.ObjectMeta)}
+		ks.state.replicaSets[r.source()] = rs
+	}
+
+	rs.generationLag = generationLag(replicaSet.Generation, replicaSet.Status.ObservedGeneration)
+	rs.replicas = workloadReplicas{
+		desired:   int32PtrToInt(replicaSet.Spec.Replicas, 1),
+		current:   int64(replicaSet.Status.Replicas),
+		ready:     int64(replicaSet.Status.ReadyReplicas),
+		available: int64(replicaSet.Status.AvailableReplicas),
+	}
+}
+
+func (ks *KubeState) updateStatefulSetState(r resource) {
+	if r.value() == nil {
+		if ss, ok := ks.state.statefulSets[r.source()]; ok {
+			ss.deleted = true
+		}
+		return
+	}
+
+	sts, err := toStatefulSet(r)
+	if err != nil {
+		ks.Warning(err)
+		return
+	}
+
+	ss, ok := ks.state.statefulSets[r.source()]
+	if !ok {
+		ss = &statefulSetState{workloadMeta: newWorkloadMeta(sts.ObjectMeta)}
+		ks.state.statefulSets[r.source()] = ss
+	}
+
+	ss.generationLag = generationLag(sts.Generation, sts.Status.ObservedGeneration)
+	ss.replicas = workloadReplicas{
+		desired:   int32PtrToInt(sts.Spec.Replicas, 1),
+		current:   int64(sts.Status.CurrentReplicas),
+		updated:   int64(sts.Status.UpdatedReplicas),
+		ready:     int64(sts.Status.ReadyReplicas),
+		available: int64(sts.Status.AvailableReplicas),
+	}
+}
+
+func (ks *KubeState) updateDaemonSetState(r resource) {
+	if r.value() == nil {
+		if ds, ok := ks.state.daemonSets[r.source()]; ok {
+			ds.deleted = true
+		}
+		return
+	}
+
+	daemonSet, err := toDaemonSet(r)
+	if err != nil {
+		ks.Warning(err)
+		return
+	}
+
+	ds, ok := ks.state.daemonSets[r.source()]
+	if !ok {
+		ds = &daemonSetState{workloadMeta: newWorkloadMeta(daemonSet.ObjectMeta)}
+		ks.state.daemonSets[r.source()] = ds
+	}
+
+	ds.generationLag = generationLag(daemonSet.Generation, daemonSet.Status.ObservedGeneration)
+	ds.replicas = workloadReplicas{
+		desired:      int64(daemonSet.Status.DesiredNumberScheduled),
+		current:      int64(daemonSet.Status.CurrentNumberScheduled),
+		updated:      int64(daemonSet.Status.UpdatedNumberScheduled),
+		ready:        int64(daemonSet.Status.NumberReady),
+		available:    int64(daemonSet.Status.NumberAvailable),
+		unavailable:  int64(daemonSet.Status.NumberUnavailable),
+		misscheduled: int64(daemonSet.Status.NumberMisscheduled),
+	}
+}
+
+func (ks *KubeState) updateJobState(r resource) {
+	if r.value() == nil {
+		if js, ok := ks.state.jobs[r.source()]; ok {
+			js.deleted = true
+		}
+		return
+	}
+
+	job, err := toJob(r)
+	if err != nil {
+		ks.Warning(err)
+		return
+	}
+
+	js, ok := ks.state.jobs[r.source()]
+	if !ok {
+		js = &jobState{workloadMeta: newWorkloadMeta(job.ObjectMeta)}
+		ks.state.jobs[r.source()] = js
+		if ref := metav1.GetControllerOf(job); ref != nil && ref.Kind == "CronJob" {
+			js.cronJobName = ref.Name
+		}
+	}
+
+	js.podsActive = int64(job.Status.Active)
+	js.podsSucceeded = int64(job.Status.Succeeded)
+	js.podsFailed = int64(job.Status.Failed)
+	js.suspended = job.Spec.Suspend != nil && *job.Spec.Suspend
+
+	js.condComplete, js.condFailed = false, false
+	for _, c := range job.Status.Conditions {
+		switch c.Type {
+		case batchv1.JobComplete:
+			js.condComplete = c.Status == corev1.ConditionTrue
+		case batchv1.JobFailed:
+			js.condFailed = c.Status == corev1.ConditionTrue
+		}
+	}
+}
+
+func (ks *KubeState) updateCronJobState(r resource) {
+	if r.value() == nil {
+		if cs, ok := ks.state.cronJobs[r.source()]; ok {
+			cs.deleted = true
+		}
+		return
+	}
+
+	cronJob, err := toCronJob(r)
+	if err != nil {
+		ks.Warning(err)
+		return
+	}
+
+	cs, ok := ks.state.cronJobs[r.source()]
+	if !ok {
+		cs = &cronJobState{workloadMeta: newWorkloadMeta(cronJob.ObjectMeta)}
+		ks.state.cronJobs[r.source()] = cs
+	}
+
+	cs.suspended = cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend
+	cs.activeJobs = int64(len(cronJob.Status.Active))
+	cs.lastScheduleTime = timeOrZero(cronJob.Status.LastScheduleTime)
+	cs.lastSuccessfulTime = timeOrZero(cronJob.Status.LastSuccessfulTime)
+}
+
+func (ks *KubeState) updatePVCState(r resource) {
+	if r.value() == nil {
+		if ps, ok := ks.state.pvcs[r.source()]; ok {
+			ps.deleted = true
+		}
+		return
+	}
+
+	pvc, err := toPVC(r)
+	if err != nil {
+		ks.Warning(err)
+		return
+	}
+
+	ps, ok := ks.state.pvcs[r.source()]
+	if !ok {
+		ps = &pvcState{workloadMeta: newWorkloadMeta(pvc.ObjectMeta)}
+		ks.state.pvcs[r.source()] = ps
+	}
+
+	if pvc.Spec.StorageClassName != nil {
+		ps.storageClass = *pvc.Spec.StorageClassName
+	}
+	ps.phase = pvc.Status.Phase
+	ps.requested = pvc.Spec.Resources.Requests.Storage().Value()
+	ps.capacity = pvc.Status.Capacity.Storage().Value()
+}
+
+func (ks *KubeState) updateHPAState(r resource) {
+	if r.value() == nil {
+		if hs, ok := ks.state.hpas[r.source()]; ok {
+			hs.deleted = true
+		}
+		return
+	}
+
+	hpa, err := toHPA(r)
+	if err != nil {
+		ks.Warning(err)
+		return
+	}
+
+	hs, ok := ks.state.hpas[r.source()]
+	if !ok {
+		hs = &hpaState{workloadMeta: newWorkloadMeta(hpa.ObjectMeta)}
+		ks.state.hpas[r.source()] = hs
+	}
+
+	hs.targetKind = hpa.Spec.ScaleTargetRef.Kind
+	hs.targetName = hpa.Spec.ScaleTargetRef.Name
+	hs.minReplicas = int32PtrToInt(hpa.Spec.MinReplicas, 1)
+	hs.maxReplicas = int64(hpa.Spec.MaxReplicas)
+	hs.currentReplicas = int64(hpa.Status.CurrentReplicas)
+	hs.desiredReplicas = int64(hpa.Status.DesiredReplicas)
+}
+
+func newWorkloadMeta(meta metav1.ObjectMeta) workloadMeta {
+	return workloadMeta{
+		new:          true,
+		name:         meta.Name,
+		namespace:    meta.Namespace,
+		creationTime: meta.CreationTimestamp.Time,
+	}
+}
+
+// generationLag is the number of spec changes not yet observed by the controller.
+func generationLag(generation, observed int64) int64 {
+	if generation <= observed {
+		return 0
+	}
+	return generation - observed
+}
+
+func int32PtrToInt(v *int32, def int64) int64 {
+	if v == nil {
+		return def
+	}
+	return int64(*v)
+}
+
+func timeOrZero(t *metav1.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.Time
+}
@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package logs
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/metrics"
+)
+
+const (
+	prioLines = module.Priority + iota
+	prioMetric
+)
+
+var linesCharts = module.Charts{
+	linesChart.Copy(),
+}
+
+var linesChart = module.Chart{
+	ID:       "lines",
+	Title:    "Processed log lines",
+	Units:    "lines/s",
+	Fam:      "lines",
+	Ctx:      "logs.lines",
+	Priority: prioLines,
+	Dims: module.Dims{
+		{ID: "lines_total", Name: "total", Algo: module.Incremental},
+		{ID: "lines_unmatched", Name: "unmatched", Algo: module.Incremental},
+		{ID: "lines_dropped", Name: "dropped", Algo: module.Incremental},
+	},
+}
+
+func (l *Logs) addSeriesCharts(rule *metricRule, s *metricSeries) {
+	chart := newSeriesChart(rule, s)
+	chart.Priority = prioMetric + l.ruleIndex(rule)
+
+	if err := l.Charts().Add(chart); err != nil {
+		l.Warning(err)
+	}
+}
+
+func (l *Logs) ruleIndex(rule *metricRule) int {
+	for i, r := range l.rules {
+		if r == rule {
+			return i
+		}
+	}
+	return 0
+}
+
+func newSeriesChart(rule *metricRule, s *metricSeries) *module.Chart {
+	chart := &module.Chart{
+		ID:     s.id,
+		Title:  rule.title,
+		Units:  rule.units,
+		Fam:    rule.name,
+		Ctx:    "logs." + rule.name,
+		Labels: append([]module.Label(nil), s.labels...),
+	}
+	if chart.Title == "" {
+		chart.Title = rule.name
+	}
+
+	switch rule.typ {
+	case metricTypeCounter:
+		if chart.Units == "" {
+			chart.Units = "events/s"
+		}
+		chart.Dims = module.Dims{
+			{ID: s.id, Name: rule.name, Algo: module.Incremental, Div: precision},
+		}
+	case metricTypeGauge:
+		if chart.Units == "" {
+			chart.Units = "value"
+		}
+		chart.Dims = module.Dims{
+			{ID: s.id, Name: rule.name, Div: precision},
+		}
+	case metricTypeSummary:
+		if chart.Units == "" {
+			chart.Units = "value"
+		}
+		chart.Dims = module.Dims{
+			{ID: s.id + "_min", Name: "min", Div: precision},
+			{ID: s.id + "_avg", Name: "avg", Div: precision},
+			{ID: s.id + "_max", Name: "max", Div: precision},
+		}
+	case metricTypeHistogram:
+		if chart.Units == "" {
+			chart.Units = "observations/s"
+		}
+		chart.Type = module.Stacked
+		for i, v := range histogramBuckets(rule.buckets) {
+			chart.Dims = append(chart.Dims, &module.Dim{
+				ID:   fmt.Sprintf("%s_bucket_%d", s.id, i+1),
+				Name: strconv.FormatFloat(v, 'f', -1, 64),
+				Algo: module.Incremental,
+			})
+		}
+		chart.Dims = append(chart.Dims, &module.Dim{ID: s.id + "_bucket_inf", Name: "+Inf", Algo: module.Incremental})
+	}
+
+	return chart
+}
+
+func histogramBuckets(buckets []float64) []float64 {
+	if len(buckets) == 0 {
+		return metrics.DefBuckets
+	}
+	v := append([]float64(nil), buckets...)
+	sort.Float64s(v)
+	return v
+}
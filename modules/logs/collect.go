@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package logs
+
+import (
+	"io"
+
+	pkglogs "github.com/netdata/go.d.plugin/pkg/logs"
+)
+
+func (l *Logs) collect() (map[string]int64, error) {
+	for _, rule := range l.rules {
+		for _, s := range rule.series {
+			s.reset()
+		}
+	}
+
+	n, err := l.collectLogLines()
+	if n == 0 && err != nil {
+		return nil, err
+	}
+
+	mx := make(map[string]int64)
+
+	mx["lines_total"] = l.linesTotal
+	mx["lines_unmatched"] = l.linesUnmatched
+	mx["lines_dropped"] = l.linesDropped
+
+	for _, rule := range l.rules {
+		for _, s := range rule.series {
+			s.writeTo(mx, rule.typ)
+		}
+	}
+
+	return mx, err
+}
+
+func (l *Logs) collectLogLines() (int, error) {
+	var n int
+	for {
+		l.line.reset()
+		err := l.parser.ReadLine(l.line)
+		if err != nil {
+			if err == io.EOF {
+				return n, nil
+			}
+			if !pkglogs.IsParseError(err) {
+				return n, err
+			}
+			n++
+			l.linesTotal++
+			l.linesUnmatched++
+			continue
+		}
+		n++
+		l.linesTotal++
+		if l.line.empty() {
+			l.linesUnmatched++
+			continue
+		}
+		l.collectLogLine()
+	}
+}
+
+func (l *Logs) collectLogLine() {
+	var dropped bool
+	for _, rule := range l.rules {
+		if !rule.matches(l.line) {
+			continue
+		}
+
+		value, hasValue := rule.observedValue(l.line)
+		if rule.value != "" && !hasValue {
+			continue
+		}
+
+		key := rule.seriesKey(l.line)
+		s, ok := rule.series[key]
+		if !ok {
+			if len(rule.series) >= l.MaxLabelValues {
+				if !rule.overflow {
+					rule.overflow = true
+					l.Warningf("metric '%s': reached max label values limit (%d), new label values are dropped",
+						rule.name, l.MaxLabelValues)
+				}
+				dropped = true
+				continue
+			}
+			s = rule.newSeries(key, l.line)
+			rule.series[key] = s
+			l.addSeriesCharts(rule, s)
+		}
+
+		switch rule.typ {
+		case metricTypeCounter:
+			if !hasValue {
+				s.counter.Inc()
+			} else if value >= 0 {
+				s.counter.Add(value)
+			}
+		case metricTypeGauge:
+			s.gauge.Set(value)
+		case metricTypeHistogram, metricTypeSummary:
+			s.observer.Observe(value)
+		}
+	}
+	if dropped {
+		l.linesDropped++
+	}
+}
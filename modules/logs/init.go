@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package logs
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	pkglogs "github.com/netdata/go.d.plugin/pkg/logs"
+	"github.com/netdata/go.d.plugin/pkg/matcher"
+)
+
+var reMetricName = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
+func (l *Logs) validateConfig() error {
+	switch l.Source {
+	case pkglogs.SourceFile, "":
+		if l.Path == "" {
+			return errors.New("'path' not set")
+		}
+	case pkglogs.SourceJournal:
+	default:
+		return fmt.Errorf("unknown 'source' value '%s'", l.Source)
+	}
+	if len(l.Metrics) == 0 {
+		return errors.New("'metrics' not set")
+	}
+	if l.MaxLabelValues <= 0 {
+		return errors.New("'max_label_values' must be positive")
+	}
+	return nil
+}
+
+func (l *Logs) initMetricRules() ([]*metricRule, error) {
+	var rules []*metricRule
+	seen := make(map[string]bool)
+
+	for i, cfg := range l.Metrics {
+		if cfg.Name == "" {
+			return nil, fmt.Errorf("metric %d: 'name' not set", i+1)
+		}
+		if !reMetricName.MatchString(cfg.Name) {
+			return nil, fmt.Errorf("metric '%s': 'name' must contain only letters, digits and underscores", cfg.Name)
+		}
+		if seen[cfg.Name] {
+			return nil, fmt.Errorf("metric '%s': duplicate name", cfg.Name)
+		}
+		seen[cfg.Name] = true
+
+		rule, err := newMetricRule(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("metric '%s': %v", cfg.Name, err)
+		}
+		l.Debugf("created metric rule '%s' (type '%s', %d condition(s), labels %v)",
+			rule.name, rule.typ, len(rule.conds), rule.labels)
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func newMetricRule(cfg metricConfig) (*metricRule, error) {
+	switch cfg.Type {
+	case metricTypeCounter:
+	case metricTypeGauge, metricTypeHistogram, metricTypeSummary:
+		if cfg.Value == "" {
+			return nil, fmt.Errorf("'value' is required for '%s' metric type", cfg.Type)
+		}
+	case "":
+		return nil, errors.New("'type' not set")
+	default:
+		return nil, fmt.Errorf("unknown metric type '%s'", cfg.Type)
+	}
+
+	rule := &metricRule{
+		name:    cfg.Name,
+		typ:     cfg.Type,
+		title:   cfg.Title,
+		units:   cfg.Units,
+		value:   cfg.Value,
+		labels:  cfg.Labels,
+		buckets: cfg.Buckets,
+		series:  make(map[string]*metricSeries),
+	}
+
+	for _, fm := range cfg.Match {
+		if fm.Field == "" || fm.Pattern == "" {
+			return nil, errors.New("match: empty 'field' or 'pattern'")
+		}
+		m, err := matcher.Parse(fm.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("match: field '%s' pattern '%s': %v", fm.Field, fm.Pattern, err)
+		}
+		rule.conds = append(rule.conds, fieldCond{field: fm.Field, Matcher: m})
+	}
+
+	return rule, nil
+}
+
+func (l *Logs) createLogReader() error {
+	l.Cleanup()
+	l.Debug("starting log reader creating")
+
+	var reader pkglogs.LogReader
+	var err error
+	switch l.Source {
+	case pkglogs.SourceJournal:
+		reader, err = pkglogs.OpenJournal(l.Journal, l.Logger)
+	case pkglogs.SourceFile, "":
+		reader, err = pkglogs.Open(l.Path, l.ExcludePath, l.Logger)
+	default:
+		err = fmt.Errorf("unknown source '%s'", l.Source)
+	}
+	if err != nil {
+		return fmt.Errorf("creating log reader: %v", err)
+	}
+
+	l.Debugf("created log reader, %s", reader.Info())
+	l.file = reader
+	return nil
+}
+
+func (l *Logs) createParser() error {
+	l.Debug("starting parser creating")
+
+	parser, err := pkglogs.NewParser(l.Parser, l.file)
+	if err != nil {
+		return fmt.Errorf("create parser: %v", err)
+	}
+
+	l.Debugf("created parser: %s", parser.Info())
+	l.parser = parser
+	return nil
+}
+
+func checkCSVFormatField(name string) (newName string, offset int, valid bool) {
+	name = strings.TrimLeft(name, "$%")
+	if name == "" || name == "-" {
+		return "", 0, false
+	}
+	return name, 0, true
+}
@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package logs
+
+func newLogLine() *logLine {
+	return &logLine{fields: make(map[string]string)}
+}
+
+// logLine holds fields of a parsed log line as is, the values are interpreted by the metric rules.
+type logLine struct {
+	fields map[string]string
+}
+
+func (l *logLine) Assign(name, value string) error {
+	l.fields[name] = value
+	return nil
+}
+
+func (l *logLine) reset() {
+	for k := range l.fields {
+		delete(l.fields, k)
+	}
+}
+
+func (l *logLine) empty() bool {
+	return len(l.fields) == 0
+}
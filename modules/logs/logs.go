@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package logs
+
+import (
+	"github.com/netdata/go.d.plugin/agent/module"
+	pkglogs "github.com/netdata/go.d.plugin/pkg/logs"
+)
+
+func init() {
+	module.Register("logs", module.Creator{
+		Create: func() module.Module { return New() },
+	})
+}
+
+func New() *Logs {
+	return &Logs{
+		Config: Config{
+			ExcludePath:    "*.gz",
+			MaxLabelValues: 100,
+			Parser: pkglogs.ParserConfig{
+				LogType: pkglogs.TypeJSON,
+				CSV: pkglogs.CSVConfig{
+					FieldsPerRecord:  -1,
+					Delimiter:        " ",
+					TrimLeadingSpace: false,
+					CheckField:       checkCSVFormatField,
+				},
+				LTSV: pkglogs.LTSVConfig{
+					FieldDelimiter: "\t",
+					ValueDelimiter: ":",
+				},
+			},
+		},
+		charts: linesCharts.Copy(),
+	}
+}
+
+type (
+	Config struct {
+		Parser         pkglogs.ParserConfig  `yaml:",inline"`
+		Source         string                `yaml:"source"`
+		Path           string                `yaml:"path"`
+		ExcludePath    string                `yaml:"exclude_path"`
+		Journal        pkglogs.JournalConfig `yaml:"journal"`
+		MaxLabelValues int                   `yaml:"max_label_values"`
+		Metrics        []metricConfig        `yaml:"metrics"`
+	}
+	metricConfig struct {
+		Name    string       `yaml:"name"`
+		Type    string       `yaml:"type"`
+		Title   string       `yaml:"title"`
+		Units   string       `yaml:"units"`
+		Match   []fieldMatch `yaml:"match"`
+		Value   string       `yaml:"value"`
+		Labels  []string     `yaml:"labels"`
+		Buckets []float64    `yaml:"buckets"`
+	}
+	fieldMatch struct {
+		Field   string `yaml:"field"`
+		Pattern string `yaml:"pattern"`
+	}
+)
+
+type Logs struct {
+	module.Base
+	Config `yaml:",inline"`
+
+	charts *module.Charts
+
+	file   pkglogs.LogReader
+	parser pkglogs.Parser
+	line   *logLine
+
+	rules []*metricRule
+
+	linesTotal     int64
+	linesUnmatched int64
+	linesDropped   int64
+}
+
+func (l *Logs) Init() bool {
+	if err := l.validateConfig(); err != nil {
+		l.Errorf("config validation: %v", err)
+		return false
+	}
+
+	rules, err := l.initMetricRules()
+	if err != nil {
+		l.Errorf("init metric rules: %v", err)
+		return false
+	}
+	l.rules = rules
+
+	l.line = newLogLine()
+
+	return true
+}
+
+func (l *Logs) Check() bool {
+	// Note: these inits are here to make auto-detection retry working
+	if err := l.createLogReader(); err != nil {
+		l.Warning("check failed: ", err)
+		return false
+	}
+
+	if err := l.createParser(); err != nil {
+		l.Warning("check failed: ", err)
+		return false
+	}
+
+	return true
+}
+
+func (l *Logs) Charts() *module.Charts {
+	return l.charts
+}
+
+func (l *Logs) Collect() map[string]int64 {
+	mx, err := l.collect()
+	if err != nil {
+		l.Error(err)
+	}
+
+	if len(mx) == 0 {
+		return nil
+	}
+	return mx
+}
+
+func (l *Logs) Cleanup() {
+	if l.file != nil {
+		_ = l.file.Close()
+	}
+}
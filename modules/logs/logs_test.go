@@ -0,0 +1,353 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package logs
+
+import (
+	"bytes"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+	pkglogs "github.com/netdata/go.d.plugin/pkg/logs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	dataAppLog, _ = os.ReadFile("testdata/app.log")
+)
+
+func Test_testDataIsValid(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"dataAppLog": dataAppLog,
+	} {
+		require.NotNilf(t, data, name)
+	}
+}
+
+func TestNew(t *testing.T) {
+	assert.Implements(t, (*module.Module)(nil), New())
+}
+
+func TestLogs_Init(t *testing.T) {
+	tests := map[string]struct {
+		config   Config
+		wantFail bool
+	}{
+		"success with default metrics": {
+			config: prepareConfig(),
+		},
+		"fails if path not set": {
+			wantFail: true,
+			config: func() Config {
+				cfg := prepareConfig()
+				cfg.Path = ""
+				return cfg
+			}(),
+		},
+		"success with journal source without path": {
+			config: func() Config {
+				cfg := prepareConfig()
+				cfg.Path = ""
+				cfg.Source = pkglogs.SourceJournal
+				return cfg
+			}(),
+		},
+		"fails on unknown source": {
+			wantFail: true,
+			config: func() Config {
+				cfg := prepareConfig()
+				cfg.Source = "syslog"
+				return cfg
+			}(),
+		},
+		"fails if metrics not set": {
+			wantFail: true,
+			config: func() Config {
+				cfg := prepareConfig()
+				cfg.Metrics = nil
+				return cfg
+			}(),
+		},
+		"fails on unknown metric type": {
+			wantFail: true,
+			config: func() Config {
+				cfg := prepareConfig()
+				cfg.Metrics[0].Type = "meter"
+				return cfg
+			}(),
+		},
+		"fails on gauge without value": {
+			wantFail: true,
+			config: func() Config {
+				cfg := prepareConfig()
+				cfg.Metrics[0].Type = metricTypeGauge
+				return cfg
+			}(),
+		},
+		"fails on duplicate metric name": {
+			wantFail: true,
+			config: func() Config {
+				cfg := prepareConfig()
+				cfg.Metrics[1].Name = cfg.Metrics[0].Name
+				return cfg
+			}(),
+		},
+		"fails on bad metric name": {
+			wantFail: true,
+			config: func() Config {
+				cfg := prepareConfig()
+				cfg.Metrics[0].Name = "app.errors"
+				return cfg
+			}(),
+		},
+		"fails on bad match pattern": {
+			wantFail: true,
+			config: func() Config {
+				cfg := prepareConfig()
+				cfg.Metrics[0].Match[0].Pattern = "~ (["
+				return cfg
+			}(),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			l := New()
+			l.Config = test.config
+
+			if test.wantFail {
+				assert.False(t, l.Init())
+			} else {
+				assert.True(t, l.Init())
+			}
+		})
+	}
+}
+
+func TestLogs_Check(t *testing.T) {
+	tests := map[string]struct {
+		prepare  func() *Logs
+		wantFail bool
+	}{
+		"success on existing log file": {
+			prepare: func() *Logs {
+				l := New()
+				l.Config = prepareConfig()
+				return l
+			},
+		},
+		"fails if log file not exists": {
+			wantFail: true,
+			prepare: func() *Logs {
+				l := New()
+				l.Config = prepareConfig()
+				l.Path = "testdata/not_exists.log"
+				return l
+			},
+		},
+		"fails on unknown log type": {
+			wantFail: true,
+			prepare: func() *Logs {
+				l := New()
+				l.Config = prepareConfig()
+				l.Parser.LogType = "xml"
+				return l
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			l := test.prepare()
+			defer l.Cleanup()
+
+			require.True(t, l.Init())
+
+			if test.wantFail {
+				assert.False(t, l.Check())
+			} else {
+				assert.True(t, l.Check())
+			}
+		})
+	}
+}
+
+func TestLogs_Charts(t *testing.T) {
+	assert.NotNil(t, New().Charts())
+}
+
+func TestLogs_Cleanup(t *testing.T) {
+	assert.NotPanics(t, New().Cleanup)
+}
+
+func TestLogs_Collect(t *testing.T) {
+	l := prepareLogsCollect(t, prepareConfig())
+
+	mx := l.Collect()
+
+	expected := map[string]int64{
+		"lines_dropped":                          0,
+		"lines_total":                            9,
+		"lines_unmatched":                        1,
+		seriesID("errors", "api"):                2000,
+		seriesID("errors", "db"):                 1000,
+		seriesID("errors", "worker"):             1000,
+		"metric_last_latency":                    500,
+		seriesID("latency", "api") + "_avg":      640625,
+		seriesID("latency", "api") + "_count":    4,
+		seriesID("latency", "api") + "_max":      1500000,
+		seriesID("latency", "api") + "_min":      12500,
+		seriesID("latency", "api") + "_sum":      2562500,
+		seriesID("latency", "db") + "_avg":       21625,
+		seriesID("latency", "db") + "_count":     2,
+		seriesID("latency", "db") + "_max":       40000,
+		seriesID("latency", "db") + "_min":       3250,
+		seriesID("latency", "db") + "_sum":       43250,
+		"metric_latency_hist_bucket_1":           2,
+		"metric_latency_hist_bucket_2":           2,
+		"metric_latency_hist_bucket_3":           2,
+		"metric_latency_hist_bucket_inf":         1,
+		"metric_latency_hist_count":              7,
+		"metric_latency_hist_sum":                2606250,
+		seriesID("latency", "worker") + "_avg":   500,
+		seriesID("latency", "worker") + "_count": 1,
+		seriesID("latency", "worker") + "_max":   500,
+		seriesID("latency", "worker") + "_min":   500,
+		seriesID("latency", "worker") + "_sum":   500,
+	}
+
+	assert.Equal(t, expected, mx)
+	testMetricsHasAllChartsDims(t, l, mx)
+
+	chart := l.Charts().Get(seriesID("errors", "api"))
+	require.NotNil(t, chart)
+	assert.Equal(t, []module.Label{{Key: "component", Value: "api"}}, chart.Labels)
+}
+
+func TestLogs_Collect_ReturnOldDataIfNothingRead(t *testing.T) {
+	l := prepareLogsCollect(t, prepareConfig())
+
+	_ = l.Collect()
+	mx := l.Collect()
+
+	assert.Equal(t, int64(9), mx["lines_total"])
+	assert.Equal(t, int64(2000), mx[seriesID("errors", "api")])
+	assert.Equal(t, int64(0), mx[seriesID("latency", "api")+"_count"])
+	assert.Equal(t, int64(0), mx[seriesID("latency", "api")+"_avg"])
+	testMetricsHasAllChartsDims(t, l, mx)
+}
+
+func TestLogs_Collect_MaxLabelValues(t *testing.T) {
+	cfg := prepareConfig()
+	cfg.MaxLabelValues = 2
+	l := prepareLogsCollect(t, cfg)
+
+	mx := l.Collect()
+
+	assert.Equal(t, int64(2000), mx[seriesID("errors", "api")])
+	assert.Equal(t, int64(1000), mx[seriesID("errors", "db")])
+	assert.NotContains(t, mx, seriesID("errors", "worker"))
+	assert.NotContains(t, mx, seriesID("latency", "worker")+"_count")
+	assert.Equal(t, int64(2), mx["lines_dropped"])
+	assert.False(t, l.Charts().Has(seriesID("errors", "worker")))
+}
+
+func TestLogs_Collect_LabelValuesDoNotCollide(t *testing.T) {
+	cfg := New().Config
+	cfg.Path = "testdata/app.log"
+	cfg.Metrics = []metricConfig{
+		{
+			Name:   "requests",
+			Type:   metricTypeCounter,
+			Labels: []string{"component", "handler"},
+		},
+	}
+	l := New()
+	l.Config = cfg
+	require.True(t, l.Init())
+	require.True(t, l.Check())
+	defer l.Cleanup()
+
+	data := `{"component":"a_b","handler":"c"}
+{"component":"a","handler":"b_c"}
+{"component":"a","handler":"b_c"}
+`
+	p, err := pkglogs.NewJSONParser(l.Parser.JSON, strings.NewReader(data))
+	require.NoError(t, err)
+	l.parser = p
+
+	mx := l.Collect()
+
+	assert.Equal(t, int64(1000), mx[seriesID("requests", "a_b", "c")])
+	assert.Equal(t, int64(2000), mx[seriesID("requests", "a", "b_c")])
+	assert.Len(t, *l.Charts(), len(linesCharts)+2)
+}
+
+func seriesID(metric string, labelValues ...string) string {
+	var key string
+	for _, v := range labelValues {
+		key += strconv.Itoa(len(v)) + ":" + v
+	}
+	return "metric_" + metric + "_" + hashSeriesKey(key)
+}
+
+func testMetricsHasAllChartsDims(t *testing.T, l *Logs, mx map[string]int64) {
+	for _, chart := range *l.Charts() {
+		if chart.Obsolete {
+			continue
+		}
+		for _, dim := range chart.Dims {
+			_, ok := mx[dim.ID]
+			assert.Truef(t, ok, "collected metrics has no data for dim '%s' chart '%s'", dim.ID, chart.ID)
+		}
+	}
+}
+
+func prepareLogsCollect(t *testing.T, cfg Config) *Logs {
+	t.Helper()
+	l := New()
+	l.Config = cfg
+	require.True(t, l.Init())
+	require.True(t, l.Check())
+	defer l.Cleanup()
+
+	p, err := pkglogs.NewJSONParser(l.Parser.JSON, bytes.NewReader(dataAppLog))
+	require.NoError(t, err)
+	l.parser = p
+	return l
+}
+
+func prepareConfig() Config {
+	cfg := New().Config
+	cfg.Path = "testdata/app.log"
+	cfg.Metrics = []metricConfig{
+		{
+			Name:   "errors",
+			Type:   metricTypeCounter,
+			Match:  []fieldMatch{{Field: "level", Pattern: "= ERROR"}},
+			Labels: []string{"component"},
+		},
+		{
+			Name:   "latency",
+			Type:   metricTypeSummary,
+			Value:  "latency",
+			Labels: []string{"component"},
+		},
+		{
+			Name:    "latency_hist",
+			Type:    metricTypeHistogram,
+			Value:   "latency",
+			Buckets: []float64{10, 100, 1000},
+		},
+		{
+			Name:  "last_latency",
+			Type:  metricTypeGauge,
+			Value: "latency",
+		},
+	}
+	return cfg
+}
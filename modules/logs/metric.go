@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package logs
+
+import (
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/matcher"
+	"github.com/netdata/go.d.plugin/pkg/metrics"
+)
+
+const (
+	metricTypeCounter   = "counter"
+	metricTypeGauge     = "gauge"
+	metricTypeHistogram = "histogram"
+	metricTypeSummary   = "summary"
+)
+
+const precision = 1000
+
+type (
+	metricRule struct {
+		name    string
+		typ     string
+		title   string
+		units   string
+		value   string
+		labels  []string
+		buckets []float64
+		conds   []fieldCond
+
+		series   map[string]*metricSeries
+		overflow bool
+	}
+	fieldCond struct {
+		field string
+		matcher.Matcher
+	}
+	metricSeries struct {
+		id       string
+		labels   []module.Label
+		counter  metrics.Counter
+		gauge    metrics.Gauge
+		observer metrics.Observer
+	}
+)
+
+func (r *metricRule) matches(line *logLine) bool {
+	for _, cond := range r.conds {
+		v, ok := line.fields[cond.field]
+		if !ok || !cond.MatchString(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *metricRule) observedValue(line *logLine) (float64, bool) {
+	if r.value == "" {
+		return 0, false
+	}
+	v, ok := line.fields[r.value]
+	if !ok {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+// seriesKey encodes the label values unambiguously: every value is prefixed with its length.
+func (r *metricRule) seriesKey(line *logLine) string {
+	if len(r.labels) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	for _, name := range r.labels {
+		v := labelValue(line, name)
+		sb.WriteString(strconv.Itoa(len(v)))
+		sb.WriteByte(':')
+		sb.WriteString(v)
+	}
+	return sb.String()
+}
+
+func (r *metricRule) newSeries(key string, line *logLine) *metricSeries {
+	s := &metricSeries{id: "metric_" + r.name}
+	if key != "" {
+		s.id += "_" + hashSeriesKey(key)
+	}
+	for _, name := range r.labels {
+		s.labels = append(s.labels, module.Label{Key: name, Value: labelValue(line, name)})
+	}
+
+	switch r.typ {
+	case metricTypeHistogram:
+		s.observer = metrics.NewHistogramWithRangeBuckets(histogramBuckets(r.buckets))
+	case metricTypeSummary:
+		s.observer = metrics.NewSummary()
+	}
+	return s
+}
+
+func (s *metricSeries) reset() {
+	if v, ok := s.observer.(metrics.Summary); ok {
+		v.Reset()
+	}
+}
+
+func (s *metricSeries) writeTo(mx map[string]int64, typ string) {
+	switch typ {
+	case metricTypeCounter:
+		s.counter.WriteTo(mx, s.id, precision, 1)
+	case metricTypeGauge:
+		s.gauge.WriteTo(mx, s.id, precision, 1)
+	case metricTypeHistogram:
+		s.observer.WriteTo(mx, s.id, precision, 1)
+	case metricTypeSummary:
+		s.observer.WriteTo(mx, s.id, precision, 1)
+		if _, ok := mx[s.id+"_min"]; !ok {
+			mx[s.id+"_min"] = 0
+			mx[s.id+"_max"] = 0
+			mx[s.id+"_avg"] = 0
+		}
+	}
+}
+
+func labelValue(line *logLine, name string) string {
+	if v, ok := line.fields[name]; ok && v != "" {
+		return v
+	}
+	return "unknown"
+}
+
+// hashSeriesKey returns the series ID suffix. The label values can't be used in IDs as is,
+// they may contain forbidden characters, and replacing them leads to collisions.
+func hashSeriesKey(key string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return strconv.FormatUint(h.Sum64(), 16)
+}
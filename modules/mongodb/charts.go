@@ -17,6 +17,9 @@ const (
 	prioActiveClientsCount
 	prioQueuedOperationsCount
 
+	prioLongRunningOperationsCount
+	prioLongRunningOperationsMaxTime
+
 	prioGlobalLockAcquisitionsRate
 	prioDatabaseLockAcquisitionsRate
 	prioCollectionLockAcquisitionsRate
@@ -76,6 +79,14 @@ const (
 	prioDatabaseStorageSize
 	prioDatabaseIndexSize
 
+	prioCollectionDocumentsCount
+	prioCollectionSize
+	prioCollectionIndexesCount
+	prioCollectionIndexSize
+	prioCollectionOperationsRate
+	prioCollectionOperationsLatencyTime
+	prioCollectionIndexAccessesRate
+
 	prioReplSetMemberState
 	prioReplSetMemberHealthStatus
 	prioReplSetMemberReplicationLagTime
@@ -91,6 +102,7 @@ const (
 
 const (
 	chartPxDatabase      = "database_"
+	chartPxCollection    = "collection_"
 	chartPxReplSetMember = "replica_set_member_"
 	chartPxShard         = "sharding_shard_"
 )
@@ -126,6 +138,20 @@ var chartsTmplDatabase = module.Charts{
 	chartTmplDatabaseIndexSize.Copy(),
 }
 
+var chartsTmplCollection = module.Charts{
+	chartTmplCollectionDocumentsCount.Copy(),
+	chartTmplCollectionSize.Copy(),
+	chartTmplCollectionIndexesCount.Copy(),
+	chartTmplCollectionIndexSize.Copy(),
+	chartTmplCollectionOperationsRate.Copy(),
+	chartTmplCollectionOperationsLatencyTime.Copy(),
+}
+
+var chartsCurrentOp = module.Charts{
+	chartLongRunningOperationsCount.Copy(),
+	chartLongRunningOperationsMaxTime.Copy(),
+}
+
 var chartsTmplReplSetMember = module.Charts{
 	chartTmplReplSetMemberState.Copy(),
 	chartTmplReplSetMemberHealthStatus.Copy(),
@@ -901,6 +927,120 @@ var (
 	}
 )
 
+var (
+	chartTmplCollectionDocumentsCount = &module.Chart{
+		ID:       chartPxCollection + "%s_documents_count",
+		Title:    "Collection documents",
+		Units:    "documents",
+		Fam:      "collections",
+		Ctx:      "mongodb.collection_documents_count",
+		Priority: prioCollectionDocumentsCount,
+		Dims: module.Dims{
+			{ID: "collection_%s_documents", Name: "documents"},
+		},
+	}
+	chartTmplCollectionSize = &module.Chart{
+		ID:       chartPxCollection + "%s_size",
+		Title:    "Collection size",
+		Units:    "bytes",
+		Fam:      "collections",
+		Ctx:      "mongodb.collection_size",
+		Priority: prioCollectionSize,
+		Dims: module.Dims{
+			{ID: "collection_%s_data_size", Name: "data"},
+			{ID: "collection_%s_storage_size", Name: "storage"},
+			{ID: "collection_%s_index_size", Name: "indexes"},
+		},
+	}
+	chartTmplCollectionIndexesCount = &module.Chart{
+		ID:       chartPxCollection + "%s_indexes_count",
+		Title:    "Collection indexes",
+		Units:    "indexes",
+		Fam:      "collections",
+		Ctx:      "mongodb.collection_indexes_count",
+		Priority: prioCollectionIndexesCount,
+		Dims: module.Dims{
+			{ID: "collection_%s_indexes", Name: "indexes"},
+		},
+	}
+	chartTmplCollectionIndexSize = &module.Chart{
+		ID:       chartPxCollection + "%s_index_size",
+		Title:    "Collection index size",
+		Units:    "bytes",
+		Fam:      "collections",
+		Ctx:      "mongodb.collection_index_size",
+		Type:     module.Stacked,
+		Priority: prioCollectionIndexSize,
+	}
+	chartTmplCollectionOperationsRate = &module.Chart{
+		ID:       chartPxCollection + "%s_operations_rate",
+		Title:    "Collection operations",
+		Units:    "operations/s",
+		Fam:      "collections",
+		Ctx:      "mongodb.collection_operations_rate",
+		Priority: prioCollectionOperationsRate,
+		Dims: module.Dims{
+			{ID: "collection_%s_reads_ops", Name: "reads", Algo: module.Incremental},
+			{ID: "collection_%s_writes_ops", Name: "writes", Algo: module.Incremental},
+			{ID: "collection_%s_commands_ops", Name: "commands", Algo: module.Incremental},
+		},
+	}
+	chartTmplCollectionOperationsLatencyTime = &module.Chart{
+		ID:       chartPxCollection + "%s_operations_latency_time",
+		Title:    "Collection operations latency",
+		Units:    "milliseconds",
+		Fam:      "collections",
+		Ctx:      "mongodb.collection_operations_latency_time",
+		Priority: prioCollectionOperationsLatencyTime,
+		Dims: module.Dims{
+			{ID: "collection_%s_reads_latency", Name: "reads", Algo: module.Incremental, Div: 1000},
+			{ID: "collection_%s_writes_latency", Name: "writes", Algo: module.Incremental, Div: 1000},
+			{ID: "collection_%s_commands_latency", Name: "commands", Algo: module.Incremental, Div: 1000},
+		},
+	}
+	chartTmplCollectionIndexAccessesRate = &module.Chart{
+		ID:       chartPxCollection + "%s_index_accesses_rate",
+		Title:    "Collection index accesses",
+		Units:    "accesses/s",
+		Fam:      "collections",
+		Ctx:      "mongodb.collection_index_accesses_rate",
+		Type:     module.Stacked,
+		Priority: prioCollectionIndexAccessesRate,
+	}
+)
+
+var (
+	chartLongRunningOperationsCount = &module.Chart{
+		ID:       "long_running_operations_count",
+		Title:    "Long-running operations",
+		Units:    "operations",
+		Fam:      "operations",
+		Ctx:      "mongodb.long_running_operations_count",
+		Type:     module.Stacked,
+		Priority: prioLongRunningOperationsCount,
+		Dims: module.Dims{
+			{ID: "current_op_long_running_insert", Name: "insert"},
+			{ID: "current_op_long_running_query", Name: "query"},
+			{ID: "current_op_long_running_update", Name: "update"},
+			{ID: "current_op_long_running_remove", Name: "remove"},
+			{ID: "current_op_long_running_getmore", Name: "getmore"},
+			{ID: "current_op_long_running_command", Name: "command"},
+			{ID: "current_op_long_running_other", Name: "other"},
+		},
+	}
+	chartLongRunningOperationsMaxTime = &module.Chart{
+		ID:       "long_running_operations_max_time",
+		Title:    "Longest running operation",
+		Units:    "milliseconds",
+		Fam:      "operations",
+		Ctx:      "mongodb.long_running_operations_max_time",
+		Priority: prioLongRunningOperationsMaxTime,
+		Dims: module.Dims{
+			{ID: "current_op_long_running_max_time", Name: "max", Div: 1000},
+		},
+	}
+)
+
 var (
 	chartTmplReplSetMemberState = &module.Chart{
 		ID:       chartPxReplSetMember + "%s_state",
@@ -27,6 +27,10 @@ type mongoConn interface {
 	shardDatabasesPartitioning() (*documentPartitionedResult, error)
 	shardCollectionsPartitioning() (*documentPartitionedResult, error)
 	shardChunks() (map[string]int64, error)
+	listCollectionNames(db string) ([]string, error)
+	collStats(db, collection string) (*documentCollStats, error)
+	indexStats(db, collection string) ([]documentIndexStats, error)
+	currentOp(threshold int64) ([]documentCurrentOp, error)
 	initClient(uri string, timeout time.Duration) error
 	close() error
 }
@@ -235,6 +239,95 @@ func (c *mongoClient) shardChunks() (map[string]int64, error) {
 	return result, err
 }
 
+func (c *mongoClient) listCollectionNames(db string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*c.timeout)
+	defer cancel()
+
+	// views don't support $collStats storage stats and $indexStats
+	return c.client.Database(db).ListCollectionNames(ctx, bson.D{{Key: "type", Value: "collection"}})
+}
+
+func (c *mongoClient) collStats(db, collection string) (*documentCollStats, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*c.timeout)
+	defer cancel()
+
+	stage := bson.D{{Key: "$collStats", Value: bson.D{
+		{Key: "latencyStats", Value: bson.D{{Key: "histograms", Value: false}}},
+		{Key: "storageStats", Value: bson.D{}},
+	}}}
+
+	cursor, err := c.client.Database(db).Collection(collection).Aggregate(ctx, mongo.Pipeline{stage})
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = cursor.Close(ctx) }()
+
+	// mongos returns a document per shard
+	var docs []documentCollStats
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	return sumCollStats(docs), nil
+}
+
+func (c *mongoClient) indexStats(db, collection string) ([]documentIndexStats, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*c.timeout)
+	defer cancel()
+
+	stage := bson.D{{Key: "$indexStats", Value: bson.D{}}}
+
+	cursor, err := c.client.Database(db).Collection(collection).Aggregate(ctx, mongo.Pipeline{stage})
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var stats []documentIndexStats
+	if err := cursor.All(ctx, &stats); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (c *mongoClient) currentOp(threshold int64) ([]documentCurrentOp, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*c.timeout)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$currentOp", Value: bson.D{
+			{Key: "allUsers", Value: true},
+			{Key: "idleConnections", Value: false},
+		}}},
+		{{Key: "$match", Value: bson.D{
+			{Key: "active", Value: true},
+			{Key: "secs_running", Value: bson.D{{Key: "$gte", Value: threshold}}},
+		}}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "op", Value: 1},
+			{Key: "ns", Value: 1},
+			{Key: "microsecs_running", Value: 1},
+		}}},
+	}
+
+	cursor, err := c.client.Database("admin").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var ops []documentCurrentOp
+	if err := cursor.All(ctx, &ops); err != nil {
+		return nil, err
+	}
+
+	return ops, nil
+}
+
 func (c *mongoClient) initClient(uri string, timeout time.Duration) error {
 	if c.client != nil {
 		return nil
@@ -32,6 +32,17 @@ func (m *Mongo) collect() (map[string]int64, error) {
 		}
 	}
 
+	// the optional metrics, their failure doesn't affect the rest
+	if err := m.collectCollStats(mx); err != nil {
+		m.Warningf("couldn't collect collection metrics: %v", err)
+	}
+
+	if m.CollectCurrentOp {
+		if err := m.collectCurrentOp(mx); err != nil {
+			m.Warningf("couldn't collect current operations metrics: %v", err)
+		}
+	}
+
 	return mx, nil
 }
 
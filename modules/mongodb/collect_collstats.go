@@ -0,0 +1,251 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mongo
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+)
+
+func (m *Mongo) collectCollStats(mx map[string]int64) error {
+	if m.collSelector == nil {
+		m.Debug("'collections' selector not set, skip collecting collection statistics")
+		return nil
+	}
+
+	dbs, err := m.conn.listDatabaseNames()
+	if err != nil {
+		return fmt.Errorf("cannot get database names: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	seenIndexes := make(map[string]bool)
+	// the collections that failed to be listed or collected keep their charts as is
+	keep := make(map[string]bool)
+
+	for _, db := range dbs {
+		colls, err := m.conn.listCollectionNames(db)
+		if err != nil {
+			m.Warningf("cannot get collection names of '%s' database: %v", db, err)
+			for id, collDB := range m.collections {
+				if collDB == db {
+					keep[id] = true
+				}
+			}
+			continue
+		}
+
+		for _, coll := range colls {
+			if !m.collSelector.MatchString(db + "." + coll) {
+				continue
+			}
+
+			id := collectionID(db, coll)
+
+			s, err := m.conn.collStats(db, coll)
+			if err != nil {
+				// the collection may be dropped after listing, then its charts are removed on the next run
+				m.Warningf("$collStats of '%s.%s' failed: %v", db, coll, err)
+				keep[id] = true
+				continue
+			}
+
+			seen[id] = true
+
+			if _, ok := m.collections[id]; !ok {
+				m.collections[id] = db
+				m.Debugf("new collection '%s.%s': creating charts", db, coll)
+				m.addCollectionCharts(id, db, coll)
+			}
+
+			px := chartPxCollection + id + "_"
+			mx[px+"documents"] = s.StorageStats.Count
+			mx[px+"data_size"] = s.StorageStats.Size
+			mx[px+"storage_size"] = s.StorageStats.StorageSize
+			mx[px+"index_size"] = s.StorageStats.TotalIndexSize
+			mx[px+"indexes"] = s.StorageStats.NIndexes
+			mx[px+"reads_ops"] = s.LatencyStats.Reads.Ops
+			mx[px+"reads_latency"] = s.LatencyStats.Reads.Latency
+			mx[px+"writes_ops"] = s.LatencyStats.Writes.Ops
+			mx[px+"writes_latency"] = s.LatencyStats.Writes.Latency
+			mx[px+"commands_ops"] = s.LatencyStats.Commands.Ops
+			mx[px+"commands_latency"] = s.LatencyStats.Commands.Latency
+
+			for idx, size := range s.StorageStats.IndexSizes {
+				m.collectCollectionIndex(seenIndexes, id, idx)
+				mx[px+"index_"+idx+"_size"] = size
+			}
+
+			if !m.CollectIndexStats {
+				continue
+			}
+
+			stats, err := m.conn.indexStats(db, coll)
+			if err != nil {
+				m.Warningf("$indexStats of '%s.%s' failed: %v", db, coll, err)
+				continue
+			}
+			for _, idx := range stats {
+				m.collectCollectionIndex(seenIndexes, id, idx.Name)
+				// mongos returns a document per shard
+				mx[px+"index_"+idx.Name+"_accesses"] += idx.Accesses.Ops
+			}
+			// an index may be missing in the '$indexStats' output on some shards
+			for idx := range s.StorageStats.IndexSizes {
+				if _, ok := mx[px+"index_"+idx+"_accesses"]; !ok {
+					mx[px+"index_"+idx+"_accesses"] = 0
+				}
+			}
+		}
+	}
+
+	for key, v := range m.collectionIndexes {
+		if !seenIndexes[key] && seen[v.collID] {
+			delete(m.collectionIndexes, key)
+			m.Debugf("stale index '%s' of collection '%s': removing dimensions", v.name, v.collID)
+			m.removeCollectionIndexDims(v.collID, v.name)
+		}
+	}
+
+	for id := range m.collections {
+		if !seen[id] && !keep[id] {
+			delete(m.collections, id)
+			m.Debugf("stale collection '%s': removing charts", id)
+			m.removeCollectionCharts(id)
+			for key, v := range m.collectionIndexes {
+				if v.collID == id {
+					delete(m.collectionIndexes, key)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+type collectionIndex struct {
+	collID string
+	name   string
+}
+
+func (m *Mongo) collectCollectionIndex(seen map[string]bool, collID, name string) {
+	key := collID + "/" + name
+	seen[key] = true
+	if _, ok := m.collectionIndexes[key]; !ok {
+		m.collectionIndexes[key] = collectionIndex{collID: collID, name: name}
+		m.addCollectionIndexDims(collID, name)
+	}
+}
+
+// collectionID returns the collection charts ID. The database and collection names can't be used in IDs as is:
+// they may contain forbidden characters (dots), and joining them is ambiguous.
+func collectionID(db, coll string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(db))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(coll))
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
+func (m *Mongo) addCollectionCharts(id, db, coll string) {
+	charts := chartsTmplCollection.Copy()
+	if m.CollectIndexStats {
+		if err := charts.Add(chartTmplCollectionIndexAccessesRate.Copy()); err != nil {
+			m.Warning(err)
+		}
+	}
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, id)
+		chart.Labels = []module.Label{
+			{Key: "database", Value: db},
+			{Key: "collection", Value: coll},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, id)
+		}
+	}
+
+	if err := m.Charts().Add(*charts...); err != nil {
+		m.Warning(err)
+	}
+}
+
+func (m *Mongo) removeCollectionCharts(id string) {
+	px := chartPxCollection + id + "_"
+
+	for _, chart := range *m.Charts() {
+		if strings.HasPrefix(chart.ID, px) {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
+
+func (m *Mongo) addCollectionIndexDims(collID, name string) {
+	px := chartPxCollection + collID + "_"
+
+	if chart := m.Charts().Get(fmt.Sprintf(chartTmplCollectionIndexSize.ID, collID)); chart != nil {
+		if err := chart.AddDim(&module.Dim{ID: px + "index_" + name + "_size", Name: name}); err != nil {
+			m.Warning(err)
+		}
+		chart.MarkNotCreated()
+	}
+	if chart := m.Charts().Get(fmt.Sprintf(chartTmplCollectionIndexAccessesRate.ID, collID)); chart != nil {
+		dim := &module.Dim{ID: px + "index_" + name + "_accesses", Name: name, Algo: module.Incremental}
+		if err := chart.AddDim(dim); err != nil {
+			m.Warning(err)
+		}
+		chart.MarkNotCreated()
+	}
+}
+
+func (m *Mongo) removeCollectionIndexDims(collID, name string) {
+	px := chartPxCollection + collID + "_"
+
+	for _, v := range []struct{ chartID, dimID string }{
+		{fmt.Sprintf(chartTmplCollectionIndexSize.ID, collID), px + "index_" + name + "_size"},
+		{fmt.Sprintf(chartTmplCollectionIndexAccessesRate.ID, collID), px + "index_" + name + "_accesses"},
+	} {
+		chart := m.Charts().Get(v.chartID)
+		if chart == nil || chart.GetDim(v.dimID) == nil {
+			continue
+		}
+		if err := chart.MarkDimRemove(v.dimID, true); err != nil {
+			m.Warning(err)
+			continue
+		}
+		chart.MarkNotCreated()
+	}
+}
+
+func sumCollStats(docs []documentCollStats) *documentCollStats {
+	var s documentCollStats
+	s.StorageStats.IndexSizes = make(map[string]int64)
+
+	for _, doc := range docs {
+		s.LatencyStats.Reads.Ops += doc.LatencyStats.Reads.Ops
+		s.LatencyStats.Reads.Latency += doc.LatencyStats.Reads.Latency
+		s.LatencyStats.Writes.Ops += doc.LatencyStats.Writes.Ops
+		s.LatencyStats.Writes.Latency += doc.LatencyStats.Writes.Latency
+		s.LatencyStats.Commands.Ops += doc.LatencyStats.Commands.Ops
+		s.LatencyStats.Commands.Latency += doc.LatencyStats.Commands.Latency
+
+		s.StorageStats.Size += doc.StorageStats.Size
+		s.StorageStats.Count += doc.StorageStats.Count
+		s.StorageStats.StorageSize += doc.StorageStats.StorageSize
+		s.StorageStats.TotalIndexSize += doc.StorageStats.TotalIndexSize
+		if doc.StorageStats.NIndexes > s.StorageStats.NIndexes {
+			s.StorageStats.NIndexes = doc.StorageStats.NIndexes
+		}
+		for name, size := range doc.StorageStats.IndexSizes {
+			s.StorageStats.IndexSizes[name] += size
+		}
+	}
+
+	return &s
+}
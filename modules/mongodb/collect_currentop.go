@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mongo
+
+import (
+	"fmt"
+)
+
+// https://www.mongodb.com/docs/manual/reference/command/currentOp/#mongodb-data-currentOp.op
+var currentOpTypes = []string{
+	"insert",
+	"query",
+	"update",
+	"remove",
+	"getmore",
+	"command",
+	"other",
+}
+
+func (m *Mongo) collectCurrentOp(mx map[string]int64) error {
+	ops, err := m.conn.currentOp(m.CurrentOpThreshold)
+	if err != nil {
+		return fmt.Errorf("$currentOp failed: %v", err)
+	}
+
+	m.addCurrentOpChartsOnce.Do(m.addCurrentOpCharts)
+
+	px := "current_op_long_running_"
+	for _, typ := range currentOpTypes {
+		mx[px+typ] = 0
+	}
+
+	var maxTime int64
+	for _, op := range ops {
+		typ := op.Op
+		if _, ok := mx[px+typ]; !ok {
+			typ = "other"
+		}
+		mx[px+typ]++
+		if op.MicrosecsRunning > maxTime {
+			maxTime = op.MicrosecsRunning
+		}
+	}
+
+	mx[px+"max_time"] = maxTime
+
+	return nil
+}
+
+func (m *Mongo) addCurrentOpCharts() {
+	if err := m.Charts().Add(*chartsCurrentOp.Copy()...); err != nil {
+		m.Warning(err)
+	}
+}
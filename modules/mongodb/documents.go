@@ -233,6 +233,34 @@ type documentDBStats struct {
 	StorageSize int64 `bson:"storageSize"`
 }
 
+// https://www.mongodb.com/docs/manual/reference/operator/aggregation/collStats/
+type documentCollStats struct {
+	LatencyStats documentOpLatencies `bson:"latencyStats"`
+	StorageStats struct {
+		Size           int64            `bson:"size"`
+		Count          int64            `bson:"count"`
+		StorageSize    int64            `bson:"storageSize"`
+		TotalIndexSize int64            `bson:"totalIndexSize"`
+		NIndexes       int64            `bson:"nindexes"`
+		IndexSizes     map[string]int64 `bson:"indexSizes"`
+	} `bson:"storageStats"`
+}
+
+// https://www.mongodb.com/docs/manual/reference/operator/aggregation/indexStats/
+type documentIndexStats struct {
+	Name     string `bson:"name"`
+	Accesses struct {
+		Ops int64 `bson:"ops"`
+	} `bson:"accesses"`
+}
+
+// https://www.mongodb.com/docs/manual/reference/operator/aggregation/currentOp/
+type documentCurrentOp struct {
+	Op               string `bson:"op"`
+	NS               string `bson:"ns"`
+	MicrosecsRunning int64  `bson:"microsecs_running"`
+}
+
 // https://www.mongodb.com/docs/manual/reference/command/replSetGetStatus/
 type documentReplSetStatus struct {
 	Date    time.Time               `bson:"date"`
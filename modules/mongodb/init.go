@@ -27,3 +27,17 @@ func (m *Mongo) initDatabaseSelector() error {
 
 	return nil
 }
+
+func (m *Mongo) initCollectionSelector() error {
+	if m.Collections.Empty() {
+		return nil
+	}
+
+	sr, err := m.Collections.Parse()
+	if err != nil {
+		return err
+	}
+	m.collSelector = sr
+
+	return nil
+}
@@ -25,24 +25,37 @@ func New() *Mongo {
 				Includes: []string{},
 				Excludes: []string{},
 			},
+			Collections: matcher.SimpleExpr{
+				Includes: []string{},
+				Excludes: []string{},
+			},
+			CollectIndexStats:  true,
+			CurrentOpThreshold: 5,
 		},
 
 		conn: &mongoClient{},
 
-		charts:                chartsServerStatus.Copy(),
-		addShardingChartsOnce: &sync.Once{},
+		charts:                 chartsServerStatus.Copy(),
+		addShardingChartsOnce:  &sync.Once{},
+		addCurrentOpChartsOnce: &sync.Once{},
 
-		optionalCharts: make(map[string]bool),
-		replSetMembers: make(map[string]bool),
-		databases:      make(map[string]bool),
-		shards:         make(map[string]bool),
+		optionalCharts:    make(map[string]bool),
+		replSetMembers:    make(map[string]bool),
+		databases:         make(map[string]bool),
+		shards:            make(map[string]bool),
+		collections:       make(map[string]string),
+		collectionIndexes: make(map[string]collectionIndex),
 	}
 }
 
 type Config struct {
-	URI       string             `yaml:"uri"`
-	Timeout   time.Duration      `yaml:"timeout"`
-	Databases matcher.SimpleExpr `yaml:"databases"`
+	URI                string             `yaml:"uri"`
+	Timeout            time.Duration      `yaml:"timeout"`
+	Databases          matcher.SimpleExpr `yaml:"databases"`
+	Collections        matcher.SimpleExpr `yaml:"collections"`
+	CollectIndexStats  bool               `yaml:"collect_index_stats"`
+	CollectCurrentOp   bool               `yaml:"collect_current_op"`
+	CurrentOpThreshold int64              `yaml:"current_op_threshold"`
 }
 
 type Mongo struct {
@@ -53,14 +66,18 @@ type Mongo struct {
 
 	conn mongoConn
 
-	dbSelector matcher.Matcher
+	dbSelector   matcher.Matcher
+	collSelector matcher.Matcher
 
-	addShardingChartsOnce *sync.Once
+	addShardingChartsOnce  *sync.Once
+	addCurrentOpChartsOnce *sync.Once
 
-	optionalCharts map[string]bool
-	databases      map[string]bool
-	replSetMembers map[string]bool
-	shards         map[string]bool
+	optionalCharts    map[string]bool
+	databases         map[string]bool
+	replSetMembers    map[string]bool
+	shards            map[string]bool
+	collections       map[string]string // collection ID => database
+	collectionIndexes map[string]collectionIndex
 }
 
 func (m *Mongo) Init() bool {
@@ -74,6 +91,11 @@ func (m *Mongo) Init() bool {
 		return false
 	}
 
+	if err := m.initCollectionSelector(); err != nil {
+		m.Errorf("init collection selector: %v", err)
+		return false
+	}
+
 	return true
 }
 
@@ -5,6 +5,7 @@ package mongo
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"testing"
 	"time"
@@ -20,6 +21,9 @@ var (
 	dataV6MongosServerStatus, _ = os.ReadFile("testdata/v6.0.3/mongos-serverStatus.json")
 	dataV6DbStats, _            = os.ReadFile("testdata/v6.0.3/dbStats.json")
 	dataV6ReplSetGetStatus, _   = os.ReadFile("testdata/v6.0.3/replSetGetStatus.json")
+	dataV6CollStats, _          = os.ReadFile("testdata/v6.0.3/collStats.json")
+	dataV6IndexStats, _         = os.ReadFile("testdata/v6.0.3/indexStats.json")
+	dataV6CurrentOp, _          = os.ReadFile("testdata/v6.0.3/currentOp.json")
 )
 
 func Test_testDataIsValid(t *testing.T) {
@@ -28,6 +32,9 @@ func Test_testDataIsValid(t *testing.T) {
 		"dataV6MongosServerStatus": dataV6MongosServerStatus,
 		"dataV6DbStats":            dataV6DbStats,
 		"dataV6ReplSetGetStatus":   dataV6ReplSetGetStatus,
+		"dataV6CollStats":          dataV6CollStats,
+		"dataV6IndexStats":         dataV6IndexStats,
+		"dataV6CurrentOp":          dataV6CurrentOp,
 	} {
 		require.NotNilf(t, data, name)
 	}
@@ -48,6 +55,15 @@ func TestMongo_Init(t *testing.T) {
 				URI: "",
 			},
 		},
+		"fails on invalid collection selector": {
+			wantFail: true,
+			config: Config{
+				URI: "mongodb://localhost:27017",
+				Collections: matcher.SimpleExpr{
+					Includes: []string{"!@#"},
+				},
+			},
+		},
 		"fails on invalid database selector": {
 			wantFail: true,
 			config: Config{
@@ -599,6 +615,145 @@ func TestMongo_Collect(t *testing.T) {
 	}
 }
 
+func TestMongo_Collect_CollectionsAndCurrentOp(t *testing.T) {
+	mongo := prepareMongo()
+	defer mongo.Cleanup()
+	mongo.Databases = matcher.SimpleExpr{}
+	mongo.Collections = matcher.SimpleExpr{
+		Includes: []string{"* local.*"},
+		Excludes: []string{"* local.system.*"},
+	}
+	mongo.CollectCurrentOp = true
+	mock := caseMongod()
+	mongo.conn = mock
+
+	require.True(t, mongo.Init())
+
+	mx := mongo.Collect()
+
+	expected := map[string]int64{
+		"collection_" + collectionID("local", "startup_log") + "_documents":             14,
+		"collection_" + collectionID("local", "startup_log") + "_data_size":             1620,
+		"collection_" + collectionID("local", "startup_log") + "_storage_size":          36864,
+		"collection_" + collectionID("local", "startup_log") + "_index_size":            73728,
+		"collection_" + collectionID("local", "startup_log") + "_indexes":               2,
+		"collection_" + collectionID("local", "startup_log") + "_reads_ops":             12,
+		"collection_" + collectionID("local", "startup_log") + "_reads_latency":         2310,
+		"collection_" + collectionID("local", "startup_log") + "_writes_ops":            4,
+		"collection_" + collectionID("local", "startup_log") + "_writes_latency":        5124,
+		"collection_" + collectionID("local", "startup_log") + "_commands_ops":          2,
+		"collection_" + collectionID("local", "startup_log") + "_commands_latency":      780,
+		"collection_" + collectionID("local", "startup_log") + "_index__id__size":       36864,
+		"collection_" + collectionID("local", "startup_log") + "_index_name_1_size":     36864,
+		"collection_" + collectionID("local", "startup_log") + "_index__id__accesses":   25,
+		"collection_" + collectionID("local", "startup_log") + "_index_name_1_accesses": 7,
+		"collection_" + collectionID("local", "oplog.rs") + "_documents":                14,
+		"current_op_long_running_insert":                                                0,
+		"current_op_long_running_query":                                                 2,
+		"current_op_long_running_update":                                                0,
+		"current_op_long_running_remove":                                                0,
+		"current_op_long_running_getmore":                                               0,
+		"current_op_long_running_command":                                               1,
+		"current_op_long_running_other":                                                 1,
+		"current_op_long_running_max_time":                                              12873402,
+	}
+	for k, v := range expected {
+		assert.Equalf(t, v, mx[k], "metric '%s'", k)
+	}
+	assert.NotContains(t, mx, "collection_"+collectionID("local", "system.replset")+"_documents")
+	assert.NotContains(t, mx, "collection_"+collectionID("admin", "system.version")+"_documents")
+
+	for _, id := range []string{"collection_" + collectionID("local", "startup_log") + "_index_size", "collection_" + collectionID("local", "startup_log") + "_index_accesses_rate"} {
+		chart := mongo.Charts().Get(id)
+		require.NotNilf(t, chart, "chart '%s'", id)
+		assert.Lenf(t, chart.Dims, 2, "chart '%s'", id)
+	}
+	require.True(t, mongo.Charts().Has(chartLongRunningOperationsCount.ID))
+	for _, chart := range *mongo.Charts() {
+		for _, dim := range chart.Dims {
+			assert.Containsf(t, mx, dim.ID, "chart '%s' dim '%s'", chart.ID, dim.ID)
+		}
+	}
+
+	mock.collections = map[string][]string{"local": {"system.replset"}}
+	_ = mongo.Collect()
+
+	assert.True(t, mongo.Charts().Get("collection_"+collectionID("local", "startup_log")+"_documents_count").Obsolete)
+	assert.Empty(t, mongo.collections)
+	assert.Empty(t, mongo.collectionIndexes)
+}
+
+func TestMongo_Collect_CollectionsErrors(t *testing.T) {
+	mongo := prepareMongo()
+	defer mongo.Cleanup()
+	mongo.Databases = matcher.SimpleExpr{}
+	mongo.Collections = matcher.SimpleExpr{Includes: []string{"* *"}}
+	mock := caseMongod()
+	mock.collections = map[string][]string{
+		"admin": {"users"},
+		"local": {"startup_log", "oplog.rs"},
+	}
+	mongo.conn = mock
+
+	require.True(t, mongo.Init())
+
+	chartID := func(db, coll string) string {
+		return fmt.Sprintf(chartTmplCollectionDocumentsCount.ID, collectionID(db, coll))
+	}
+
+	_ = mongo.Collect()
+
+	require.Len(t, mongo.collections, 3)
+
+	// step 2: no privileges on 'admin', 'local.startup_log' is dropped after listing
+	mock.errOnListCollectionNamesOf = "admin"
+	mock.errOnCollStatsOf = "local.startup_log"
+
+	mx := mongo.Collect()
+
+	assert.Contains(t, mx, "collection_"+collectionID("local", "oplog.rs")+"_documents")
+	assert.NotContains(t, mx, "collection_"+collectionID("local", "startup_log")+"_documents")
+	assert.NotContains(t, mx, "collection_"+collectionID("admin", "users")+"_documents")
+	assert.False(t, mongo.Charts().Get(chartID("admin", "users")).Obsolete)
+	assert.False(t, mongo.Charts().Get(chartID("local", "startup_log")).Obsolete)
+
+	// step 3: 'local.startup_log' is not listed anymore
+	mock.collections["local"] = []string{"oplog.rs"}
+
+	_ = mongo.Collect()
+
+	assert.True(t, mongo.Charts().Get(chartID("local", "startup_log")).Obsolete)
+	assert.False(t, mongo.Charts().Get(chartID("local", "oplog.rs")).Obsolete)
+	assert.False(t, mongo.Charts().Get(chartID("admin", "users")).Obsolete)
+	assert.Len(t, mongo.collections, 2)
+}
+
+func Test_collectionID(t *testing.T) {
+	ids := map[string]bool{
+		collectionID("a_b", "c"): true,
+		collectionID("a", "b_c"): true,
+		collectionID("a", "b.c"): true,
+	}
+	assert.Len(t, ids, 3)
+}
+
+func TestMongo_Collect_CurrentOpErrorDoesNotAffectReplSet(t *testing.T) {
+	mongo := prepareMongo()
+	defer mongo.Cleanup()
+	mongo.CollectCurrentOp = true
+	mock := caseMongodReplicaSet()
+	mock.errOnCurrentOp = true
+	mongo.conn = mock
+
+	require.True(t, mongo.Init())
+
+	mx := mongo.Collect()
+
+	require.NotNil(t, mx)
+	assert.Contains(t, mx, "repl_set_member_mongodb-primary:27017_health_status_up")
+	assert.NotContains(t, mx, "current_op_long_running_query")
+}
+
 func prepareMongo() *Mongo {
 	m := New()
 	m.Databases = matcher.SimpleExpr{Includes: []string{"* *"}}
@@ -628,9 +783,13 @@ type mockMongoClient struct {
 	errOnShardDatabasesPartitioning   bool
 	errOnShardCollectionsPartitioning bool
 	errOnShardChunks                  bool
+	errOnCurrentOp                    bool
 	errOnInitClient                   bool
 	clientInited                      bool
 	closeCalled                       bool
+	collections                       map[string][]string
+	errOnListCollectionNamesOf        string
+	errOnCollStatsOf                  string
 }
 
 func (m *mockMongoClient) serverStatus() (*documentServerStatus, error) {
@@ -790,6 +949,70 @@ func (m *mockMongoClient) shardChunks() (map[string]int64, error) {
 	}, nil
 }
 
+func (m *mockMongoClient) listCollectionNames(db string) ([]string, error) {
+	if !m.clientInited {
+		return nil, errors.New("mock.listCollectionNames() error: mongo client not inited")
+	}
+	if m.errOnListCollectionNamesOf == db {
+		return nil, errors.New("mock.listCollectionNames() error")
+	}
+	if m.collections != nil {
+		return m.collections[db], nil
+	}
+	switch db {
+	case "admin":
+		return []string{"system.version"}, nil
+	case "local":
+		return []string{"startup_log", "oplog.rs", "system.replset"}, nil
+	}
+	return nil, nil
+}
+
+func (m *mockMongoClient) collStats(db, coll string) (*documentCollStats, error) {
+	if !m.clientInited {
+		return nil, errors.New("mock.collStats() error: mongo client not inited")
+	}
+	if m.errOnCollStatsOf == db+"."+coll {
+		return nil, errors.New("mock.collStats() error")
+	}
+
+	var s documentCollStats
+	if err := json.Unmarshal(dataV6CollStats, &s); err != nil {
+		return nil, err
+	}
+
+	return sumCollStats([]documentCollStats{s}), nil
+}
+
+func (m *mockMongoClient) indexStats(_, _ string) ([]documentIndexStats, error) {
+	if !m.clientInited {
+		return nil, errors.New("mock.indexStats() error: mongo client not inited")
+	}
+
+	var s []documentIndexStats
+	if err := json.Unmarshal(dataV6IndexStats, &s); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (m *mockMongoClient) currentOp(_ int64) ([]documentCurrentOp, error) {
+	if !m.clientInited {
+		return nil, errors.New("mock.currentOp() error: mongo client not inited")
+	}
+	if m.errOnCurrentOp {
+		return nil, errors.New("mock.currentOp() error")
+	}
+
+	var ops []documentCurrentOp
+	if err := json.Unmarshal(dataV6CurrentOp, &ops); err != nil {
+		return nil, err
+	}
+
+	return ops, nil
+}
+
 func (m *mockMongoClient) initClient(_ string, _ time.Duration) error {
 	if m.errOnInitClient {
 		return errors.New("mock.initClient() error")
@@ -72,6 +72,13 @@ const (
 	prioGaleraThreadCount
 	prioSlaveSecondsBehindMaster
 	prioSlaveSQLIOThreadRunningState
+	prioGroupReplicationMembers
+	prioGroupReplicationApplierLag
+	prioGroupReplicationMemberState
+	prioGroupReplicationMemberRole
+	prioGroupReplicationMemberTransactionsInQueue
+	prioGroupReplicationMemberConflicts
+	prioGroupReplicationMemberTransactions
 	prioUserStatsCPUTime
 	prioUserStatsRows
 	prioUserStatsCommands
@@ -82,6 +89,12 @@ const (
 	prioUserStatsConnections
 	prioUserStatsLostConnections
 	prioUserStatsDeniedConnections
+	prioStmtDigestCalls
+	prioStmtDigestLatency
+	prioStmtDigestRows
+	prioStmtDigestErrors
+	prioTableIOOperations
+	prioTableIOWaitTime
 )
 
 var baseCharts = module.Charts{
@@ -967,6 +980,116 @@ var (
 	}
 )
 
+var (
+	chartsGroupReplication = module.Charts{
+		chartGroupReplicationMembers.Copy(),
+		chartGroupReplicationApplierLag.Copy(),
+	}
+
+	chartGroupReplicationMembers = module.Chart{
+		ID:       "group_replication_members",
+		Title:    "Group Replication Members",
+		Units:    "members",
+		Fam:      "group replication",
+		Ctx:      "mysql.group_replication_members",
+		Type:     module.Stacked,
+		Priority: prioGroupReplicationMembers,
+		Dims: module.Dims{
+			{ID: "group_replication_members_online", Name: "online"},
+			{ID: "group_replication_members_recovering", Name: "recovering"},
+			{ID: "group_replication_members_offline", Name: "offline"},
+			{ID: "group_replication_members_error", Name: "error"},
+			{ID: "group_replication_members_unreachable", Name: "unreachable"},
+		},
+	}
+	chartGroupReplicationApplierLag = module.Chart{
+		ID:       "group_replication_applier_lag",
+		Title:    "Group Replication Applier Lag",
+		Units:    "milliseconds",
+		Fam:      "group replication",
+		Ctx:      "mysql.group_replication_applier_lag",
+		Priority: prioGroupReplicationApplierLag,
+		Dims: module.Dims{
+			{ID: "group_replication_applier_lag", Name: "lag", Div: 1000},
+		},
+	}
+)
+
+var (
+	chartsTmplGroupReplicationMember = module.Charts{
+		chartTmplGroupReplicationMemberState.Copy(),
+		chartTmplGroupReplicationMemberRole.Copy(),
+		chartTmplGroupReplicationMemberTransactionsInQueue.Copy(),
+		chartTmplGroupReplicationMemberConflicts.Copy(),
+		chartTmplGroupReplicationMemberTransactions.Copy(),
+	}
+
+	chartTmplGroupReplicationMemberState = module.Chart{
+		ID:       "group_replication_member_state_%s",
+		Title:    "Group Replication Member State",
+		Units:    "state",
+		Fam:      "group replication members",
+		Ctx:      "mysql.group_replication_member_state",
+		Priority: prioGroupReplicationMemberState,
+		Dims: module.Dims{
+			{ID: "group_replication_member_%s_state_online", Name: "online"},
+			{ID: "group_replication_member_%s_state_recovering", Name: "recovering"},
+			{ID: "group_replication_member_%s_state_offline", Name: "offline"},
+			{ID: "group_replication_member_%s_state_error", Name: "error"},
+			{ID: "group_replication_member_%s_state_unreachable", Name: "unreachable"},
+		},
+	}
+	chartTmplGroupReplicationMemberRole = module.Chart{
+		ID:       "group_replication_member_role_%s",
+		Title:    "Group Replication Member Role",
+		Units:    "role",
+		Fam:      "group replication members",
+		Ctx:      "mysql.group_replication_member_role",
+		Priority: prioGroupReplicationMemberRole,
+		Dims: module.Dims{
+			{ID: "group_replication_member_%s_role_primary", Name: "primary"},
+			{ID: "group_replication_member_%s_role_secondary", Name: "secondary"},
+		},
+	}
+	chartTmplGroupReplicationMemberTransactionsInQueue = module.Chart{
+		ID:       "group_replication_member_transactions_in_queue_%s",
+		Title:    "Group Replication Member Transactions In Queue",
+		Units:    "transactions",
+		Fam:      "group replication members",
+		Ctx:      "mysql.group_replication_member_transactions_in_queue",
+		Priority: prioGroupReplicationMemberTransactionsInQueue,
+		Dims: module.Dims{
+			{ID: "group_replication_member_%s_certification_queue", Name: "certification"},
+			{ID: "group_replication_member_%s_applier_queue", Name: "applier"},
+		},
+	}
+	chartTmplGroupReplicationMemberConflicts = module.Chart{
+		ID:       "group_replication_member_conflicts_%s",
+		Title:    "Group Replication Member Certification Conflicts",
+		Units:    "conflicts/s",
+		Fam:      "group replication members",
+		Ctx:      "mysql.group_replication_member_conflicts",
+		Priority: prioGroupReplicationMemberConflicts,
+		Dims: module.Dims{
+			{ID: "group_replication_member_%s_conflicts_detected", Name: "detected", Algo: module.Incremental},
+		},
+	}
+	chartTmplGroupReplicationMemberTransactions = module.Chart{
+		ID:       "group_replication_member_transactions_%s",
+		Title:    "Group Replication Member Transactions",
+		Units:    "transactions/s",
+		Fam:      "group replication members",
+		Ctx:      "mysql.group_replication_member_transactions",
+		Priority: prioGroupReplicationMemberTransactions,
+		Dims: module.Dims{
+			{ID: "group_replication_member_%s_transactions_checked", Name: "checked", Algo: module.Incremental},
+			{ID: "group_replication_member_%s_transactions_remote_applied", Name: "remote_applied", Algo: module.Incremental},
+			{ID: "group_replication_member_%s_transactions_local_proposed", Name: "local_proposed", Algo: module.Incremental},
+			{ID: "group_replication_member_%s_transactions_local_rollback", Name: "local_rollback", Algo: module.Incremental},
+		},
+	}
+)
+
 func newSlaveReplConnCharts(conn string) *module.Charts {
 	orig := conn
 	conn = strings.ToLower(conn)
@@ -1172,6 +1295,98 @@ var (
 	}
 )
 
+var (
+	chartsTmplStmtDigest = module.Charts{
+		chartTmplStmtDigestCalls.Copy(),
+		chartTmplStmtDigestLatency.Copy(),
+		chartTmplStmtDigestRows.Copy(),
+		chartTmplStmtDigestErrors.Copy(),
+	}
+
+	chartTmplStmtDigestCalls = module.Chart{
+		ID:       "stmt_digest_calls_%s",
+		Title:    "Statement Digest Calls",
+		Units:    "calls/s",
+		Fam:      "statement digests",
+		Ctx:      "mysql.stmt_digest_calls",
+		Priority: prioStmtDigestCalls,
+		Dims: module.Dims{
+			{ID: "stmt_digest_%s_calls", Name: "calls", Algo: module.Incremental},
+		},
+	}
+	chartTmplStmtDigestLatency = module.Chart{
+		ID:       "stmt_digest_latency_%s",
+		Title:    "Statement Digest Average Latency",
+		Units:    "milliseconds",
+		Fam:      "statement digests",
+		Ctx:      "mysql.stmt_digest_latency",
+		Priority: prioStmtDigestLatency,
+		Dims: module.Dims{
+			{ID: "stmt_digest_%s_avg_latency", Name: "avg", Div: 1000},
+		},
+	}
+	chartTmplStmtDigestRows = module.Chart{
+		ID:       "stmt_digest_rows_%s",
+		Title:    "Statement Digest Rows",
+		Units:    "rows/s",
+		Fam:      "statement digests",
+		Ctx:      "mysql.stmt_digest_rows",
+		Priority: prioStmtDigestRows,
+		Dims: module.Dims{
+			{ID: "stmt_digest_%s_rows_examined", Name: "examined", Algo: module.Incremental},
+			{ID: "stmt_digest_%s_rows_sent", Name: "sent", Algo: module.Incremental},
+			{ID: "stmt_digest_%s_rows_affected", Name: "affected", Algo: module.Incremental},
+		},
+	}
+	chartTmplStmtDigestErrors = module.Chart{
+		ID:       "stmt_digest_errors_%s",
+		Title:    "Statement Digest Errors",
+		Units:    "statements/s",
+		Fam:      "statement digests",
+		Ctx:      "mysql.stmt_digest_errors",
+		Priority: prioStmtDigestErrors,
+		Dims: module.Dims{
+			{ID: "stmt_digest_%s_errors", Name: "errors", Algo: module.Incremental},
+			{ID: "stmt_digest_%s_warnings", Name: "warnings", Algo: module.Incremental},
+		},
+	}
+)
+
+var (
+	chartsTmplTableIO = module.Charts{
+		chartTmplTableIOOperations.Copy(),
+		chartTmplTableIOWaitTime.Copy(),
+	}
+
+	chartTmplTableIOOperations = module.Chart{
+		ID:       "table_io_operations_%s",
+		Title:    "Table I/O Operations",
+		Units:    "operations/s",
+		Fam:      "table io",
+		Ctx:      "mysql.table_io_operations",
+		Type:     module.Stacked,
+		Priority: prioTableIOOperations,
+		Dims: module.Dims{
+			{ID: "table_io_%s_fetch", Name: "fetch", Algo: module.Incremental},
+			{ID: "table_io_%s_insert", Name: "insert", Algo: module.Incremental},
+			{ID: "table_io_%s_update", Name: "update", Algo: module.Incremental},
+			{ID: "table_io_%s_delete", Name: "delete", Algo: module.Incremental},
+		},
+	}
+	chartTmplTableIOWaitTime = module.Chart{
+		ID:       "table_io_wait_time_%s",
+		Title:    "Table I/O Wait Time",
+		Units:    "milliseconds/s",
+		Fam:      "table io",
+		Ctx:      "mysql.table_io_wait_time",
+		Priority: prioTableIOWaitTime,
+		Dims: module.Dims{
+			{ID: "table_io_%s_read_wait_time", Name: "read", Div: 1000, Algo: module.Incremental},
+			{ID: "table_io_%s_write_wait_time", Name: "write", Div: 1000, Algo: module.Incremental},
+		},
+	}
+)
+
 func (m *MySQL) addSlaveReplicationConnCharts(conn string) {
 	var charts *module.Charts
 	if conn == "" {
@@ -1232,8 +1447,92 @@ func (m *MySQL) addGaleraCharts() {
 	}
 }
 
+func (m *MySQL) addGroupReplicationCharts() {
+	if err := m.Charts().Add(*chartsGroupReplication.Copy()...); err != nil {
+		m.Warning(err)
+	}
+}
+
+func (m *MySQL) addGroupReplicationMemberCharts(id, host, port string) {
+	charts := chartsTmplGroupReplicationMember.Copy()
+	for _, c := range *charts {
+		c.ID = fmt.Sprintf(c.ID, id)
+		c.Labels = []module.Label{
+			{Key: "member_id", Value: id},
+			{Key: "member_host", Value: host},
+			{Key: "member_port", Value: port},
+		}
+		for _, dim := range c.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, id)
+		}
+	}
+	if err := m.Charts().Add(*charts...); err != nil {
+		m.Warning(err)
+	}
+}
+
+func (m *MySQL) removeGroupReplicationMemberCharts(id string) {
+	m.removeChartsFromTmpl(chartsTmplGroupReplicationMember, id)
+}
+
 func (m *MySQL) addTableOpenCacheOverflowChart() {
 	if err := m.Charts().Add(chartTableOpenCacheOverflows.Copy()); err != nil {
 		m.Warning(err)
 	}
 }
+
+func (m *MySQL) addStmtDigestCharts(id string, d *stmtDigest) {
+	text := d.text
+	if len(text) > maxDigestTextLabelLen {
+		text = text[:maxDigestTextLabelLen] + "..."
+	}
+	charts := chartsTmplStmtDigest.Copy()
+	for _, c := range *charts {
+		c.ID = fmt.Sprintf(c.ID, id)
+		c.Labels = []module.Label{
+			{Key: "schema", Value: d.schema},
+			{Key: "digest", Value: d.digest},
+			{Key: "query", Value: text},
+		}
+		for _, dim := range c.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, id)
+		}
+	}
+	if err := m.Charts().Add(*charts...); err != nil {
+		m.Warning(err)
+	}
+}
+
+func (m *MySQL) removeStmtDigestCharts(id string) {
+	m.removeChartsFromTmpl(chartsTmplStmtDigest, id)
+}
+
+func (m *MySQL) addTableIOCharts(id, schema, table string) {
+	charts := chartsTmplTableIO.Copy()
+	for _, c := range *charts {
+		c.ID = fmt.Sprintf(c.ID, id)
+		c.Labels = []module.Label{
+			{Key: "schema", Value: schema},
+			{Key: "table", Value: table},
+		}
+		for _, dim := range c.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, id)
+		}
+	}
+	if err := m.Charts().Add(*charts...); err != nil {
+		m.Warning(err)
+	}
+}
+
+func (m *MySQL) removeTableIOCharts(id string) {
+	m.removeChartsFromTmpl(chartsTmplTableIO, id)
+}
+
+func (m *MySQL) removeChartsFromTmpl(tmpl module.Charts, id string) {
+	for _, t := range tmpl {
+		if c := m.Charts().Get(fmt.Sprintf(t.ID, id)); c != nil {
+			c.MarkRemove()
+			c.MarkNotCreated()
+		}
+	}
+}
@@ -5,12 +5,14 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/blang/semver/v4"
+	"github.com/go-sql-driver/mysql"
 )
 
 func (m *MySQL) collect() (map[string]int64, error) {
@@ -25,6 +27,8 @@ func (m *MySQL) collect() (map[string]int64, error) {
 		}
 		// https://mariadb.com/kb/en/user-statistics/
 		m.doUserStatistics = m.isPercona || m.isMariaDB && m.version.GTE(semver.Version{Major: 10, Minor: 1, Patch: 1})
+		// https://dev.mysql.com/doc/refman/8.0/en/performance-schema-replication-group-members-table.html (MEMBER_ROLE)
+		m.doGroupReplication = !m.isMariaDB && m.version.GTE(semver.Version{Major: 8, Minor: 0, Patch: 2})
 	}
 
 	mx := make(map[string]int64)
@@ -74,6 +78,17 @@ func (m *MySQL) collect() (map[string]int64, error) {
 		}
 	}
 
+	if m.doGroupReplication && m.varGroupReplicationGroupName != "" {
+		if err := m.collectGroupReplication(mx); err != nil {
+			m.Errorf("error on collecting group replication statistics: %v", err)
+			if isAccessDeniedOrNoTableError(err) {
+				m.Warning("group replication statistics collection is disabled")
+				m.doGroupReplication = false
+				m.removeAllGroupReplicationCharts()
+			}
+		}
+	}
+
 	if m.doUserStatistics {
 		if err := m.collectUserStatistics(mx); err != nil {
 			m.Errorf("error on collecting user statistics: %v", err)
@@ -86,6 +101,28 @@ func (m *MySQL) collect() (map[string]int64, error) {
 		m.Errorf("error on collecting process list statistics: %v", err)
 	}
 
+	if m.doStatementDigests {
+		if err := m.collectStatementDigests(mx); err != nil {
+			m.Errorf("error on collecting statement digests: %v", err)
+			if isAccessDeniedOrNoTableError(err) {
+				m.Warning("statement digests collection is disabled")
+				m.doStatementDigests = false
+				m.removeAllStmtDigestCharts()
+			}
+		}
+	}
+
+	if m.doTableIO {
+		if err := m.collectTableIO(mx); err != nil {
+			m.Errorf("error on collecting table I/O statistics: %v", err)
+			if isAccessDeniedOrNoTableError(err) {
+				m.Warning("table I/O statistics collection is disabled")
+				m.doTableIO = false
+				m.removeAllTableIOCharts()
+			}
+		}
+	}
+
 	calcThreadCacheMisses(mx)
 	return mx, nil
 }
@@ -110,6 +147,24 @@ func (m *MySQL) openConnection() error {
 	return nil
 }
 
+// isAccessDeniedOrNoTableError returns true if the query can't succeed until the server configuration changes:
+// the user lacks privileges or the performance_schema table doesn't exist.
+func isAccessDeniedOrNoTableError(err error) bool {
+	var myErr *mysql.MySQLError
+	if !errors.As(err, &myErr) {
+		return false
+	}
+	switch myErr.Number {
+	case 1044, // ER_DBACCESS_DENIED_ERROR
+		1142, // ER_TABLEACCESS_DENIED_ERROR
+		1143, // ER_COLUMNACCESS_DENIED_ERROR
+		1227, // ER_SPECIFIC_ACCESS_DENIED_ERROR
+		1146: // ER_NO_SUCH_TABLE
+		return true
+	}
+	return false
+}
+
 func calcThreadCacheMisses(collected map[string]int64) {
 	threads, cons := collected["threads_created"], collected["connections"]
 	if threads == 0 || cons == 0 {
@@ -195,6 +250,10 @@ func parseInt(s string) int64 {
 	return v
 }
 
+func (m *MySQL) matchSchema(schema string) bool {
+	return m.schemaMatcher == nil || m.schemaMatcher.MatchString(schema)
+}
+
 func parseFloat(s string) float64 {
 	v, _ := strconv.ParseFloat(s, 64)
 	return v
@@ -9,7 +9,8 @@ WHERE
   Variable_name LIKE 'max_connections' 
   OR Variable_name LIKE 'table_open_cache' 
   OR Variable_name LIKE 'disabled_storage_engines' 
-  OR Variable_name LIKE 'log_bin';`
+  OR Variable_name LIKE 'log_bin'
+  OR Variable_name LIKE 'group_replication_group_name';`
 )
 
 func (m *MySQL) collectGlobalVariables() error {
@@ -29,6 +30,8 @@ func (m *MySQL) collectGlobalVariables() error {
 				m.varDisabledStorageEngine = value
 			case "log_bin":
 				m.varLogBin = value
+			case "group_replication_group_name":
+				m.varGroupReplicationGroupName = value
 			case "max_connections":
 				m.varMaxConns = parseInt(value)
 			case "table_open_cache":
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mysql
+
+import (
+	"strings"
+)
+
+// Table Schema:
+// https://dev.mysql.com/doc/refman/8.0/en/performance-schema-replication-group-members-table.html
+// https://dev.mysql.com/doc/refman/8.0/en/performance-schema-replication-group-member-stats-table.html
+const queryGroupReplicationMembers = `
+SELECT
+  m.MEMBER_ID,
+  m.MEMBER_HOST,
+  m.MEMBER_PORT,
+  m.MEMBER_STATE,
+  m.MEMBER_ROLE,
+  s.COUNT_TRANSACTIONS_IN_QUEUE,
+  s.COUNT_TRANSACTIONS_REMOTE_IN_APPLIER_QUEUE,
+  s.COUNT_TRANSACTIONS_CHECKED,
+  s.COUNT_CONFLICTS_DETECTED,
+  s.COUNT_TRANSACTIONS_REMOTE_APPLIED,
+  s.COUNT_TRANSACTIONS_LOCAL_PROPOSED,
+  s.COUNT_TRANSACTIONS_LOCAL_ROLLBACK
+FROM
+  performance_schema.replication_group_members m
+  LEFT JOIN performance_schema.replication_group_member_stats s ON m.MEMBER_ID = s.MEMBER_ID;`
+
+// Applier lag of the local member: how long ago the transaction being applied was committed on its originating member.
+// https://dev.mysql.com/doc/refman/8.0/en/performance-schema-replication-applier-status-by-worker-table.html
+const queryGroupReplicationApplierLag = `
+SELECT
+  COALESCE(MAX(
+    CASE WHEN APPLYING_TRANSACTION <> ''
+    THEN TIMESTAMPDIFF(MICROSECOND, APPLYING_TRANSACTION_ORIGINAL_COMMIT_TIMESTAMP, NOW(6))
+    ELSE 0 END
+  ), 0) AS APPLIER_LAG
+FROM
+  performance_schema.replication_applier_status_by_worker
+WHERE
+  CHANNEL_NAME = 'group_replication_applier';`
+
+var (
+	groupReplicationMemberStates = []string{"online", "recovering", "offline", "error", "unreachable"}
+	groupReplicationMemberRoles  = []string{"primary", "secondary"}
+)
+
+func (m *MySQL) collectGroupReplication(mx map[string]int64) error {
+	q := queryGroupReplicationMembers
+	m.Debugf("executing query: '%s'", q)
+
+	for _, st := range groupReplicationMemberStates {
+		mx["group_replication_members_"+st] = 0
+	}
+
+	seen := make(map[string]bool)
+	var id, host, port, px string
+
+	_, err := m.collectQuery(q, func(column, value string, lineEnd bool) {
+		if column == "MEMBER_ID" {
+			id = strings.ToLower(value)
+			px = "group_replication_member_" + id + "_"
+		}
+		// a single OFFLINE row without the member ID is returned when the plugin is loaded but not started
+		if id == "" {
+			return
+		}
+
+		switch column {
+		case "MEMBER_HOST":
+			host = value
+		case "MEMBER_PORT":
+			port = value
+		case "MEMBER_STATE":
+			value = strings.ToLower(value)
+			mx["group_replication_members_"+value]++
+			for _, st := range groupReplicationMemberStates {
+				mx[px+"state_"+st] = boolToInt(value == st)
+			}
+		case "MEMBER_ROLE":
+			value = strings.ToLower(value)
+			for _, r := range groupReplicationMemberRoles {
+				mx[px+"role_"+r] = boolToInt(value == r)
+			}
+		case "COUNT_TRANSACTIONS_IN_QUEUE":
+			mx[px+"certification_queue"] = parseInt(value)
+		case "COUNT_TRANSACTIONS_REMOTE_IN_APPLIER_QUEUE":
+			mx[px+"applier_queue"] = parseInt(value)
+		case "COUNT_TRANSACTIONS_CHECKED":
+			mx[px+"transactions_checked"] = parseInt(value)
+		case "COUNT_CONFLICTS_DETECTED":
+			mx[px+"conflicts_detected"] = parseInt(value)
+		case "COUNT_TRANSACTIONS_REMOTE_APPLIED":
+			mx[px+"transactions_remote_applied"] = parseInt(value)
+		case "COUNT_TRANSACTIONS_LOCAL_PROPOSED":
+			mx[px+"transactions_local_proposed"] = parseInt(value)
+		case "COUNT_TRANSACTIONS_LOCAL_ROLLBACK":
+			mx[px+"transactions_local_rollback"] = parseInt(value)
+		}
+		if lineEnd {
+			seen[id] = true
+			if !m.groupReplicationMembers[id] {
+				m.groupReplicationMembers[id] = true
+				m.addGroupReplicationMemberCharts(id, host, port)
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	for id := range m.groupReplicationMembers {
+		if !seen[id] {
+			delete(m.groupReplicationMembers, id)
+			m.removeGroupReplicationMemberCharts(id)
+		}
+	}
+
+	if len(seen) == 0 {
+		return nil
+	}
+
+	m.addGroupReplicationOnce.Do(m.addGroupReplicationCharts)
+
+	q = queryGroupReplicationApplierLag
+	m.Debugf("executing query: '%s'", q)
+
+	_, err = m.collectQuery(q, func(column, value string, _ bool) {
+		if column == "APPLIER_LAG" {
+			mx["group_replication_applier_lag"] = parseInt(value)
+		}
+	})
+	return err
+}
+
+func (m *MySQL) removeAllGroupReplicationCharts() {
+	for id := range m.groupReplicationMembers {
+		delete(m.groupReplicationMembers, id)
+		m.removeGroupReplicationMemberCharts(id)
+	}
+	for _, c := range chartsGroupReplication {
+		if chart := m.Charts().Get(c.ID); chart != nil {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
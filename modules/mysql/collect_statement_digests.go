@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mysql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Table Schema:
+// (MariaDB) https://mariadb.com/kb/en/performance-schema-events_statements_summary_by_digest-table/
+// (MySql) https://dev.mysql.com/doc/refman/8.0/en/statement-summary-tables.html
+const queryStatementDigests = `
+SELECT
+  SCHEMA_NAME,
+  DIGEST,
+  DIGEST_TEXT,
+  COUNT_STAR,
+  SUM_TIMER_WAIT,
+  SUM_ERRORS,
+  SUM_WARNINGS,
+  SUM_ROWS_EXAMINED,
+  SUM_ROWS_SENT,
+  SUM_ROWS_AFFECTED
+FROM
+  performance_schema.events_statements_summary_by_digest
+WHERE
+  SCHEMA_NAME IS NOT NULL
+  AND DIGEST IS NOT NULL
+ORDER BY
+  SUM_TIMER_WAIT DESC`
+
+const maxDigestTextLabelLen = 256
+
+type stmtDigest struct {
+	schema    string
+	digest    string
+	text      string
+	calls     int64
+	timerWait int64 // microseconds
+	updated   bool
+}
+
+func (m *MySQL) queryStatementDigests() string {
+	// schemas are filtered on our side, so we can't limit the result set if a filter is set
+	if m.schemaMatcher != nil {
+		return queryStatementDigests + ";"
+	}
+	return fmt.Sprintf("%s\nLIMIT %d;", queryStatementDigests, m.MaxStatementDigests)
+}
+
+func (m *MySQL) collectStatementDigests(mx map[string]int64) error {
+	q := m.queryStatementDigests()
+	m.Debugf("executing query: '%s'", q)
+
+	for _, d := range m.stmtDigests {
+		d.updated = false
+	}
+
+	var n int
+	var skip bool
+	var d stmtDigest
+	var errors, warnings, rowsExamined, rowsSent, rowsAffected int64
+
+	_, err := m.collectQuery(q, func(column, value string, lineEnd bool) {
+		switch column {
+		case "SCHEMA_NAME":
+			d = stmtDigest{schema: value}
+			skip = n >= m.MaxStatementDigests || !m.matchSchema(value)
+		case "DIGEST":
+			d.digest = value
+		case "DIGEST_TEXT":
+			d.text = value
+		case "COUNT_STAR":
+			d.calls = parseInt(value)
+		case "SUM_TIMER_WAIT":
+			d.timerWait = parseInt(value) / 1e6 // picoseconds
+		case "SUM_ERRORS":
+			errors = parseInt(value)
+		case "SUM_WARNINGS":
+			warnings = parseInt(value)
+		case "SUM_ROWS_EXAMINED":
+			rowsExamined = parseInt(value)
+		case "SUM_ROWS_SENT":
+			rowsSent = parseInt(value)
+		case "SUM_ROWS_AFFECTED":
+			rowsAffected = parseInt(value)
+		}
+		if !lineEnd || skip {
+			return
+		}
+		n++
+
+		id := stmtDigestID(d.schema, d.digest)
+		prev, ok := m.stmtDigests[id]
+		if !ok {
+			prev = &stmtDigest{schema: d.schema, digest: d.digest, text: d.text}
+			m.stmtDigests[id] = prev
+			m.addStmtDigestCharts(id, prev)
+		}
+
+		// average latency of the calls made since the previous collection
+		var avgLatency int64
+		if ok && d.calls > prev.calls && d.timerWait >= prev.timerWait {
+			avgLatency = (d.timerWait - prev.timerWait) / (d.calls - prev.calls)
+		}
+		prev.calls, prev.timerWait, prev.updated = d.calls, d.timerWait, true
+
+		px := "stmt_digest_" + id + "_"
+		mx[px+"calls"] = d.calls
+		mx[px+"avg_latency"] = avgLatency
+		mx[px+"errors"] = errors
+		mx[px+"warnings"] = warnings
+		mx[px+"rows_examined"] = rowsExamined
+		mx[px+"rows_sent"] = rowsSent
+		mx[px+"rows_affected"] = rowsAffected
+	})
+	if err != nil {
+		return err
+	}
+
+	for id, d := range m.stmtDigests {
+		if !d.updated {
+			delete(m.stmtDigests, id)
+			m.removeStmtDigestCharts(id)
+		}
+	}
+	return nil
+}
+
+func (m *MySQL) removeAllStmtDigestCharts() {
+	for id := range m.stmtDigests {
+		delete(m.stmtDigests, id)
+		m.removeStmtDigestCharts(id)
+	}
+}
+
+func stmtDigestID(schema, digest string) string {
+	return strings.ToLower(schema + "_" + digest)
+}
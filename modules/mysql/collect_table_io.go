@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mysql
+
+import (
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+// Table Schema:
+// (MariaDB) https://mariadb.com/kb/en/performance-schema-table_io_waits_summary_by_table-table/
+// (MySql) https://dev.mysql.com/doc/refman/8.0/en/performance-schema-table-wait-summary-tables.html
+const queryTableIOWaits = `
+SELECT
+  OBJECT_SCHEMA,
+  OBJECT_NAME,
+  COUNT_FETCH,
+  COUNT_INSERT,
+  COUNT_UPDATE,
+  COUNT_DELETE,
+  SUM_TIMER_READ,
+  SUM_TIMER_WRITE
+FROM
+  performance_schema.table_io_waits_summary_by_table
+WHERE
+  OBJECT_SCHEMA NOT IN ('mysql', 'performance_schema', 'information_schema', 'sys')
+  AND COUNT_STAR > 0;`
+
+func (m *MySQL) collectTableIO(mx map[string]int64) error {
+	q := queryTableIOWaits
+	m.Debugf("executing query: '%s'", q)
+
+	seen := make(map[string]bool)
+	var schema, table, px string
+	var skip bool
+
+	_, err := m.collectQuery(q, func(column, value string, _ bool) {
+		switch column {
+		case "OBJECT_SCHEMA":
+			schema = value
+			skip = !m.matchSchema(schema)
+		case "OBJECT_NAME":
+			table = value
+			if skip {
+				return
+			}
+			id := tableIOID(schema, table)
+			px = "table_io_" + id + "_"
+			seen[id] = true
+			if !m.tablesIO[id] {
+				m.tablesIO[id] = true
+				m.addTableIOCharts(id, schema, table)
+			}
+		case "COUNT_FETCH", "COUNT_INSERT", "COUNT_UPDATE", "COUNT_DELETE":
+			if !skip {
+				mx[px+strings.ToLower(strings.TrimPrefix(column, "COUNT_"))] = parseInt(value)
+			}
+		case "SUM_TIMER_READ", "SUM_TIMER_WRITE":
+			if !skip {
+				// picoseconds to microseconds
+				mx[px+strings.ToLower(strings.TrimPrefix(column, "SUM_TIMER_"))+"_wait_time"] = parseInt(value) / 1e6
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	for id := range m.tablesIO {
+		if !seen[id] {
+			delete(m.tablesIO, id)
+			m.removeTableIOCharts(id)
+		}
+	}
+	return nil
+}
+
+func (m *MySQL) removeAllTableIOCharts() {
+	for id := range m.tablesIO {
+		delete(m.tablesIO, id)
+		m.removeTableIOCharts(id)
+	}
+}
+
+// tableIOID returns the table charts ID. The schema and table names can't be used in IDs as is:
+// they may contain forbidden characters, joining them is ambiguous, and they are case-sensitive on most platforms.
+func tableIOID(schema, table string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(schema))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(table))
+	return strconv.FormatUint(h.Sum64(), 16)
+}
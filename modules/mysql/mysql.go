@@ -13,6 +13,7 @@ import (
 	_ "github.com/go-sql-driver/mysql"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/matcher"
 	"github.com/netdata/go.d.plugin/pkg/web"
 )
 
@@ -25,8 +26,9 @@ func init() {
 func New() *MySQL {
 	return &MySQL{
 		Config: Config{
-			DSN:     "root@tcp(localhost:3306)/",
-			Timeout: web.Duration{Duration: time.Second},
+			DSN:                 "root@tcp(localhost:3306)/",
+			Timeout:             web.Duration{Duration: time.Second},
+			MaxStatementDigests: 10,
 		},
 
 		charts:                         baseCharts.Copy(),
@@ -37,10 +39,14 @@ func New() *MySQL {
 		addGaleraOnce:                  &sync.Once{},
 		addQCacheOnce:                  &sync.Once{},
 		addTableOpenCacheOverflowsOnce: &sync.Once{},
+		addGroupReplicationOnce:        &sync.Once{},
 		doSlaveStatus:                  true,
 		doUserStatistics:               true,
 		collectedReplConns:             make(map[string]bool),
 		collectedUsers:                 make(map[string]bool),
+		stmtDigests:                    make(map[string]*stmtDigest),
+		tablesIO:                       make(map[string]bool),
+		groupReplicationMembers:        make(map[string]bool),
 
 		recheckGlobalVarsEvery: time.Minute * 10,
 	}
@@ -51,6 +57,11 @@ type Config struct {
 	MyCNF       string       `yaml:"my.cnf"`
 	UpdateEvery int          `yaml:"update_every"`
 	Timeout     web.Duration `yaml:"timeout"`
+
+	CollectStatementDigests bool               `yaml:"collect_statement_digests"`
+	MaxStatementDigests     int                `yaml:"max_statement_digests"`
+	CollectTableIO          bool               `yaml:"collect_table_io"`
+	Schemas                 matcher.SimpleExpr `yaml:"schemas"`
 }
 
 type MySQL struct {
@@ -72,18 +83,29 @@ type MySQL struct {
 	addGaleraOnce                  *sync.Once
 	addQCacheOnce                  *sync.Once
 	addTableOpenCacheOverflowsOnce *sync.Once
+	addGroupReplicationOnce        *sync.Once
 
 	doSlaveStatus      bool
 	collectedReplConns map[string]bool
 	doUserStatistics   bool
 	collectedUsers     map[string]bool
 
-	recheckGlobalVarsTime    time.Time
-	recheckGlobalVarsEvery   time.Duration
-	varMaxConns              int64
-	varTableOpenCache        int64
-	varDisabledStorageEngine string
-	varLogBin                string
+	doGroupReplication      bool
+	groupReplicationMembers map[string]bool
+
+	schemaMatcher      matcher.Matcher
+	doStatementDigests bool
+	stmtDigests        map[string]*stmtDigest
+	doTableIO          bool
+	tablesIO           map[string]bool
+
+	recheckGlobalVarsTime        time.Time
+	recheckGlobalVarsEvery       time.Duration
+	varMaxConns                  int64
+	varTableOpenCache            int64
+	varDisabledStorageEngine     string
+	varLogBin                    string
+	varGroupReplicationGroupName string
 }
 
 func (m *MySQL) Init() bool {
@@ -107,6 +129,21 @@ func (m *MySQL) Init() bool {
 		return false
 	}
 
+	if !m.Schemas.Empty() {
+		mr, err := m.Schemas.Parse()
+		if err != nil {
+			m.Errorf("error on creating 'schemas' matcher: %v", err)
+			return false
+		}
+		m.schemaMatcher = mr
+	}
+
+	if m.MaxStatementDigests <= 0 {
+		m.MaxStatementDigests = 10
+	}
+	m.doStatementDigests = m.CollectStatementDigests
+	m.doTableIO = m.CollectTableIO
+
 	cfg.Passwd = strings.Repeat("*", len(cfg.Passwd))
 	m.safeDSN = cfg.FormatDSN()
 
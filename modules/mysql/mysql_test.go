@@ -13,9 +13,11 @@ import (
 	"testing"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/matcher"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/blang/semver/v4"
+	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -26,6 +28,12 @@ var (
 	dataMySQLV8030GlobalVariables, _        = os.ReadFile("testdata/mysql/v8.0.30/global_variables.txt")
 	dataMySQLV8030SlaveStatusMultiSource, _ = os.ReadFile("testdata/mysql/v8.0.30/slave_status_multi_source.txt")
 	dataMySQLV8030ProcessList, _            = os.ReadFile("testdata/mysql/v8.0.30/process_list.txt")
+	dataMySQLV8030StatementDigests, _       = os.ReadFile("testdata/mysql/v8.0.30/statement_digests.txt")
+	dataMySQLV8030TableIOWaits, _           = os.ReadFile("testdata/mysql/v8.0.30/table_io_waits.txt")
+
+	dataMySQLGroupReplicationV8030GlobalVariables, _ = os.ReadFile("testdata/mysql/v8.0.30-group-replication/global_variables.txt")
+	dataMySQLGroupReplicationV8030Members, _         = os.ReadFile("testdata/mysql/v8.0.30-group-replication/group_replication_members.txt")
+	dataMySQLGroupReplicationV8030ApplierLag, _      = os.ReadFile("testdata/mysql/v8.0.30-group-replication/group_replication_applier_lag.txt")
 
 	dataPerconaV8029Version, _         = os.ReadFile("testdata/percona/v8.0.29/version.txt")
 	dataPerconaV8029GlobalStatus, _    = os.ReadFile("testdata/percona/v8.0.29/global_status.txt")
@@ -60,6 +68,12 @@ func Test_testDataIsValid(t *testing.T) {
 		"dataMySQLV8030GlobalVariables":        dataMySQLV8030GlobalVariables,
 		"dataMySQLV8030SlaveStatusMultiSource": dataMySQLV8030SlaveStatusMultiSource,
 		"dataMySQLV8030ProcessList":            dataMySQLV8030ProcessList,
+		"dataMySQLV8030StatementDigests":       dataMySQLV8030StatementDigests,
+		"dataMySQLV8030TableIOWaits":           dataMySQLV8030TableIOWaits,
+
+		"dataMySQLGroupReplicationV8030GlobalVariables": dataMySQLGroupReplicationV8030GlobalVariables,
+		"dataMySQLGroupReplicationV8030Members":         dataMySQLGroupReplicationV8030Members,
+		"dataMySQLGroupReplicationV8030ApplierLag":      dataMySQLGroupReplicationV8030ApplierLag,
 
 		"dataPerconaV8029Version":         dataPerconaV8029Version,
 		"dataPerconaV8029GlobalStatus":    dataPerconaV8029GlobalStatus,
@@ -1620,6 +1634,280 @@ func TestMySQL_Collect(t *testing.T) {
 	}
 }
 
+func TestMySQL_Collect_PerformanceSchema(t *testing.T) {
+	const (
+		digestOrders = "shop_1c5c4d8b8e7d2b07f6cbb1f4a8a0fd0c1d3a0c4b5e7f6a8b9c0d1e2f3a4b5c6d"
+		digestStock  = "shop_7e2a6b0c5d8f1a3b9c4e7d2f0a1b3c5d7e9f0a2b4c6d8e0f1a3b5c7d9e1f3a5b"
+		digestCRM    = "crm_3f9e1d7c5b3a1f0e8d6c4b2a0f9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e"
+	)
+	// the 'orders' digest got 100 calls that took 10ms, 'crm' digest moved up, 'stock' digest left the top 2
+	digestsStep2 := []byte(`
+| SCHEMA_NAME | DIGEST                                                           | DIGEST_TEXT                                   | COUNT_STAR | SUM_TIMER_WAIT | SUM_ERRORS | SUM_WARNINGS | SUM_ROWS_EXAMINED | SUM_ROWS_SENT | SUM_ROWS_AFFECTED |
+| shop        | 1c5c4d8b8e7d2b07f6cbb1f4a8a0fd0c1d3a0c4b5e7f6a8b9c0d1e2f3a4b5c6d | SELECT * FROM orders WHERE customer_id = ?    |       1300 |   106000000000 |          0 |            2 |            260000 |          2600 |                 0 |
+| crm         | 3f9e1d7c5b3a1f0e8d6c4b2a0f9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e | SELECT name FROM contacts WHERE id = ?        |       5500 |    60000000000 |          0 |            0 |              5500 |          5500 |                 0 |
+`)
+	tableIOStep2 := []byte(`
+| OBJECT_SCHEMA | OBJECT_NAME | COUNT_FETCH | COUNT_INSERT | COUNT_UPDATE | COUNT_DELETE | SUM_TIMER_READ | SUM_TIMER_WRITE |
+| shop          | orders      |      262400 |          130 |            0 |            0 |    52000000000 |      6500000000 |
+`)
+
+	prepareCommon := func(t *testing.T, m sqlmock.Sqlmock, withVersion bool) {
+		if withVersion {
+			mockExpect(t, m, queryShowVersion, dataMySQLV8030Version)
+		}
+		mockExpect(t, m, queryShowGlobalStatus, dataMySQLV8030GlobalStatus)
+		mockExpect(t, m, queryShowGlobalVariables, dataMySQLV8030GlobalVariables)
+		mockExpect(t, m, queryShowSlaveStatus, dataMySQLV8030SlaveStatusMultiSource)
+		mockExpect(t, m, queryShowProcessList, dataMySQLV8030ProcessList)
+	}
+
+	db, mock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+	)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	my := New()
+	my.db = db
+	my.CollectStatementDigests = true
+	my.MaxStatementDigests = 2
+	my.CollectTableIO = true
+	require.True(t, my.Init())
+
+	// step 1
+	prepareCommon(t, mock, true)
+	mockExpect(t, mock, my.queryStatementDigests(), dataMySQLV8030StatementDigests)
+	mockExpect(t, mock, queryTableIOWaits, dataMySQLV8030TableIOWaits)
+
+	mx := my.Collect()
+
+	for _, id := range []string{digestOrders, digestStock} {
+		assert.Truef(t, my.Charts().Has("stmt_digest_calls_"+id), "digest '%s' chart", id)
+	}
+	assert.False(t, my.Charts().Has("stmt_digest_calls_"+digestCRM))
+	assert.Equal(t, int64(1200), mx["stmt_digest_"+digestOrders+"_calls"])
+	assert.Equal(t, int64(0), mx["stmt_digest_"+digestOrders+"_avg_latency"])
+	assert.Equal(t, int64(3), mx["stmt_digest_"+digestStock+"_errors"])
+	assert.Equal(t, int64(297), mx["stmt_digest_"+digestStock+"_rows_affected"])
+	assert.Equal(t, int64(242400), mx["table_io_"+tableIOID("shop", "orders")+"_fetch"])
+	assert.Equal(t, int64(12000), mx["table_io_"+tableIOID("shop", "stock")+"_write_wait_time"])
+	assert.Equal(t, int64(2), mx["table_io_"+tableIOID("crm", "contacts")+"_delete"])
+	ensureCollectedHasAllChartsDimsVarsIDs(t, my, mx)
+
+	// step 2
+	prepareCommon(t, mock, false)
+	mockExpect(t, mock, my.queryStatementDigests(), digestsStep2)
+	mockExpect(t, mock, queryTableIOWaits, tableIOStep2)
+
+	mx = my.Collect()
+
+	assert.Equal(t, int64(100), mx["stmt_digest_"+digestOrders+"_avg_latency"])
+	assert.Equal(t, int64(0), mx["stmt_digest_"+digestCRM+"_avg_latency"])
+	assert.True(t, my.Charts().Get("stmt_digest_calls_"+digestStock).Obsolete)
+	assert.False(t, my.Charts().Get("stmt_digest_calls_"+digestCRM).Obsolete)
+	assert.True(t, my.Charts().Get("table_io_operations_"+tableIOID("shop", "stock")).Obsolete)
+	assert.True(t, my.Charts().Get("table_io_operations_"+tableIOID("crm", "contacts")).Obsolete)
+	assert.False(t, my.Charts().Get("table_io_operations_"+tableIOID("shop", "orders")).Obsolete)
+	assert.Len(t, my.stmtDigests, 2)
+	assert.Len(t, my.tablesIO, 1)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQL_Collect_PerformanceSchemaErrors(t *testing.T) {
+	const digestOrders = "shop_1c5c4d8b8e7d2b07f6cbb1f4a8a0fd0c1d3a0c4b5e7f6a8b9c0d1e2f3a4b5c6d"
+
+	prepareCommon := func(t *testing.T, m sqlmock.Sqlmock, withVersion bool) {
+		if withVersion {
+			mockExpect(t, m, queryShowVersion, dataMySQLV8030Version)
+		}
+		mockExpect(t, m, queryShowGlobalStatus, dataMySQLV8030GlobalStatus)
+		mockExpect(t, m, queryShowGlobalVariables, dataMySQLV8030GlobalVariables)
+		mockExpect(t, m, queryShowSlaveStatus, dataMySQLV8030SlaveStatusMultiSource)
+		mockExpect(t, m, queryShowProcessList, dataMySQLV8030ProcessList)
+	}
+
+	db, mock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+	)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	my := New()
+	my.db = db
+	my.CollectStatementDigests = true
+	my.CollectTableIO = true
+	require.True(t, my.Init())
+
+	// step 1
+	prepareCommon(t, mock, true)
+	mockExpect(t, mock, my.queryStatementDigests(), dataMySQLV8030StatementDigests)
+	mockExpect(t, mock, queryTableIOWaits, dataMySQLV8030TableIOWaits)
+
+	_ = my.Collect()
+
+	require.True(t, my.Charts().Has("stmt_digest_calls_"+digestOrders))
+	require.True(t, my.Charts().Has("table_io_operations_"+tableIOID("shop", "orders")))
+
+	// step 2: the digests table access is revoked, the table I/O query fails temporarily
+	prepareCommon(t, mock, false)
+	mock.ExpectQuery(my.queryStatementDigests()).WillReturnError(&mysql.MySQLError{Number: 1142, Message: "SELECT command denied"})
+	mockExpectErr(mock, queryTableIOWaits)
+
+	_ = my.Collect()
+
+	assert.False(t, my.doStatementDigests)
+	assert.True(t, my.CollectStatementDigests)
+	assert.True(t, my.Charts().Get("stmt_digest_calls_"+digestOrders).Obsolete)
+	assert.Empty(t, my.stmtDigests)
+	assert.True(t, my.doTableIO)
+	assert.False(t, my.Charts().Get("table_io_operations_"+tableIOID("shop", "orders")).Obsolete)
+
+	// step 3: the digests are not queried anymore
+	prepareCommon(t, mock, false)
+	mockExpect(t, mock, queryTableIOWaits, dataMySQLV8030TableIOWaits)
+
+	mx := my.Collect()
+
+	assert.Equal(t, int64(242400), mx["table_io_"+tableIOID("shop", "orders")+"_fetch"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_tableIOID(t *testing.T) {
+	ids := map[string]bool{
+		tableIOID("a_b", "c"): true,
+		tableIOID("a", "b_c"): true,
+		tableIOID("a", "b.c"): true,
+		tableIOID("a", "B_c"): true,
+		tableIOID("A", "b_c"): true,
+	}
+	assert.Len(t, ids, 5)
+}
+
+func TestMySQL_Collect_GroupReplication(t *testing.T) {
+	const (
+		member1 = "1e5d5a4a-2b3c-11ed-9f3a-0242ac120002"
+		member2 = "2f6e6b5b-2b3c-11ed-9f3a-0242ac120003"
+		member3 = "3a7f7c6c-2b3c-11ed-9f3a-0242ac120004"
+	)
+	// 'mysql-3' left the group, 'mysql-2' applier has a backlog
+	membersStep2 := []byte(`
+| MEMBER_ID                            | MEMBER_HOST | MEMBER_PORT | MEMBER_STATE | MEMBER_ROLE | COUNT_TRANSACTIONS_IN_QUEUE | COUNT_TRANSACTIONS_REMOTE_IN_APPLIER_QUEUE | COUNT_TRANSACTIONS_CHECKED | COUNT_CONFLICTS_DETECTED | COUNT_TRANSACTIONS_REMOTE_APPLIED | COUNT_TRANSACTIONS_LOCAL_PROPOSED | COUNT_TRANSACTIONS_LOCAL_ROLLBACK |
+| 1E5D5A4A-2B3C-11ED-9F3A-0242AC120002 | mysql-1     | 3306        | ONLINE       | PRIMARY     | 0                           | 0                                          | 15520                      | 4                        | 2                                 | 15518                             | 4                                 |
+| 2F6E6B5B-2B3C-11ED-9F3A-0242AC120003 | mysql-2     | 3306        | ONLINE       | SECONDARY   | 5                           | 80                                         | 15520                      | 4                        | 15440                             | 0                                 | 0                                 |
+`)
+	applierLagStep2 := []byte(`
+| APPLIER_LAG |
+| 1500        |
+`)
+	membersStep3 := []byte(`
+| MEMBER_ID | MEMBER_HOST | MEMBER_PORT | MEMBER_STATE | MEMBER_ROLE | COUNT_TRANSACTIONS_IN_QUEUE | COUNT_TRANSACTIONS_REMOTE_IN_APPLIER_QUEUE | COUNT_TRANSACTIONS_CHECKED | COUNT_CONFLICTS_DETECTED | COUNT_TRANSACTIONS_REMOTE_APPLIED | COUNT_TRANSACTIONS_LOCAL_PROPOSED | COUNT_TRANSACTIONS_LOCAL_ROLLBACK |
+|           |             |             | OFFLINE      |             | 0                           | 0                                          | 0                          | 0                        | 0                                 | 0                                 | 0                                 |
+`)
+
+	prepareCommon := func(t *testing.T, m sqlmock.Sqlmock, withVersion bool) {
+		if withVersion {
+			mockExpect(t, m, queryShowVersion, dataMySQLV8030Version)
+		}
+		mockExpect(t, m, queryShowGlobalStatus, dataMySQLV8030GlobalStatus)
+		mockExpect(t, m, queryShowGlobalVariables, dataMySQLGroupReplicationV8030GlobalVariables)
+		mockExpect(t, m, queryShowSlaveStatus, dataMySQLV8030SlaveStatusMultiSource)
+	}
+
+	db, mock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+	)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	my := New()
+	my.db = db
+	require.True(t, my.Init())
+
+	// step 1
+	prepareCommon(t, mock, true)
+	mockExpect(t, mock, queryGroupReplicationMembers, dataMySQLGroupReplicationV8030Members)
+	mockExpect(t, mock, queryGroupReplicationApplierLag, dataMySQLGroupReplicationV8030ApplierLag)
+	mockExpect(t, mock, queryShowProcessList, dataMySQLV8030ProcessList)
+
+	mx := my.Collect()
+
+	assert.True(t, my.Charts().Has("group_replication_members"))
+	for _, id := range []string{member1, member2, member3} {
+		assert.Truef(t, my.Charts().Has("group_replication_member_state_"+id), "member '%s' chart", id)
+	}
+	assert.Equal(t, int64(2), mx["group_replication_members_online"])
+	assert.Equal(t, int64(1), mx["group_replication_members_recovering"])
+	assert.Equal(t, int64(1), mx["group_replication_member_"+member1+"_role_primary"])
+	assert.Equal(t, int64(1), mx["group_replication_member_"+member3+"_state_recovering"])
+	assert.Equal(t, int64(14), mx["group_replication_member_"+member2+"_applier_queue"])
+	assert.Equal(t, int64(0), mx["group_replication_applier_lag"])
+	ensureCollectedHasAllChartsDimsVarsIDs(t, my, mx)
+
+	// step 2
+	prepareCommon(t, mock, false)
+	mockExpect(t, mock, queryGroupReplicationMembers, membersStep2)
+	mockExpect(t, mock, queryGroupReplicationApplierLag, applierLagStep2)
+	mockExpect(t, mock, queryShowProcessList, dataMySQLV8030ProcessList)
+
+	mx = my.Collect()
+
+	assert.Equal(t, int64(2), mx["group_replication_members_online"])
+	assert.Equal(t, int64(0), mx["group_replication_members_recovering"])
+	assert.Equal(t, int64(5), mx["group_replication_member_"+member2+"_certification_queue"])
+	assert.Equal(t, int64(1500), mx["group_replication_applier_lag"])
+	assert.True(t, my.Charts().Get("group_replication_member_state_"+member3).Obsolete)
+	assert.False(t, my.Charts().Get("group_replication_member_state_"+member2).Obsolete)
+	assert.Len(t, my.groupReplicationMembers, 2)
+
+	// step 3: the plugin is stopped, a single OFFLINE row without the member ID
+	prepareCommon(t, mock, false)
+	mockExpect(t, mock, queryGroupReplicationMembers, membersStep3)
+	mockExpect(t, mock, queryShowProcessList, dataMySQLV8030ProcessList)
+
+	mx = my.Collect()
+
+	for k := range mx {
+		assert.Falsef(t, strings.HasPrefix(k, "group_replication_member__"), "metric '%s'", k)
+	}
+	assert.Empty(t, my.groupReplicationMembers)
+
+	// step 4: the query fails temporarily
+	prepareCommon(t, mock, false)
+	mockExpectErr(mock, queryGroupReplicationMembers)
+	mockExpect(t, mock, queryShowProcessList, dataMySQLV8030ProcessList)
+
+	_ = my.Collect()
+
+	assert.True(t, my.doGroupReplication)
+
+	// step 5: the performance_schema access is revoked
+	prepareCommon(t, mock, false)
+	mock.ExpectQuery(queryGroupReplicationMembers).WillReturnError(&mysql.MySQLError{Number: 1142, Message: "SELECT command denied"})
+	mockExpect(t, mock, queryShowProcessList, dataMySQLV8030ProcessList)
+
+	_ = my.Collect()
+
+	assert.False(t, my.doGroupReplication)
+	assert.True(t, my.Charts().Get("group_replication_members").Obsolete)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQL_queryStatementDigests(t *testing.T) {
+	my := New()
+	my.MaxStatementDigests = 5
+	require.True(t, my.Init())
+	assert.True(t, strings.HasSuffix(my.queryStatementDigests(), "LIMIT 5;"))
+
+	my = New()
+	my.Schemas = matcher.SimpleExpr{Includes: []string{"* shop"}}
+	require.True(t, my.Init())
+	assert.False(t, strings.Contains(my.queryStatementDigests(), "LIMIT"))
+	assert.True(t, my.matchSchema("shop"))
+	assert.False(t, my.matchSchema("crm"))
+}
+
 func ensureCollectedHasAllChartsDimsVarsIDs(t *testing.T, mySQL *MySQL, collected map[string]int64) {
 	for _, chart := range *mySQL.Charts() {
 		if mySQL.isMariaDB {
@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package ntpquery
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+)
+
+const (
+	prioMaxOffset = module.Priority + iota
+	prioServersReachability
+	prioServerOffset
+	prioServerDelay
+	prioServerRootDistance
+	prioServerStratum
+	prioServerLeapIndicator
+	prioServerReachability
+)
+
+var summaryCharts = module.Charts{
+	maxOffsetChart.Copy(),
+	serversReachabilityChart.Copy(),
+}
+
+var (
+	maxOffsetChart = module.Chart{
+		ID:       "max_offset",
+		Title:    "Maximum absolute offset across servers",
+		Units:    "milliseconds",
+		Fam:      "summary",
+		Ctx:      "ntpquery.max_offset",
+		Priority: prioMaxOffset,
+		Dims: module.Dims{
+			{ID: "max_offset", Name: "max_offset", Div: 1e3},
+		},
+	}
+	serversReachabilityChart = module.Chart{
+		ID:       "servers_reachability",
+		Title:    "Servers reachability",
+		Units:    "servers",
+		Fam:      "summary",
+		Ctx:      "ntpquery.servers_reachability",
+		Priority: prioServersReachability,
+		Type:     module.Stacked,
+		Dims: module.Dims{
+			{ID: "servers_reachable", Name: "reachable"},
+			{ID: "servers_unreachable", Name: "unreachable"},
+		},
+	}
+)
+
+var serverChartsTmpl = module.Charts{
+	serverOffsetChartTmpl.Copy(),
+	serverDelayChartTmpl.Copy(),
+	serverRootDistanceChartTmpl.Copy(),
+	serverStratumChartTmpl.Copy(),
+	serverLeapIndicatorChartTmpl.Copy(),
+	serverReachabilityChartTmpl.Copy(),
+}
+
+var (
+	serverOffsetChartTmpl = module.Chart{
+		ID:       "server_%s_offset",
+		Title:    "Server clock offset relative to this host",
+		Units:    "milliseconds",
+		Fam:      "offset",
+		Ctx:      "ntpquery.server_offset",
+		Priority: prioServerOffset,
+		Type:     module.Area,
+		Dims: module.Dims{
+			{ID: "server_%s_offset", Name: "offset", Div: 1e3},
+		},
+	}
+	serverDelayChartTmpl = module.Chart{
+		ID:       "server_%s_delay",
+		Title:    "Server round-trip delay",
+		Units:    "milliseconds",
+		Fam:      "delay",
+		Ctx:      "ntpquery.server_delay",
+		Priority: prioServerDelay,
+		Dims: module.Dims{
+			{ID: "server_%s_delay", Name: "delay", Div: 1e3},
+		},
+	}
+	serverRootDistanceChartTmpl = module.Chart{
+		ID:       "server_%s_root_distance",
+		Title:    "Server delay and dispersion to the reference clock",
+		Units:    "milliseconds",
+		Fam:      "delay",
+		Ctx:      "ntpquery.server_root_distance",
+		Priority: prioServerRootDistance,
+		Dims: module.Dims{
+			{ID: "server_%s_root_delay", Name: "root_delay", Div: 1e3},
+			{ID: "server_%s_root_dispersion", Name: "root_dispersion", Div: 1e3},
+		},
+	}
+	serverStratumChartTmpl = module.Chart{
+		ID:       "server_%s_stratum",
+		Title:    "Server stratum",
+		Units:    "stratum",
+		Fam:      "stratum",
+		Ctx:      "ntpquery.server_stratum",
+		Priority: prioServerStratum,
+		Dims: module.Dims{
+			{ID: "server_%s_stratum", Name: "stratum"},
+		},
+	}
+	serverLeapIndicatorChartTmpl = module.Chart{
+		ID:       "server_%s_leap_indicator",
+		Title:    "Server leap indicator",
+		Units:    "status",
+		Fam:      "leap indicator",
+		Ctx:      "ntpquery.server_leap_indicator",
+		Priority: prioServerLeapIndicator,
+		Dims: module.Dims{
+			{ID: "server_%s_leap_no_warning", Name: "no_warning"},
+			{ID: "server_%s_leap_insert_second", Name: "insert_second"},
+			{ID: "server_%s_leap_delete_second", Name: "delete_second"},
+			{ID: "server_%s_leap_unsynchronized", Name: "unsynchronized"},
+		},
+	}
+	serverReachabilityChartTmpl = module.Chart{
+		ID:       "server_%s_reachability",
+		Title:    "Server reachability",
+		Units:    "status",
+		Fam:      "reachability",
+		Ctx:      "ntpquery.server_reachability",
+		Priority: prioServerReachability,
+		Dims: module.Dims{
+			{ID: "server_%s_reachable", Name: "reachable"},
+			{ID: "server_%s_unreachable", Name: "unreachable"},
+		},
+	}
+)
+
+func newServerCharts(server string) *module.Charts {
+	charts := serverChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, strings.NewReplacer(".", "_", ":", "_").Replace(server))
+		chart.Labels = []module.Label{
+			{Key: "server", Value: server},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, server)
+		}
+	}
+
+	return charts
+}
@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package ntpquery
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"time"
+
+	"github.com/facebook/time/ntp/protocol"
+)
+
+const (
+	modeClient = 3
+	modeServer = 4
+
+	leapNoWarning      = 0
+	leapInsertSecond   = 1
+	leapDeleteSecond   = 2
+	leapUnsynchronized = 3
+)
+
+func newNTPClient(timeout time.Duration, version int) ntpClient {
+	return &sntpClient{timeout: timeout, version: version}
+}
+
+// sntpClient is a SNTP (RFC 4330) client.
+type sntpClient struct {
+	timeout time.Duration
+	version int
+}
+
+type ntpResponse struct {
+	offset         time.Duration
+	delay          time.Duration
+	stratum        int
+	leap           int
+	rootDelay      time.Duration
+	rootDispersion time.Duration
+}
+
+func (c *sntpClient) query(addr string) (*ntpResponse, error) {
+	conn, err := net.DialTimeout("udp", addr, c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	if err := conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		return nil, err
+	}
+
+	// The transmit timestamp is random to not disclose the local clock, it's used only to match the response.
+	req := &protocol.Packet{
+		Settings:   uint8(c.version<<3 | modeClient),
+		TxTimeSec:  rand.Uint32(),
+		TxTimeFrac: rand.Uint32(),
+	}
+	bs, err := req.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	sentAt := time.Now()
+	if _, err := conn.Write(bs); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 1024)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		recvAt := time.Now()
+
+		if n < protocol.PacketSizeBytes {
+			continue
+		}
+		resp, err := protocol.BytesToPacket(buf[:protocol.PacketSizeBytes])
+		if err != nil {
+			return nil, err
+		}
+		// ignore the bogus and duplicate responses (RFC 5905 7.3 origin timestamp check)
+		if resp.OrigTimeSec != req.TxTimeSec || resp.OrigTimeFrac != req.TxTimeFrac {
+			continue
+		}
+
+		return parseResponse(resp, sentAt, recvAt)
+	}
+}
+
+func parseResponse(resp *protocol.Packet, sentAt, recvAt time.Time) (*ntpResponse, error) {
+	if mode := resp.Settings & 0x07; mode != modeServer {
+		return nil, fmt.Errorf("unexpected response mode %d", mode)
+	}
+	if resp.Stratum == 0 {
+		// Kiss-o'-Death packet, the reference ID is the kiss code
+		code := []byte{byte(resp.ReferenceID >> 24), byte(resp.ReferenceID >> 16), byte(resp.ReferenceID >> 8), byte(resp.ReferenceID)}
+		return nil, fmt.Errorf("kiss-o'-death response, code '%s'", code)
+	}
+	if resp.TxTimeSec == 0 && resp.TxTimeFrac == 0 {
+		return nil, errors.New("response transmit timestamp is zero")
+	}
+
+	rxTime := protocol.Unix(resp.RxTimeSec, resp.RxTimeFrac)
+	txTime := protocol.Unix(resp.TxTimeSec, resp.TxTimeFrac)
+
+	// the receive time is derived from the monotonic clock to not be affected by the wall clock changes
+	t1 := sentAt.Round(0)
+	t4 := t1.Add(recvAt.Sub(sentAt))
+
+	delay := time.Duration(protocol.RoundTripDelay(t1, rxTime, txTime, t4))
+	if delay < 0 {
+		delay = 0
+	}
+
+	return &ntpResponse{
+		offset:         time.Duration(protocol.Offset(t1, rxTime, txTime, t4)),
+		delay:          delay,
+		stratum:        int(resp.Stratum),
+		leap:           int(resp.Settings >> 6),
+		rootDelay:      shortFormatToDuration(resp.RootDelay),
+		rootDispersion: shortFormatToDuration(resp.RootDispersion),
+	}, nil
+}
+
+// shortFormatToDuration converts the NTP short format (16 bits seconds, 16 bits fraction).
+func shortFormatToDuration(v uint32) time.Duration {
+	return time.Duration(uint64(v) * uint64(time.Second) >> 16)
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package ntpquery
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+func (n *NTPQuery) collect() (map[string]int64, error) {
+	mu := &sync.Mutex{}
+	mx := make(map[string]int64)
+	var wg sync.WaitGroup
+
+	for _, v := range n.servers {
+		wg.Add(1)
+		go func(v string) { defer wg.Done(); n.queryServer(v, mx, mu) }(v)
+	}
+	wg.Wait()
+
+	var reachable, maxOffset int64
+	for _, srv := range n.servers {
+		if mx["server_"+srv+"_reachable"] == 0 {
+			continue
+		}
+		if offset := abs(mx["server_"+srv+"_offset"]); reachable == 0 || offset > maxOffset {
+			maxOffset = offset
+		}
+		reachable++
+	}
+
+	mx["servers_reachable"] = reachable
+	mx["servers_unreachable"] = int64(len(n.servers)) - reachable
+
+	if reachable == 0 {
+		return mx, errors.New("no NTP server responded")
+	}
+	mx["max_offset"] = maxOffset
+
+	return mx, nil
+}
+
+func (n *NTPQuery) queryServer(server string, mx map[string]int64, mu *sync.Mutex) {
+	resp, err := n.client.query(server)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	px := fmt.Sprintf("server_%s_", server)
+
+	if err != nil {
+		n.Warningf("error on querying NTP server '%s': %v", server, err)
+		mx[px+"reachable"] = 0
+		mx[px+"unreachable"] = 1
+		return
+	}
+
+	mx[px+"reachable"] = 1
+	mx[px+"unreachable"] = 0
+	mx[px+"offset"] = resp.offset.Microseconds()
+	mx[px+"delay"] = resp.delay.Microseconds()
+	mx[px+"root_delay"] = resp.rootDelay.Microseconds()
+	mx[px+"root_dispersion"] = resp.rootDispersion.Microseconds()
+	mx[px+"stratum"] = int64(resp.stratum)
+	mx[px+"leap_no_warning"] = boolToInt(resp.leap == leapNoWarning)
+	mx[px+"leap_insert_second"] = boolToInt(resp.leap == leapInsertSecond)
+	mx[px+"leap_delete_second"] = boolToInt(resp.leap == leapDeleteSecond)
+	mx[px+"leap_unsynchronized"] = boolToInt(resp.leap == leapUnsynchronized)
+}
+
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func boolToInt(v bool) int64 {
+	if v {
+		return 1
+	}
+	return 0
+}
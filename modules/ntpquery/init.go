@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package ntpquery
+
+import (
+	"errors"
+	"fmt"
+	"net"
+)
+
+const defaultPort = "123"
+
+func (n *NTPQuery) validateConfig() error {
+	if len(n.Servers) == 0 {
+		return errors.New("'servers' can't be empty")
+	}
+	if n.Timeout.Duration <= 0 {
+		return errors.New("'timeout' can't be <= 0")
+	}
+	if n.Version != 3 && n.Version != 4 {
+		return fmt.Errorf("'version' must be 3 or 4, got %d", n.Version)
+	}
+	return nil
+}
+
+// initServers adds the default port to the servers that have no port.
+func (n *NTPQuery) initServers() ([]string, error) {
+	seen := make(map[string]bool)
+	var servers []string
+
+	for _, srv := range n.Servers {
+		addr := srv
+		if _, _, err := net.SplitHostPort(srv); err != nil {
+			addr = net.JoinHostPort(srv, defaultPort)
+		}
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			return nil, fmt.Errorf("invalid server address '%s': %v", srv, err)
+		}
+		if seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		servers = append(servers, addr)
+	}
+
+	return servers, nil
+}
+
+func (n *NTPQuery) initCharts() error {
+	if err := n.charts.Add(*summaryCharts.Copy()...); err != nil {
+		return err
+	}
+	for _, srv := range n.servers {
+		if err := n.charts.Add(*newServerCharts(srv)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package ntpquery
+
+import (
+	"time"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/web"
+)
+
+func init() {
+	module.Register("ntpquery", module.Creator{
+		Defaults: module.Defaults{
+			UpdateEvery: 10,
+		},
+		Create: func() module.Module { return New() },
+	})
+}
+
+func New() *NTPQuery {
+	return &NTPQuery{
+		Config: Config{
+			Timeout: web.Duration{Duration: time.Second},
+			Version: 4,
+		},
+		charts:    &module.Charts{},
+		newClient: newNTPClient,
+	}
+}
+
+type Config struct {
+	Servers []string     `yaml:"servers"`
+	Timeout web.Duration `yaml:"timeout"`
+	Version int          `yaml:"version"`
+}
+
+type (
+	NTPQuery struct {
+		module.Base
+		Config `yaml:",inline"`
+
+		charts *module.Charts
+
+		newClient func(timeout time.Duration, version int) ntpClient
+		client    ntpClient
+
+		servers []string
+	}
+	ntpClient interface {
+		query(addr string) (*ntpResponse, error)
+	}
+)
+
+func (n *NTPQuery) Init() bool {
+	if err := n.validateConfig(); err != nil {
+		n.Errorf("config validation: %v", err)
+		return false
+	}
+
+	servers, err := n.initServers()
+	if err != nil {
+		n.Errorf("init servers: %v", err)
+		return false
+	}
+	n.servers = servers
+
+	n.client = n.newClient(n.Timeout.Duration, n.Version)
+
+	if err := n.initCharts(); err != nil {
+		n.Errorf("init charts: %v", err)
+		return false
+	}
+
+	return true
+}
+
+func (n *NTPQuery) Check() bool {
+	mx := n.Collect()
+	return mx["servers_reachable"] > 0
+}
+
+func (n *NTPQuery) Charts() *module.Charts {
+	return n.charts
+}
+
+func (n *NTPQuery) Collect() map[string]int64 {
+	mx, err := n.collect()
+	if err != nil {
+		n.Error(err)
+	}
+
+	if len(mx) == 0 {
+		return nil
+	}
+	return mx
+}
+
+func (n *NTPQuery) Cleanup() {}
@@ -0,0 +1,221 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package ntpquery
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/netdata/go.d.plugin/pkg/web"
+
+	"github.com/facebook/time/ntp/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNTPQuery_Init(t *testing.T) {
+	tests := map[string]struct {
+		wantFail    bool
+		config      Config
+		wantServers []string
+	}{
+		"fail with default": {
+			wantFail: true,
+			config:   New().Config,
+		},
+		"success when 'servers' set": {
+			config: Config{
+				Servers: []string{"192.0.2.1", "192.0.2.2:1123", "192.0.2.1:123", "2001:db8::1", "[2001:db8::2]:123"},
+				Timeout: web.Duration{Duration: time.Second},
+				Version: 4,
+			},
+			wantServers: []string{"192.0.2.1:123", "192.0.2.2:1123", "[2001:db8::1]:123", "[2001:db8::2]:123"},
+		},
+		"fail when 'version' is not supported": {
+			wantFail: true,
+			config: Config{
+				Servers: []string{"192.0.2.1"},
+				Timeout: web.Duration{Duration: time.Second},
+				Version: 2,
+			},
+		},
+		"fail when 'timeout' is not set": {
+			wantFail: true,
+			config: Config{
+				Servers: []string{"192.0.2.1"},
+				Version: 4,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ntp := New()
+			ntp.Config = test.config
+
+			if test.wantFail {
+				assert.False(t, ntp.Init())
+			} else {
+				require.True(t, ntp.Init())
+				assert.Equal(t, test.wantServers, ntp.servers)
+				assert.Len(t, *ntp.Charts(), len(summaryCharts)+len(test.wantServers)*len(serverChartsTmpl))
+			}
+		})
+	}
+}
+
+func TestNTPQuery_Charts(t *testing.T) {
+	assert.NotNil(t, New().Charts())
+}
+
+func TestNTPQuery_Cleanup(t *testing.T) {
+	assert.NotPanics(t, New().Cleanup)
+}
+
+func TestNTPQuery_Check(t *testing.T) {
+	srv := newNTPResponder(t, ntpResponderConfig{stratum: 2})
+	defer srv.close()
+
+	tests := map[string]struct {
+		wantFail bool
+		servers  []string
+	}{
+		"success when the server responds": {
+			servers: []string{srv.addr(), unreachableAddr(t)},
+		},
+		"fail when no server responds": {
+			wantFail: true,
+			servers:  []string{unreachableAddr(t)},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ntp := New()
+			ntp.Servers = test.servers
+			require.True(t, ntp.Init())
+
+			if test.wantFail {
+				assert.False(t, ntp.Check())
+			} else {
+				assert.True(t, ntp.Check())
+			}
+		})
+	}
+}
+
+func TestNTPQuery_Collect(t *testing.T) {
+	synced := newNTPResponder(t, ntpResponderConfig{stratum: 2, offset: time.Millisecond * 50})
+	defer synced.close()
+	leap := newNTPResponder(t, ntpResponderConfig{stratum: 3, offset: -time.Millisecond * 200, leap: leapInsertSecond})
+	defer leap.close()
+	kod := newNTPResponder(t, ntpResponderConfig{stratum: 0})
+	defer kod.close()
+	unreachable := unreachableAddr(t)
+
+	ntp := New()
+	ntp.Servers = []string{synced.addr(), leap.addr(), kod.addr(), unreachable}
+	require.True(t, ntp.Init())
+
+	mx := ntp.Collect()
+	require.NotNil(t, mx)
+
+	px := "server_" + synced.addr() + "_"
+	assert.InDelta(t, 50000, mx[px+"offset"], 10000)
+	assert.Equal(t, int64(2), mx[px+"stratum"])
+	assert.Equal(t, int64(1), mx[px+"reachable"])
+	assert.Equal(t, int64(1), mx[px+"leap_no_warning"])
+	assert.Equal(t, int64(0), mx[px+"leap_insert_second"])
+	assert.Equal(t, int64(15625), mx[px+"root_delay"])
+	assert.Equal(t, int64(7812), mx[px+"root_dispersion"])
+
+	px = "server_" + leap.addr() + "_"
+	assert.InDelta(t, -200000, mx[px+"offset"], 10000)
+	assert.Equal(t, int64(3), mx[px+"stratum"])
+	assert.Equal(t, int64(0), mx[px+"leap_no_warning"])
+	assert.Equal(t, int64(1), mx[px+"leap_insert_second"])
+
+	for _, addr := range []string{kod.addr(), unreachable} {
+		px = "server_" + addr + "_"
+		assert.Equal(t, int64(0), mx[px+"reachable"])
+		assert.Equal(t, int64(1), mx[px+"unreachable"])
+		_, ok := mx[px+"offset"]
+		assert.False(t, ok)
+	}
+
+	assert.InDelta(t, 200000, mx["max_offset"], 10000)
+	assert.Equal(t, int64(2), mx["servers_reachable"])
+	assert.Equal(t, int64(2), mx["servers_unreachable"])
+}
+
+func TestShortFormatToDuration(t *testing.T) {
+	assert.Equal(t, time.Second, shortFormatToDuration(1<<16))
+	assert.Equal(t, time.Millisecond*500, shortFormatToDuration(1<<15))
+	assert.Equal(t, time.Duration(0), shortFormatToDuration(0))
+}
+
+type ntpResponderConfig struct {
+	stratum uint8
+	leap    int
+	offset  time.Duration
+}
+
+type ntpResponder struct {
+	conn *net.UDPConn
+}
+
+// newNTPResponder starts a minimal NTP server whose clock is shifted by the offset.
+func newNTPResponder(t *testing.T, cfg ntpResponderConfig) *ntpResponder {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, raddr, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			req, err := protocol.BytesToPacket(buf[:n])
+			if err != nil || !req.ValidSettingsFormat() {
+				continue
+			}
+
+			rxSec, rxFrac := protocol.Time(time.Now().Add(cfg.offset))
+			resp := &protocol.Packet{
+				Settings:       uint8(cfg.leap<<6 | 4<<3 | modeServer),
+				Stratum:        cfg.stratum,
+				RootDelay:      1 << 10, // 15.625ms
+				RootDispersion: 1 << 9,  // 7.8125ms
+				ReferenceID:    0x52415445,
+				OrigTimeSec:    req.TxTimeSec,
+				OrigTimeFrac:   req.TxTimeFrac,
+				RxTimeSec:      rxSec,
+				RxTimeFrac:     rxFrac,
+			}
+			resp.TxTimeSec, resp.TxTimeFrac = protocol.Time(time.Now().Add(cfg.offset))
+
+			bs, err := resp.Bytes()
+			if err != nil {
+				continue
+			}
+			_, _ = conn.WriteToUDP(bs, raddr)
+		}
+	}()
+
+	return &ntpResponder{conn: conn}
+}
+
+func (r *ntpResponder) addr() string { return r.conn.LocalAddr().String() }
+
+func (r *ntpResponder) close() { _ = r.conn.Close() }
+
+// unreachableAddr returns the address of a closed UDP port.
+func unreachableAddr(t *testing.T) string {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	addr := conn.LocalAddr().String()
+	_ = conn.Close()
+	return addr
+}
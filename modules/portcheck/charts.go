@@ -13,6 +13,16 @@ const (
 	prioCheckStatus = module.Priority + iota
 	prioCheckInStatusDuration
 	prioCheckLatency
+
+	prioTLSCheckStatus
+	prioTLSCheckLatency
+	prioTLSCertExpiry
+
+	prioBannerCheckStatus
+	prioBannerCheckLatency
+
+	prioUDPCheckStatus
+	prioUDPCheckLatency
 )
 
 var chartsTmpl = module.Charts{
@@ -59,8 +69,139 @@ var checkConnectionLatencyChartTmpl = module.Chart{
 	},
 }
 
+var tlsChartsTmpl = module.Charts{
+	tlsCheckStatusChartTmpl.Copy(),
+	tlsCheckLatencyChartTmpl.Copy(),
+	tlsCertExpiryChartTmpl.Copy(),
+}
+
+var (
+	tlsCheckStatusChartTmpl = module.Chart{
+		ID:       "tls_port_%d_status",
+		Title:    "TLS Check Status",
+		Units:    "boolean",
+		Fam:      "tls status",
+		Ctx:      "portcheck.tls_status",
+		Priority: prioTLSCheckStatus,
+		Dims: module.Dims{
+			{ID: "tls_port_%d_success", Name: "success"},
+			{ID: "tls_port_%d_failed", Name: "failed"},
+			{ID: "tls_port_%d_timeout", Name: "timeout"},
+			{ID: "tls_port_%d_tls_error", Name: "tls_error"},
+		},
+	}
+	tlsCheckLatencyChartTmpl = module.Chart{
+		ID:       "tls_port_%d_latency",
+		Title:    "TLS Check Latency",
+		Units:    "ms",
+		Fam:      "tls latency",
+		Ctx:      "portcheck.tls_latency",
+		Priority: prioTLSCheckLatency,
+		Type:     module.Stacked,
+		Dims: module.Dims{
+			{ID: "tls_port_%d_connect_latency", Name: "connect"},
+			{ID: "tls_port_%d_handshake_latency", Name: "handshake"},
+		},
+	}
+	tlsCertExpiryChartTmpl = module.Chart{
+		ID:       "tls_port_%d_cert_expiry",
+		Title:    "TLS Certificate Time Until Expiration",
+		Units:    "days",
+		Fam:      "tls certificate",
+		Ctx:      "portcheck.tls_cert_expiry",
+		Priority: prioTLSCertExpiry,
+		Dims: module.Dims{
+			{ID: "tls_port_%d_cert_expiry", Name: "expiry", Div: 86400},
+		},
+	}
+)
+
+var bannerChartsTmpl = module.Charts{
+	bannerCheckStatusChartTmpl.Copy(),
+	bannerCheckLatencyChartTmpl.Copy(),
+}
+
+var (
+	bannerCheckStatusChartTmpl = module.Chart{
+		ID:       "banner_port_%d_status",
+		Title:    "Banner Check Status",
+		Units:    "boolean",
+		Fam:      "banner status",
+		Ctx:      "portcheck.banner_status",
+		Priority: prioBannerCheckStatus,
+		Dims: module.Dims{
+			{ID: "banner_port_%d_success", Name: "success"},
+			{ID: "banner_port_%d_failed", Name: "failed"},
+			{ID: "banner_port_%d_timeout", Name: "timeout"},
+			{ID: "banner_port_%d_no_match", Name: "no_match"},
+		},
+	}
+	bannerCheckLatencyChartTmpl = module.Chart{
+		ID:       "banner_port_%d_latency",
+		Title:    "Banner Check Latency",
+		Units:    "ms",
+		Fam:      "banner latency",
+		Ctx:      "portcheck.banner_latency",
+		Priority: prioBannerCheckLatency,
+		Type:     module.Stacked,
+		Dims: module.Dims{
+			{ID: "banner_port_%d_connect_latency", Name: "connect"},
+			{ID: "banner_port_%d_response_latency", Name: "response"},
+		},
+	}
+)
+
+var udpChartsTmpl = module.Charts{
+	udpCheckStatusChartTmpl.Copy(),
+	udpCheckLatencyChartTmpl.Copy(),
+}
+
+var (
+	udpCheckStatusChartTmpl = module.Chart{
+		ID:       "udp_port_%d_status",
+		Title:    "UDP Check Status",
+		Units:    "boolean",
+		Fam:      "udp status",
+		Ctx:      "portcheck.udp_status",
+		Priority: prioUDPCheckStatus,
+		Dims: module.Dims{
+			{ID: "udp_port_%d_success", Name: "success"},
+			{ID: "udp_port_%d_failed", Name: "failed"},
+			{ID: "udp_port_%d_timeout", Name: "timeout"},
+			{ID: "udp_port_%d_no_match", Name: "no_match"},
+		},
+	}
+	udpCheckLatencyChartTmpl = module.Chart{
+		ID:       "udp_port_%d_latency",
+		Title:    "UDP Check Response Latency",
+		Units:    "ms",
+		Fam:      "udp latency",
+		Ctx:      "portcheck.udp_latency",
+		Priority: prioUDPCheckLatency,
+		Dims: module.Dims{
+			{ID: "udp_port_%d_response_latency", Name: "response"},
+		},
+	}
+)
+
 func newPortCharts(host string, port int) *module.Charts {
-	charts := chartsTmpl.Copy()
+	return newCharts(chartsTmpl, host, port)
+}
+
+func newTLSPortCharts(host string, port int) *module.Charts {
+	return newCharts(tlsChartsTmpl, host, port)
+}
+
+func newBannerPortCharts(host string, port int) *module.Charts {
+	return newCharts(bannerChartsTmpl, host, port)
+}
+
+func newUDPPortCharts(host string, port int) *module.Charts {
+	return newCharts(udpChartsTmpl, host, port)
+}
+
+func newCharts(tmpl module.Charts, host string, port int) *module.Charts {
+	charts := tmpl.Copy()
 	for _, chart := range *charts {
 		chart.Labels = []module.Label{
 			{Key: "host", Value: host},
@@ -3,6 +3,7 @@
 package portcheck
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -11,9 +12,11 @@ import (
 type checkState string
 
 const (
-	checkStateSuccess checkState = "success"
-	checkStateTimeout checkState = "timeout"
-	checkStateFailed  checkState = "failed"
+	checkStateSuccess  checkState = "success"
+	checkStateTimeout  checkState = "timeout"
+	checkStateFailed   checkState = "failed"
+	checkStateTLSError checkState = "tls_error"
+	checkStateNoMatch  checkState = "no_match"
 )
 
 func (pc *PortCheck) collect() (map[string]int64, error) {
@@ -23,6 +26,18 @@ func (pc *PortCheck) collect() (map[string]int64, error) {
 		wg.Add(1)
 		go func(p *port) { pc.checkPort(p); wg.Done() }(p)
 	}
+	for _, p := range pc.tlsPorts {
+		wg.Add(1)
+		go func(p *tlsPort) { pc.checkTLSPort(p); wg.Done() }(p)
+	}
+	for _, p := range pc.bannerPorts {
+		wg.Add(1)
+		go func(p *probePort) { pc.checkProbePort(p); wg.Done() }(p)
+	}
+	for _, p := range pc.udpPorts {
+		wg.Add(1)
+		go func(p *probePort) { pc.checkProbePort(p); wg.Done() }(p)
+	}
 	wg.Wait()
 
 	mx := make(map[string]int64)
@@ -36,6 +51,26 @@ func (pc *PortCheck) collect() (map[string]int64, error) {
 		mx[fmt.Sprintf("port_%d_%s", p.number, p.state)] = 1
 	}
 
+	for _, p := range pc.tlsPorts {
+		px := fmt.Sprintf("tls_port_%d_", p.number)
+		mx[px+"connect_latency"] = int64(p.connect)
+		mx[px+"handshake_latency"] = int64(p.handshake)
+		for _, s := range []checkState{checkStateSuccess, checkStateFailed, checkStateTimeout, checkStateTLSError} {
+			mx[px+string(s)] = 0
+		}
+		mx[px+string(p.state)] = 1
+		if p.hasCert {
+			mx[px+"cert_expiry"] = p.certExpiry
+		}
+	}
+
+	for _, p := range pc.bannerPorts {
+		collectProbePort(mx, "banner_port_", p)
+	}
+	for _, p := range pc.udpPorts {
+		collectProbePort(mx, "udp_port_", p)
+	}
+
 	return mx, nil
 }
 
@@ -51,8 +86,7 @@ func (pc *PortCheck) checkPort(p *port) {
 	}()
 
 	if err != nil {
-		v, ok := err.(interface{ Timeout() bool })
-		if ok && v.Timeout() {
+		if isTimeout(err) {
 			pc.setPortState(p, checkStateTimeout)
 		} else {
 			pc.setPortState(p, checkStateFailed)
@@ -63,6 +97,18 @@ func (pc *PortCheck) checkPort(p *port) {
 	p.latency = durationToMs(dur)
 }
 
+func collectProbePort(mx map[string]int64, prefix string, p *probePort) {
+	px := fmt.Sprintf("%s%d_", prefix, p.number)
+	if p.network == "tcp" {
+		mx[px+"connect_latency"] = int64(p.connect)
+	}
+	mx[px+"response_latency"] = int64(p.response)
+	for _, s := range []checkState{checkStateSuccess, checkStateFailed, checkStateTimeout, checkStateNoMatch} {
+		mx[px+string(s)] = 0
+	}
+	mx[px+string(p.state)] = 1
+}
+
 func (pc *PortCheck) setPortState(p *port, s checkState) {
 	if p.state != s {
 		p.inState = pc.UpdateEvery
@@ -72,6 +118,11 @@ func (pc *PortCheck) setPortState(p *port, s checkState) {
 	}
 }
 
+func isTimeout(err error) bool {
+	var v interface{ Timeout() bool }
+	return errors.As(err, &v) && v.Timeout()
+}
+
 func durationToMs(duration time.Duration) int {
 	return int(duration) / (int(time.Millisecond) / int(time.Nanosecond))
 }
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package portcheck
+
+import (
+	"fmt"
+	"net"
+	"time"
+)
+
+const maxProbeResponseSize = 4096
+
+func (pc *PortCheck) checkProbePort(p *probePort) {
+	start := time.Now()
+	conn, err := pc.dial(p.network, fmt.Sprintf("%s:%d", pc.Host, p.number), pc.Timeout.Duration)
+	connect := time.Since(start)
+
+	defer func() {
+		if conn != nil {
+			_ = conn.Close()
+		}
+	}()
+
+	if err != nil {
+		pc.Debugf("%s port %d: %v", p.network, p.number, err)
+		p.state = probeErrorState(err)
+		return
+	}
+
+	_ = conn.SetDeadline(start.Add(pc.Timeout.Duration))
+
+	start = time.Now()
+	if len(p.send) > 0 {
+		if _, err := conn.Write(p.send); err != nil {
+			pc.Debugf("%s port %d: write: %v", p.network, p.number, err)
+			p.state = probeErrorState(err)
+			return
+		}
+	}
+
+	resp, err := readProbeResponse(conn, p)
+	response := time.Since(start)
+
+	switch {
+	case len(resp) == 0 && err != nil:
+		pc.Debugf("%s port %d: read: %v", p.network, p.number, err)
+		p.state = probeErrorState(err)
+		return
+	case p.expect != nil && !p.expect.Match(resp):
+		pc.Debugf("%s port %d: response '%s' doesn't match '%s'", p.network, p.number, resp, p.expect)
+		p.state = checkStateNoMatch
+		return
+	}
+
+	p.state = checkStateSuccess
+	p.connect = durationToMs(connect)
+	p.response = durationToMs(response)
+}
+
+// readProbeResponse reads a single datagram for udp. For tcp it reads until the response matches,
+// the connection is closed, the deadline is reached or the response size limit is exceeded.
+func readProbeResponse(conn net.Conn, p *probePort) ([]byte, error) {
+	buf := make([]byte, maxProbeResponseSize)
+
+	if p.network == "udp" {
+		n, err := conn.Read(buf)
+		return buf[:n], err
+	}
+
+	var resp []byte
+	for len(resp) < maxProbeResponseSize {
+		n, err := conn.Read(buf[:maxProbeResponseSize-len(resp)])
+		resp = append(resp, buf[:n]...)
+		if err != nil || p.expect == nil || p.expect.Match(resp) {
+			return resp, err
+		}
+	}
+	return resp, nil
+}
+
+func probeErrorState(err error) checkState {
+	if isTimeout(err) {
+		return checkStateTimeout
+	}
+	return checkStateFailed
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package portcheck
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+)
+
+func (pc *PortCheck) checkTLSPort(p *tlsPort) {
+	p.hasCert, p.certExpiry = false, 0
+
+	start := time.Now()
+	conn, err := pc.dial("tcp", fmt.Sprintf("%s:%d", pc.Host, p.number), pc.Timeout.Duration)
+	connect := time.Since(start)
+
+	defer func() {
+		if conn != nil {
+			_ = conn.Close()
+		}
+	}()
+
+	if err != nil {
+		pc.Debugf("tls port %d: %v", p.number, err)
+		if isTimeout(err) {
+			p.state = checkStateTimeout
+		} else {
+			p.state = checkStateFailed
+		}
+		return
+	}
+
+	_ = conn.SetDeadline(start.Add(pc.Timeout.Duration))
+
+	tlsConn := tls.Client(conn, p.tlsConfig)
+	start = time.Now()
+	err = tlsConn.Handshake()
+	handshake := time.Since(start)
+
+	// the certificate is reported even if it fails verification (expired, self-signed, etc.)
+	var verifyErr *tls.CertificateVerificationError
+	if errors.As(err, &verifyErr) {
+		setCertExpiry(p, verifyErr.UnverifiedCertificates)
+	}
+
+	if err != nil {
+		pc.Debugf("tls port %d: handshake: %v", p.number, err)
+		if isTimeout(err) {
+			p.state = checkStateTimeout
+		} else {
+			p.state = checkStateTLSError
+		}
+		return
+	}
+
+	p.state = checkStateSuccess
+	p.connect = durationToMs(connect)
+	p.handshake = durationToMs(handshake)
+
+	setCertExpiry(p, tlsConn.ConnectionState().PeerCertificates)
+}
+
+func setCertExpiry(p *tlsPort, certs []*x509.Certificate) {
+	if len(certs) == 0 {
+		return
+	}
+	p.certExpiry = int64(time.Until(certs[0].NotAfter).Seconds())
+	p.hasCert = true
+}
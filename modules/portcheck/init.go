@@ -3,18 +3,92 @@
 package portcheck
 
 import (
+	"crypto/tls"
 	"errors"
+	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/tlscfg"
 )
 
 func (pc *PortCheck) validateConfig() error {
 	if pc.Host == "" {
 		return errors.New("'host' parameter not set")
 	}
-	if len(pc.Ports) == 0 {
+	if len(pc.Ports) == 0 && len(pc.TLSPorts) == 0 && len(pc.BannerPorts) == 0 && len(pc.UDPPorts) == 0 {
 		return errors.New("'ports' parameter not set")
 	}
+	for _, p := range pc.TLSPorts {
+		if p.Port <= 0 {
+			return fmt.Errorf("tls_ports: invalid port %d", p.Port)
+		}
+	}
+	for _, p := range pc.BannerPorts {
+		if p.Port <= 0 {
+			return fmt.Errorf("banner_ports: invalid port %d", p.Port)
+		}
+		if p.Expect == "" {
+			return fmt.Errorf("banner_ports: port %d: 'expect' not set", p.Port)
+		}
+	}
+	for _, p := range pc.UDPPorts {
+		if p.Port <= 0 {
+			return fmt.Errorf("udp_ports: invalid port %d", p.Port)
+		}
+		if p.Send == "" {
+			return fmt.Errorf("udp_ports: port %d: 'send' not set", p.Port)
+		}
+	}
+	return nil
+}
+
+func (pc *PortCheck) initTLSPorts() error {
+	for _, cfg := range pc.TLSPorts {
+		tlsConfig, err := tlscfg.NewTLSConfig(cfg.TLSConfig)
+		if err != nil {
+			return fmt.Errorf("port %d: %v", cfg.Port, err)
+		}
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		}
+		tlsConfig.ServerName = cfg.ServerName
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = strings.Trim(pc.Host, "[]")
+		}
+		pc.tlsPorts = append(pc.tlsPorts, &tlsPort{number: cfg.Port, tlsConfig: tlsConfig})
+	}
+	return nil
+}
+
+func (pc *PortCheck) initProbePorts() error {
+	newProbePort := func(network string, cfg ProbePortConfig) (*probePort, error) {
+		p := &probePort{number: cfg.Port, network: network, send: []byte(cfg.Send)}
+		if cfg.Expect != "" {
+			re, err := regexp.Compile(cfg.Expect)
+			if err != nil {
+				return nil, fmt.Errorf("%s port %d: 'expect': %v", network, cfg.Port, err)
+			}
+			p.expect = re
+		}
+		return p, nil
+	}
+
+	for _, cfg := range pc.BannerPorts {
+		p, err := newProbePort("tcp", cfg)
+		if err != nil {
+			return err
+		}
+		pc.bannerPorts = append(pc.bannerPorts, p)
+	}
+	for _, cfg := range pc.UDPPorts {
+		p, err := newProbePort("udp", cfg)
+		if err != nil {
+			return err
+		}
+		pc.udpPorts = append(pc.udpPorts, p)
+	}
 	return nil
 }
 
@@ -26,6 +100,21 @@ func (pc *PortCheck) initCharts() (*module.Charts, error) {
 			return nil, err
 		}
 	}
+	for _, p := range pc.TLSPorts {
+		if err := charts.Add(*newTLSPortCharts(pc.Host, p.Port)...); err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range pc.BannerPorts {
+		if err := charts.Add(*newBannerPortCharts(pc.Host, p.Port)...); err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range pc.UDPPorts {
+		if err := charts.Add(*newUDPPortCharts(pc.Host, p.Port)...); err != nil {
+			return nil, err
+		}
+	}
 
 	return &charts, nil
 }
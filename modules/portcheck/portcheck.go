@@ -3,10 +3,13 @@
 package portcheck
 
 import (
+	"crypto/tls"
 	"net"
+	"regexp"
 	"time"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/tlscfg"
 	"github.com/netdata/go.d.plugin/pkg/web"
 )
 
@@ -28,11 +31,26 @@ func New() *PortCheck {
 	}
 }
 
-type Config struct {
-	Host    string       `yaml:"host"`
-	Ports   []int        `yaml:"ports"`
-	Timeout web.Duration `yaml:"timeout"`
-}
+type (
+	Config struct {
+		Host        string            `yaml:"host"`
+		Ports       []int             `yaml:"ports"`
+		TLSPorts    []TLSPortConfig   `yaml:"tls_ports"`
+		BannerPorts []ProbePortConfig `yaml:"banner_ports"`
+		UDPPorts    []ProbePortConfig `yaml:"udp_ports"`
+		Timeout     web.Duration      `yaml:"timeout"`
+	}
+	TLSPortConfig struct {
+		tlscfg.TLSConfig `yaml:",inline"`
+		Port             int    `yaml:"port"`
+		ServerName       string `yaml:"server_name"`
+	}
+	ProbePortConfig struct {
+		Port   int    `yaml:"port"`
+		Send   string `yaml:"send"`
+		Expect string `yaml:"expect"`
+	}
+)
 
 type dialFunc func(network, address string, timeout time.Duration) (net.Conn, error)
 
@@ -43,14 +61,38 @@ type port struct {
 	latency int
 }
 
+type tlsPort struct {
+	number     int
+	tlsConfig  *tls.Config
+	state      checkState
+	connect    int
+	handshake  int
+	certExpiry int64
+	hasCert    bool
+}
+
+// probePort is a banner (tcp) or udp port check: send an optional payload and match the response.
+type probePort struct {
+	number   int
+	network  string
+	send     []byte
+	expect   *regexp.Regexp
+	state    checkState
+	connect  int
+	response int
+}
+
 type PortCheck struct {
 	module.Base
 	Config      `yaml:",inline"`
 	UpdateEvery int `yaml:"update_every"`
 
-	charts *module.Charts
-	dial   dialFunc
-	ports  []*port
+	charts      *module.Charts
+	dial        dialFunc
+	ports       []*port
+	tlsPorts    []*tlsPort
+	bannerPorts []*probePort
+	udpPorts    []*probePort
 }
 
 func (pc *PortCheck) Init() bool {
@@ -70,6 +112,16 @@ func (pc *PortCheck) Init() bool {
 		pc.ports = append(pc.ports, &port{number: p})
 	}
 
+	if err := pc.initTLSPorts(); err != nil {
+		pc.Errorf("init tls ports: %v", err)
+		return false
+	}
+
+	if err := pc.initProbePorts(); err != nil {
+		pc.Errorf("init probe ports: %v", err)
+		return false
+	}
+
 	pc.Debugf("using host: %s", pc.Host)
 	pc.Debugf("using ports: %v", pc.Ports)
 	pc.Debugf("using TCP connection timeout: %s", pc.Timeout)
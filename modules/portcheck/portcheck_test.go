@@ -5,11 +5,16 @@ package portcheck
 import (
 	"errors"
 	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/tlscfg"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -36,6 +41,22 @@ func TestPortCheck_InitNG(t *testing.T) {
 	assert.False(t, job.Init())
 	job.Ports = []int{39001, 39002}
 	assert.True(t, job.Init())
+
+	job = New()
+	job.Host = "127.0.0.1"
+	job.BannerPorts = []ProbePortConfig{{Port: 25}}
+	assert.False(t, job.Init())
+	job.BannerPorts = []ProbePortConfig{{Port: 25, Expect: "^220 "}}
+	assert.True(t, job.Init())
+
+	job = New()
+	job.Host = "127.0.0.1"
+	job.UDPPorts = []ProbePortConfig{{Port: 53, Expect: "pong"}}
+	assert.False(t, job.Init())
+	job.UDPPorts = []ProbePortConfig{{Port: 53, Send: "ping", Expect: "("}}
+	assert.False(t, job.Init())
+	job.UDPPorts = []ProbePortConfig{{Port: 53, Send: "ping", Expect: "pong"}}
+	assert.True(t, job.Init())
 }
 
 func TestPortCheck_Check(t *testing.T) {
@@ -145,6 +166,98 @@ func TestPortCheck_Collect(t *testing.T) {
 	assert.Equal(t, expected, collected)
 }
 
+func TestPortCheck_Collect_TLSAndProbes(t *testing.T) {
+	tlsSrv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer tlsSrv.Close()
+	tlsSrvUntrusted := httptest.NewTLSServer(http.NotFoundHandler())
+	defer tlsSrvUntrusted.Close()
+
+	bannerLn, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = bannerLn.Close() }()
+	go func() {
+		for {
+			conn, err := bannerLn.Accept()
+			if err != nil {
+				return
+			}
+			_, _ = conn.Write([]byte("220 mail.example.com ESMTP\r\n"))
+			_ = conn.Close()
+		}
+	}()
+
+	udpConn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = udpConn.Close() }()
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := udpConn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			_, _ = udpConn.WriteTo(buf[:n], addr)
+		}
+	}()
+
+	portOf := func(addr net.Addr) int {
+		_, p, _ := net.SplitHostPort(addr.String())
+		v, _ := strconv.Atoi(p)
+		return v
+	}
+	tlsPort := portOf(tlsSrv.Listener.Addr())
+	tlsPortUntrusted := portOf(tlsSrvUntrusted.Listener.Addr())
+	bannerPort := portOf(bannerLn.Addr())
+	udpPort := portOf(udpConn.LocalAddr())
+
+	job := New()
+	job.Host = "127.0.0.1"
+	job.TLSPorts = []TLSPortConfig{
+		{Port: tlsPort, TLSConfig: tlscfg.TLSConfig{InsecureSkipVerify: true}},
+		{Port: tlsPortUntrusted},
+	}
+	job.BannerPorts = []ProbePortConfig{{Port: bannerPort, Expect: "^220 "}}
+	job.UDPPorts = []ProbePortConfig{{Port: udpPort, Send: "ping", Expect: "^ping$"}}
+	require.True(t, job.Init())
+	require.True(t, job.Check())
+
+	mx := job.Collect()
+
+	tlsPx := "tls_port_" + strconv.Itoa(tlsPort) + "_"
+	assert.Equal(t, int64(1), mx[tlsPx+"success"])
+	assert.Greater(t, mx[tlsPx+"cert_expiry"], int64(0))
+	assert.Equal(t, int64(1), mx["tls_port_"+strconv.Itoa(tlsPortUntrusted)+"_tls_error"])
+	assert.Greater(t, mx["tls_port_"+strconv.Itoa(tlsPortUntrusted)+"_cert_expiry"], int64(0))
+	assert.Equal(t, int64(1), mx["banner_port_"+strconv.Itoa(bannerPort)+"_success"])
+	assert.Equal(t, int64(1), mx["udp_port_"+strconv.Itoa(udpPort)+"_success"])
+	assert.Len(t, *job.Charts(), len(tlsChartsTmpl)*2+len(bannerChartsTmpl)+len(udpChartsTmpl))
+
+	for _, key := range []string{
+		tlsPx + "connect_latency",
+		tlsPx + "handshake_latency",
+		"banner_port_" + strconv.Itoa(bannerPort) + "_connect_latency",
+		"banner_port_" + strconv.Itoa(bannerPort) + "_response_latency",
+		"udp_port_" + strconv.Itoa(udpPort) + "_response_latency",
+	} {
+		assert.Contains(t, mx, key)
+	}
+
+	job.bannerPorts[0].expect = regexp.MustCompile(`^\+PONG`)
+	job.udpPorts[0].send = []byte("pong")
+
+	mx = job.Collect()
+
+	assert.Equal(t, int64(1), mx["banner_port_"+strconv.Itoa(bannerPort)+"_no_match"])
+	assert.Equal(t, int64(1), mx["udp_port_"+strconv.Itoa(udpPort)+"_no_match"])
+
+	tlsSrv.Close()
+
+	mx = job.Collect()
+
+	assert.Equal(t, int64(1), mx[tlsPx+"failed"])
+	assert.NotContains(t, mx, tlsPx+"cert_expiry")
+}
+
 func testDial(err error) dialFunc {
 	return func(_, _ string, _ time.Duration) (net.Conn, error) { return &net.TCPConn{}, err }
 }
@@ -94,6 +94,13 @@ const (
 	prioCatalogRelationsSize
 
 	prioUptime
+
+	prioStatementCalls
+	prioStatementTotalTime
+	prioStatementTime
+	prioStatementRows
+	prioStatementSharedBlocks
+	prioStatementTempBlocks
 )
 
 var baseCharts = module.Charts{
@@ -1398,3 +1405,113 @@ func (p *Postgres) removeIndexCharts(idx *indexMetrics) {
 		}
 	}
 }
+
+var (
+	statementChartsTmpl = module.Charts{
+		statementCallsChartTmpl.Copy(),
+		statementTotalTimeChartTmpl.Copy(),
+		statementTimeChartTmpl.Copy(),
+		statementRowsChartTmpl.Copy(),
+		statementSharedBlocksChartTmpl.Copy(),
+		statementTempBlocksChartTmpl.Copy(),
+	}
+	statementCallsChartTmpl = module.Chart{
+		ID:       "statement_%s_calls",
+		Title:    "Statement calls",
+		Units:    "calls/s",
+		Fam:      "statements",
+		Ctx:      "postgres.statement_calls",
+		Priority: prioStatementCalls,
+		Dims: module.Dims{
+			{ID: "statement_%s_calls", Name: "calls", Algo: module.Incremental},
+		},
+	}
+	statementTotalTimeChartTmpl = module.Chart{
+		ID:       "statement_%s_total_time",
+		Title:    "Statement total execution time",
+		Units:    "milliseconds/s",
+		Fam:      "statements",
+		Ctx:      "postgres.statement_total_time",
+		Priority: prioStatementTotalTime,
+		Dims: module.Dims{
+			{ID: "statement_%s_total_time", Name: "total", Algo: module.Incremental, Div: 1000},
+		},
+	}
+	statementTimeChartTmpl = module.Chart{
+		ID:       "statement_%s_time",
+		Title:    "Statement execution time",
+		Units:    "milliseconds",
+		Fam:      "statements",
+		Ctx:      "postgres.statement_time",
+		Priority: prioStatementTime,
+		Dims: module.Dims{
+			{ID: "statement_%s_mean_time", Name: "mean", Div: 1000},
+			{ID: "statement_%s_max_time", Name: "max", Div: 1000},
+		},
+	}
+	statementRowsChartTmpl = module.Chart{
+		ID:       "statement_%s_rows",
+		Title:    "Statement rows",
+		Units:    "rows/s",
+		Fam:      "statements",
+		Ctx:      "postgres.statement_rows",
+		Priority: prioStatementRows,
+		Dims: module.Dims{
+			{ID: "statement_%s_rows", Name: "rows", Algo: module.Incremental},
+		},
+	}
+	statementSharedBlocksChartTmpl = module.Chart{
+		ID:       "statement_%s_shared_blocks",
+		Title:    "Statement shared buffer blocks",
+		Units:    "blocks/s",
+		Fam:      "statements",
+		Ctx:      "postgres.statement_shared_blocks",
+		Priority: prioStatementSharedBlocks,
+		Type:     module.Stacked,
+		Dims: module.Dims{
+			{ID: "statement_%s_shared_blks_hit", Name: "hit", Algo: module.Incremental},
+			{ID: "statement_%s_shared_blks_read", Name: "read", Algo: module.Incremental},
+		},
+	}
+	statementTempBlocksChartTmpl = module.Chart{
+		ID:       "statement_%s_temp_blocks",
+		Title:    "Statement temp blocks written",
+		Units:    "blocks/s",
+		Fam:      "statements",
+		Ctx:      "postgres.statement_temp_blocks",
+		Priority: prioStatementTempBlocks,
+		Dims: module.Dims{
+			{ID: "statement_%s_temp_blks_written", Name: "written", Algo: module.Incremental},
+		},
+	}
+)
+
+func (p *Postgres) addNewStatementCharts(stmt *statementMetrics) {
+	charts := statementChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, stmt.queryID)
+		chart.Labels = []module.Label{
+			{Key: "queryid", Value: stmt.queryID},
+			{Key: "database", Value: stmt.db},
+			{Key: "query", Value: stmt.query},
+		}
+		for _, d := range chart.Dims {
+			d.ID = fmt.Sprintf(d.ID, stmt.queryID)
+		}
+	}
+
+	if err := p.Charts().Add(*charts...); err != nil {
+		p.Warning(err)
+	}
+}
+
+func (p *Postgres) removeStatementCharts(stmt *statementMetrics) {
+	prefix := fmt.Sprintf("statement_%s_", stmt.queryID)
+	for _, c := range *p.Charts() {
+		if strings.HasPrefix(c.ID, prefix) {
+			c.MarkRemove()
+			c.MarkNotCreated()
+		}
+	}
+}
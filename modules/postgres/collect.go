@@ -17,6 +17,8 @@ const (
 	pgVersion94 = 9_04_00
 	pgVersion10 = 10_00_00
 	pgVersion11 = 11_00_00
+	pgVersion13 = 13_00_00
+	pgVersion14 = 14_00_00
 )
 
 func (p *Postgres) collect() (map[string]int64, error) {
@@ -70,6 +72,14 @@ func (p *Postgres) collect() (map[string]int64, error) {
 			return nil, fmt.Errorf("querying settings max locks held error: %v", err)
 		}
 		p.mx.maxLocksHeld = maxLocks
+
+		if p.MaxStatements > 0 {
+			v, err := p.doQueryPGStatStatementsInstalled()
+			if err != nil {
+				p.Warningf("querying pg_stat_statements extension error: %v", err)
+			}
+			p.hasPGStatStatements = v
+		}
 	}
 
 	p.resetMetrics()
@@ -106,6 +116,14 @@ func (p *Postgres) collect() (map[string]int64, error) {
 		return nil, err
 	}
 
+	if p.hasPGStatStatements {
+		if err := p.doQueryStatementsMetrics(); err != nil {
+			p.Warningf("querying pg_stat_statements error: %v", err)
+			// most likely the library is not in 'shared_preload_libraries', recheck later
+			p.hasPGStatStatements = false
+		}
+	}
+
 	if now.Sub(p.doSlowTime) > p.doSlowEvery {
 		p.doSlowTime = now
 		if err := p.doQueryBloat(); err != nil {
@@ -219,6 +237,15 @@ func (p *Postgres) hasIndexMetrics(name, table, db, schema string) bool {
 	return ok
 }
 
+func (p *Postgres) getStatementMetrics(queryID string) *statementMetrics {
+	m, ok := p.mx.statements[queryID]
+	if !ok {
+		m = &statementMetrics{queryID: queryID}
+		p.mx.statements[queryID] = m
+	}
+	return m
+}
+
 func (p *Postgres) getReplAppMetrics(name string) *replStandbyAppMetrics {
 	app, ok := p.mx.replApps[name]
 	if !ok {
@@ -258,6 +258,28 @@ func (p *Postgres) collectMetrics(mx map[string]int64) {
 		}
 	}
 
+	for id, m := range p.mx.statements {
+		if !m.updated {
+			delete(p.mx.statements, id)
+			p.removeStatementCharts(m)
+			continue
+		}
+		if !m.hasCharts {
+			m.hasCharts = true
+			p.addNewStatementCharts(m)
+		}
+
+		px := "statement_" + m.queryID + "_"
+		mx[px+"calls"] = m.calls.acc
+		mx[px+"total_time"] = m.totalTime.acc
+		mx[px+"mean_time"] = m.meanTime
+		mx[px+"max_time"] = m.maxTime
+		mx[px+"rows"] = m.rows.acc
+		mx[px+"shared_blks_hit"] = m.sharedBlksHit.acc
+		mx[px+"shared_blks_read"] = m.sharedBlksRead.acc
+		mx[px+"temp_blks_written"] = m.tempBlksWritten.acc
+	}
+
 	for name, m := range p.mx.replApps {
 		if !m.updated {
 			delete(p.mx.replApps, name)
@@ -352,6 +374,9 @@ func (p *Postgres) resetMetrics() {
 			bloatSizePerc: m.bloatSizePerc,
 		}
 	}
+	for _, m := range p.mx.statements {
+		m.updated = false
+	}
 	for name, m := range p.mx.replApps {
 		p.mx.replApps[name] = &replStandbyAppMetrics{
 			name:      m.name,
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package postgres
+
+import (
+	"strconv"
+	"strings"
+)
+
+const statementQueryTextMaxLen = 256
+
+func (p *Postgres) doQueryPGStatStatementsInstalled() (bool, error) {
+	q := queryPGStatStatementsInstalled()
+
+	var v bool
+	if err := p.doQueryRow(q, &v); err != nil {
+		return false, err
+	}
+
+	return v, nil
+}
+
+func (p *Postgres) doQueryStatementsMetrics() error {
+	q := queryPGStatStatements(p.pgVersion, p.MaxStatements)
+
+	var m *statementMetrics
+	var statsReset string
+	var calls, totalTime, rows, sharedBlksHit, sharedBlksRead, tempBlksWritten int64
+
+	err := p.doQuery(q, func(column, value string, rowEnd bool) {
+		switch column {
+		case "stats_reset":
+			statsReset = value
+		case "queryid":
+			m = p.getStatementMetrics(value)
+			m.updated = true
+		case "datname":
+			m.db = value
+		case "query":
+			m.query = cleanStatementQuery(value)
+		case "calls":
+			calls = parseInt(value)
+		case "total_time":
+			totalTime = parseMilliseconds(value)
+		case "max_time":
+			m.maxTime = parseMilliseconds(value)
+		case "rows":
+			rows = parseInt(value)
+		case "shared_blks_hit":
+			sharedBlksHit = parseInt(value)
+		case "shared_blks_read":
+			sharedBlksRead = parseInt(value)
+		case "temp_blks_written":
+			tempBlksWritten = parseInt(value)
+		}
+		if !rowEnd {
+			return
+		}
+
+		// all entries were reset (pg_stat_statements_reset()), or the entry was evicted and added again
+		reset := m.collected &&
+			(statsReset != p.stmtStatsReset || calls < m.calls.prev || totalTime < m.totalTime.prev)
+
+		for _, v := range []struct {
+			c *stmtCounter
+			v int64
+		}{
+			{c: &m.calls, v: calls},
+			{c: &m.totalTime, v: totalTime},
+			{c: &m.rows, v: rows},
+			{c: &m.sharedBlksHit, v: sharedBlksHit},
+			{c: &m.sharedBlksRead, v: sharedBlksRead},
+			{c: &m.tempBlksWritten, v: tempBlksWritten},
+		} {
+			if !m.collected {
+				v.c.prev = v.v
+			}
+			v.c.set(v.v, reset)
+		}
+		m.collected = true
+
+		m.meanTime = 0
+		if m.calls.delta > 0 {
+			m.meanTime = m.totalTime.delta / m.calls.delta
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if statsReset != "" {
+		p.stmtStatsReset = statsReset
+	}
+	return nil
+}
+
+// parseMilliseconds parses a float number of milliseconds and returns microseconds.
+func parseMilliseconds(s string) int64 {
+	v, _ := strconv.ParseFloat(s, 64)
+	return int64(v * 1000)
+}
+
+// cleanStatementQuery makes the statement text safe to use as a chart label value:
+// the plugin protocol is line based and the label value is single-quoted.
+func cleanStatementQuery(query string) string {
+	return strings.ReplaceAll(strings.Join(strings.Fields(query), " "), "'", "")
+}
@@ -6,11 +6,12 @@ import "github.com/netdata/go.d.plugin/pkg/metrics"
 
 type pgMetrics struct {
 	srvMetrics
-	dbs       map[string]*dbMetrics
-	tables    map[string]*tableMetrics
-	indexes   map[string]*indexMetrics
-	replApps  map[string]*replStandbyAppMetrics
-	replSlots map[string]*replSlotMetrics
+	dbs        map[string]*dbMetrics
+	tables     map[string]*tableMetrics
+	indexes    map[string]*indexMetrics
+	replApps   map[string]*replStandbyAppMetrics
+	replSlots  map[string]*replSlotMetrics
+	statements map[string]*statementMetrics
 }
 
 type srvMetrics struct {
@@ -226,6 +227,44 @@ type indexMetrics struct {
 	bloatSize     *int64 // need 'SELECT' access to the table
 	bloatSizePerc *int64 // need 'SELECT' access to the table
 }
+type statementMetrics struct {
+	queryID string
+	db      string
+	query   string
+
+	updated   bool
+	hasCharts bool
+	collected bool
+
+	// pg_stat_statements counters go back on pg_stat_statements_reset() and entries eviction,
+	// so they are accumulated from the per-interval deltas to keep them monotonic.
+	calls           stmtCounter
+	totalTime       stmtCounter // microseconds
+	rows            stmtCounter
+	sharedBlksHit   stmtCounter
+	sharedBlksRead  stmtCounter
+	tempBlksWritten stmtCounter
+
+	meanTime int64 // microseconds, calls made since the previous collection
+	maxTime  int64 // microseconds
+}
+
+type stmtCounter struct {
+	prev  int64
+	acc   int64
+	delta int64
+}
+
+func (c *stmtCounter) set(v int64, reset bool) {
+	if reset {
+		c.delta = v
+	} else {
+		c.delta = v - c.prev
+	}
+	c.prev = v
+	c.acc += c.delta
+}
+
 type incDelta struct{ prev, last int64 }
 
 func (pc *incDelta) delta() int64 { return pc.last - pc.prev }
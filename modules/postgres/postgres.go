@@ -31,17 +31,19 @@ func New() *Postgres {
 			QueryTimeHistogram: []float64{.1, .5, 1, 2.5, 5, 10},
 			// charts: 20 x table, 4 x index.
 			// https://discord.com/channels/847502280503590932/1022693928874549368
-			MaxDBTables:  50,
-			MaxDBIndexes: 250,
+			MaxDBTables:   50,
+			MaxDBIndexes:  250,
+			MaxStatements: 10,
 		},
 		charts:  baseCharts.Copy(),
 		dbConns: make(map[string]*dbConn),
 		mx: &pgMetrics{
-			dbs:       make(map[string]*dbMetrics),
-			indexes:   make(map[string]*indexMetrics),
-			tables:    make(map[string]*tableMetrics),
-			replApps:  make(map[string]*replStandbyAppMetrics),
-			replSlots: make(map[string]*replSlotMetrics),
+			dbs:        make(map[string]*dbMetrics),
+			indexes:    make(map[string]*indexMetrics),
+			tables:     make(map[string]*tableMetrics),
+			replApps:   make(map[string]*replStandbyAppMetrics),
+			replSlots:  make(map[string]*replSlotMetrics),
+			statements: make(map[string]*statementMetrics),
 		},
 		recheckSettingsEvery:              time.Minute * 30,
 		doSlowEvery:                       time.Minute * 5,
@@ -58,6 +60,7 @@ type Config struct {
 	QueryTimeHistogram []float64    `yaml:"query_time_histogram"`
 	MaxDBTables        int64        `yaml:"max_db_tables"`
 	MaxDBIndexes       int64        `yaml:"max_db_indexes"`
+	MaxStatements      int64        `yaml:"max_statements"`
 }
 
 type (
@@ -74,6 +77,9 @@ type (
 		pgIsInRecovery *bool
 		pgVersion      int
 
+		hasPGStatStatements bool
+		stmtStatsReset      string
+
 		addXactQueryRunningTimeChartsOnce *sync.Once
 		addWALFilesChartsOnce             *sync.Once
 
@@ -12,6 +12,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/netdata/go.d.plugin/agent/module"
 	"github.com/netdata/go.d.plugin/pkg/matcher"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -28,6 +29,10 @@ var (
 	dataV140004SettingsMaxConnections, _ = os.ReadFile("testdata/v14.4/settings_max_connections.txt")
 	dataV140004SettingsMaxLocksHeld, _   = os.ReadFile("testdata/v14.4/settings_max_locks_held.txt")
 
+	dataV140004PGStatStatementsInstalledFalse, _ = os.ReadFile("testdata/v14.4/pg_stat_statements_installed-false.txt")
+	dataV140004PGStatStatementsInstalledTrue, _  = os.ReadFile("testdata/v14.4/pg_stat_statements_installed-true.txt")
+	dataV140004PGStatStatements, _               = os.ReadFile("testdata/v14.4/pg_stat_statements.txt")
+
 	dataV140004ServerCurrentConnections, _ = os.ReadFile("testdata/v14.4/server_current_connections.txt")
 	dataV140004ServerConnectionsState, _   = os.ReadFile("testdata/v14.4/server_connections_state.txt")
 	dataV140004Checkpoints, _              = os.ReadFile("testdata/v14.4/checkpoints.txt")
@@ -71,6 +76,10 @@ func Test_testDataIsValid(t *testing.T) {
 		"dataV140004SettingsMaxConnections": dataV140004SettingsMaxConnections,
 		"dataV140004SettingsMaxLocksHeld":   dataV140004SettingsMaxLocksHeld,
 
+		"dataV140004PGStatStatementsInstalledFalse": dataV140004PGStatStatementsInstalledFalse,
+		"dataV140004PGStatStatementsInstalledTrue":  dataV140004PGStatStatementsInstalledTrue,
+		"dataV140004PGStatStatements":               dataV140004PGStatStatements,
+
 		"dataV140004ServerCurrentConnections": dataV140004ServerCurrentConnections,
 		"dataV140004ServerConnectionsState":   dataV140004ServerConnectionsState,
 		"dataV140004Checkpoints":              dataV140004Checkpoints,
@@ -160,6 +169,7 @@ func TestPostgres_Check(t *testing.T) {
 
 				mockExpect(t, m, querySettingsMaxConnections(), dataV140004SettingsMaxConnections)
 				mockExpect(t, m, querySettingsMaxLocksHeld(), dataV140004SettingsMaxLocksHeld)
+				mockExpect(t, m, queryPGStatStatementsInstalled(), dataV140004PGStatStatementsInstalledFalse)
 
 				mockExpect(t, m, queryServerCurrentConnectionsUsed(), dataV140004ServerCurrentConnections)
 				mockExpect(t, m, queryServerConnectionsState(), dataV140004ServerConnectionsState)
@@ -200,6 +210,7 @@ func TestPostgres_Check(t *testing.T) {
 
 				mockExpect(t, m, querySettingsMaxConnections(), dataV140004ServerVersionNum)
 				mockExpect(t, m, querySettingsMaxLocksHeld(), dataV140004SettingsMaxLocksHeld)
+				mockExpect(t, m, queryPGStatStatementsInstalled(), dataV140004PGStatStatementsInstalledFalse)
 
 				mockExpect(t, m, queryServerCurrentConnectionsUsed(), dataV140004ServerCurrentConnections)
 				mockExpectErr(m, queryServerConnectionsState())
@@ -263,6 +274,7 @@ func TestPostgres_Collect(t *testing.T) {
 
 					mockExpect(t, m, querySettingsMaxConnections(), dataV140004SettingsMaxConnections)
 					mockExpect(t, m, querySettingsMaxLocksHeld(), dataV140004SettingsMaxLocksHeld)
+					mockExpect(t, m, queryPGStatStatementsInstalled(), dataV140004PGStatStatementsInstalledFalse)
 
 					mockExpect(t, m, queryServerCurrentConnectionsUsed(), dataV140004ServerCurrentConnections)
 					mockExpect(t, m, queryServerConnectionsState(), dataV140004ServerConnectionsState)
@@ -647,6 +659,7 @@ func TestPostgres_Collect(t *testing.T) {
 
 					mockExpect(t, m, querySettingsMaxConnections(), dataV140004SettingsMaxConnections)
 					mockExpect(t, m, querySettingsMaxLocksHeld(), dataV140004SettingsMaxLocksHeld)
+					mockExpect(t, m, queryPGStatStatementsInstalled(), dataV140004PGStatStatementsInstalledFalse)
 
 					mockExpectErr(m, queryServerCurrentConnectionsUsed())
 				},
@@ -682,6 +695,124 @@ func TestPostgres_Collect(t *testing.T) {
 	}
 }
 
+func TestPostgres_Collect_PGStatStatements(t *testing.T) {
+	const (
+		q1 = "-6405911435093245123"
+		q2 = "4451282493014853231"
+		q3 = "-919129573512308522"
+	)
+	header := " stats_reset | queryid | datname | query | calls | total_time | max_time | rows | shared_blks_hit | shared_blks_read | temp_blks_written\n"
+	row := func(statsReset, queryID string, calls int, totalTime string) string {
+		return fmt.Sprintf(" %s | %s | postgres | SELECT 1 | %d | %s | 1.5 | %d | 10 | 1 | 0\n", statsReset, queryID, calls, totalTime, calls)
+	}
+
+	type testCaseStep struct {
+		data  string
+		check func(t *testing.T, pg *Postgres, mx map[string]int64)
+	}
+	steps := []testCaseStep{
+		{
+			// the first collection sets the baseline
+			data: string(dataV140004PGStatStatements),
+			check: func(t *testing.T, pg *Postgres, mx map[string]int64) {
+				for _, id := range []string{q1, q2, q3} {
+					assert.Truef(t, pg.Charts().Has("statement_"+id+"_calls"), "statement %s charts", id)
+					assert.Equal(t, int64(0), mx["statement_"+id+"_calls"])
+					assert.Equal(t, int64(0), mx["statement_"+id+"_mean_time"])
+				}
+				assert.Equal(t, int64(310701), mx["statement_"+q3+"_max_time"])
+			},
+		},
+		{
+			// q1: 50 calls in 100ms, q2 and q3 left the top N
+			data: header + row("1664185000", q1, 100050, "25112.345678000004"),
+			check: func(t *testing.T, pg *Postgres, mx map[string]int64) {
+				assert.Equal(t, int64(50), mx["statement_"+q1+"_calls"])
+				assert.Equal(t, int64(100000), mx["statement_"+q1+"_total_time"])
+				assert.Equal(t, int64(2000), mx["statement_"+q1+"_mean_time"])
+				assert.True(t, pg.Charts().Get("statement_"+q2+"_calls").Obsolete)
+				assert.True(t, pg.Charts().Get("statement_"+q3+"_calls").Obsolete)
+				assert.Len(t, pg.mx.statements, 1)
+			},
+		},
+		{
+			// pg_stat_statements_reset(): q1 has more calls than before the reset
+			data: header + row("1664186000", q1, 200000, "40000.0"),
+			check: func(t *testing.T, pg *Postgres, mx map[string]int64) {
+				assert.Equal(t, int64(50+200000), mx["statement_"+q1+"_calls"])
+				assert.Equal(t, int64(200), mx["statement_"+q1+"_mean_time"])
+			},
+		},
+		{
+			// q1 was evicted and added again
+			data: header + row("1664186000", q1, 4, "2.0"),
+			check: func(t *testing.T, pg *Postgres, mx map[string]int64) {
+				assert.Equal(t, int64(50+200000+4), mx["statement_"+q1+"_calls"])
+				assert.Equal(t, int64(500), mx["statement_"+q1+"_mean_time"])
+			},
+		},
+	}
+
+	db, mock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+	)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	pg := New()
+	pg.db = db
+	require.True(t, pg.Init())
+	pg.pgVersion = 140004
+
+	mockExpect(t, mock, queryPGStatStatementsInstalled(), dataV140004PGStatStatementsInstalledTrue)
+	installed, err := pg.doQueryPGStatStatementsInstalled()
+	require.NoError(t, err)
+	require.True(t, installed)
+
+	for i, step := range steps {
+		t.Run(fmt.Sprintf("step[%d]", i), func(t *testing.T) {
+			pg.resetMetrics()
+			mockExpect(t, mock, queryPGStatStatements(pg.pgVersion, pg.MaxStatements), []byte(step.data))
+			require.NoError(t, pg.doQueryStatementsMetrics())
+
+			mx := make(map[string]int64)
+			pg.collectMetrics(mx)
+			step.check(t, pg, mx)
+		})
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgres_Collect_PGStatStatements_QueryLabel(t *testing.T) {
+	db, mock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+	)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	pg := New()
+	pg.db = db
+	require.True(t, pg.Init())
+	pg.pgVersion = 140004
+
+	rows := sqlmock.NewRows([]string{
+		"stats_reset", "queryid", "datname", "query", "calls", "total_time", "max_time",
+		"rows", "shared_blks_hit", "shared_blks_read", "temp_blks_written",
+	}).AddRow(
+		"1664185000", "123", "postgres", "SELECT *\n\tFROM users\r\n WHERE name = 'bob'", "1", "1.0", "1.0",
+		"1", "1", "1", "0",
+	)
+	mock.ExpectQuery(queryPGStatStatements(pg.pgVersion, pg.MaxStatements)).WillReturnRows(rows).RowsWillBeClosed()
+	require.NoError(t, pg.doQueryStatementsMetrics())
+
+	pg.collectMetrics(make(map[string]int64))
+
+	chart := pg.Charts().Get("statement_123_calls")
+	require.NotNil(t, chart)
+	assert.Contains(t, chart.Labels, module.Label{Key: "query", Value: "SELECT * FROM users WHERE name = bob"})
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func mockExpect(t *testing.T, mock sqlmock.Sqlmock, query string, rows []byte) {
 	mock.ExpectQuery(query).WillReturnRows(mustMockRows(t, rows)).RowsWillBeClosed()
 }
@@ -2,6 +2,8 @@
 
 package postgres
 
+import "fmt"
+
 func queryServerVersion() string {
 	return "SHOW server_version_num;"
 }
@@ -745,3 +747,43 @@ ORDER BY nspname,
          st.attname;
 `
 }
+
+func queryPGStatStatementsInstalled() string {
+	return "SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_stat_statements');"
+}
+
+func queryPGStatStatements(version int, limit int64) string {
+	// the execution time columns were renamed in v13
+	// https://www.postgresql.org/docs/current/pgstatstatements.html
+	totalTime, maxTime := "total_exec_time", "max_exec_time"
+	if version < pgVersion13 {
+		totalTime, maxTime = "total_time", "max_time"
+	}
+
+	// the last reset time is available since v14
+	statsReset := "0"
+	if version >= pgVersion14 {
+		statsReset = "(SELECT extract(epoch FROM stats_reset)::bigint FROM pg_stat_statements_info)"
+	}
+
+	// the same query executed by different users or in different databases has multiple entries
+	return fmt.Sprintf(`
+SELECT %s AS stats_reset,
+       s.queryid,
+       min(d.datname)              AS datname,
+       left(min(s.query), %d)      AS query,
+       sum(s.calls)                AS calls,
+       sum(s.%s)                   AS total_time,
+       max(s.%s)                   AS max_time,
+       sum(s.rows)                 AS rows,
+       sum(s.shared_blks_hit)      AS shared_blks_hit,
+       sum(s.shared_blks_read)     AS shared_blks_read,
+       sum(s.temp_blks_written)    AS temp_blks_written
+FROM pg_stat_statements s
+         LEFT JOIN pg_database d ON d.oid = s.dbid
+WHERE s.queryid IS NOT NULL
+GROUP BY s.queryid
+ORDER BY total_time DESC
+LIMIT %d;
+`, statsReset, statementQueryTextMaxLen, totalTime, maxTime, limit)
+}
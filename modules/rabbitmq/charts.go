@@ -31,6 +31,18 @@ const (
 
 	prioQueueMessagesCount
 	prioQueueMessagesRate
+	prioQueueQuorumMembers
+	prioQueueQuorumLeaderStatus
+	prioQueueStreamConsumers
+	prioQueueStreamConsumersOffsetLag
+	prioQueueStreamConsumersMessagesRate
+
+	prioExchangeMessagesRate
+
+	prioConnectionState
+	prioConnectionChannels
+	prioConnectionMessages
+	prioConnectionTraffic
 )
 
 var baseCharts = module.Charts{
@@ -56,6 +68,28 @@ var chartsTmplVhost = module.Charts{
 	chartTmplVhostMessagesRate.Copy(),
 }
 
+var chartsTmplQueueQuorum = module.Charts{
+	chartTmplQueueQuorumMembers.Copy(),
+	chartTmplQueueQuorumLeaderStatus.Copy(),
+}
+
+var chartsTmplQueueStream = module.Charts{
+	chartTmplQueueStreamConsumers.Copy(),
+	chartTmplQueueStreamConsumersOffsetLag.Copy(),
+	chartTmplQueueStreamConsumersMessagesRate.Copy(),
+}
+
+var chartsTmplExchange = module.Charts{
+	chartTmplExchangeMessagesRate.Copy(),
+}
+
+var chartsTmplConnection = module.Charts{
+	chartTmplConnectionState.Copy(),
+	chartTmplConnectionChannels.Copy(),
+	chartTmplConnectionMessages.Copy(),
+	chartTmplConnectionTraffic.Copy(),
+}
+
 var chartsTmplQueue = module.Charts{
 	chartTmplQueueMessagesCount.Copy(),
 	chartTmplQueueMessagesRate.Copy(),
@@ -300,6 +334,143 @@ var (
 	}
 )
 
+var (
+	chartTmplQueueQuorumMembers = module.Chart{
+		ID:       "queue_%s_vhost_%s_quorum_members",
+		Title:    "Quorum queue members",
+		Units:    "members",
+		Fam:      "queue quorum",
+		Ctx:      "rabbitmq.queue_quorum_members",
+		Type:     module.Stacked,
+		Priority: prioQueueQuorumMembers,
+		Dims: module.Dims{
+			{ID: "queue_%s_vhost_%s_quorum_members_online", Name: "online"},
+			{ID: "queue_%s_vhost_%s_quorum_members_offline", Name: "offline"},
+		},
+	}
+	chartTmplQueueQuorumLeaderStatus = module.Chart{
+		ID:       "queue_%s_vhost_%s_quorum_leader_status",
+		Title:    "Quorum queue leader status",
+		Units:    "status",
+		Fam:      "queue quorum",
+		Ctx:      "rabbitmq.queue_quorum_leader_status",
+		Priority: prioQueueQuorumLeaderStatus,
+		Dims: module.Dims{
+			{ID: "queue_%s_vhost_%s_quorum_leader_elected", Name: "elected"},
+			{ID: "queue_%s_vhost_%s_quorum_leader_not_elected", Name: "not_elected"},
+		},
+	}
+)
+
+var (
+	chartTmplQueueStreamConsumers = module.Chart{
+		ID:       "queue_%s_vhost_%s_stream_consumers",
+		Title:    "Stream queue consumers",
+		Units:    "consumers",
+		Fam:      "queue stream",
+		Ctx:      "rabbitmq.queue_stream_consumers",
+		Priority: prioQueueStreamConsumers,
+		Dims: module.Dims{
+			{ID: "queue_%s_vhost_%s_stream_consumers", Name: "consumers"},
+		},
+	}
+	chartTmplQueueStreamConsumersOffsetLag = module.Chart{
+		ID:       "queue_%s_vhost_%s_stream_consumers_offset_lag",
+		Title:    "Stream queue consumers max offset lag",
+		Units:    "offsets",
+		Fam:      "queue stream",
+		Ctx:      "rabbitmq.queue_stream_consumers_offset_lag",
+		Priority: prioQueueStreamConsumersOffsetLag,
+		Dims: module.Dims{
+			{ID: "queue_%s_vhost_%s_stream_consumers_offset_lag_max", Name: "max"},
+		},
+	}
+	chartTmplQueueStreamConsumersMessagesRate = module.Chart{
+		ID:       "queue_%s_vhost_%s_stream_consumers_messages_rate",
+		Title:    "Stream queue consumed messages rate",
+		Units:    "messages/s",
+		Fam:      "queue stream",
+		Ctx:      "rabbitmq.queue_stream_consumers_messages_rate",
+		Priority: prioQueueStreamConsumersMessagesRate,
+		Dims: module.Dims{
+			{ID: "queue_%s_vhost_%s_stream_consumers_messages_consumed", Name: "consumed", Algo: module.Incremental},
+		},
+	}
+)
+
+var (
+	chartTmplExchangeMessagesRate = module.Chart{
+		ID:       "exchange_%s_vhost_%s_message_stats",
+		Title:    "Exchange messages rate",
+		Units:    "messages/s",
+		Fam:      "exchange messages",
+		Ctx:      "rabbitmq.exchange_messages_rate",
+		Priority: prioExchangeMessagesRate,
+		Dims: module.Dims{
+			{ID: "exchange_%s_vhost_%s_message_stats_publish_in", Name: "publish_in", Algo: module.Incremental},
+			{ID: "exchange_%s_vhost_%s_message_stats_publish_out", Name: "publish_out", Algo: module.Incremental},
+		},
+	}
+)
+
+var (
+	chartTmplConnectionState = module.Chart{
+		ID:       "connection_%s_state",
+		Title:    "Connection state",
+		Units:    "state",
+		Fam:      "connections",
+		Ctx:      "rabbitmq.connection_state",
+		Priority: prioConnectionState,
+		Dims: module.Dims{
+			{ID: "connection_%s_state_starting", Name: "starting"},
+			{ID: "connection_%s_state_tuning", Name: "tuning"},
+			{ID: "connection_%s_state_opening", Name: "opening"},
+			{ID: "connection_%s_state_running", Name: "running"},
+			{ID: "connection_%s_state_flow", Name: "flow"},
+			{ID: "connection_%s_state_blocking", Name: "blocking"},
+			{ID: "connection_%s_state_blocked", Name: "blocked"},
+			{ID: "connection_%s_state_closing", Name: "closing"},
+			{ID: "connection_%s_state_closed", Name: "closed"},
+		},
+	}
+	chartTmplConnectionChannels = module.Chart{
+		ID:       "connection_%s_channels",
+		Title:    "Connection channels",
+		Units:    "channels",
+		Fam:      "connections",
+		Ctx:      "rabbitmq.connection_channels",
+		Priority: prioConnectionChannels,
+		Dims: module.Dims{
+			{ID: "connection_%s_channels", Name: "channels"},
+		},
+	}
+	chartTmplConnectionMessages = module.Chart{
+		ID:       "connection_%s_messages",
+		Title:    "Connection channels pending messages",
+		Units:    "messages",
+		Fam:      "connections",
+		Ctx:      "rabbitmq.connection_messages",
+		Priority: prioConnectionMessages,
+		Dims: module.Dims{
+			{ID: "connection_%s_messages_unacknowledged", Name: "unacknowledged"},
+			{ID: "connection_%s_messages_unconfirmed", Name: "unconfirmed"},
+		},
+	}
+	chartTmplConnectionTraffic = module.Chart{
+		ID:       "connection_%s_traffic",
+		Title:    "Connection traffic",
+		Units:    "bytes/s",
+		Fam:      "connections",
+		Ctx:      "rabbitmq.connection_traffic",
+		Type:     module.Area,
+		Priority: prioConnectionTraffic,
+		Dims: module.Dims{
+			{ID: "connection_%s_recv_oct", Name: "received", Algo: module.Incremental},
+			{ID: "connection_%s_send_oct", Name: "sent", Algo: module.Incremental, Mul: -1},
+		},
+	}
+)
+
 func (r *RabbitMQ) addVhostCharts(name string) {
 	charts := chartsTmplVhost.Copy()
 
@@ -328,8 +499,23 @@ func (r *RabbitMQ) removeVhostCharts(vhost string) {
 	}
 }
 
-func (r *RabbitMQ) addQueueCharts(queue, vhost string) {
+func (r *RabbitMQ) addQueueCharts(queue, vhost, typ string) {
 	charts := chartsTmplQueue.Copy()
+	switch typ {
+	case queueTypeQuorum:
+		if err := charts.Add(*chartsTmplQueueQuorum.Copy()...); err != nil {
+			r.Warning(err)
+		}
+	case queueTypeStream:
+		tmpl := chartsTmplQueueStream.Copy()
+		if r.noStreamConsumersStats {
+			_ = tmpl.Remove(chartTmplQueueStreamConsumersOffsetLag.ID)
+			_ = tmpl.Remove(chartTmplQueueStreamConsumersMessagesRate.ID)
+		}
+		if err := charts.Add(*tmpl...); err != nil {
+			r.Warning(err)
+		}
+	}
 
 	for _, chart := range *charts {
 		chart.ID = fmt.Sprintf(chart.ID, forbiddenCharsReplacer.Replace(queue), forbiddenCharsReplacer.Replace(vhost))
@@ -357,4 +543,65 @@ func (r *RabbitMQ) removeQueueCharts(queue, vhost string) {
 	}
 }
 
+func (r *RabbitMQ) addExchangeCharts(exchange, vhost, typ string) {
+	charts := chartsTmplExchange.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, forbiddenCharsReplacer.Replace(exchange), forbiddenCharsReplacer.Replace(vhost))
+		chart.Labels = []module.Label{
+			{Key: "exchange", Value: exchange},
+			{Key: "exchange_type", Value: typ},
+			{Key: "vhost", Value: vhost},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, exchange, vhost)
+		}
+	}
+
+	if err := r.Charts().Add(*charts...); err != nil {
+		r.Warning(err)
+	}
+}
+
+func (r *RabbitMQ) removeExchangeCharts(exchange, vhost string) {
+	px := fmt.Sprintf("exchange_%s_vhost_%s_", forbiddenCharsReplacer.Replace(exchange), forbiddenCharsReplacer.Replace(vhost))
+	for _, chart := range *r.Charts() {
+		if strings.HasPrefix(chart.ID, px) {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
+
+func (r *RabbitMQ) addConnectionCharts(id string, conn connectionStats) {
+	charts := chartsTmplConnection.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, id)
+		chart.Labels = []module.Label{
+			{Key: "connection", Value: conn.Name},
+			{Key: "vhost", Value: conn.Vhost},
+			{Key: "user", Value: conn.User},
+			{Key: "node", Value: conn.Node},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, id)
+		}
+	}
+
+	if err := r.Charts().Add(*charts...); err != nil {
+		r.Warning(err)
+	}
+}
+
+func (r *RabbitMQ) removeConnectionCharts(id string) {
+	px := fmt.Sprintf("connection_%s_", id)
+	for _, chart := range *r.Charts() {
+		if strings.HasPrefix(chart.ID, px) {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
+
 var forbiddenCharsReplacer = strings.NewReplacer(" ", "_", ".", "_")
@@ -4,10 +4,12 @@ package rabbitmq
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"path/filepath"
+	"strings"
 
 	"github.com/netdata/go.d.plugin/pkg/stm"
 	"github.com/netdata/go.d.plugin/pkg/web"
@@ -18,11 +20,20 @@ const (
 	urlPathAPINodes    = "/api/nodes/"
 	urlPathAPIVhosts   = "/api/vhosts"
 	urlPathAPIQueues   = "/api/queues"
+
+	urlPathAPIExchanges       = "/api/exchanges"
+	urlPathAPIConnections     = "/api/connections"
+	urlPathAPIChannels        = "/api/channels"
+	urlPathAPIStreamConsumers = "/api/stream/consumers"
 )
 
-// TODO: there is built-in prometheus collector since v3.8.0 (https://www.rabbitmq.com/prometheus.html).
-// Should use it (in addition?), it is the recommended option  according to the docs.
+var errNotFound = errors.New("not found")
+
 func (r *RabbitMQ) collect() (map[string]int64, error) {
+	if r.prom != nil {
+		return r.collectPrometheus()
+	}
+
 	mx := make(map[string]int64)
 
 	if err := r.collectOverviewStats(mx); err != nil {
@@ -39,6 +50,16 @@ func (r *RabbitMQ) collect() (map[string]int64, error) {
 			return mx, err
 		}
 	}
+	if r.CollectExchanges {
+		if err := r.collectExchangesStats(mx); err != nil {
+			return mx, err
+		}
+	}
+	if r.CollectConnections {
+		if err := r.collectConnectionsStats(mx); err != nil {
+			return mx, err
+		}
+	}
 
 	return mx, nil
 }
@@ -118,19 +139,54 @@ func (r *RabbitMQ) collectQueuesStats(mx map[string]int64) error {
 	}
 
 	seen := make(map[string]queueCache)
+	var hasStreams bool
 
 	for _, queue := range stats {
-		seen[queue.Name+"|"+queue.Vhost] = queueCache{name: queue.Name, vhost: queue.Vhost}
+		if !r.matchVhost(queue.Vhost) || (r.queueSr != nil && !r.queueSr.MatchString(queue.Name)) {
+			continue
+		}
+
+		seen[queue.Name+"|"+queue.Vhost] = queueCache{name: queue.Name, vhost: queue.Vhost, typ: queue.Type}
 		for k, v := range stm.ToMap(queue) {
 			mx[fmt.Sprintf("queue_%s_vhost_%s_%s", queue.Name, queue.Vhost, k)] = v
 		}
+
+		px := fmt.Sprintf("queue_%s_vhost_%s_", queue.Name, queue.Vhost)
+
+		switch queue.Type {
+		case queueTypeQuorum:
+			online := make(map[string]bool)
+			for _, v := range queue.Online {
+				online[v] = true
+			}
+			mx[px+"quorum_members_online"] = 0
+			mx[px+"quorum_members_offline"] = 0
+			for _, v := range queue.Members {
+				if online[v] {
+					mx[px+"quorum_members_online"]++
+				} else {
+					mx[px+"quorum_members_offline"]++
+				}
+			}
+			mx[px+"quorum_leader_elected"] = boolToInt(queue.Leader != "")
+			mx[px+"quorum_leader_not_elected"] = boolToInt(queue.Leader == "")
+		case queueTypeStream:
+			hasStreams = true
+			mx[px+"stream_consumers"] = queue.Consumers
+		}
+	}
+
+	if hasStreams && !r.noStreamConsumersStats {
+		if err := r.collectStreamConsumersStats(mx, seen); err != nil {
+			return err
+		}
 	}
 
 	for key, queue := range seen {
 		if _, ok := r.queues[key]; !ok {
 			r.queues[key] = queue
-			r.Debugf("new queue name='%s', vhost='%s': creating charts", queue.name, queue.vhost)
-			r.addQueueCharts(queue.name, queue.vhost)
+			r.Debugf("new queue name='%s', vhost='%s', type='%s': creating charts", queue.name, queue.vhost, queue.typ)
+			r.addQueueCharts(queue.name, queue.vhost, queue.typ)
 		}
 	}
 	for key, queue := range r.queues {
@@ -144,6 +200,165 @@ func (r *RabbitMQ) collectQueuesStats(mx map[string]int64) error {
 	return nil
 }
 
+func (r *RabbitMQ) collectStreamConsumersStats(mx map[string]int64, queues map[string]queueCache) error {
+	var stats []streamConsumerStats
+	if err := r.doOKDecode(urlPathAPIStreamConsumers, &stats); err != nil {
+		if !errors.Is(err, errNotFound) {
+			return err
+		}
+		r.Warningf("stream consumers statistics are not available (the 'rabbitmq_stream_management' plugin is not enabled?), disabling them: %v", err)
+		r.noStreamConsumersStats = true
+		return nil
+	}
+
+	for _, queue := range queues {
+		if queue.typ != queueTypeStream {
+			continue
+		}
+		px := fmt.Sprintf("queue_%s_vhost_%s_", queue.name, queue.vhost)
+		mx[px+"stream_consumers_offset_lag_max"] = 0
+		mx[px+"stream_consumers_messages_consumed"] = 0
+	}
+
+	for _, consumer := range stats {
+		if _, ok := queues[consumer.Queue.Name+"|"+consumer.Queue.Vhost]; !ok {
+			continue
+		}
+		px := fmt.Sprintf("queue_%s_vhost_%s_", consumer.Queue.Name, consumer.Queue.Vhost)
+		if consumer.OffsetLag > mx[px+"stream_consumers_offset_lag_max"] {
+			mx[px+"stream_consumers_offset_lag_max"] = consumer.OffsetLag
+		}
+		mx[px+"stream_consumers_messages_consumed"] += consumer.MessagesConsumed
+	}
+
+	return nil
+}
+
+func (r *RabbitMQ) collectExchangesStats(mx map[string]int64) error {
+	var stats []exchangeStats
+	if err := r.doOKDecode(urlPathAPIExchanges, &stats); err != nil {
+		return err
+	}
+
+	seen := make(map[string]exchangeCache)
+
+	for _, exchange := range stats {
+		// the default exchange has no name
+		if exchange.Name == "" {
+			exchange.Name = "amq.default"
+		}
+		if !r.matchVhost(exchange.Vhost) || (r.exchangeSr != nil && !r.exchangeSr.MatchString(exchange.Name)) {
+			continue
+		}
+
+		key := exchange.Name + "|" + exchange.Vhost
+		if _, ok := r.exchanges[key]; !ok {
+			r.exchanges[key] = exchangeCache{name: exchange.Name, vhost: exchange.Vhost}
+			r.Debugf("new exchange name='%s', vhost='%s': creating charts", exchange.Name, exchange.Vhost)
+			r.addExchangeCharts(exchange.Name, exchange.Vhost, exchange.Type)
+		}
+		seen[key] = r.exchanges[key]
+
+		for k, v := range stm.ToMap(exchange) {
+			mx[fmt.Sprintf("exchange_%s_vhost_%s_%s", exchange.Name, exchange.Vhost, k)] = v
+		}
+	}
+
+	for key, exchange := range r.exchanges {
+		if _, ok := seen[key]; !ok {
+			delete(r.exchanges, key)
+			r.Debugf("stale exchange name='%s', vhost='%s': removing charts", exchange.name, exchange.vhost)
+			r.removeExchangeCharts(exchange.name, exchange.vhost)
+		}
+	}
+
+	return nil
+}
+
+func (r *RabbitMQ) collectConnectionsStats(mx map[string]int64) error {
+	var conns []connectionStats
+	if err := r.doOKDecode(urlPathAPIConnections, &conns); err != nil {
+		return err
+	}
+	var channels []channelStats
+	if err := r.doOKDecode(urlPathAPIChannels, &channels); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+
+	for _, conn := range conns {
+		if !r.matchVhost(conn.Vhost) {
+			continue
+		}
+
+		id := connectionID(conn.Name)
+		seen[id] = true
+		if !r.connections[id] {
+			r.connections[id] = true
+			r.Debugf("new connection name='%s': creating charts", conn.Name)
+			r.addConnectionCharts(id, conn)
+		}
+
+		px := "connection_" + id + "_"
+		for _, v := range connectionStates {
+			mx[px+"state_"+v] = 0
+		}
+		mx[px+"state_"+conn.State] = 1
+		mx[px+"channels"] = conn.Channels
+		mx[px+"recv_oct"] = conn.RecvOct
+		mx[px+"send_oct"] = conn.SendOct
+		mx[px+"messages_unacknowledged"] = 0
+		mx[px+"messages_unconfirmed"] = 0
+	}
+
+	for _, ch := range channels {
+		id := connectionID(ch.ConnectionDetails.Name)
+		if !seen[id] {
+			continue
+		}
+		px := "connection_" + id + "_"
+		mx[px+"messages_unacknowledged"] += ch.MessagesUnacknowledged
+		mx[px+"messages_unconfirmed"] += ch.MessagesUnconfirmed
+	}
+
+	for id := range r.connections {
+		if !seen[id] {
+			delete(r.connections, id)
+			r.Debugf("stale connection '%s': removing charts", id)
+			r.removeConnectionCharts(id)
+		}
+	}
+
+	return nil
+}
+
+func (r *RabbitMQ) matchVhost(name string) bool {
+	return r.vhostSr == nil || r.vhostSr.MatchString(name)
+}
+
+const (
+	queueTypeQuorum = "quorum"
+	queueTypeStream = "stream"
+)
+
+// https://www.rabbitmq.com/connections.html#monitoring
+var connectionStates = []string{"starting", "tuning", "opening", "running", "flow", "blocking", "blocked", "closing", "closed"}
+
+var connectionIDReplacer = strings.NewReplacer(" -> ", "_", " ", "_", ".", "_", ":", "_", "[", "", "]", "")
+
+func connectionID(name string) string {
+	// "127.0.0.1:49826 -> 127.0.0.1:5672"
+	return connectionIDReplacer.Replace(name)
+}
+
+func boolToInt(v bool) int64 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
 func (r *RabbitMQ) doOKDecode(urlPath string, in interface{}) error {
 	req, err := web.NewHTTPRequest(r.Request.Copy())
 	if err != nil {
@@ -160,6 +375,9 @@ func (r *RabbitMQ) doOKDecode(urlPath string, in interface{}) error {
 
 	defer closeBody(resp)
 
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%s returned HTTP status %d (%s): %w", req.URL, resp.StatusCode, resp.Status, errNotFound)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("%s returned HTTP status %d (%s)", req.URL, resp.StatusCode, resp.Status)
 	}
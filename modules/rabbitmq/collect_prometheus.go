@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package rabbitmq
+
+import (
+	"errors"
+
+	"github.com/netdata/go.d.plugin/pkg/prometheus"
+
+	"github.com/prometheus/prometheus/model/textparse"
+)
+
+// https://github.com/rabbitmq/rabbitmq-server/blob/main/deps/rabbitmq_prometheus/metrics.md
+// The metrics are node-local. If the per-object metrics are enabled, they are summed up.
+var prometheusMetrics = map[string][]string{
+	"rabbitmq_queue_messages_ready":   {"queue_totals_messages_ready"},
+	"rabbitmq_queue_messages_unacked": {"queue_totals_messages_unacknowledged"},
+	"rabbitmq_queue_messages":         {"queue_totals_messages"},
+
+	"rabbitmq_connections": {"object_totals_connections"},
+	"rabbitmq_channels":    {"object_totals_channels"},
+	"rabbitmq_consumers":   {"object_totals_consumers"},
+	"rabbitmq_queues":      {"object_totals_queues"},
+
+	"rabbitmq_connections_opened_total": {"churn_rates_connection_created"},
+	"rabbitmq_connections_closed_total": {"churn_rates_connection_closed"},
+	"rabbitmq_channels_opened_total":    {"churn_rates_channel_created"},
+	"rabbitmq_channels_closed_total":    {"churn_rates_channel_closed"},
+	"rabbitmq_queues_created_total":     {"churn_rates_queue_created"},
+	"rabbitmq_queues_declared_total":    {"churn_rates_queue_declared"},
+	"rabbitmq_queues_deleted_total":     {"churn_rates_queue_deleted"},
+
+	"rabbitmq_global_messages_received_total":                     {"message_stats_publish", "message_stats_publish_in"},
+	"rabbitmq_global_messages_routed_total":                       {"message_stats_publish_out"},
+	"rabbitmq_global_messages_acknowledged_total":                 {"message_stats_ack"},
+	"rabbitmq_global_messages_confirmed_total":                    {"message_stats_confirm"},
+	"rabbitmq_global_messages_delivered_total":                    {"message_stats_deliver_get"},
+	"rabbitmq_global_messages_delivered_consume_manual_ack_total": {"message_stats_deliver"},
+	"rabbitmq_global_messages_delivered_consume_auto_ack_total":   {"message_stats_deliver_no_ack"},
+	"rabbitmq_global_messages_delivered_get_manual_ack_total":     {"message_stats_get"},
+	"rabbitmq_global_messages_delivered_get_auto_ack_total":       {"message_stats_get_no_ack"},
+	"rabbitmq_global_messages_redelivered_total":                  {"message_stats_redeliver"},
+	"rabbitmq_global_messages_unroutable_returned_total":          {"message_stats_return_unroutable"},
+
+	"rabbitmq_process_open_fds":              {"fd_used"},
+	"rabbitmq_process_max_fds":               {"fd_total"},
+	"rabbitmq_process_open_tcp_sockets":      {"sockets_used"},
+	"rabbitmq_process_max_tcp_sockets":       {"sockets_total"},
+	"rabbitmq_erlang_processes_used":         {"proc_used"},
+	"rabbitmq_erlang_processes_limit":        {"proc_total"},
+	"rabbitmq_erlang_scheduler_run_queue":    {"run_queue"},
+	"rabbitmq_process_resident_memory_bytes": {"mem_used"},
+	"rabbitmq_resident_memory_limit_bytes":   {"mem_limit"},
+	"rabbitmq_disk_space_available_bytes":    {"disk_free"},
+}
+
+func (r *RabbitMQ) collectPrometheus() (map[string]int64, error) {
+	mfs, err := r.prom.Scrape()
+	if err != nil {
+		return nil, err
+	}
+
+	mx := make(map[string]int64)
+
+	for name, keys := range prometheusMetrics {
+		mf := mfs.Get(name)
+		if mf == nil {
+			continue
+		}
+		v := int64(sumMetricFamily(mf))
+		for _, key := range keys {
+			mx[key] = v
+		}
+	}
+
+	if len(mx) == 0 {
+		return nil, errors.New("no RabbitMQ metrics found in the response (is it the 'rabbitmq_prometheus' plugin endpoint?)")
+	}
+
+	if _, ok := mx["proc_total"]; ok {
+		mx["proc_available"] = mx["proc_total"] - mx["proc_used"]
+	}
+
+	return mx, nil
+}
+
+func sumMetricFamily(mf *prometheus.MetricFamily) float64 {
+	var sum float64
+	for _, m := range mf.Metrics() {
+		switch mf.Type() {
+		case textparse.MetricTypeGauge:
+			sum += m.Gauge().Value()
+		case textparse.MetricTypeCounter:
+			sum += m.Counter().Value()
+		case textparse.MetricTypeUnknown:
+			sum += m.Untyped().Value()
+		}
+	}
+	return sum
+}
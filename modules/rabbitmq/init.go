@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package rabbitmq
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/netdata/go.d.plugin/pkg/matcher"
+	"github.com/netdata/go.d.plugin/pkg/prometheus"
+)
+
+const (
+	sourceManagement = "management"
+	sourcePrometheus = "prometheus"
+)
+
+func (r *RabbitMQ) validateConfig() error {
+	switch r.Source {
+	case "":
+		r.Source = sourceManagement
+	case sourceManagement, sourcePrometheus:
+	default:
+		return fmt.Errorf("unknown source '%s' (supported: '%s', '%s')", r.Source, sourceManagement, sourcePrometheus)
+	}
+
+	if r.Source == sourcePrometheus {
+		// the per-object metrics are collected only from the management plugin API
+		var opts []string
+		for _, v := range []struct {
+			set  bool
+			name string
+		}{
+			{set: r.CollectQueues, name: "collect_queues_metrics"},
+			{set: r.CollectExchanges, name: "collect_exchanges_metrics"},
+			{set: r.CollectConnections, name: "collect_connections_metrics"},
+			{set: !r.Vhosts.Empty(), name: "vhosts"},
+			{set: !r.Queues.Empty(), name: "queues"},
+			{set: !r.Exchanges.Empty(), name: "exchanges"},
+		} {
+			if v.set {
+				opts = append(opts, v.name)
+			}
+		}
+		if len(opts) > 0 {
+			return fmt.Errorf("'%s' not supported with the '%s' source", strings.Join(opts, "', '"), sourcePrometheus)
+		}
+	}
+
+	return nil
+}
+
+func (r *RabbitMQ) initSelectors() error {
+	for _, v := range []struct {
+		expr matcher.SimpleExpr
+		sr   *matcher.Matcher
+		name string
+	}{
+		{expr: r.Vhosts, sr: &r.vhostSr, name: "vhosts"},
+		{expr: r.Queues, sr: &r.queueSr, name: "queues"},
+		{expr: r.Exchanges, sr: &r.exchangeSr, name: "exchanges"},
+	} {
+		if v.expr.Empty() {
+			continue
+		}
+		sr, err := v.expr.Parse()
+		if err != nil {
+			return fmt.Errorf("'%s' selector: %v", v.name, err)
+		}
+		*v.sr = sr
+	}
+	return nil
+}
+
+func (r *RabbitMQ) initPrometheusSource() {
+	r.prom = prometheus.New(r.httpClient, r.Request)
+
+	// the number of exchanges is not exposed by the 'rabbitmq_prometheus' plugin
+	if chart := r.charts.Get(chartObjectsCount.ID); chart != nil {
+		_ = chart.RemoveDim("object_totals_exchanges")
+	}
+}
@@ -63,6 +63,52 @@ type queueStats struct {
 	MessagesPagedOut       int64        `json:"messages_paged_out" stm:"messages_paged_out"`
 	MessagesPersistent     int64        `json:"messages_persistent" stm:"messages_persistent"`
 	MessageStats           messageStats `json:"message_stats" stm:"message_stats"`
+	Consumers              int64        `json:"consumers"`
+	// quorum and stream queues only
+	Leader  string   `json:"leader"`
+	Members []string `json:"members"`
+	Online  []string `json:"online"`
+}
+
+type exchangeStats struct {
+	Name         string `json:"name"`
+	Vhost        string `json:"vhost"`
+	Type         string `json:"type"`
+	MessageStats struct {
+		PublishIn  int64 `json:"publish_in" stm:"publish_in"`
+		PublishOut int64 `json:"publish_out" stm:"publish_out"`
+	} `json:"message_stats" stm:"message_stats"`
+}
+
+type connectionStats struct {
+	Name     string `json:"name"`
+	Vhost    string `json:"vhost"`
+	User     string `json:"user"`
+	Node     string `json:"node"`
+	State    string `json:"state"`
+	Channels int64  `json:"channels"`
+	RecvOct  int64  `json:"recv_oct"`
+	SendOct  int64  `json:"send_oct"`
+}
+
+type channelStats struct {
+	Name              string `json:"name"`
+	ConnectionDetails struct {
+		Name string `json:"name"`
+	} `json:"connection_details"`
+	MessagesUnacknowledged int64 `json:"messages_unacknowledged"`
+	MessagesUnconfirmed    int64 `json:"messages_unconfirmed"`
+}
+
+// https://github.com/rabbitmq/rabbitmq-server/tree/main/deps/rabbitmq_stream_management
+type streamConsumerStats struct {
+	Queue struct {
+		Name  string `json:"name"`
+		Vhost string `json:"vhost"`
+	} `json:"queue"`
+	Offset           int64 `json:"offset"`
+	OffsetLag        int64 `json:"offset_lag"`
+	MessagesConsumed int64 `json:"messages_consumed"`
 }
 
 // https://rawcdn.githack.com/rabbitmq/rabbitmq-server/v3.11.5/deps/rabbitmq_management/priv/www/api/index.html
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/matcher"
+	"github.com/netdata/go.d.plugin/pkg/prometheus"
 	"github.com/netdata/go.d.plugin/pkg/web"
 )
 
@@ -29,17 +31,28 @@ func New() *RabbitMQ {
 					Timeout: web.Duration{Duration: time.Second},
 				},
 			},
-			CollectQueues: false,
+			Source:             sourceManagement,
+			CollectQueues:      false,
+			CollectExchanges:   false,
+			CollectConnections: false,
 		},
-		charts: baseCharts.Copy(),
-		vhosts: make(map[string]bool),
-		queues: make(map[string]queueCache),
+		charts:      baseCharts.Copy(),
+		vhosts:      make(map[string]bool),
+		queues:      make(map[string]queueCache),
+		exchanges:   make(map[string]exchangeCache),
+		connections: make(map[string]bool),
 	}
 }
 
 type Config struct {
-	web.HTTP      `yaml:",inline"`
-	CollectQueues bool `yaml:"collect_queues_metrics"`
+	web.HTTP           `yaml:",inline"`
+	Source             string             `yaml:"source"`
+	CollectQueues      bool               `yaml:"collect_queues_metrics"`
+	CollectExchanges   bool               `yaml:"collect_exchanges_metrics"`
+	CollectConnections bool               `yaml:"collect_connections_metrics"`
+	Vhosts             matcher.SimpleExpr `yaml:"vhosts"`
+	Queues             matcher.SimpleExpr `yaml:"queues"`
+	Exchanges          matcher.SimpleExpr `yaml:"exchanges"`
 }
 
 type (
@@ -50,13 +63,25 @@ type (
 		charts *module.Charts
 
 		httpClient *http.Client
+		prom       prometheus.Prometheus
+
+		vhostSr    matcher.Matcher
+		queueSr    matcher.Matcher
+		exchangeSr matcher.Matcher
 
 		nodeName string
 
-		vhosts map[string]bool
-		queues map[string]queueCache
+		noStreamConsumersStats bool
+
+		vhosts      map[string]bool
+		queues      map[string]queueCache
+		exchanges   map[string]exchangeCache
+		connections map[string]bool
 	}
 	queueCache struct {
+		name, vhost, typ string
+	}
+	exchangeCache struct {
 		name, vhost string
 	}
 )
@@ -67,6 +92,11 @@ func (r *RabbitMQ) Init() bool {
 		return false
 	}
 
+	if err := r.validateConfig(); err != nil {
+		r.Errorf("config validation: %v", err)
+		return false
+	}
+
 	client, err := web.NewHTTPClient(r.Client)
 	if err != nil {
 		r.Errorf("init HTTP client: %v", err)
@@ -74,6 +104,15 @@ func (r *RabbitMQ) Init() bool {
 	}
 	r.httpClient = client
 
+	if err := r.initSelectors(); err != nil {
+		r.Errorf("init selectors: %v", err)
+		return false
+	}
+
+	if r.Source == sourcePrometheus {
+		r.initPrometheusSource()
+	}
+
 	r.Debugf("using URL %s", r.URL)
 	r.Debugf("using timeout: %s", r.Timeout.Duration)
 
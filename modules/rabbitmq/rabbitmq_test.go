@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/netdata/go.d.plugin/pkg/matcher"
 	"github.com/netdata/go.d.plugin/pkg/web"
 )
 
@@ -20,6 +21,13 @@ var (
 	testNodeStats, _     = os.ReadFile("testdata/v3.11.5/api-nodes-node.json")
 	testVhostsStats, _   = os.ReadFile("testdata/v3.11.5/api-vhosts.json")
 	testQueuesStats, _   = os.ReadFile("testdata/v3.11.5/api-queues.json")
+
+	testQueuesQuorumStreamStats, _ = os.ReadFile("testdata/v3.11.5/api-queues-quorum-stream.json")
+	testStreamConsumersStats, _    = os.ReadFile("testdata/v3.11.5/api-stream-consumers.json")
+	testExchangesStats, _          = os.ReadFile("testdata/v3.11.5/api-exchanges.json")
+	testConnectionsStats, _        = os.ReadFile("testdata/v3.11.5/api-connections.json")
+	testChannelsStats, _           = os.ReadFile("testdata/v3.11.5/api-channels.json")
+	testPrometheusMetrics, _       = os.ReadFile("testdata/v3.11.5/metrics.txt")
 )
 
 func Test_testDataIsValid(t *testing.T) {
@@ -28,6 +36,13 @@ func Test_testDataIsValid(t *testing.T) {
 		"testNodeStats":     testNodeStats,
 		"testVhostsStats":   testVhostsStats,
 		"testQueuesStats":   testQueuesStats,
+
+		"testQueuesQuorumStreamStats": testQueuesQuorumStreamStats,
+		"testStreamConsumersStats":    testStreamConsumersStats,
+		"testExchangesStats":          testExchangesStats,
+		"testConnectionsStats":        testConnectionsStats,
+		"testChannelsStats":           testChannelsStats,
+		"testPrometheusMetrics":       testPrometheusMetrics,
 	} {
 		require.NotNilf(t, data, name)
 	}
@@ -42,6 +57,53 @@ func TestRabbitMQ_Init(t *testing.T) {
 			wantFail: false,
 			config:   New().Config,
 		},
+		"success with prometheus source": {
+			wantFail: false,
+			config: Config{
+				HTTP: web.HTTP{
+					Request: web.Request{URL: "http://127.0.0.1:15692/metrics"},
+				},
+				Source: sourcePrometheus,
+			},
+		},
+		"fail on per-object options with prometheus source": {
+			wantFail: true,
+			config: Config{
+				HTTP: web.HTTP{
+					Request: web.Request{URL: "http://127.0.0.1:15692/metrics"},
+				},
+				Source:        sourcePrometheus,
+				CollectQueues: true,
+			},
+		},
+		"fail on selectors with prometheus source": {
+			wantFail: true,
+			config: Config{
+				HTTP: web.HTTP{
+					Request: web.Request{URL: "http://127.0.0.1:15692/metrics"},
+				},
+				Source: sourcePrometheus,
+				Vhosts: matcher.SimpleExpr{Includes: []string{"* *"}},
+			},
+		},
+		"fail on unknown source": {
+			wantFail: true,
+			config: Config{
+				HTTP: web.HTTP{
+					Request: web.Request{URL: "http://127.0.0.1:15672"},
+				},
+				Source: "amqp",
+			},
+		},
+		"fail on invalid selector": {
+			wantFail: true,
+			config: Config{
+				HTTP: web.HTTP{
+					Request: web.Request{URL: "http://127.0.0.1:15672"},
+				},
+				Queues: matcher.SimpleExpr{Includes: []string{"~ ("}},
+			},
+		},
 		"fail when URL not set": {
 			wantFail: true,
 			config: Config{
@@ -295,6 +357,203 @@ func TestRabbitMQ_Collect(t *testing.T) {
 	}
 }
 
+func TestRabbitMQ_Collect_QuorumStreamQueuesExchangesConnections(t *testing.T) {
+	tests := map[string]struct {
+		noStreamManagement bool
+		wantCollected      map[string]int64
+		wantCharts         int
+	}{
+		"with stream management plugin": {
+			wantCharts: len(baseCharts) +
+				len(chartsTmplVhost)*3 +
+				len(chartsTmplQueue)*3 +
+				len(chartsTmplQueueQuorum) +
+				len(chartsTmplQueueStream) +
+				len(chartsTmplExchange)*2 +
+				len(chartsTmplConnection)*2,
+			wantCollected: map[string]int64{
+				"queue_myQuorumQueue_vhost_myFirstVhost_messages":                         5,
+				"queue_myQuorumQueue_vhost_myFirstVhost_quorum_members_online":            2,
+				"queue_myQuorumQueue_vhost_myFirstVhost_quorum_members_offline":           1,
+				"queue_myQuorumQueue_vhost_myFirstVhost_quorum_leader_elected":            1,
+				"queue_myQuorumQueue_vhost_myFirstVhost_quorum_leader_not_elected":        0,
+				"queue_myStream_vhost_myFirstVhost_messages":                              1000,
+				"queue_myStream_vhost_myFirstVhost_stream_consumers":                      2,
+				"queue_myStream_vhost_myFirstVhost_stream_consumers_offset_lag_max":       400,
+				"queue_myStream_vhost_myFirstVhost_stream_consumers_messages_consumed":    1550,
+				"queue_myClassicQueue_vhost_mySecondVhost_messages":                       1,
+				"exchange_myFirstExchange_vhost_myFirstVhost_message_stats_publish_in":    42,
+				"exchange_myFirstExchange_vhost_myFirstVhost_message_stats_publish_out":   40,
+				"exchange_mySecondExchange_vhost_mySecondVhost_message_stats_publish_in":  7,
+				"exchange_mySecondExchange_vhost_mySecondVhost_message_stats_publish_out": 7,
+				"connection_172_17_0_1_49826_172_17_0_2_5672_state_running":               1,
+				"connection_172_17_0_1_49826_172_17_0_2_5672_state_blocked":               0,
+				"connection_172_17_0_1_49826_172_17_0_2_5672_channels":                    2,
+				"connection_172_17_0_1_49826_172_17_0_2_5672_messages_unacknowledged":     5,
+				"connection_172_17_0_1_49826_172_17_0_2_5672_messages_unconfirmed":        1,
+				"connection_172_17_0_1_49826_172_17_0_2_5672_recv_oct":                    24561,
+				"connection_172_17_0_1_49826_172_17_0_2_5672_send_oct":                    12034,
+				"connection_172_17_0_1_49830_172_17_0_2_5672_state_running":               0,
+				"connection_172_17_0_1_49830_172_17_0_2_5672_state_blocked":               1,
+				"connection_172_17_0_1_49830_172_17_0_2_5672_channels":                    1,
+				"connection_172_17_0_1_49830_172_17_0_2_5672_messages_unacknowledged":     0,
+				"connection_172_17_0_1_49830_172_17_0_2_5672_messages_unconfirmed":        0,
+			},
+		},
+		"without stream management plugin": {
+			noStreamManagement: true,
+			wantCharts: len(baseCharts) +
+				len(chartsTmplVhost)*3 +
+				len(chartsTmplQueue)*3 +
+				len(chartsTmplQueueQuorum) +
+				1 +
+				len(chartsTmplExchange)*2 +
+				len(chartsTmplConnection)*2,
+			wantCollected: map[string]int64{
+				"queue_myStream_vhost_myFirstVhost_messages":         1000,
+				"queue_myStream_vhost_myFirstVhost_stream_consumers": 2,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					switch r.URL.Path {
+					case urlPathAPIOverview:
+						_, _ = w.Write(testOverviewStats)
+					case filepath.Join(urlPathAPINodes, "rabbit@localhost"):
+						_, _ = w.Write(testNodeStats)
+					case urlPathAPIVhosts:
+						_, _ = w.Write(testVhostsStats)
+					case urlPathAPIQueues:
+						_, _ = w.Write(testQueuesQuorumStreamStats)
+					case urlPathAPIExchanges:
+						_, _ = w.Write(testExchangesStats)
+					case urlPathAPIConnections:
+						_, _ = w.Write(testConnectionsStats)
+					case urlPathAPIChannels:
+						_, _ = w.Write(testChannelsStats)
+					case urlPathAPIStreamConsumers:
+						if test.noStreamManagement {
+							w.WriteHeader(http.StatusNotFound)
+							return
+						}
+						_, _ = w.Write(testStreamConsumersStats)
+					default:
+						w.WriteHeader(http.StatusNotFound)
+					}
+				}))
+			defer srv.Close()
+
+			rabbit := New()
+			rabbit.URL = srv.URL
+			rabbit.CollectQueues = true
+			rabbit.CollectExchanges = true
+			rabbit.CollectConnections = true
+			rabbit.Vhosts = matcher.SimpleExpr{Excludes: []string{"= /"}}
+			rabbit.Exchanges = matcher.SimpleExpr{Includes: []string{"* my*"}}
+			require.True(t, rabbit.Init())
+
+			mx := rabbit.Collect()
+			require.NotNil(t, mx)
+
+			for k, v := range test.wantCollected {
+				got, ok := mx[k]
+				if assert.Truef(t, ok, "metric '%s' is not collected", k) {
+					assert.Equalf(t, v, got, "metric '%s'", k)
+				}
+			}
+			for k := range mx {
+				assert.NotContainsf(t, k, "amq_", "metric '%s' belongs to a filtered out exchange", k)
+				assert.NotContainsf(t, k, "amq.", "metric '%s' belongs to a filtered out exchange", k)
+			}
+			assert.Equal(t, test.wantCharts, len(*rabbit.Charts()))
+			ensureCollectedHasAllChartsDimsVarsIDs(t, rabbit, mx)
+		})
+	}
+}
+
+func TestRabbitMQ_Collect_Prometheus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/metrics" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(testPrometheusMetrics)
+		}))
+	defer srv.Close()
+
+	rabbit := New()
+	rabbit.URL = srv.URL + "/metrics"
+	rabbit.Source = sourcePrometheus
+	require.True(t, rabbit.Init())
+
+	expected := map[string]int64{
+		"churn_rates_channel_closed":           22,
+		"churn_rates_channel_created":          25,
+		"churn_rates_connection_closed":        10,
+		"churn_rates_connection_created":       12,
+		"churn_rates_queue_created":            6,
+		"churn_rates_queue_declared":           6,
+		"churn_rates_queue_deleted":            2,
+		"disk_free":                            189799186432,
+		"fd_total":                             1048576,
+		"fd_used":                              43,
+		"mem_limit":                            6713820774,
+		"mem_used":                             172720128,
+		"message_stats_ack":                    100,
+		"message_stats_confirm":                1110,
+		"message_stats_deliver":                100,
+		"message_stats_deliver_get":            105,
+		"message_stats_deliver_no_ack":         3,
+		"message_stats_get":                    2,
+		"message_stats_get_no_ack":             0,
+		"message_stats_publish":                1120,
+		"message_stats_publish_in":             1120,
+		"message_stats_publish_out":            1118,
+		"message_stats_redeliver":              4,
+		"message_stats_return_unroutable":      1,
+		"object_totals_channels":               3,
+		"object_totals_connections":            2,
+		"object_totals_consumers":              2,
+		"object_totals_queues":                 4,
+		"proc_available":                       1048135,
+		"proc_total":                           1048576,
+		"proc_used":                            441,
+		"queue_totals_messages":                9,
+		"queue_totals_messages_ready":          4,
+		"queue_totals_messages_unacknowledged": 5,
+		"run_queue":                            1,
+		"sockets_total":                        943629,
+		"sockets_used":                         3,
+	}
+
+	mx := rabbit.Collect()
+
+	assert.Equal(t, expected, mx)
+	assert.Equal(t, len(baseCharts), len(*rabbit.Charts()))
+	ensureCollectedHasAllChartsDimsVarsIDs(t, rabbit, mx)
+}
+
+func ensureCollectedHasAllChartsDimsVarsIDs(t *testing.T, rabbit *RabbitMQ, mx map[string]int64) {
+	for _, chart := range *rabbit.Charts() {
+		if chart.Obsolete {
+			continue
+		}
+		for _, dim := range chart.Dims {
+			_, ok := mx[dim.ID]
+			assert.Truef(t, ok, "collected metrics has no data for dim '%s' chart '%s'", dim.ID, chart.ID)
+		}
+		for _, v := range chart.Vars {
+			_, ok := mx[v.ID]
+			assert.Truef(t, ok, "collected metrics has no data for var '%s' chart '%s'", v.ID, chart.ID)
+		}
+	}
+}
+
 func caseSuccessAllRequests() (*RabbitMQ, func()) {
 	srv := prepareRabbitMQEndpoint()
 	rabbit := New()
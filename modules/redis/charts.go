@@ -37,6 +37,27 @@ const (
 	prioKeys
 	prioExpiresKeys
 
+	prioSlowlogEntries
+	prioSlowlogDuration
+	prioLatencyEventsLatest
+	prioLatencyEventsMax
+
+	prioClusterState
+	prioClusterSlots
+	prioClusterKnownNodes
+	prioClusterNodeRoles
+	prioClusterNodeFailures
+	prioClusterNodeLinks
+	prioClusterMessages
+
+	prioSentinelMasters
+	prioSentinelTilt
+	prioSentinelScripts
+	prioSentinelMasterStatus
+	prioSentinelMasterReplicas
+	prioSentinelMasterSentinels
+	prioSentinelMasterConfigEpoch
+
 	prioUptime
 )
 
@@ -381,3 +402,260 @@ var (
 		},
 	}
 )
+
+var (
+	slowlogCharts = module.Charts{
+		chartSlowlogEntries.Copy(),
+		chartSlowlogDuration.Copy(),
+	}
+
+	chartSlowlogEntries = module.Chart{
+		ID:       "slowlog_entries",
+		Title:    "Slow log entries",
+		Units:    "entries/s",
+		Fam:      "slowlog",
+		Ctx:      "redis.slowlog_entries",
+		Priority: prioSlowlogEntries,
+		Dims: module.Dims{
+			{ID: "slowlog_entries", Name: "entries", Algo: module.Incremental},
+		},
+	}
+	chartSlowlogDuration = module.Chart{
+		ID:       "slowlog_duration",
+		Title:    "Slow log entries execution time",
+		Units:    "microseconds",
+		Fam:      "slowlog",
+		Ctx:      "redis.slowlog_duration",
+		Priority: prioSlowlogDuration,
+		Dims: module.Dims{
+			{ID: "slowlog_duration_avg", Name: "avg"},
+			{ID: "slowlog_duration_max", Name: "max"},
+		},
+	}
+)
+
+var (
+	latencyCharts = module.Charts{
+		chartLatencyEventsLatest.Copy(),
+		chartLatencyEventsMax.Copy(),
+	}
+
+	chartLatencyEventsLatest = module.Chart{
+		ID:       "latency_events_latest",
+		Title:    "Latency of the latest spike per event",
+		Units:    "milliseconds",
+		Fam:      "latency",
+		Ctx:      "redis.latency_events_latest",
+		Priority: prioLatencyEventsLatest,
+	}
+	chartLatencyEventsMax = module.Chart{
+		ID:       "latency_events_max",
+		Title:    "Maximum latency per event",
+		Units:    "milliseconds",
+		Fam:      "latency",
+		Ctx:      "redis.latency_events_max",
+		Priority: prioLatencyEventsMax,
+	}
+)
+
+var (
+	clusterCharts = module.Charts{
+		chartClusterState.Copy(),
+		chartClusterSlots.Copy(),
+		chartClusterKnownNodes.Copy(),
+		chartClusterNodeRoles.Copy(),
+		chartClusterNodeFailures.Copy(),
+		chartClusterNodeLinks.Copy(),
+		chartClusterMessages.Copy(),
+	}
+
+	chartClusterState = module.Chart{
+		ID:       "cluster_state",
+		Title:    "Cluster state",
+		Units:    "state",
+		Fam:      "cluster",
+		Ctx:      "redis.cluster_state",
+		Priority: prioClusterState,
+		Dims: module.Dims{
+			{ID: "cluster_state_ok", Name: "ok"},
+			{ID: "cluster_state_fail", Name: "fail"},
+		},
+	}
+	chartClusterSlots = module.Chart{
+		ID:       "cluster_slots",
+		Title:    "Cluster hash slots",
+		Units:    "slots",
+		Fam:      "cluster",
+		Ctx:      "redis.cluster_slots",
+		Priority: prioClusterSlots,
+		Dims: module.Dims{
+			{ID: "cluster_slots_assigned", Name: "assigned"},
+			{ID: "cluster_slots_ok", Name: "ok"},
+			{ID: "cluster_slots_pfail", Name: "pfail"},
+			{ID: "cluster_slots_fail", Name: "fail"},
+		},
+	}
+	chartClusterKnownNodes = module.Chart{
+		ID:       "cluster_known_nodes",
+		Title:    "Cluster nodes",
+		Units:    "nodes",
+		Fam:      "cluster",
+		Ctx:      "redis.cluster_known_nodes",
+		Priority: prioClusterKnownNodes,
+		Dims: module.Dims{
+			{ID: "cluster_known_nodes", Name: "known"},
+			{ID: "cluster_size", Name: "serving_slots"},
+		},
+	}
+	chartClusterNodeRoles = module.Chart{
+		ID:       "cluster_node_roles",
+		Title:    "Cluster nodes by role",
+		Units:    "nodes",
+		Fam:      "cluster",
+		Ctx:      "redis.cluster_node_roles",
+		Priority: prioClusterNodeRoles,
+		Type:     module.Stacked,
+		Dims: module.Dims{
+			{ID: "cluster_nodes_master", Name: "master"},
+			{ID: "cluster_nodes_replica", Name: "replica"},
+		},
+	}
+	chartClusterNodeFailures = module.Chart{
+		ID:       "cluster_node_failures",
+		Title:    "Cluster nodes in failure state",
+		Units:    "nodes",
+		Fam:      "cluster",
+		Ctx:      "redis.cluster_node_failures",
+		Priority: prioClusterNodeFailures,
+		Dims: module.Dims{
+			{ID: "cluster_nodes_pfail", Name: "pfail"},
+			{ID: "cluster_nodes_fail", Name: "fail"},
+		},
+	}
+	chartClusterNodeLinks = module.Chart{
+		ID:       "cluster_node_links",
+		Title:    "Cluster bus links state",
+		Units:    "links",
+		Fam:      "cluster",
+		Ctx:      "redis.cluster_node_links",
+		Priority: prioClusterNodeLinks,
+		Type:     module.Stacked,
+		Dims: module.Dims{
+			{ID: "cluster_nodes_link_connected", Name: "connected"},
+			{ID: "cluster_nodes_link_disconnected", Name: "disconnected"},
+		},
+	}
+	chartClusterMessages = module.Chart{
+		ID:       "cluster_messages",
+		Title:    "Cluster bus messages",
+		Units:    "messages/s",
+		Fam:      "cluster",
+		Ctx:      "redis.cluster_messages",
+		Priority: prioClusterMessages,
+		Dims: module.Dims{
+			{ID: "cluster_stats_messages_received", Name: "received", Algo: module.Incremental},
+			{ID: "cluster_stats_messages_sent", Name: "sent", Algo: module.Incremental, Mul: -1},
+		},
+	}
+)
+
+var (
+	sentinelCharts = module.Charts{
+		chartSentinelMasters.Copy(),
+		chartSentinelTilt.Copy(),
+		chartSentinelScripts.Copy(),
+	}
+
+	chartSentinelMasters = module.Chart{
+		ID:       "sentinel_masters",
+		Title:    "Monitored masters",
+		Units:    "masters",
+		Fam:      "sentinel",
+		Ctx:      "redis.sentinel_masters",
+		Priority: prioSentinelMasters,
+		Dims: module.Dims{
+			{ID: "sentinel_masters", Name: "masters"},
+		},
+	}
+	chartSentinelTilt = module.Chart{
+		ID:       "sentinel_tilt",
+		Title:    "Sentinel TILT mode",
+		Units:    "status",
+		Fam:      "sentinel",
+		Ctx:      "redis.sentinel_tilt",
+		Priority: prioSentinelTilt,
+		Dims: module.Dims{
+			{ID: "sentinel_tilt", Name: "tilt"},
+		},
+	}
+	chartSentinelScripts = module.Chart{
+		ID:       "sentinel_scripts",
+		Title:    "Sentinel scripts",
+		Units:    "scripts",
+		Fam:      "sentinel",
+		Ctx:      "redis.sentinel_scripts",
+		Priority: prioSentinelScripts,
+		Dims: module.Dims{
+			{ID: "sentinel_running_scripts", Name: "running"},
+			{ID: "sentinel_scripts_queue_length", Name: "queued"},
+		},
+	}
+)
+
+var (
+	sentinelMasterChartsTmpl = module.Charts{
+		chartSentinelMasterStatusTmpl.Copy(),
+		chartSentinelMasterReplicasTmpl.Copy(),
+		chartSentinelMasterSentinelsTmpl.Copy(),
+		chartSentinelMasterConfigEpochTmpl.Copy(),
+	}
+
+	chartSentinelMasterStatusTmpl = module.Chart{
+		ID:       "sentinel_master_%s_status",
+		Title:    "Sentinel monitored master status",
+		Units:    "status",
+		Fam:      "sentinel",
+		Ctx:      "redis.sentinel_master_status",
+		Priority: prioSentinelMasterStatus,
+		Dims: module.Dims{
+			{ID: "sentinel_master_%s_status_ok", Name: "ok"},
+			{ID: "sentinel_master_%s_status_s_down", Name: "s_down"},
+			{ID: "sentinel_master_%s_status_o_down", Name: "o_down"},
+			{ID: "sentinel_master_%s_status_failover_in_progress", Name: "failover_in_progress"},
+		},
+	}
+	chartSentinelMasterReplicasTmpl = module.Chart{
+		ID:       "sentinel_master_%s_replicas",
+		Title:    "Sentinel monitored master replicas",
+		Units:    "replicas",
+		Fam:      "sentinel",
+		Ctx:      "redis.sentinel_master_replicas",
+		Priority: prioSentinelMasterReplicas,
+		Dims: module.Dims{
+			{ID: "sentinel_master_%s_replicas", Name: "replicas"},
+		},
+	}
+	chartSentinelMasterSentinelsTmpl = module.Chart{
+		ID:       "sentinel_master_%s_sentinels",
+		Title:    "Sentinel monitored master sentinels and quorum",
+		Units:    "sentinels",
+		Fam:      "sentinel",
+		Ctx:      "redis.sentinel_master_sentinels",
+		Priority: prioSentinelMasterSentinels,
+		Dims: module.Dims{
+			{ID: "sentinel_master_%s_sentinels", Name: "sentinels"},
+			{ID: "sentinel_master_%s_quorum", Name: "quorum"},
+		},
+	}
+	chartSentinelMasterConfigEpochTmpl = module.Chart{
+		ID:       "sentinel_master_%s_config_epoch",
+		Title:    "Sentinel monitored master configuration epoch",
+		Units:    "epoch",
+		Fam:      "sentinel",
+		Ctx:      "redis.sentinel_master_config_epoch",
+		Priority: prioSentinelMasterConfigEpoch,
+		Dims: module.Dims{
+			{ID: "sentinel_master_%s_config_epoch", Name: "config_epoch"},
+		},
+	}
+)
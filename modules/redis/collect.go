@@ -10,10 +10,18 @@ import (
 	"github.com/blang/semver/v4"
 	"regexp"
 	"strings"
+
+	"github.com/go-redis/redis/v8"
 )
 
 const precision = 1000 // float values multiplier and dimensions divisor
 
+const (
+	modeStandalone = "standalone"
+	modeCluster    = "cluster"
+	modeSentinel   = "sentinel"
+)
+
 func (r *Redis) collect() (map[string]int64, error) {
 	info, err := r.rdb.Info(context.Background(), "all").Result()
 	if err != nil {
@@ -26,7 +34,8 @@ func (r *Redis) collect() (map[string]int64, error) {
 			return nil, fmt.Errorf("can not extract server app and version: %v", err)
 		}
 		r.server, r.version = s, v
-		r.Debugf(`server="%s",version="%s"`, s, v)
+		r.mode = extractServerMode(info)
+		r.Debugf(`server="%s",version="%s",mode="%s"`, s, v, r.mode)
 	}
 
 	if r.server != "redis" {
@@ -37,9 +46,75 @@ func (r *Redis) collect() (map[string]int64, error) {
 	r.collectInfo(mx, info)
 	r.collectPingLatency(mx)
 
+	if r.mode == modeCluster && r.doCluster {
+		if err := r.collectCluster(mx); err != nil {
+			r.Errorf("error on collecting cluster metrics: %v", err)
+			if isUnknownCommandOrNoPermError(err) {
+				r.Warning("cluster metrics collection is disabled")
+				r.doCluster = false
+			}
+		}
+	}
+	if r.mode == modeSentinel {
+		// Sentinel INFO lacks most of the sections (memory, persistence, replication, ...)
+		r.removeEmptyChartsOnce.Do(func() { r.removeChartsWithoutData(mx) })
+		if r.doSentinel {
+			if err := r.collectSentinel(mx); err != nil {
+				r.Errorf("error on collecting sentinel metrics: %v", err)
+				if isUnknownCommandOrNoPermError(err) {
+					r.Warning("sentinel metrics collection is disabled")
+					r.doSentinel = false
+				}
+			}
+		}
+	}
+	// SLOWLOG and LATENCY are not available in Sentinel mode
+	if r.mode != modeSentinel && r.doSlowlog {
+		if err := r.collectSlowlog(mx); err != nil {
+			r.Errorf("error on collecting slowlog metrics: %v", err)
+			if isUnknownCommandOrNoPermError(err) {
+				r.Warning("slowlog metrics collection is disabled")
+				r.doSlowlog = false
+			}
+		}
+	}
+	if r.mode != modeSentinel && r.doLatency {
+		if err := r.collectLatency(mx); err != nil {
+			r.Errorf("error on collecting latency metrics: %v", err)
+			if isUnknownCommandOrNoPermError(err) {
+				r.Warning("latency metrics collection is disabled")
+				r.doLatency = false
+			}
+		}
+	}
+
 	return mx, nil
 }
 
+// isUnknownCommandOrNoPermError returns true if the command can't succeed until the server configuration changes:
+// the command is renamed or disabled, or the user lacks permissions to run it.
+func isUnknownCommandOrNoPermError(err error) bool {
+	var rErr redis.Error
+	if !errors.As(err, &rErr) {
+		return false
+	}
+	msg := rErr.Error()
+	return strings.HasPrefix(msg, "ERR unknown command") ||
+		strings.HasPrefix(msg, "ERR unknown subcommand") ||
+		strings.HasPrefix(msg, "NOPERM")
+}
+
+// redis_mode:standalone
+func extractServerMode(info string) string {
+	for sc := bufio.NewScanner(strings.NewReader(info)); sc.Scan(); {
+		line := strings.TrimSpace(sc.Text())
+		if strings.HasPrefix(line, "redis_mode:") {
+			return strings.TrimPrefix(line, "redis_mode:")
+		}
+	}
+	return modeStandalone
+}
+
 // redis_version:6.0.9
 var reVersion = regexp.MustCompile(`([a-z]+)_version:(\d+\.\d+\.\d+)`)
 
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package redis
+
+import (
+	"bufio"
+	"context"
+	"strings"
+)
+
+var clusterInfoFields = map[string]bool{
+	"cluster_slots_assigned":          true,
+	"cluster_slots_ok":                true,
+	"cluster_slots_pfail":             true,
+	"cluster_slots_fail":              true,
+	"cluster_known_nodes":             true,
+	"cluster_size":                    true,
+	"cluster_stats_messages_sent":     true,
+	"cluster_stats_messages_received": true,
+}
+
+func (r *Redis) collectCluster(mx map[string]int64) error {
+	info, err := r.rdb.ClusterInfo(context.Background()).Result()
+	if err != nil {
+		return err
+	}
+	nodes, err := r.rdb.ClusterNodes(context.Background()).Result()
+	if err != nil {
+		return err
+	}
+
+	r.addClusterChartsOnce.Do(r.addClusterCharts)
+
+	r.collectClusterInfo(mx, info)
+	r.collectClusterNodes(mx, nodes)
+
+	return nil
+}
+
+func (r *Redis) collectClusterInfo(mx map[string]int64, info string) {
+	// https://redis.io/commands/cluster-info/
+	// cluster_state:ok
+	// cluster_slots_assigned:16384
+	sc := bufio.NewScanner(strings.NewReader(info))
+	for sc.Scan() {
+		field, value, ok := parseProperty(strings.TrimSpace(sc.Text()))
+		if !ok {
+			continue
+		}
+
+		switch {
+		case field == "cluster_state":
+			mx["cluster_state_ok"] = boolToInt(value == "ok")
+			mx["cluster_state_fail"] = boolToInt(value != "ok")
+		case clusterInfoFields[field]:
+			collectNumericValue(mx, field, value)
+		}
+	}
+}
+
+func (r *Redis) collectClusterNodes(mx map[string]int64, nodes string) {
+	// https://redis.io/commands/cluster-nodes/
+	// <id> <ip:port@cport[,hostname]> <flags> <master> <ping-sent> <pong-recv> <config-epoch> <link-state> <slot> ...
+	for _, v := range []string{"master", "replica", "fail", "pfail", "link_connected", "link_disconnected"} {
+		mx["cluster_nodes_"+v] = 0
+	}
+
+	sc := bufio.NewScanner(strings.NewReader(nodes))
+	for sc.Scan() {
+		parts := strings.Fields(sc.Text())
+		if len(parts) < 8 {
+			continue
+		}
+
+		for _, flag := range strings.Split(parts[2], ",") {
+			switch flag {
+			case "master":
+				mx["cluster_nodes_master"]++
+			case "slave":
+				mx["cluster_nodes_replica"]++
+			case "fail":
+				mx["cluster_nodes_fail"]++
+			case "fail?":
+				mx["cluster_nodes_pfail"]++
+			}
+		}
+
+		switch parts[7] {
+		case "connected":
+			mx["cluster_nodes_link_connected"]++
+		case "disconnected":
+			mx["cluster_nodes_link_disconnected"]++
+		}
+	}
+}
+
+func (r *Redis) addClusterCharts() {
+	if err := r.Charts().Add(*clusterCharts.Copy()...); err != nil {
+		r.Warningf("error on adding cluster charts: %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+)
+
+func (r *Redis) collectLatency(mx map[string]int64) error {
+	// https://redis.io/commands/latency-latest/
+	// every entry is: event name, unix timestamp of the latest spike, latest and all-time max latency in milliseconds.
+	// Events are reported only if 'latency-monitor-threshold' is set.
+	events, err := r.rdb.Do(context.Background(), "LATENCY", "LATEST").Slice()
+	if err != nil {
+		return err
+	}
+
+	// events are removed by LATENCY RESET
+	for name := range r.collectedLatencyEvents {
+		mx["latency_event_"+name+"_latest"] = 0
+		mx["latency_event_"+name+"_max"] = 0
+	}
+
+	for _, v := range events {
+		event, ok := v.([]interface{})
+		if !ok || len(event) < 4 {
+			continue
+		}
+		name := fmt.Sprint(event[0])
+		latest, ok1 := event[2].(int64)
+		maxLatency, ok2 := event[3].(int64)
+		if name == "" || !ok1 || !ok2 {
+			continue
+		}
+
+		if !r.collectedLatencyEvents[name] {
+			r.collectedLatencyEvents[name] = true
+			r.addLatencyEventToCharts(name)
+		}
+
+		mx["latency_event_"+name+"_latest"] = latest
+		mx["latency_event_"+name+"_max"] = maxLatency
+	}
+
+	return nil
+}
+
+func (r *Redis) addLatencyEventToCharts(name string) {
+	r.addLatencyChartsOnce.Do(r.addLatencyCharts)
+
+	r.addDimToChart(chartLatencyEventsLatest.ID, &module.Dim{
+		ID:   "latency_event_" + name + "_latest",
+		Name: name,
+	})
+	r.addDimToChart(chartLatencyEventsMax.ID, &module.Dim{
+		ID:   "latency_event_" + name + "_max",
+		Name: name,
+	})
+}
+
+func (r *Redis) addLatencyCharts() {
+	if err := r.Charts().Add(*latencyCharts.Copy()...); err != nil {
+		r.Warningf("error on adding latency charts: %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+)
+
+func (r *Redis) collectSentinel(mx map[string]int64) error {
+	// https://redis.io/docs/management/sentinel/#sentinel-commands
+	masters, err := r.rdb.Do(context.Background(), "SENTINEL", "MASTERS").Slice()
+	if err != nil {
+		return err
+	}
+
+	r.addSentinelChartsOnce.Do(r.addSentinelCharts)
+
+	seen := make(map[string]bool)
+	for _, v := range masters {
+		master, ok := parseSentinelMaster(v)
+		if !ok {
+			continue
+		}
+		name := master["name"]
+		seen[name] = true
+		if !r.collectedSentinelMasters[name] {
+			r.collectedSentinelMasters[name] = true
+			r.addSentinelMasterCharts(name, master["ip"]+":"+master["port"])
+		}
+
+		px := "sentinel_master_" + name + "_"
+		flags := strings.Split(master["flags"], ",")
+		sdown, odown := hasFlag(flags, "s_down"), hasFlag(flags, "o_down")
+		mx[px+"status_ok"] = boolToInt(!sdown && !odown)
+		mx[px+"status_s_down"] = boolToInt(sdown)
+		mx[px+"status_o_down"] = boolToInt(odown)
+		mx[px+"status_failover_in_progress"] = boolToInt(hasFlag(flags, "failover_in_progress"))
+
+		collectNumericValue(mx, px+"replicas", master["num-slaves"])
+		collectNumericValue(mx, px+"quorum", master["quorum"])
+		// the reported number of sentinels doesn't include the one we are connected to
+		if n, err := strconv.ParseInt(master["num-other-sentinels"], 10, 64); err == nil {
+			mx[px+"sentinels"] = n + 1
+		}
+		// the configuration epoch is incremented on every failover (and on some configuration changes)
+		collectNumericValue(mx, px+"config_epoch", master["config-epoch"])
+	}
+
+	for name := range r.collectedSentinelMasters {
+		if !seen[name] {
+			delete(r.collectedSentinelMasters, name)
+			r.removeSentinelMasterCharts(name)
+		}
+	}
+
+	return nil
+}
+
+func parseSentinelMaster(v interface{}) (map[string]string, bool) {
+	// flat list of field-value pairs: ["name", "mymaster", "ip", "127.0.0.1", ...]
+	values, ok := v.([]interface{})
+	if !ok || len(values)%2 != 0 {
+		return nil, false
+	}
+
+	master := make(map[string]string, len(values)/2)
+	for i := 0; i < len(values); i += 2 {
+		master[fmt.Sprint(values[i])] = fmt.Sprint(values[i+1])
+	}
+
+	return master, master["name"] != ""
+}
+
+func hasFlag(flags []string, flag string) bool {
+	for _, f := range flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Redis) addSentinelCharts() {
+	if err := r.Charts().Add(*sentinelCharts.Copy()...); err != nil {
+		r.Warningf("error on adding sentinel charts: %v", err)
+	}
+}
+
+func (r *Redis) addSentinelMasterCharts(name, address string) {
+	charts := sentinelMasterChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, name)
+		chart.Labels = []module.Label{
+			{Key: "master_name", Value: name},
+			{Key: "master_address", Value: address},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, name)
+		}
+	}
+
+	if err := r.Charts().Add(*charts...); err != nil {
+		r.Warning(err)
+	}
+}
+
+func (r *Redis) removeSentinelMasterCharts(name string) {
+	px := "sentinel_master_" + name + "_"
+	for _, chart := range *r.Charts() {
+		if strings.HasPrefix(chart.ID, px) {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
+
+func (r *Redis) removeChartsWithoutData(mx map[string]int64) {
+	var ids []string
+	for _, chart := range *r.Charts() {
+		if len(chart.Dims) == 0 || chart.Obsolete {
+			continue
+		}
+		collected := false
+		for _, dim := range chart.Dims {
+			if _, collected = mx[dim.ID]; collected {
+				break
+			}
+		}
+		if !collected {
+			ids = append(ids, chart.ID)
+		}
+	}
+
+	for _, id := range ids {
+		_ = r.Charts().Remove(id)
+	}
+}
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package redis
+
+import (
+	"context"
+)
+
+// the default 'slowlog-max-len' value
+const slowlogGetEntries = 128
+
+func (r *Redis) collectSlowlog(mx map[string]int64) error {
+	// https://redis.io/commands/slowlog-get/
+	entries, err := r.rdb.SlowLogGet(context.Background(), slowlogGetEntries).Result()
+	if err != nil {
+		return err
+	}
+
+	r.addSlowlogChartsOnce.Do(r.addSlowlogCharts)
+
+	// entries are returned newest first, IDs are unique and incremented for every new entry,
+	// they are not reset by SLOWLOG RESET, only by a server restart.
+	// An empty slowlog (e.g. after SLOWLOG RESET) has no last ID, the previous one is kept.
+	lastID := int64(-1)
+	if r.slowlogPrimed {
+		lastID = r.slowlogLastID
+	}
+	if len(entries) > 0 {
+		lastID = entries[0].ID
+	}
+
+	var maxDuration, sumDuration, num int64
+	if r.slowlogPrimed {
+		newEntries := lastID - r.slowlogLastID
+		if newEntries < 0 {
+			// server restart
+			newEntries = lastID + 1
+		}
+		r.slowlogEntries += newEntries
+
+		for _, e := range entries {
+			if num >= newEntries {
+				break
+			}
+			num++
+			d := e.Duration.Microseconds()
+			sumDuration += d
+			if d > maxDuration {
+				maxDuration = d
+			}
+		}
+	}
+	r.slowlogPrimed, r.slowlogLastID = true, lastID
+
+	mx["slowlog_entries"] = r.slowlogEntries
+	mx["slowlog_duration_max"] = maxDuration
+	mx["slowlog_duration_avg"] = 0
+	if num > 0 {
+		mx["slowlog_duration_avg"] = sumDuration / num
+	}
+
+	return nil
+}
+
+func (r *Redis) addSlowlogCharts() {
+	if err := r.Charts().Add(*slowlogCharts.Copy()...); err != nil {
+		r.Warningf("error on adding slowlog charts: %v", err)
+	}
+}
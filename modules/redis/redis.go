@@ -28,6 +28,11 @@ func New() *Redis {
 			Address:     "redis://@localhost:6379",
 			Timeout:     web.Duration{Duration: time.Second},
 			PingSamples: 5,
+
+			CollectCluster:  true,
+			CollectSentinel: true,
+			CollectSlowlog:  true,
+			CollectLatency:  true,
 		},
 
 		addAOFChartsOnce:       &sync.Once{},
@@ -35,6 +40,14 @@ func New() *Redis {
 		pingSummary:            metrics.NewSummary(),
 		collectedCommands:      make(map[string]bool),
 		collectedDbs:           make(map[string]bool),
+
+		addClusterChartsOnce:     &sync.Once{},
+		addSentinelChartsOnce:    &sync.Once{},
+		addSlowlogChartsOnce:     &sync.Once{},
+		addLatencyChartsOnce:     &sync.Once{},
+		removeEmptyChartsOnce:    &sync.Once{},
+		collectedLatencyEvents:   make(map[string]bool),
+		collectedSentinelMasters: make(map[string]bool),
 	}
 }
 
@@ -44,6 +57,10 @@ type Config struct {
 	Username         string       `yaml:"username"`
 	Timeout          web.Duration `yaml:"timeout"`
 	PingSamples      int          `yaml:"ping_samples"`
+	CollectCluster   bool         `yaml:"collect_cluster"`
+	CollectSentinel  bool         `yaml:"collect_sentinel"`
+	CollectSlowlog   bool         `yaml:"collect_slowlog"`
+	CollectLatency   bool         `yaml:"collect_latency"`
 	tlscfg.TLSConfig `yaml:",inline"`
 }
 
@@ -58,18 +75,41 @@ type (
 
 		server  string
 		version *semver.Version
+		mode    string
 
 		addAOFChartsOnce       *sync.Once
 		addReplSlaveChartsOnce *sync.Once
 
 		pingSummary metrics.Summary
 
+		doCluster  bool
+		doSentinel bool
+		doSlowlog  bool
+		doLatency  bool
+
 		collectedCommands map[string]bool
 		collectedDbs      map[string]bool
+
+		addClusterChartsOnce  *sync.Once
+		addSentinelChartsOnce *sync.Once
+		addSlowlogChartsOnce  *sync.Once
+		addLatencyChartsOnce  *sync.Once
+		removeEmptyChartsOnce *sync.Once
+
+		slowlogPrimed  bool
+		slowlogLastID  int64
+		slowlogEntries int64
+
+		collectedLatencyEvents   map[string]bool
+		collectedSentinelMasters map[string]bool
 	}
 	redisClient interface {
 		Info(ctx context.Context, section ...string) *redis.StringCmd
 		Ping(context.Context) *redis.StatusCmd
+		ClusterInfo(ctx context.Context) *redis.StringCmd
+		ClusterNodes(ctx context.Context) *redis.StringCmd
+		SlowLogGet(ctx context.Context, num int64) *redis.SlowLogCmd
+		Do(ctx context.Context, args ...interface{}) *redis.Cmd
 		Close() error
 	}
 )
@@ -88,6 +128,11 @@ func (r *Redis) Init() bool {
 	}
 	r.rdb = rdb
 
+	r.doCluster = r.CollectCluster
+	r.doSentinel = r.CollectSentinel
+	r.doSlowlog = r.CollectSlowlog
+	r.doLatency = r.CollectLatency
+
 	charts, err := r.initCharts()
 	if err != nil {
 		r.Errorf("init charts: %v", err)
@@ -3,11 +3,13 @@
 package redis
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/netdata/go.d.plugin/pkg/tlscfg"
 
@@ -17,14 +19,20 @@ import (
 )
 
 var (
-	pikaInfoAll, _ = os.ReadFile("testdata/pika/info_all.txt")
-	v609InfoAll, _ = os.ReadFile("testdata/v6.0.9/info_all.txt")
+	pikaInfoAll, _         = os.ReadFile("testdata/pika/info_all.txt")
+	v609InfoAll, _         = os.ReadFile("testdata/v6.0.9/info_all.txt")
+	v609InfoAllSentinel, _ = os.ReadFile("testdata/v6.0.9/info_all_sentinel.txt")
+	v609ClusterInfo, _     = os.ReadFile("testdata/v6.0.9/cluster_info.txt")
+	v609ClusterNodes, _    = os.ReadFile("testdata/v6.0.9/cluster_nodes.txt")
 )
 
 func Test_Testdata(t *testing.T) {
 	for name, data := range map[string][]byte{
-		"pikaInfoAll": pikaInfoAll,
-		"v609InfoAll": v609InfoAll,
+		"pikaInfoAll":         pikaInfoAll,
+		"v609InfoAll":         v609InfoAll,
+		"v609InfoAllSentinel": v609InfoAllSentinel,
+		"v609ClusterInfo":     v609ClusterInfo,
+		"v609ClusterNodes":    v609ClusterNodes,
 	} {
 		require.NotNilf(t, data, name)
 	}
@@ -248,6 +256,9 @@ func TestRedis_Collect(t *testing.T) {
 				"rss_overhead_ratio":              1070,
 				"second_repl_offset":              -1,
 				"slave_expires_tracked_keys":      0,
+				"slowlog_duration_avg":            0,
+				"slowlog_duration_max":            0,
+				"slowlog_entries":                 0,
 				"sync_full":                       0,
 				"sync_partial_err":                0,
 				"sync_partial_ok":                 0,
@@ -306,6 +317,237 @@ func TestRedis_Collect(t *testing.T) {
 	}
 }
 
+func TestRedis_Collect_Cluster(t *testing.T) {
+	rdb := New()
+	require.True(t, rdb.Init())
+	rdb.rdb = &mockRedisClient{
+		result:       bytes.Replace(v609InfoAll, []byte("redis_mode:standalone"), []byte("redis_mode:cluster"), 1),
+		clusterInfo:  v609ClusterInfo,
+		clusterNodes: v609ClusterNodes,
+	}
+
+	mx := rdb.Collect()
+
+	expected := map[string]int64{
+		"cluster_state_ok":                1,
+		"cluster_state_fail":              0,
+		"cluster_slots_assigned":          16384,
+		"cluster_slots_ok":                16380,
+		"cluster_slots_pfail":             4,
+		"cluster_slots_fail":              0,
+		"cluster_known_nodes":             6,
+		"cluster_size":                    3,
+		"cluster_stats_messages_sent":     2967940,
+		"cluster_stats_messages_received": 2967940,
+		"cluster_nodes_master":            3,
+		"cluster_nodes_replica":           3,
+		"cluster_nodes_pfail":             1,
+		"cluster_nodes_fail":              1,
+		"cluster_nodes_link_connected":    4,
+		"cluster_nodes_link_disconnected": 2,
+	}
+	for k, v := range expected {
+		assert.Equalf(t, v, mx[k], "metric '%s'", k)
+	}
+	assert.True(t, rdb.Charts().Has(chartClusterState.ID))
+	ensureCollectedHasAllChartsDimsVarsIDs(t, rdb, mx)
+}
+
+func TestRedis_Collect_Sentinel(t *testing.T) {
+	rdb := New()
+	require.True(t, rdb.Init())
+	m := &mockRedisClient{
+		result: v609InfoAllSentinel,
+		sentinelMasters: []interface{}{
+			sentinelMaster("mymaster", "172.17.0.2", "master", "2", "2", "2", "3"),
+			sentinelMaster("cache", "172.17.0.5", "master,s_down,o_down", "1", "2", "2", "1"),
+		},
+	}
+	rdb.rdb = m
+
+	mx := rdb.Collect()
+
+	expected := map[string]int64{
+		"sentinel_masters":                                     2,
+		"sentinel_tilt":                                        0,
+		"sentinel_running_scripts":                             0,
+		"sentinel_scripts_queue_length":                        0,
+		"sentinel_master_mymaster_status_ok":                   1,
+		"sentinel_master_mymaster_status_s_down":               0,
+		"sentinel_master_mymaster_status_o_down":               0,
+		"sentinel_master_mymaster_status_failover_in_progress": 0,
+		"sentinel_master_mymaster_replicas":                    2,
+		"sentinel_master_mymaster_sentinels":                   3,
+		"sentinel_master_mymaster_quorum":                      2,
+		"sentinel_master_mymaster_config_epoch":                3,
+		"sentinel_master_cache_status_ok":                      0,
+		"sentinel_master_cache_status_s_down":                  1,
+		"sentinel_master_cache_status_o_down":                  1,
+		"sentinel_master_cache_status_failover_in_progress":    0,
+		"sentinel_master_cache_replicas":                       1,
+		"sentinel_master_cache_sentinels":                      3,
+		"sentinel_master_cache_quorum":                         2,
+		"sentinel_master_cache_config_epoch":                   1,
+	}
+	for k, v := range expected {
+		assert.Equalf(t, v, mx[k], "metric '%s'", k)
+	}
+	for _, k := range []string{"slowlog_entries", "used_memory"} {
+		assert.NotContainsf(t, mx, k, "metric '%s'", k)
+	}
+	assert.False(t, rdb.Charts().Has(chartMemory.ID))
+	assert.False(t, rdb.Charts().Has(chartPersistenceRDBChanges.ID))
+	assert.True(t, rdb.Charts().Has("sentinel_master_cache_status"))
+	ensureCollectedHasAllChartsDimsVarsIDs(t, rdb, mx)
+
+	m.sentinelMasters = m.sentinelMasters[:1]
+	_ = rdb.Collect()
+
+	assert.True(t, rdb.Charts().Get("sentinel_master_cache_status").Obsolete)
+	assert.False(t, rdb.Charts().Get("sentinel_master_mymaster_status").Obsolete)
+}
+
+func TestRedis_Collect_SlowlogAndLatency(t *testing.T) {
+	rdb := New()
+	require.True(t, rdb.Init())
+	m := &mockRedisClient{
+		result:  v609InfoAll,
+		slowlog: []redis.SlowLog{slowlogEntry(4, 20*time.Millisecond), slowlogEntry(3, 10*time.Millisecond)},
+	}
+	rdb.rdb = m
+
+	steps := []struct {
+		prepare  func()
+		expected map[string]int64
+	}{
+		{
+			// the entries logged before the first collection are not counted
+			prepare: func() {},
+			expected: map[string]int64{
+				"slowlog_entries":      0,
+				"slowlog_duration_avg": 0,
+				"slowlog_duration_max": 0,
+			},
+		},
+		{
+			prepare: func() {
+				m.slowlog = append([]redis.SlowLog{
+					slowlogEntry(7, 30*time.Millisecond),
+					slowlogEntry(6, 10*time.Millisecond),
+					slowlogEntry(5, 5*time.Millisecond),
+				}, m.slowlog...)
+				m.latency = []interface{}{
+					[]interface{}{"command", int64(1405067976), int64(251), int64(1001)},
+					[]interface{}{"fast-command", int64(1405067822), int64(0), int64(3)},
+				}
+			},
+			expected: map[string]int64{
+				"slowlog_entries":                   3,
+				"slowlog_duration_avg":              15000,
+				"slowlog_duration_max":              30000,
+				"latency_event_command_latest":      251,
+				"latency_event_command_max":         1001,
+				"latency_event_fast-command_latest": 0,
+				"latency_event_fast-command_max":    3,
+			},
+		},
+		{
+			// SLOWLOG RESET
+			prepare: func() {
+				m.slowlog = nil
+			},
+			expected: map[string]int64{
+				"slowlog_entries":      3,
+				"slowlog_duration_avg": 0,
+				"slowlog_duration_max": 0,
+			},
+		},
+		{
+			// the entry IDs continue after SLOWLOG RESET
+			prepare: func() {
+				m.slowlog = []redis.SlowLog{slowlogEntry(8, 50*time.Millisecond)}
+			},
+			expected: map[string]int64{
+				"slowlog_entries":      4,
+				"slowlog_duration_avg": 50000,
+				"slowlog_duration_max": 50000,
+			},
+		},
+		{
+			// server restart
+			prepare: func() {
+				m.slowlog = []redis.SlowLog{slowlogEntry(1, 40*time.Millisecond), slowlogEntry(0, 20*time.Millisecond)}
+				m.latency = nil
+			},
+			expected: map[string]int64{
+				"slowlog_entries":                   6,
+				"slowlog_duration_avg":              30000,
+				"slowlog_duration_max":              40000,
+				"latency_event_command_latest":      0,
+				"latency_event_command_max":         0,
+				"latency_event_fast-command_latest": 0,
+				"latency_event_fast-command_max":    0,
+			},
+		},
+	}
+
+	for i, step := range steps {
+		step.prepare()
+		mx := rdb.Collect()
+
+		for k, v := range step.expected {
+			assert.Equalf(t, v, mx[k], "step %d: metric '%s'", i+1, k)
+		}
+		ensureCollectedHasAllChartsDimsVarsIDs(t, rdb, mx)
+	}
+
+	assert.True(t, rdb.doSlowlog)
+	assert.True(t, rdb.doLatency)
+	assert.Len(t, rdb.Charts().Get(chartLatencyEventsMax.ID).Dims, 2)
+}
+
+func TestRedis_Collect_DisablesNotSupportedCommands(t *testing.T) {
+	rdb := New()
+	require.True(t, rdb.Init())
+	rdb.rdb = &mockRedisClient{
+		result:           v609InfoAll,
+		errOnCommands:    true,
+		noPermOnCommands: true,
+	}
+
+	mx := rdb.Collect()
+
+	assert.NotEmpty(t, mx)
+	assert.False(t, rdb.doSlowlog)
+	assert.False(t, rdb.doLatency)
+	assert.True(t, rdb.CollectSlowlog)
+	assert.True(t, rdb.CollectLatency)
+	assert.False(t, rdb.Charts().Has(chartSlowlogEntries.ID))
+}
+
+func TestRedis_Collect_KeepsCommandsOnTemporaryErrors(t *testing.T) {
+	rdb := New()
+	require.True(t, rdb.Init())
+	mock := &mockRedisClient{
+		result:        v609InfoAll,
+		errOnCommands: true,
+		slowlog:       []redis.SlowLog{slowlogEntry(1, time.Millisecond)},
+	}
+	rdb.rdb = mock
+
+	mx := rdb.Collect()
+
+	assert.NotEmpty(t, mx)
+	assert.True(t, rdb.doSlowlog)
+	assert.True(t, rdb.doLatency)
+
+	mock.errOnCommands = false
+	mx = rdb.Collect()
+
+	assert.Contains(t, mx, "slowlog_entries")
+	assert.True(t, rdb.Charts().Has(chartSlowlogEntries.ID))
+}
+
 func prepareRedisV609(t *testing.T) *Redis {
 	rdb := New()
 	require.True(t, rdb.Init())
@@ -387,10 +629,35 @@ func copyTimeRelatedMetrics(dst, src map[string]int64) {
 	}
 }
 
+func sentinelMaster(name, ip, flags, replicas, quorum, otherSentinels, epoch string) []interface{} {
+	return []interface{}{
+		"name", name,
+		"ip", ip,
+		"port", "6379",
+		"flags", flags,
+		"num-slaves", replicas,
+		"num-other-sentinels", otherSentinels,
+		"quorum", quorum,
+		"config-epoch", epoch,
+	}
+}
+
+func slowlogEntry(id int64, duration time.Duration) redis.SlowLog {
+	return redis.SlowLog{ID: id, Time: time.Unix(1405067976, 0), Duration: duration, Args: []string{"KEYS", "*"}}
+}
+
+// mockRedisClient is a minimal in-memory stand-in for a Redis server.
 type mockRedisClient struct {
-	errOnInfo   bool
-	result      []byte
-	calledClose bool
+	errOnInfo        bool
+	errOnCommands    bool
+	noPermOnCommands bool
+	result           []byte
+	clusterInfo      []byte
+	clusterNodes     []byte
+	slowlog          []redis.SlowLog
+	latency          []interface{}
+	sentinelMasters  []interface{}
+	calledClose      bool
 }
 
 func (m *mockRedisClient) Info(_ context.Context, _ ...string) (cmd *redis.StringCmd) {
@@ -406,6 +673,61 @@ func (m *mockRedisClient) Ping(_ context.Context) (cmd *redis.StatusCmd) {
 	return redis.NewStatusResult("PONG", nil)
 }
 
+func (m *mockRedisClient) ClusterInfo(_ context.Context) *redis.StringCmd {
+	if m.errOnCommands {
+		return redis.NewStringResult("", m.commandErr("error on ClusterInfo"))
+	}
+	return redis.NewStringResult(string(m.clusterInfo), nil)
+}
+
+func (m *mockRedisClient) ClusterNodes(_ context.Context) *redis.StringCmd {
+	if m.errOnCommands {
+		return redis.NewStringResult("", m.commandErr("error on ClusterNodes"))
+	}
+	return redis.NewStringResult(string(m.clusterNodes), nil)
+}
+
+func (m *mockRedisClient) SlowLogGet(ctx context.Context, num int64) *redis.SlowLogCmd {
+	cmd := redis.NewSlowLogCmd(ctx, "slowlog", "get", num)
+	if m.errOnCommands {
+		cmd.SetErr(m.commandErr("error on SlowLogGet"))
+		return cmd
+	}
+	entries := m.slowlog
+	if int64(len(entries)) > num {
+		entries = entries[:num]
+	}
+	cmd.SetVal(entries)
+	return cmd
+}
+
+func (m *mockRedisClient) Do(_ context.Context, args ...interface{}) *redis.Cmd {
+	if m.errOnCommands {
+		return redis.NewCmdResult(nil, m.commandErr("error on Do"))
+	}
+	switch strings.ToUpper(strings.Join([]string{args[0].(string), args[1].(string)}, " ")) {
+	case "LATENCY LATEST":
+		return redis.NewCmdResult(m.latency, nil)
+	case "SENTINEL MASTERS":
+		return redis.NewCmdResult(m.sentinelMasters, nil)
+	}
+	return redis.NewCmdResult(nil, mockRedisError("ERR unknown command"))
+}
+
+func (m *mockRedisClient) commandErr(msg string) error {
+	if m.noPermOnCommands {
+		return mockRedisError("NOPERM this user has no permissions to run the command")
+	}
+	return errors.New(msg)
+}
+
+// mockRedisError is an error reply from the server.
+type mockRedisError string
+
+func (e mockRedisError) Error() string { return string(e) }
+
+func (mockRedisError) RedisError() {}
+
 func (m *mockRedisClient) Close() error {
 	m.calledClose = true
 	return nil
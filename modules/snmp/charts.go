@@ -5,6 +5,7 @@ package snmp
 import (
 	"fmt"
 	"strings"
+	"unicode"
 
 	"github.com/netdata/go.d.plugin/agent/module"
 )
@@ -114,3 +115,190 @@ func newChart(cfg ChartConfig) (*module.Chart, error) {
 
 	return chart, nil
 }
+
+const (
+	prioInterfaceTraffic = module.Priority + iota
+	prioInterfacePackets
+	prioInterfaceErrors
+	prioInterfaceDiscards
+	prioInterfaceSpeed
+	prioInterfaceAdminStatus
+	prioInterfaceOperStatus
+)
+
+var (
+	chartsTmplInterface = module.Charts{
+		chartTmplInterfaceTraffic.Copy(),
+		chartTmplInterfacePackets.Copy(),
+		chartTmplInterfaceErrors.Copy(),
+		chartTmplInterfaceDiscards.Copy(),
+		chartTmplInterfaceSpeed.Copy(),
+		chartTmplInterfaceAdminStatus.Copy(),
+		chartTmplInterfaceOperStatus.Copy(),
+	}
+
+	chartTmplInterfaceTraffic = module.Chart{
+		ID:       "interface_traffic_%s",
+		Title:    "Interface Traffic",
+		Units:    "kilobits/s",
+		Fam:      "interfaces",
+		Ctx:      "snmp.interface_traffic",
+		Type:     module.Area,
+		Priority: prioInterfaceTraffic,
+		Dims: module.Dims{
+			{ID: "interface_%s_traffic_in", Name: "received", Algo: module.Incremental, Mul: 8, Div: 1000},
+			{ID: "interface_%s_traffic_out", Name: "sent", Algo: module.Incremental, Mul: -8, Div: 1000},
+		},
+	}
+	chartTmplInterfacePackets = module.Chart{
+		ID:       "interface_packets_%s",
+		Title:    "Interface Packets",
+		Units:    "packets/s",
+		Fam:      "interfaces",
+		Ctx:      "snmp.interface_packets",
+		Priority: prioInterfacePackets,
+		Dims: module.Dims{
+			{ID: "interface_%s_packets_in_unicast", Name: "received_unicast", Algo: module.Incremental},
+			{ID: "interface_%s_packets_in_multicast", Name: "received_multicast", Algo: module.Incremental},
+			{ID: "interface_%s_packets_in_broadcast", Name: "received_broadcast", Algo: module.Incremental},
+			{ID: "interface_%s_packets_out_unicast", Name: "sent_unicast", Algo: module.Incremental, Mul: -1},
+			{ID: "interface_%s_packets_out_multicast", Name: "sent_multicast", Algo: module.Incremental, Mul: -1},
+			{ID: "interface_%s_packets_out_broadcast", Name: "sent_broadcast", Algo: module.Incremental, Mul: -1},
+		},
+	}
+	chartTmplInterfaceErrors = module.Chart{
+		ID:       "interface_errors_%s",
+		Title:    "Interface Errors",
+		Units:    "errors/s",
+		Fam:      "interfaces",
+		Ctx:      "snmp.interface_errors",
+		Priority: prioInterfaceErrors,
+		Dims: module.Dims{
+			{ID: "interface_%s_errors_in", Name: "inbound", Algo: module.Incremental},
+			{ID: "interface_%s_errors_out", Name: "outbound", Algo: module.Incremental, Mul: -1},
+		},
+	}
+	chartTmplInterfaceDiscards = module.Chart{
+		ID:       "interface_discards_%s",
+		Title:    "Interface Discards",
+		Units:    "discards/s",
+		Fam:      "interfaces",
+		Ctx:      "snmp.interface_discards",
+		Priority: prioInterfaceDiscards,
+		Dims: module.Dims{
+			{ID: "interface_%s_discards_in", Name: "inbound", Algo: module.Incremental},
+			{ID: "interface_%s_discards_out", Name: "outbound", Algo: module.Incremental, Mul: -1},
+		},
+	}
+	chartTmplInterfaceSpeed = module.Chart{
+		ID:       "interface_speed_%s",
+		Title:    "Interface Speed",
+		Units:    "kilobits/s",
+		Fam:      "interfaces",
+		Ctx:      "snmp.interface_speed",
+		Priority: prioInterfaceSpeed,
+		Dims: module.Dims{
+			// ifHighSpeed is in units of 1,000,000 bits per second
+			{ID: "interface_%s_speed", Name: "speed", Mul: 1000},
+		},
+	}
+	chartTmplInterfaceAdminStatus = module.Chart{
+		ID:       "interface_admin_status_%s",
+		Title:    "Interface Administrative Status",
+		Units:    "status",
+		Fam:      "interfaces",
+		Ctx:      "snmp.interface_admin_status",
+		Priority: prioInterfaceAdminStatus,
+		Dims: module.Dims{
+			{ID: "interface_%s_admin_status_up", Name: "up"},
+			{ID: "interface_%s_admin_status_down", Name: "down"},
+			{ID: "interface_%s_admin_status_testing", Name: "testing"},
+		},
+	}
+	chartTmplInterfaceOperStatus = module.Chart{
+		ID:       "interface_oper_status_%s",
+		Title:    "Interface Operational Status",
+		Units:    "status",
+		Fam:      "interfaces",
+		Ctx:      "snmp.interface_oper_status",
+		Priority: prioInterfaceOperStatus,
+		Dims: module.Dims{
+			{ID: "interface_%s_oper_status_up", Name: "up"},
+			{ID: "interface_%s_oper_status_down", Name: "down"},
+			{ID: "interface_%s_oper_status_testing", Name: "testing"},
+			{ID: "interface_%s_oper_status_unknown", Name: "unknown"},
+			{ID: "interface_%s_oper_status_dormant", Name: "dormant"},
+			{ID: "interface_%s_oper_status_not_present", Name: "not_present"},
+			{ID: "interface_%s_oper_status_lower_layer_down", Name: "lower_layer_down"},
+		},
+	}
+)
+
+func (s *SNMP) addInterfaceCharts(idx, name, alias string) {
+	charts := chartsTmplInterface.Copy()
+	for _, c := range *charts {
+		c.ID = fmt.Sprintf(c.ID, idx)
+		c.Labels = []module.Label{
+			{Key: "interface", Value: cleanLabelValue(name)},
+			{Key: "alias", Value: cleanLabelValue(alias)},
+		}
+		for _, dim := range c.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, idx)
+		}
+	}
+	if err := s.Charts().Add(*charts...); err != nil {
+		s.Warning(err)
+	}
+}
+
+// cleanLabelValue removes the characters that break the plugin protocol:
+// the label value is single-quoted and the protocol is line based.
+func cleanLabelValue(v string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\'' || unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, v)
+}
+
+func (s *SNMP) removeInterfaceCharts(idx string) {
+	for _, t := range chartsTmplInterface {
+		s.removeChart(fmt.Sprintf(t.ID, idx))
+	}
+}
+
+func (s *SNMP) addTableRowCharts(cfg TableConfig, idx string, labels []module.Label) {
+	for _, chartCfg := range cfg.Charts {
+		chart, err := newChart(chartCfg)
+		if err != nil {
+			s.Warning(err)
+			continue
+		}
+		chart.ID = tableRowChartID(chartCfg.ID, idx)
+		chart.Labels = labels
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf("%s.%s", dim.ID, idx)
+		}
+		if err := s.Charts().Add(chart); err != nil {
+			s.Warning(err)
+		}
+	}
+}
+
+func (s *SNMP) removeTableRowCharts(cfg TableConfig, idx string) {
+	for _, chartCfg := range cfg.Charts {
+		s.removeChart(tableRowChartID(chartCfg.ID, idx))
+	}
+}
+
+func (s *SNMP) removeChart(id string) {
+	if chart := s.Charts().Get(id); chart != nil {
+		chart.MarkRemove()
+		chart.MarkNotCreated()
+	}
+}
+
+func tableRowChartID(id, idx string) string {
+	return fmt.Sprintf("%s_%s", id, strings.ReplaceAll(idx, ".", "_"))
+}
@@ -3,16 +3,35 @@
 package snmp
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/gosnmp/gosnmp"
 )
 
 func (s *SNMP) collect() (map[string]int64, error) {
 	collected := make(map[string]int64)
 
+	if len(s.profiles) > 0 && !s.profilesApplied {
+		if err := s.applyProfiles(); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := s.collectOIDs(collected); err != nil {
 		return nil, err
 	}
 
+	if err := s.collectTables(collected); err != nil {
+		return nil, err
+	}
+
+	if s.Interfaces {
+		if err := s.collectInterfaces(collected); err != nil {
+			return nil, err
+		}
+	}
+
 	return collected, nil
 }
 
@@ -34,22 +53,116 @@ func (s *SNMP) collectOIDs(collected map[string]int64) error {
 				continue
 			}
 
-			switch v := resp.Variables[i]; v.Type {
-			case gosnmp.Boolean,
-				gosnmp.Counter32,
-				gosnmp.Counter64,
-				gosnmp.Gauge32,
-				gosnmp.TimeTicks,
-				gosnmp.Uinteger32,
-				gosnmp.OpaqueFloat,
-				gosnmp.OpaqueDouble,
-				gosnmp.Integer:
-				collected[oid] = gosnmp.ToBigInt(v.Value).Int64()
-			default:
-				s.Debugf("skipping OID '%s' (unsupported type '%s')", oid, v.Type)
+			if v, ok := pduToInt64(resp.Variables[i]); ok {
+				collected[oid] = v
+			} else {
+				s.Debugf("skipping OID '%s' (unsupported type '%s')", oid, resp.Variables[i].Type)
 			}
 		}
 	}
 
 	return nil
 }
+
+func (s *SNMP) applyProfiles() error {
+	resp, err := s.snmpClient.Get([]string{oidSysObjectID})
+	if err != nil {
+		s.Errorf("cannot get sysObjectID: %v", err)
+		return err
+	}
+	s.profilesApplied = true
+
+	var sysObjectID string
+	if len(resp.Variables) > 0 && resp.Variables[0].Type == gosnmp.ObjectIdentifier {
+		sysObjectID, _ = resp.Variables[0].Value.(string)
+	}
+	if sysObjectID == "" {
+		s.Warning("device sysObjectID is not available, profiles are not applied")
+		return nil
+	}
+
+	for _, p := range s.profiles {
+		if !p.match(sysObjectID) {
+			continue
+		}
+		s.Infof("applying profile '%s' (sysObjectID '%s')", p.Name, sysObjectID)
+
+		charts, err := newCharts(p.Charts)
+		if err != nil {
+			s.Warningf("profile '%s': %v", p.Name, err)
+			continue
+		}
+		for _, chart := range *charts {
+			if err := s.charts.Add(chart); err != nil {
+				s.Warningf("profile '%s': %v", p.Name, err)
+				continue
+			}
+			for _, dim := range chart.Dims {
+				s.oids = append(s.oids, dim.ID)
+			}
+		}
+
+		tables, err := newSNMPTables(p.Tables)
+		if err != nil {
+			s.Warningf("profile '%s': %v", p.Name, err)
+			continue
+		}
+		s.tables = append(s.tables, tables...)
+	}
+
+	return nil
+}
+
+func (s *SNMP) walk(oid string) ([]gosnmp.SnmpPDU, error) {
+	// GetBulk is not supported by SNMPv1
+	if ver, _ := parseSNMPVersion(s.Options.Version); ver == gosnmp.Version1 {
+		return s.snmpClient.WalkAll(oid)
+	}
+	return s.snmpClient.BulkWalkAll(oid)
+}
+
+func pduToInt64(pdu gosnmp.SnmpPDU) (int64, bool) {
+	switch pdu.Type {
+	case gosnmp.Boolean,
+		gosnmp.Counter32,
+		gosnmp.Counter64,
+		gosnmp.Gauge32,
+		gosnmp.TimeTicks,
+		gosnmp.Uinteger32,
+		gosnmp.OpaqueFloat,
+		gosnmp.OpaqueDouble,
+		gosnmp.Integer:
+		return gosnmp.ToBigInt(pdu.Value).Int64(), true
+	default:
+		return 0, false
+	}
+}
+
+func pduToString(pdu gosnmp.SnmpPDU) string {
+	switch v := pdu.Value.(type) {
+	case []byte:
+		return strings.TrimSpace(string(v))
+	case string:
+		return v
+	}
+	if v, ok := pduToInt64(pdu); ok {
+		return strconv.FormatInt(v, 10)
+	}
+	return ""
+}
+
+// oidIndex returns the table row index: the part of the instance OID that follows the column OID.
+func oidIndex(name, column string) (string, bool) {
+	name = strings.TrimPrefix(name, ".")
+	if !strings.HasPrefix(name, column+".") {
+		return "", false
+	}
+	return name[len(column)+1:], true
+}
+
+func boolToInt(v bool) int64 {
+	if v {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp
+
+import (
+	"sort"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// IF-MIB: https://www.rfc-editor.org/rfc/rfc2863
+const (
+	oidIfDescr = "1.3.6.1.2.1.2.2.1.2"
+	oidIfName  = "1.3.6.1.2.1.31.1.1.1.1"
+	oidIfAlias = "1.3.6.1.2.1.31.1.1.1.18"
+)
+
+// ifMetrics maps IF-MIB columns to metrics. The 64-bit ifXTable counters are preferred,
+// the 32-bit ifTable ones (oid32) are used if the device doesn't support them (SNMPv1 has no Counter64).
+var ifMetrics = []struct {
+	name  string
+	oid   string
+	oid32 string
+}{
+	{name: "admin_status", oid: "1.3.6.1.2.1.2.2.1.7"},
+	{name: "oper_status", oid: "1.3.6.1.2.1.2.2.1.8"},
+	{name: "speed", oid: "1.3.6.1.2.1.31.1.1.1.15"},
+	{name: "traffic_in", oid: "1.3.6.1.2.1.31.1.1.1.6", oid32: "1.3.6.1.2.1.2.2.1.10"},
+	{name: "traffic_out", oid: "1.3.6.1.2.1.31.1.1.1.10", oid32: "1.3.6.1.2.1.2.2.1.16"},
+	{name: "packets_in_unicast", oid: "1.3.6.1.2.1.31.1.1.1.7", oid32: "1.3.6.1.2.1.2.2.1.11"},
+	{name: "packets_in_multicast", oid: "1.3.6.1.2.1.31.1.1.1.8", oid32: "1.3.6.1.2.1.31.1.1.1.2"},
+	{name: "packets_in_broadcast", oid: "1.3.6.1.2.1.31.1.1.1.9", oid32: "1.3.6.1.2.1.31.1.1.1.3"},
+	{name: "packets_out_unicast", oid: "1.3.6.1.2.1.31.1.1.1.11", oid32: "1.3.6.1.2.1.2.2.1.17"},
+	{name: "packets_out_multicast", oid: "1.3.6.1.2.1.31.1.1.1.12", oid32: "1.3.6.1.2.1.31.1.1.1.4"},
+	{name: "packets_out_broadcast", oid: "1.3.6.1.2.1.31.1.1.1.13", oid32: "1.3.6.1.2.1.31.1.1.1.5"},
+	{name: "discards_in", oid: "1.3.6.1.2.1.2.2.1.13"},
+	{name: "errors_in", oid: "1.3.6.1.2.1.2.2.1.14"},
+	{name: "discards_out", oid: "1.3.6.1.2.1.2.2.1.19"},
+	{name: "errors_out", oid: "1.3.6.1.2.1.2.2.1.20"},
+}
+
+var (
+	ifAdminStatuses = []string{"up", "down", "testing"}
+	ifOperStatuses  = []string{"up", "down", "testing", "unknown", "dormant", "not_present", "lower_layer_down"}
+)
+
+func (s *SNMP) collectInterfaces(collected map[string]int64) error {
+	seen := make(map[string]bool)
+	ver, _ := parseSNMPVersion(s.Options.Version)
+
+	for _, m := range ifMetrics {
+		oid := m.oid
+		if m.oid32 != "" && ver == gosnmp.Version1 {
+			oid = m.oid32
+		}
+		pdus, err := s.walk(oid)
+		if err != nil {
+			s.Errorf("cannot walk IF-MIB column '%s': %v", oid, err)
+			return err
+		}
+		if len(pdus) == 0 && m.oid32 != "" && oid != m.oid32 {
+			oid = m.oid32
+			if pdus, err = s.walk(oid); err != nil {
+				s.Errorf("cannot walk IF-MIB column '%s': %v", oid, err)
+				return err
+			}
+		}
+
+		for _, pdu := range pdus {
+			idx, ok := oidIndex(pdu.Name, oid)
+			if !ok {
+				continue
+			}
+			v, ok := pduToInt64(pdu)
+			if !ok {
+				continue
+			}
+			px := "interface_" + idx + "_"
+			switch m.name {
+			case "admin_status":
+				for i, st := range ifAdminStatuses {
+					collected[px+"admin_status_"+st] = boolToInt(v == int64(i+1))
+				}
+			case "oper_status":
+				seen[idx] = true
+				for i, st := range ifOperStatuses {
+					collected[px+"oper_status_"+st] = boolToInt(v == int64(i+1))
+				}
+			default:
+				collected[px+m.name] = v
+			}
+		}
+	}
+
+	var newIfaces []string
+	for idx := range seen {
+		if !s.interfaces[idx] {
+			newIfaces = append(newIfaces, idx)
+		}
+	}
+	if len(newIfaces) > 0 {
+		names, err := s.walkInterfaceNames()
+		if err != nil {
+			s.Errorf("cannot walk IF-MIB interface names: %v", err)
+			return err
+		}
+		sort.Strings(newIfaces)
+		for _, idx := range newIfaces {
+			s.interfaces[idx] = true
+			n := names[idx]
+			if n == nil {
+				n = &ifName{}
+			}
+			s.addInterfaceCharts(idx, n.name, n.alias)
+		}
+	}
+
+	for idx := range s.interfaces {
+		if !seen[idx] {
+			delete(s.interfaces, idx)
+			s.removeInterfaceCharts(idx)
+		}
+	}
+
+	return nil
+}
+
+type ifName struct {
+	name  string
+	alias string
+}
+
+func (s *SNMP) walkInterfaceNames() (map[string]*ifName, error) {
+	names := make(map[string]*ifName)
+	get := func(idx string) *ifName {
+		if names[idx] == nil {
+			names[idx] = &ifName{}
+		}
+		return names[idx]
+	}
+
+	// ifName is preferred, ifDescr is used if the device doesn't support ifXTable
+	for _, oid := range []string{oidIfDescr, oidIfName, oidIfAlias} {
+		pdus, err := s.walk(oid)
+		if err != nil {
+			return nil, err
+		}
+		for _, pdu := range pdus {
+			idx, ok := oidIndex(pdu.Name, oid)
+			if !ok {
+				continue
+			}
+			v := pduToString(pdu)
+			switch oid {
+			case oidIfDescr, oidIfName:
+				if v != "" {
+					get(idx).name = v
+				}
+			case oidIfAlias:
+				get(idx).alias = v
+			}
+		}
+	}
+	return names, nil
+}
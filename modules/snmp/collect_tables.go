@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+)
+
+type snmpTable struct {
+	cfg     TableConfig
+	columns []string
+	rows    map[string]bool
+}
+
+func newSNMPTables(configs []TableConfig) ([]*snmpTable, error) {
+	var tables []*snmpTable
+	for i, cfg := range configs {
+		if err := validateTableConfig(cfg); err != nil {
+			return nil, fmt.Errorf("table #%d: %v", i+1, err)
+		}
+
+		t := &snmpTable{cfg: cfg, rows: make(map[string]bool)}
+		seen := make(map[string]bool)
+		for _, c := range cfg.Charts {
+			for _, d := range c.Dimensions {
+				oid := strings.TrimPrefix(d.OID, ".")
+				if !seen[oid] {
+					seen[oid] = true
+					t.columns = append(t.columns, oid)
+				}
+			}
+		}
+		tables = append(tables, t)
+	}
+	return tables, nil
+}
+
+func validateTableConfig(cfg TableConfig) error {
+	if len(cfg.Charts) == 0 {
+		return errors.New("'charts' are required but not set")
+	}
+	for _, c := range cfg.Charts {
+		if len(c.IndexRange) != 0 {
+			return fmt.Errorf("chart '%s': 'multiply_range' is not supported in tables", c.ID)
+		}
+		if len(c.Dimensions) == 0 {
+			return fmt.Errorf("chart '%s': 'dimensions' are required but not set", c.ID)
+		}
+	}
+	for _, l := range cfg.Labels {
+		if l.Name == "" || l.OID == "" {
+			return errors.New("'labels' require both 'name' and 'oid'")
+		}
+	}
+	if _, err := newCharts(cfg.Charts); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *SNMP) collectTables(collected map[string]int64) error {
+	for _, t := range s.tables {
+		if err := s.collectTable(collected, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SNMP) collectTable(collected map[string]int64, t *snmpTable) error {
+	seen := make(map[string]bool)
+
+	for _, column := range t.columns {
+		pdus, err := s.walk(column)
+		if err != nil {
+			s.Errorf("cannot walk SNMP table column '%s': %v", column, err)
+			return err
+		}
+		for _, pdu := range pdus {
+			idx, ok := oidIndex(pdu.Name, column)
+			if !ok {
+				continue
+			}
+			if v, ok := pduToInt64(pdu); ok {
+				collected[column+"."+idx] = v
+				seen[idx] = true
+			}
+		}
+	}
+
+	var newRows []string
+	for idx := range seen {
+		if !t.rows[idx] {
+			newRows = append(newRows, idx)
+		}
+	}
+	if len(newRows) > 0 {
+		labels, err := s.walkTableLabels(t.cfg.Labels)
+		if err != nil {
+			s.Errorf("cannot walk SNMP table labels: %v", err)
+			return err
+		}
+		sort.Strings(newRows)
+		for _, idx := range newRows {
+			t.rows[idx] = true
+			s.addTableRowCharts(t.cfg, idx, labels[idx])
+		}
+	}
+
+	for idx := range t.rows {
+		if !seen[idx] {
+			delete(t.rows, idx)
+			s.removeTableRowCharts(t.cfg, idx)
+		}
+	}
+
+	return nil
+}
+
+func (s *SNMP) walkTableLabels(configs []TableLabelConfig) (map[string][]module.Label, error) {
+	labels := make(map[string][]module.Label)
+	for _, cfg := range configs {
+		column := strings.TrimPrefix(cfg.OID, ".")
+		pdus, err := s.walk(column)
+		if err != nil {
+			return nil, err
+		}
+		for _, pdu := range pdus {
+			if idx, ok := oidIndex(pdu.Name, column); ok {
+				labels[idx] = append(labels[idx], module.Label{Key: cfg.Name, Value: cleanLabelValue(pduToString(pdu))})
+			}
+		}
+	}
+	return labels, nil
+}
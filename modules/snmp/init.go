@@ -7,27 +7,29 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/netdata/go.d.plugin/pkg/snmpv3"
+
 	"github.com/gosnmp/gosnmp"
 )
 
 var newSNMPClient = gosnmp.NewHandler
 
 func (s SNMP) validateConfig() error {
-	if len(s.ChartsInput) == 0 {
-		return errors.New("'charts' are required but not set")
+	if len(s.ChartsInput) == 0 && len(s.Tables) == 0 && len(s.Profiles) == 0 && !s.Interfaces {
+		return errors.New("'charts', 'tables', 'profiles' or 'collect_interfaces' are required but not set")
 	}
 
 	if s.Options.Version == gosnmp.Version3.String() {
 		if s.User.Name == "" {
 			return errors.New("'user.name' is required when using SNMPv3 but not set")
 		}
-		if _, err := parseSNMPv3SecurityLevel(s.User.SecurityLevel); err != nil {
+		if _, err := snmpv3.ParseSecurityLevel(s.User.SecurityLevel); err != nil {
 			return err
 		}
-		if _, err := parseSNMPv3AuthProtocol(s.User.AuthProto); err != nil {
+		if _, err := snmpv3.ParseAuthProtocol(s.User.AuthProto); err != nil {
 			return err
 		}
-		if _, err := parseSNMPv3PrivProtocol(s.User.PrivProto); err != nil {
+		if _, err := snmpv3.ParsePrivProtocol(s.User.PrivProto); err != nil {
 			return err
 		}
 	}
@@ -119,71 +121,16 @@ func parseSNMPVersion(version string) (gosnmp.SnmpVersion, error) {
 }
 
 func safeParseSNMPv3SecurityLevel(level string) gosnmp.SnmpV3MsgFlags {
-	v, _ := parseSNMPv3SecurityLevel(level)
+	v, _ := snmpv3.ParseSecurityLevel(level)
 	return v
 }
 
-func parseSNMPv3SecurityLevel(level string) (gosnmp.SnmpV3MsgFlags, error) {
-	switch level {
-	case "1", "none", "noAuthNoPriv", "":
-		return gosnmp.NoAuthNoPriv, nil
-	case "2", "authNoPriv":
-		return gosnmp.AuthNoPriv, nil
-	case "3", "authPriv":
-		return gosnmp.AuthPriv, nil
-	default:
-		return gosnmp.NoAuthNoPriv, fmt.Errorf("invalid snmpv3 user security level value (%s)", level)
-	}
-}
-
 func safeParseSNMPv3AuthProtocol(protocol string) gosnmp.SnmpV3AuthProtocol {
-	v, _ := parseSNMPv3AuthProtocol(protocol)
+	v, _ := snmpv3.ParseAuthProtocol(protocol)
 	return v
 }
 
-func parseSNMPv3AuthProtocol(protocol string) (gosnmp.SnmpV3AuthProtocol, error) {
-	switch protocol {
-	case "1", "none", "noAuth", "":
-		return gosnmp.NoAuth, nil
-	case "2", "md5":
-		return gosnmp.MD5, nil
-	case "3", "sha":
-		return gosnmp.SHA, nil
-	case "4", "sha224":
-		return gosnmp.SHA224, nil
-	case "5", "sha256":
-		return gosnmp.SHA256, nil
-	case "6", "sha384":
-		return gosnmp.SHA384, nil
-	case "7", "sha512":
-		return gosnmp.SHA512, nil
-	default:
-		return gosnmp.NoAuth, fmt.Errorf("invalid snmpv3 user auth protocol value (%s)", protocol)
-	}
-}
-
 func safeParseSNMPv3PrivProtocol(protocol string) gosnmp.SnmpV3PrivProtocol {
-	v, _ := parseSNMPv3PrivProtocol(protocol)
+	v, _ := snmpv3.ParsePrivProtocol(protocol)
 	return v
 }
-
-func parseSNMPv3PrivProtocol(protocol string) (gosnmp.SnmpV3PrivProtocol, error) {
-	switch protocol {
-	case "1", "none", "noPriv", "":
-		return gosnmp.NoPriv, nil
-	case "2", "des":
-		return gosnmp.DES, nil
-	case "3", "aes":
-		return gosnmp.AES, nil
-	case "4", "aes192":
-		return gosnmp.AES192, nil
-	case "5", "aes256":
-		return gosnmp.AES256, nil
-	case "6", "aes192c":
-		return gosnmp.AES192C, nil
-	case "7", "aes256c":
-		return gosnmp.AES256C, nil
-	default:
-		return gosnmp.NoPriv, fmt.Errorf("invalid snmpv3 user priv protocol value (%s)", protocol)
-	}
-}
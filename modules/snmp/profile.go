@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/netdata/go.d.plugin/pkg/matcher"
+
+	"gopkg.in/yaml.v2"
+)
+
+// oidSysObjectID is SNMPv2-MIB::sysObjectID, the vendor's authoritative identification of the device.
+const oidSysObjectID = "1.3.6.1.2.1.1.2.0"
+
+// Profile is a reusable set of metric definitions applied to the devices whose sysObjectID matches.
+type Profile struct {
+	Name         string        `yaml:"name"`
+	SysObjectIDs []string      `yaml:"sysobjectid"`
+	Charts       []ChartConfig `yaml:"charts"`
+	Tables       []TableConfig `yaml:"tables"`
+
+	sysObjectIDMatcher matcher.Matcher
+}
+
+func (p *Profile) match(sysObjectID string) bool {
+	return p.sysObjectIDMatcher.MatchString(strings.TrimPrefix(sysObjectID, "."))
+}
+
+func loadProfiles(paths []string) ([]*Profile, error) {
+	var profiles []*Profile
+	for _, path := range paths {
+		files, err := profileFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			p, err := loadProfile(file)
+			if err != nil {
+				return nil, fmt.Errorf("profile '%s': %v", file, err)
+			}
+			profiles = append(profiles, p)
+		}
+	}
+	return profiles, nil
+}
+
+func profileFiles(path string) ([]string, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	for _, pattern := range []string{"*.yaml", "*.yml"} {
+		matches, err := filepath.Glob(filepath.Join(path, pattern))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	return files, nil
+}
+
+func loadProfile(file string) (*Profile, error) {
+	bs, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var p Profile
+	if err := yaml.Unmarshal(bs, &p); err != nil {
+		return nil, err
+	}
+
+	if p.Name == "" {
+		p.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+	if len(p.SysObjectIDs) == 0 {
+		return nil, errors.New("'sysobjectid' is required but not set")
+	}
+	if len(p.Charts) == 0 && len(p.Tables) == 0 {
+		return nil, errors.New("'charts' or 'tables' are required but not set")
+	}
+
+	mr := matcher.FALSE()
+	for _, id := range p.SysObjectIDs {
+		m, err := matcher.NewGlobMatcher(strings.TrimPrefix(id, "."))
+		if err != nil {
+			return nil, fmt.Errorf("invalid sysobjectid '%s': %v", id, err)
+		}
+		mr = matcher.Or(mr, m)
+	}
+	p.sysObjectIDMatcher = mr
+
+	if _, err := newCharts(p.Charts); err != nil {
+		return nil, err
+	}
+	if _, err := newSNMPTables(p.Tables); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/snmpv3"
 
 	"github.com/gosnmp/gosnmp"
 )
@@ -46,6 +47,7 @@ func New() *SNMP {
 				MaxOIDs: defaultMaxOIDs,
 			},
 		},
+		interfaces: make(map[string]bool),
 	}
 }
 
@@ -57,14 +59,9 @@ type (
 		User        User          `yaml:"user"`
 		Options     Options       `yaml:"options"`
 		ChartsInput []ChartConfig `yaml:"charts"`
-	}
-	User struct {
-		Name          string `yaml:"name"`
-		SecurityLevel string `yaml:"level"`
-		AuthProto     string `yaml:"auth_proto"`
-		AuthKey       string `yaml:"auth_key"`
-		PrivProto     string `yaml:"priv_proto"`
-		PrivKey       string `yaml:"priv_key"`
+		Tables      []TableConfig `yaml:"tables"`
+		Interfaces  bool          `yaml:"collect_interfaces"`
+		Profiles    []string      `yaml:"profiles"`
 	}
 	Options struct {
 		Port    int    `yaml:"port"`
@@ -90,8 +87,18 @@ type (
 		Multiplier int    `yaml:"multiplier"`
 		Divisor    int    `yaml:"divisor"`
 	}
+	TableConfig struct {
+		Labels []TableLabelConfig `yaml:"labels"`
+		Charts []ChartConfig      `yaml:"charts"`
+	}
+	TableLabelConfig struct {
+		Name string `yaml:"name"`
+		OID  string `yaml:"oid"`
+	}
 )
 
+type User = snmpv3.User
+
 type SNMP struct {
 	module.Base
 	Config `yaml:",inline"`
@@ -99,6 +106,12 @@ type SNMP struct {
 	charts     *module.Charts
 	snmpClient gosnmp.Handler
 	oids       []string
+
+	tables     []*snmpTable
+	interfaces map[string]bool
+
+	profiles        []*Profile
+	profilesApplied bool
 }
 
 func (s *SNMP) Init() bool {
@@ -132,6 +145,20 @@ func (s *SNMP) Init() bool {
 
 	s.oids = s.initOIDs()
 
+	tables, err := newSNMPTables(s.Tables)
+	if err != nil {
+		s.Errorf("tables initialization: %v", err)
+		return false
+	}
+	s.tables = tables
+
+	profiles, err := loadProfiles(s.Profiles)
+	if err != nil {
+		s.Errorf("profiles loading: %v", err)
+		return false
+	}
+	s.profiles = profiles
+
 	return true
 }
 
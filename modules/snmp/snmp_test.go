@@ -402,6 +402,214 @@ func TestSNMP_Charts(t *testing.T) {
 	}
 }
 
+func TestSNMP_Init_TablesAndProfiles(t *testing.T) {
+	tests := map[string]struct {
+		prepareConfig func() Config
+		wantFail      bool
+	}{
+		"success when only 'collect_interfaces' is set": {
+			prepareConfig: func() Config {
+				cfg := prepareV2Config()
+				cfg.ChartsInput = nil
+				cfg.Interfaces = true
+				return cfg
+			},
+		},
+		"success when loading profiles from a directory": {
+			prepareConfig: func() Config {
+				cfg := prepareV2Config()
+				cfg.ChartsInput = nil
+				cfg.Profiles = []string{"testdata/profiles"}
+				return cfg
+			},
+		},
+		"fail when profile does not exist": {
+			wantFail: true,
+			prepareConfig: func() Config {
+				cfg := prepareV2Config()
+				cfg.Profiles = []string{"testdata/profiles/not_exist.yaml"}
+				return cfg
+			},
+		},
+		"fail when table has no charts": {
+			wantFail: true,
+			prepareConfig: func() Config {
+				cfg := prepareV2Config()
+				cfg.Tables = []TableConfig{{Labels: []TableLabelConfig{{Name: "device", OID: "1.3.6.1.4.1.2021.13.15.1.1.2"}}}}
+				return cfg
+			},
+		},
+		"fail when table chart uses 'multiply_range'": {
+			wantFail: true,
+			prepareConfig: func() Config {
+				cfg := prepareV2Config()
+				cfg.Tables = []TableConfig{{Charts: prepareConfigWithIndexRange(prepareV2Config, 0, 1).ChartsInput}}
+				return cfg
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockSNMP, cleanup := mockInit(t)
+			defer cleanup()
+
+			newSNMPClient = func() gosnmp.Handler { return mockSNMP }
+			defaultMockExpects(mockSNMP)
+
+			snmp := New()
+			snmp.Config = test.prepareConfig()
+
+			if test.wantFail {
+				assert.False(t, snmp.Init())
+			} else {
+				assert.True(t, snmp.Init())
+			}
+		})
+	}
+}
+
+func TestSNMP_Collect_Interfaces(t *testing.T) {
+	mockSNMP, cleanup := mockInit(t)
+	defer cleanup()
+
+	newSNMPClient = func() gosnmp.Handler { return mockSNMP }
+	defaultMockExpects(mockSNMP)
+
+	// the device has no 64-bit multicast/broadcast counters
+	walkData := map[string][]gosnmp.SnmpPDU{
+		oidIfDescr: {
+			{Name: "." + oidIfDescr + ".1", Type: gosnmp.OctetString, Value: []byte("GigabitEthernet1/0/1")},
+			{Name: "." + oidIfDescr + ".2", Type: gosnmp.OctetString, Value: []byte("GigabitEthernet1/0/2")},
+		},
+		oidIfName: {
+			{Name: "." + oidIfName + ".1", Type: gosnmp.OctetString, Value: []byte("Gi1/0/1")},
+			{Name: "." + oidIfName + ".2", Type: gosnmp.OctetString, Value: []byte("Gi1/0/2")},
+		},
+		oidIfAlias: {
+			{Name: "." + oidIfAlias + ".1", Type: gosnmp.OctetString, Value: []byte("uplink")},
+			{Name: "." + oidIfAlias + ".2", Type: gosnmp.OctetString, Value: []byte("core's link\r\n")},
+		},
+		"1.3.6.1.2.1.2.2.1.8": {
+			{Name: ".1.3.6.1.2.1.2.2.1.8.1", Type: gosnmp.Integer, Value: 1},
+			{Name: ".1.3.6.1.2.1.2.2.1.8.2", Type: gosnmp.Integer, Value: 7},
+		},
+		"1.3.6.1.2.1.31.1.1.1.6": {
+			{Name: ".1.3.6.1.2.1.31.1.1.1.6.1", Type: gosnmp.Counter64, Value: uint64(5000000000)},
+			{Name: ".1.3.6.1.2.1.31.1.1.1.6.2", Type: gosnmp.Counter64, Value: uint64(0)},
+		},
+		"1.3.6.1.2.1.31.1.1.1.2": {
+			{Name: ".1.3.6.1.2.1.31.1.1.1.2.1", Type: gosnmp.Counter32, Value: uint(42)},
+		},
+		"1.3.6.1.2.1.31.1.1.1.15": {
+			{Name: ".1.3.6.1.2.1.31.1.1.1.15.1", Type: gosnmp.Gauge32, Value: uint(1000)},
+		},
+	}
+	mockSNMP.EXPECT().BulkWalkAll(gomock.Any()).DoAndReturn(func(oid string) ([]gosnmp.SnmpPDU, error) {
+		return walkData[oid], nil
+	}).AnyTimes()
+
+	snmp := New()
+	snmp.Config = prepareV2Config()
+	snmp.ChartsInput = nil
+	snmp.Interfaces = true
+	require.True(t, snmp.Init())
+
+	mx := snmp.Collect()
+
+	assert.Equal(t, int64(5000000000), mx["interface_1_traffic_in"])
+	assert.Equal(t, int64(42), mx["interface_1_packets_in_multicast"])
+	assert.Equal(t, int64(1000), mx["interface_1_speed"])
+	assert.Equal(t, int64(1), mx["interface_1_oper_status_up"])
+	assert.Equal(t, int64(1), mx["interface_2_oper_status_lower_layer_down"])
+	require.True(t, snmp.Charts().Has("interface_traffic_1"))
+	require.True(t, snmp.Charts().Has("interface_traffic_2"))
+	assert.Equal(t, []module.Label{{Key: "interface", Value: "Gi1/0/1"}, {Key: "alias", Value: "uplink"}},
+		snmp.Charts().Get("interface_traffic_1").Labels)
+	assert.Equal(t, []module.Label{{Key: "interface", Value: "Gi1/0/2"}, {Key: "alias", Value: "cores link"}},
+		snmp.Charts().Get("interface_traffic_2").Labels)
+
+	// interface 2 went away
+	walkData["1.3.6.1.2.1.2.2.1.8"] = walkData["1.3.6.1.2.1.2.2.1.8"][:1]
+
+	mx = snmp.Collect()
+
+	assert.NotContains(t, mx, "interface_2_oper_status_up")
+	assert.False(t, snmp.Charts().Get("interface_traffic_1").Obsolete)
+	assert.True(t, snmp.Charts().Get("interface_traffic_2").Obsolete)
+	assert.Len(t, snmp.interfaces, 1)
+}
+
+func TestSNMP_Collect_Profiles(t *testing.T) {
+	mockSNMP, cleanup := mockInit(t)
+	defer cleanup()
+
+	newSNMPClient = func() gosnmp.Handler { return mockSNMP }
+	defaultMockExpects(mockSNMP)
+
+	const (
+		oidDiskIODevice  = "1.3.6.1.4.1.2021.13.15.1.1.2"
+		oidDiskIORead    = "1.3.6.1.4.1.2021.13.15.1.1.12"
+		oidDiskIOWritten = "1.3.6.1.4.1.2021.13.15.1.1.13"
+	)
+	walkData := map[string][]gosnmp.SnmpPDU{
+		oidDiskIODevice: {
+			{Name: "." + oidDiskIODevice + ".1", Type: gosnmp.OctetString, Value: []byte("sda")},
+			{Name: "." + oidDiskIODevice + ".2", Type: gosnmp.OctetString, Value: []byte("sdb")},
+		},
+		oidDiskIORead: {
+			{Name: "." + oidDiskIORead + ".1", Type: gosnmp.Counter64, Value: uint64(1024)},
+			{Name: "." + oidDiskIORead + ".2", Type: gosnmp.Counter64, Value: uint64(2048)},
+		},
+		oidDiskIOWritten: {
+			{Name: "." + oidDiskIOWritten + ".1", Type: gosnmp.Counter64, Value: uint64(4096)},
+			{Name: "." + oidDiskIOWritten + ".2", Type: gosnmp.Counter64, Value: uint64(8192)},
+		},
+	}
+	mockSNMP.EXPECT().BulkWalkAll(gomock.Any()).DoAndReturn(func(oid string) ([]gosnmp.SnmpPDU, error) {
+		return walkData[oid], nil
+	}).AnyTimes()
+	gomock.InOrder(
+		mockSNMP.EXPECT().Get([]string{oidSysObjectID}).Return(&gosnmp.SnmpPacket{
+			Variables: []gosnmp.SnmpPDU{
+				{Name: "." + oidSysObjectID, Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.4.1.8072.3.2.10"},
+			},
+		}, nil).Times(1),
+		mockSNMP.EXPECT().Get(gomock.Any()).Return(&gosnmp.SnmpPacket{
+			Variables: []gosnmp.SnmpPDU{
+				{Type: gosnmp.Integer, Value: 10},
+				{Type: gosnmp.Integer, Value: 20},
+				{Type: gosnmp.Integer, Value: 30},
+				{Type: gosnmp.Integer, Value: 40},
+				{Type: gosnmp.Integer, Value: 50},
+			},
+		}, nil).Times(1),
+	)
+
+	snmp := New()
+	snmp.Config = prepareV2Config()
+	snmp.Profiles = []string{"testdata/profiles/net-snmp.yaml"}
+	require.True(t, snmp.Init())
+
+	mx := snmp.Collect()
+
+	expected := map[string]int64{
+		"1.3.6.1.2.1.2.2.1.10":      10,
+		"1.3.6.1.2.1.2.2.1.16":      20,
+		"1.3.6.1.4.1.2021.10.1.5.1": 30,
+		"1.3.6.1.4.1.2021.10.1.5.2": 40,
+		"1.3.6.1.4.1.2021.10.1.5.3": 50,
+		oidDiskIORead + ".1":        1024,
+		oidDiskIORead + ".2":        2048,
+		oidDiskIOWritten + ".1":     4096,
+		oidDiskIOWritten + ".2":     8192,
+	}
+	assert.Equal(t, expected, mx)
+	assert.True(t, snmp.Charts().Has("system_load"))
+	assert.True(t, snmp.Charts().Has("disk_io_1"))
+	assert.Equal(t, []module.Label{{Key: "device", Value: "sdb"}}, snmp.Charts().Get("disk_io_2").Labels)
+}
+
 func mockInit(t *testing.T) (*snmpmock.MockHandler, func()) {
 	mockCtl := gomock.NewController(t)
 	cleanup := func() { mockCtl.Finish() }
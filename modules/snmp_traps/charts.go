@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp_traps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+)
+
+const (
+	prioNotifications = module.Priority + iota
+	prioErrors
+	prioTrapsByType
+	prioSourceTraps
+)
+
+var baseCharts = module.Charts{
+	notificationsChart.Copy(),
+	errorsChart.Copy(),
+	trapsByTypeChart.Copy(),
+}
+
+var (
+	notificationsChart = module.Chart{
+		ID:       "notifications",
+		Title:    "Received notifications",
+		Units:    "notifications/s",
+		Fam:      "notifications",
+		Ctx:      "snmp_traps.notifications",
+		Priority: prioNotifications,
+		Type:     module.Stacked,
+		Dims: module.Dims{
+			{ID: "traps", Algo: module.Incremental},
+			{ID: "informs", Algo: module.Incremental},
+		},
+	}
+	errorsChart = module.Chart{
+		ID:       "errors",
+		Title:    "Rejected packets",
+		Units:    "packets/s",
+		Fam:      "notifications",
+		Ctx:      "snmp_traps.errors",
+		Priority: prioErrors,
+		Type:     module.Stacked,
+		Dims: module.Dims{
+			{ID: "decode_errors", Name: "decode", Algo: module.Incremental},
+			{ID: "auth_failures", Name: "auth", Algo: module.Incremental},
+		},
+	}
+	trapsByTypeChart = module.Chart{
+		ID:       "traps_by_type",
+		Title:    "Notifications by trap OID",
+		Units:    "notifications/s",
+		Fam:      "notifications",
+		Ctx:      "snmp_traps.traps_by_type",
+		Priority: prioTrapsByType,
+		Type:     module.Stacked,
+	}
+)
+
+var sourceTrapsChartTmpl = module.Chart{
+	ID:       "source_%s_traps",
+	Title:    "Notifications by source",
+	Units:    "notifications/s",
+	Fam:      "sources",
+	Ctx:      "snmp_traps.source_traps",
+	Priority: prioSourceTraps,
+	Type:     module.Stacked,
+}
+
+func (s *SNMPTraps) addTrapTypeDim(oid string) {
+	chart := s.Charts().Get(trapsByTypeChart.ID)
+	if chart == nil {
+		return
+	}
+	dim := &module.Dim{ID: "type_" + oid, Name: s.trapName(oid), Algo: module.Incremental}
+	if err := chart.AddDim(dim); err != nil {
+		s.Warning(err)
+		return
+	}
+	chart.MarkNotCreated()
+}
+
+func (s *SNMPTraps) addSourceCharts(source string) {
+	chart := sourceTrapsChartTmpl.Copy()
+	chart.ID = fmt.Sprintf(chart.ID, cleanSource(source))
+	chart.Labels = []module.Label{
+		{Key: "source", Value: source},
+	}
+
+	if err := s.Charts().Add(chart); err != nil {
+		s.Warning(err)
+	}
+}
+
+func (s *SNMPTraps) addSourceTrapTypeDim(source, oid string) {
+	chart := s.Charts().Get(fmt.Sprintf(sourceTrapsChartTmpl.ID, cleanSource(source)))
+	if chart == nil {
+		return
+	}
+	dim := &module.Dim{ID: sourceTrapTypeKey(source, oid), Name: s.trapName(oid), Algo: module.Incremental}
+	if err := chart.AddDim(dim); err != nil {
+		s.Warning(err)
+		return
+	}
+	chart.MarkNotCreated()
+}
+
+func (s *SNMPTraps) trapName(oid string) string {
+	if name, ok := s.trapNames[oid]; ok {
+		return name
+	}
+	return oid
+}
+
+func sourceTrapTypeKey(source, oid string) string {
+	return "source_" + cleanSource(source) + "_type_" + oid
+}
+
+func cleanSource(source string) string {
+	r := strings.NewReplacer(".", "_", ":", "_")
+	return r.Replace(source)
+}
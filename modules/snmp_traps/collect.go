@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp_traps
+
+func (s *SNMPTraps) collect() (map[string]int64, error) {
+	if err := s.startListener(); err != nil {
+		return nil, err
+	}
+
+	st := s.stats
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	mx := map[string]int64{
+		"traps":         st.traps,
+		"informs":       st.informs,
+		"decode_errors": st.decodeErrors,
+		"auth_failures": st.authFailures,
+	}
+
+	for oid, v := range st.byType {
+		if !s.seenTypes[oid] {
+			s.seenTypes[oid] = true
+			s.addTrapTypeDim(oid)
+		}
+		mx["type_"+oid] = v
+	}
+
+	for source, types := range st.bySource {
+		if !s.seenSources[source] {
+			s.seenSources[source] = true
+			s.addSourceCharts(source)
+		}
+		for oid, v := range types {
+			key := sourceTrapTypeKey(source, oid)
+			if !s.seenSrcTypes[key] {
+				s.seenSrcTypes[key] = true
+				s.addSourceTrapTypeDim(source, oid)
+			}
+			mx[key] = v
+		}
+	}
+
+	return mx, nil
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp_traps
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/netdata/go.d.plugin/pkg/snmpv3"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// Standard SNMPv2-MIB::snmpTraps notifications, the v2 equivalents of the v1 generic traps.
+var defaultTrapNames = map[string]string{
+	"1.3.6.1.6.3.1.1.5.1": "coldStart",
+	"1.3.6.1.6.3.1.1.5.2": "warmStart",
+	"1.3.6.1.6.3.1.1.5.3": "linkDown",
+	"1.3.6.1.6.3.1.1.5.4": "linkUp",
+	"1.3.6.1.6.3.1.1.5.5": "authenticationFailure",
+	"1.3.6.1.6.3.1.1.5.6": "egpNeighborLoss",
+}
+
+func (s *SNMPTraps) validateConfig() error {
+	if s.Address == "" {
+		return errors.New("'address' is required but not set")
+	}
+	if len(s.Communities) == 0 && len(s.Users) == 0 {
+		return errors.New("'communities' or 'users' are required but not set")
+	}
+	if s.MaxSources <= 0 {
+		return fmt.Errorf("'max_sources' must be positive, got %d", s.MaxSources)
+	}
+	if s.MaxTrapTypes <= 0 {
+		return fmt.Errorf("'max_trap_types' must be positive, got %d", s.MaxTrapTypes)
+	}
+	return nil
+}
+
+func (s *SNMPTraps) initCommunities() map[string]bool {
+	communities := make(map[string]bool)
+	for _, c := range s.Communities {
+		communities[c] = true
+	}
+	return communities
+}
+
+func (s *SNMPTraps) initTrapNames() map[string]string {
+	names := make(map[string]string)
+	for oid, name := range defaultTrapNames {
+		names[oid] = name
+	}
+	for oid, name := range s.TrapNames {
+		names[strings.TrimPrefix(oid, ".")] = name
+	}
+	return names
+}
+
+func (s *SNMPTraps) initUsers() ([]*usmUser, error) {
+	var users []*usmUser
+	for i, u := range s.Users {
+		if u.Name == "" {
+			return nil, fmt.Errorf("user %d: 'name' is required but not set", i+1)
+		}
+		level, err := snmpv3.ParseSecurityLevel(u.SecurityLevel)
+		if err != nil {
+			return nil, fmt.Errorf("user '%s': %v", u.Name, err)
+		}
+		authProto, err := snmpv3.ParseAuthProtocol(u.AuthProto)
+		if err != nil {
+			return nil, fmt.Errorf("user '%s': %v", u.Name, err)
+		}
+		privProto, err := snmpv3.ParsePrivProtocol(u.PrivProto)
+		if err != nil {
+			return nil, fmt.Errorf("user '%s': %v", u.Name, err)
+		}
+
+		users = append(users, &usmUser{
+			name:  u.Name,
+			level: level,
+			decoder: &gosnmp.GoSNMP{
+				Version:       gosnmp.Version3,
+				SecurityModel: gosnmp.UserSecurityModel,
+				MsgFlags:      level,
+				SecurityParameters: &gosnmp.UsmSecurityParameters{
+					UserName:                 u.Name,
+					AuthenticationProtocol:   authProto,
+					AuthenticationPassphrase: u.AuthKey,
+					PrivacyProtocol:          privProto,
+					PrivacyPassphrase:        u.PrivKey,
+				},
+			},
+		})
+	}
+	return users, nil
+}
@@ -0,0 +1,257 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp_traps
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+const (
+	// oidSnmpTrapOID is SNMPv2-MIB::snmpTrapOID.0, the second varbind of every v2c/v3 notification.
+	oidSnmpTrapOID = "1.3.6.1.6.3.1.1.4.1.0"
+	// oidSnmpTraps is SNMPv2-MIB::snmpTraps, the v2 equivalents of the v1 generic traps are its children.
+	oidSnmpTraps = "1.3.6.1.6.3.1.1.5"
+
+	otherKey = "other"
+)
+
+type usmUser struct {
+	name    string
+	level   gosnmp.SnmpV3MsgFlags
+	decoder *gosnmp.GoSNMP
+}
+
+type trapStats struct {
+	mu sync.Mutex
+
+	traps        int64
+	informs      int64
+	decodeErrors int64
+	authFailures int64
+
+	byType   map[string]int64
+	bySource map[string]map[string]int64
+}
+
+func newTrapStats() *trapStats {
+	return &trapStats{
+		byType:   make(map[string]int64),
+		bySource: make(map[string]map[string]int64),
+	}
+}
+
+func (s *SNMPTraps) startListener() error {
+	if s.conn != nil {
+		return nil
+	}
+
+	addr, err := net.ResolveUDPAddr("udp", s.Address)
+	if err != nil {
+		return err
+	}
+	conn, err := net.ListenUDP("udp", addr)
+	if err != nil {
+		return err
+	}
+	s.conn = conn
+
+	s.wg.Add(1)
+	go func() { defer s.wg.Done(); s.listen(conn) }()
+
+	return nil
+}
+
+func (s *SNMPTraps) stopListener() {
+	if s.conn == nil {
+		return
+	}
+	_ = s.conn.Close()
+	s.wg.Wait()
+	s.conn = nil
+}
+
+func (s *SNMPTraps) listen(conn *net.UDPConn) {
+	buf := make([]byte, s.readBufferLen)
+	for {
+		n, addr, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			s.Debugf("read from '%s': %v", conn.LocalAddr(), err)
+			continue
+		}
+		data := make([]byte, n)
+		copy(data, buf[:n])
+		s.handlePacket(conn, data, addr)
+	}
+}
+
+func (s *SNMPTraps) handlePacket(conn *net.UDPConn, data []byte, addr *net.UDPAddr) {
+	version, ok := peekVersion(data)
+	if !ok {
+		s.Debugf("malformed packet from '%s'", addr)
+		s.countError(false)
+		return
+	}
+
+	var pkt *gosnmp.SnmpPacket
+	var err error
+	var authFailed bool
+
+	if gosnmp.SnmpVersion(version) == gosnmp.Version3 {
+		pkt, err = s.decodeUSM(data)
+		authFailed = err != nil
+	} else {
+		pkt, err = (&gosnmp.GoSNMP{}).UnmarshalTrap(data, false)
+		if err == nil && !s.communities[pkt.Community] {
+			err, authFailed = errors.New("unknown community"), true
+		}
+	}
+	if err != nil {
+		s.Debugf("decode packet from '%s': %v", addr, err)
+		s.countError(authFailed)
+		return
+	}
+
+	oid := trapOID(pkt)
+	if oid == "" {
+		s.Debugf("packet from '%s' (pdu type %s) is not a notification", addr, pkt.PDUType)
+		s.countError(false)
+		return
+	}
+
+	inform := pkt.PDUType == gosnmp.InformRequest
+	if inform && pkt.Version != gosnmp.Version3 {
+		s.acknowledge(conn, pkt, addr)
+	}
+
+	s.countTrap(addr.IP.String(), oid, inform)
+}
+
+// decodeUSM tries every configured user, the packet is accepted only if
+// the user name matches and the security level is not lower than configured.
+func (s *SNMPTraps) decodeUSM(data []byte) (*gosnmp.SnmpPacket, error) {
+	for _, u := range s.users {
+		// decryption is done in place
+		buf := make([]byte, len(data))
+		copy(buf, data)
+
+		pkt, err := u.decoder.UnmarshalTrap(buf, true)
+		if err != nil {
+			continue
+		}
+		sp, ok := pkt.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+		if !ok || sp.UserName != u.name || pkt.MsgFlags&gosnmp.AuthPriv < u.level {
+			continue
+		}
+		// keep the keys localized to the sender's engine ID, they are regenerated only when it changes
+		u.decoder.SecurityParameters = sp.Copy()
+		return pkt, nil
+	}
+	return nil, errors.New("unknown user or authentication failure")
+}
+
+func (s *SNMPTraps) acknowledge(conn *net.UDPConn, pkt *gosnmp.SnmpPacket, addr *net.UDPAddr) {
+	resp := *pkt
+	resp.PDUType = gosnmp.GetResponse
+	resp.Error = gosnmp.NoError
+	resp.ErrorIndex = 0
+
+	bs, err := resp.MarshalMsg()
+	if err != nil {
+		s.Debugf("marshal inform response to '%s': %v", addr, err)
+		return
+	}
+	if _, err := conn.WriteToUDP(bs, addr); err != nil {
+		s.Debugf("send inform response to '%s': %v", addr, err)
+	}
+}
+
+func (s *SNMPTraps) countError(auth bool) {
+	s.stats.mu.Lock()
+	defer s.stats.mu.Unlock()
+
+	if auth {
+		s.stats.authFailures++
+	} else {
+		s.stats.decodeErrors++
+	}
+}
+
+func (s *SNMPTraps) countTrap(source, oid string, inform bool) {
+	st := s.stats
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if inform {
+		st.informs++
+	} else {
+		st.traps++
+	}
+
+	oid = bounded(oid, st.byType[oid] > 0, len(st.byType), st.byType[otherKey] > 0, s.MaxTrapTypes)
+	st.byType[oid]++
+
+	source = bounded(source, st.bySource[source] != nil, len(st.bySource), st.bySource[otherKey] != nil, s.MaxSources)
+	if st.bySource[source] == nil {
+		st.bySource[source] = make(map[string]int64)
+	}
+	st.bySource[source][oid]++
+}
+
+// bounded returns the key itself if it is already known or there is room for it, otherwise the "other" key.
+func bounded(key string, known bool, n int, hasOther bool, max int) string {
+	if known || key == otherKey {
+		return key
+	}
+	if hasOther {
+		n--
+	}
+	if n >= max {
+		return otherKey
+	}
+	return key
+}
+
+func trapOID(pkt *gosnmp.SnmpPacket) string {
+	switch pkt.PDUType {
+	case gosnmp.Trap:
+		if pkt.GenericTrap >= 0 && pkt.GenericTrap < 6 {
+			return oidSnmpTraps + "." + strconv.Itoa(pkt.GenericTrap+1)
+		}
+		// RFC 3584, 3.1: enterpriseSpecific traps are mapped to enterprise.0.specific-trap
+		return strings.TrimPrefix(pkt.Enterprise, ".") + ".0." + strconv.Itoa(pkt.SpecificTrap)
+	case gosnmp.SNMPv2Trap, gosnmp.InformRequest:
+		for _, pdu := range pkt.Variables {
+			if strings.TrimPrefix(pdu.Name, ".") != oidSnmpTrapOID {
+				continue
+			}
+			if v, ok := pdu.Value.(string); ok {
+				return strings.TrimPrefix(v, ".")
+			}
+		}
+	}
+	return ""
+}
+
+// peekVersion reads msgVersion from the BER encoded message header without decoding the rest of the packet.
+func peekVersion(data []byte) (int, bool) {
+	if len(data) < 2 || data[0] != 0x30 {
+		return 0, false
+	}
+	i := 2
+	if data[1]&0x80 != 0 {
+		i += int(data[1] & 0x7f)
+	}
+	if len(data) < i+3 || data[i] != 0x02 || data[i+1] != 0x01 {
+		return 0, false
+	}
+	return int(data[i+2]), true
+}
@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp_traps
+
+import (
+	"net"
+	"sync"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/snmpv3"
+)
+
+func init() {
+	module.Register("snmp_traps", module.Creator{
+		Create: func() module.Module { return New() },
+	})
+}
+
+func New() *SNMPTraps {
+	return &SNMPTraps{
+		Config: Config{
+			Address:      ":162",
+			Communities:  []string{"public"},
+			MaxSources:   100,
+			MaxTrapTypes: 100,
+		},
+		charts:        baseCharts.Copy(),
+		stats:         newTrapStats(),
+		seenTypes:     make(map[string]bool),
+		seenSources:   make(map[string]bool),
+		seenSrcTypes:  make(map[string]bool),
+		readBufferLen: 65535,
+	}
+}
+
+type (
+	Config struct {
+		UpdateEvery  int               `yaml:"update_every"`
+		Address      string            `yaml:"address"`
+		Communities  []string          `yaml:"communities"`
+		Users        []User            `yaml:"users"`
+		TrapNames    map[string]string `yaml:"trap_names"`
+		MaxSources   int               `yaml:"max_sources"`
+		MaxTrapTypes int               `yaml:"max_trap_types"`
+	}
+)
+
+type User = snmpv3.User
+
+type SNMPTraps struct {
+	module.Base
+	Config `yaml:",inline"`
+
+	charts *module.Charts
+
+	communities map[string]bool
+	users       []*usmUser
+	trapNames   map[string]string
+
+	conn          *net.UDPConn
+	wg            sync.WaitGroup
+	readBufferLen int
+
+	stats *trapStats
+
+	seenTypes    map[string]bool
+	seenSources  map[string]bool
+	seenSrcTypes map[string]bool
+}
+
+func (s *SNMPTraps) Init() bool {
+	if err := s.validateConfig(); err != nil {
+		s.Errorf("config validation: %v", err)
+		return false
+	}
+
+	users, err := s.initUsers()
+	if err != nil {
+		s.Errorf("init users: %v", err)
+		return false
+	}
+	s.users = users
+
+	s.communities = s.initCommunities()
+	s.trapNames = s.initTrapNames()
+
+	return true
+}
+
+func (s *SNMPTraps) Check() bool {
+	// Note: the listener is started here to make auto-detection retry working
+	if err := s.startListener(); err != nil {
+		s.Warning("check failed: ", err)
+		return false
+	}
+	return true
+}
+
+func (s *SNMPTraps) Charts() *module.Charts {
+	return s.charts
+}
+
+func (s *SNMPTraps) Collect() map[string]int64 {
+	mx, err := s.collect()
+	if err != nil {
+		s.Error(err)
+	}
+
+	if len(mx) == 0 {
+		return nil
+	}
+	return mx
+}
+
+func (s *SNMPTraps) Cleanup() {
+	s.stopListener()
+}
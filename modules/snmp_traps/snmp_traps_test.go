@@ -0,0 +1,252 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp_traps
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	assert.IsType(t, (*SNMPTraps)(nil), New())
+}
+
+func TestSNMPTraps_Init(t *testing.T) {
+	tests := map[string]struct {
+		config   func(*Config)
+		wantFail bool
+	}{
+		"success with default config": {
+			config: func(*Config) {},
+		},
+		"success with users only": {
+			config: func(cfg *Config) {
+				cfg.Communities = nil
+				cfg.Users = []User{{Name: "user", SecurityLevel: "authPriv", AuthProto: "sha", AuthKey: "authkey123", PrivProto: "aes", PrivKey: "privkey123"}}
+			},
+		},
+		"fail when address not set": {
+			wantFail: true,
+			config:   func(cfg *Config) { cfg.Address = "" },
+		},
+		"fail when neither communities nor users set": {
+			wantFail: true,
+			config:   func(cfg *Config) { cfg.Communities = nil },
+		},
+		"fail when max_sources is not positive": {
+			wantFail: true,
+			config:   func(cfg *Config) { cfg.MaxSources = 0 },
+		},
+		"fail when user has invalid auth protocol": {
+			wantFail: true,
+			config: func(cfg *Config) {
+				cfg.Users = []User{{Name: "user", SecurityLevel: "authNoPriv", AuthProto: "sha1024"}}
+			},
+		},
+		"fail when user name not set": {
+			wantFail: true,
+			config: func(cfg *Config) {
+				cfg.Users = []User{{SecurityLevel: "noAuthNoPriv"}}
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			traps := New()
+			test.config(&traps.Config)
+
+			if test.wantFail {
+				assert.False(t, traps.Init())
+			} else {
+				assert.True(t, traps.Init())
+			}
+		})
+	}
+}
+
+func TestSNMPTraps_Check(t *testing.T) {
+	traps := prepareSNMPTraps(t)
+	defer traps.Cleanup()
+
+	assert.True(t, traps.Check())
+
+	busy := New()
+	busy.Address = traps.conn.LocalAddr().String()
+	require.True(t, busy.Init())
+	defer busy.Cleanup()
+
+	assert.False(t, busy.Check())
+}
+
+func TestSNMPTraps_Charts(t *testing.T) {
+	assert.NotNil(t, New().Charts())
+}
+
+func TestSNMPTraps_Cleanup(t *testing.T) {
+	assert.NotPanics(t, New().Cleanup)
+
+	traps := prepareSNMPTraps(t)
+	require.True(t, traps.Check())
+	traps.Cleanup()
+	assert.Nil(t, traps.conn)
+}
+
+func TestSNMPTraps_Collect(t *testing.T) {
+	traps := prepareSNMPTraps(t)
+	defer traps.Cleanup()
+	require.True(t, traps.Check())
+
+	port := uint16(traps.conn.LocalAddr().(*net.UDPAddr).Port)
+
+	// v1 generic linkDown
+	sendTrap(t, newV1Sender(port, "public"), gosnmp.SnmpTrap{
+		Enterprise:   ".1.3.6.1.4.1.8072.3.2.10",
+		AgentAddress: "127.0.0.1",
+		GenericTrap:  2,
+		Variables:    []gosnmp.SnmpPDU{{Name: ".1.3.6.1.2.1.2.2.1.1.1", Type: gosnmp.Integer, Value: 1}},
+	})
+	// v1 enterprise specific
+	sendTrap(t, newV1Sender(port, "public"), gosnmp.SnmpTrap{
+		Enterprise:   ".1.3.6.1.4.1.8072.3.2.10",
+		AgentAddress: "127.0.0.1",
+		GenericTrap:  6,
+		SpecificTrap: 7,
+		Variables:    []gosnmp.SnmpPDU{{Name: ".1.3.6.1.2.1.2.2.1.1.1", Type: gosnmp.Integer, Value: 1}},
+	})
+	// v2c with a configured name
+	sendTrap(t, newV2cSender(port, "public"), newV2Trap("1.3.6.1.4.1.8072.2.3.0.1", false))
+	// v2c inform, SendTrap waits for the acknowledgement
+	sendTrap(t, newV2cSender(port, "public"), newV2Trap("1.3.6.1.6.3.1.1.5.1", true))
+	// v3 authPriv
+	sendTrap(t, newV3Sender(port, "user", "authkey123", "privkey123"), newV2Trap("1.3.6.1.6.3.1.1.5.3", false))
+
+	// rejected
+	sendTrap(t, newV2cSender(port, "private"), newV2Trap("1.3.6.1.6.3.1.1.5.1", false))
+	sendTrap(t, newV3Sender(port, "user", "authkey123", "wrongkey123"), newV2Trap("1.3.6.1.6.3.1.1.5.1", false))
+	sendTrap(t, newV3Sender(port, "unknown", "authkey123", "privkey123"), newV2Trap("1.3.6.1.6.3.1.1.5.1", false))
+
+	expected := map[string]int64{
+		"traps":         4,
+		"informs":       1,
+		"decode_errors": 0,
+		"auth_failures": 3,
+
+		"type_1.3.6.1.6.3.1.1.5.3":                          2,
+		"type_1.3.6.1.4.1.8072.3.2.10.0.7":                  1,
+		"type_1.3.6.1.4.1.8072.2.3.0.1":                     1,
+		"type_1.3.6.1.6.3.1.1.5.1":                          1,
+		"source_127_0_0_1_type_1.3.6.1.6.3.1.1.5.3":         2,
+		"source_127_0_0_1_type_1.3.6.1.4.1.8072.3.2.10.0.7": 1,
+		"source_127_0_0_1_type_1.3.6.1.4.1.8072.2.3.0.1":    1,
+		"source_127_0_0_1_type_1.3.6.1.6.3.1.1.5.1":         1,
+	}
+
+	var mx map[string]int64
+	assert.Eventually(t, func() bool {
+		mx = traps.Collect()
+		return mx["traps"]+mx["informs"]+mx["auth_failures"] == 8
+	}, time.Second*5, time.Millisecond*50)
+
+	assert.Equal(t, expected, mx)
+
+	chart := traps.Charts().Get("source_127_0_0_1_traps")
+	require.NotNil(t, chart)
+	assert.True(t, chart.HasDim("source_127_0_0_1_type_1.3.6.1.6.3.1.1.5.3"))
+	assert.Equal(t, "linkDown", chart.GetDim("source_127_0_0_1_type_1.3.6.1.6.3.1.1.5.3").Name)
+	assert.Equal(t, "nstAgentNotification", chart.GetDim("source_127_0_0_1_type_1.3.6.1.4.1.8072.2.3.0.1").Name)
+}
+
+func TestSNMPTraps_Collect_BoundsCardinality(t *testing.T) {
+	traps := New()
+	traps.MaxSources = 2
+	traps.MaxTrapTypes = 1
+	require.True(t, traps.Init())
+
+	traps.countTrap("10.0.0.1", "1.3.6.1.6.3.1.1.5.1", false)
+	traps.countTrap("10.0.0.2", "1.3.6.1.6.3.1.1.5.2", false)
+	traps.countTrap("10.0.0.3", "1.3.6.1.6.3.1.1.5.1", false)
+	traps.countTrap("10.0.0.4", "1.3.6.1.6.3.1.1.5.3", false)
+	traps.countTrap("10.0.0.1", "1.3.6.1.6.3.1.1.5.1", false)
+
+	assert.Equal(t, map[string]int64{
+		"1.3.6.1.6.3.1.1.5.1": 3,
+		"other":               2,
+	}, traps.stats.byType)
+	assert.Equal(t, map[string]map[string]int64{
+		"10.0.0.1": {"1.3.6.1.6.3.1.1.5.1": 2},
+		"10.0.0.2": {"other": 1},
+		"other":    {"1.3.6.1.6.3.1.1.5.1": 1, "other": 1},
+	}, traps.stats.bySource)
+}
+
+func prepareSNMPTraps(t *testing.T) *SNMPTraps {
+	traps := New()
+	traps.Address = "127.0.0.1:0"
+	traps.Users = []User{
+		{Name: "user", SecurityLevel: "authPriv", AuthProto: "sha", AuthKey: "authkey123", PrivProto: "aes", PrivKey: "privkey123"},
+	}
+	traps.TrapNames = map[string]string{
+		".1.3.6.1.4.1.8072.2.3.0.1": "nstAgentNotification",
+	}
+	require.True(t, traps.Init())
+	return traps
+}
+
+func newV1Sender(port uint16, community string) *gosnmp.GoSNMP {
+	return &gosnmp.GoSNMP{
+		Target:    "127.0.0.1",
+		Port:      port,
+		Community: community,
+		Version:   gosnmp.Version1,
+		Timeout:   time.Second,
+	}
+}
+
+func newV2cSender(port uint16, community string) *gosnmp.GoSNMP {
+	sender := newV1Sender(port, community)
+	sender.Version = gosnmp.Version2c
+	return sender
+}
+
+func newV3Sender(port uint16, user, authKey, privKey string) *gosnmp.GoSNMP {
+	return &gosnmp.GoSNMP{
+		Target:        "127.0.0.1",
+		Port:          port,
+		Version:       gosnmp.Version3,
+		Timeout:       time.Second,
+		SecurityModel: gosnmp.UserSecurityModel,
+		MsgFlags:      gosnmp.AuthPriv,
+		SecurityParameters: &gosnmp.UsmSecurityParameters{
+			UserName:                 user,
+			AuthoritativeEngineID:    "\x80\x00\x1f\x88\x04netdata",
+			AuthenticationProtocol:   gosnmp.SHA,
+			AuthenticationPassphrase: authKey,
+			PrivacyProtocol:          gosnmp.AES,
+			PrivacyPassphrase:        privKey,
+		},
+	}
+}
+
+func newV2Trap(oid string, inform bool) gosnmp.SnmpTrap {
+	return gosnmp.SnmpTrap{
+		IsInform: inform,
+		Variables: []gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(100)},
+			{Name: "." + oidSnmpTrapOID, Type: gosnmp.ObjectIdentifier, Value: "." + oid},
+		},
+	}
+}
+
+func sendTrap(t *testing.T, sender *gosnmp.GoSNMP, trap gosnmp.SnmpTrap) {
+	require.NoError(t, sender.Connect())
+	defer func() { _ = sender.Conn.Close() }()
+
+	_, err := sender.SendTrap(trap)
+	require.NoError(t, err)
+}
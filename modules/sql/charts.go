@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package sql
+
+import (
+	"github.com/netdata/go.d.plugin/agent/module"
+)
+
+const (
+	prioQueryExecutionTime = module.Priority + iota
+	prioQueryValue
+)
+
+var baseCharts = module.Charts{
+	queryExecutionTimeChart.Copy(),
+}
+
+var queryExecutionTimeChart = module.Chart{
+	ID:       "query_execution_time",
+	Title:    "Query execution time",
+	Units:    "milliseconds",
+	Fam:      "queries",
+	Ctx:      "sql.query_execution_time",
+	Priority: prioQueryExecutionTime,
+}
+
+func (s *SQL) addQueryExecutionTimeDims() {
+	chart := s.Charts().Get(queryExecutionTimeChart.ID)
+	if chart == nil {
+		return
+	}
+	for _, q := range s.queries {
+		dim := &module.Dim{ID: "query_" + q.name + "_execution_time", Name: q.name, Div: 1000}
+		if err := chart.AddDim(dim); err != nil {
+			s.Warning(err)
+		}
+	}
+}
+
+func (s *SQL) addSeriesCharts(q *query, series *querySeries) {
+	for i, v := range q.values {
+		chart := newSeriesChart(q, series, v)
+		chart.Priority = prioQueryValue + s.queryIndex(q)*len(q.values) + i
+
+		if err := s.Charts().Add(chart); err != nil {
+			s.Warning(err)
+		}
+	}
+}
+
+func (s *SQL) removeSeriesCharts(q *query, series *querySeries) {
+	for _, v := range q.values {
+		id := q.seriesID(series, v)
+		if chart := s.Charts().Get(id); chart != nil {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
+
+func (s *SQL) queryIndex(q *query) int {
+	for i, v := range s.queries {
+		if v == q {
+			return i
+		}
+	}
+	return 0
+}
+
+func newSeriesChart(q *query, series *querySeries, v valueConfig) *module.Chart {
+	id := q.seriesID(series, v)
+	chart := &module.Chart{
+		ID:     id,
+		Title:  v.Title,
+		Units:  v.Units,
+		Fam:    q.name,
+		Ctx:    "sql." + q.name + "_" + v.Name,
+		Labels: append([]module.Label(nil), series.labels...),
+		Dims: module.Dims{
+			{ID: id, Name: v.Name, Div: precision},
+		},
+	}
+	if chart.Title == "" {
+		chart.Title = q.name + " " + v.Name
+	}
+	if chart.Units == "" {
+		chart.Units = "value"
+		if v.Type == valueTypeCounter {
+			chart.Units = "events/s"
+		}
+	}
+	if v.Type == valueTypeCounter {
+		chart.Dims[0].Algo = module.Incremental
+	}
+	return chart
+}
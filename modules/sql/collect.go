@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+func (s *SQL) collect() (map[string]int64, error) {
+	if s.db == nil {
+		db, err := s.openConnection()
+		if err != nil {
+			return nil, err
+		}
+		s.db = db
+	}
+
+	mx := make(map[string]int64)
+
+	var errs []error
+	now := s.now()
+	for _, q := range s.queries {
+		if q.isDue(now) {
+			q.lastRun = now
+			if err := s.runQuery(q); err != nil {
+				errs = append(errs, fmt.Errorf("query '%s': %v", q.name, err))
+			}
+		}
+		if q.mx == nil {
+			continue
+		}
+		mx["query_"+q.name+"_execution_time"] = q.duration.Microseconds()
+		for k, v := range q.mx {
+			mx[k] = v
+		}
+	}
+
+	return mx, errors.Join(errs...)
+}
+
+func (s *SQL) runQuery(q *query) error {
+	ctx, cancel := context.WithTimeout(context.Background(), q.timeout)
+	defer cancel()
+
+	start := time.Now()
+
+	rows, err := s.db.QueryContext(ctx, q.query)
+	if err != nil {
+		q.mx = nil
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	mx, err := s.readRows(q, rows)
+	if err != nil {
+		q.mx = nil
+		return err
+	}
+
+	q.duration = time.Since(start)
+	q.mx = mx
+
+	return nil
+}
+
+func (s *SQL) readRows(q *query, rows *sql.Rows) (map[string]int64, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	labelIdx, valueIdx, missing := q.columnIndexes(columns)
+	if missing != "" {
+		return nil, fmt.Errorf("column '%s' not found in the result set (columns: %v)", missing, columns)
+	}
+
+	mx := make(map[string]int64)
+	seen := make(map[string]bool)
+	values := makeValues(len(columns))
+	labelValues := make([]string, len(labelIdx))
+
+	for rows.Next() {
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+
+		for i, idx := range labelIdx {
+			labelValues[i] = labelValue(values[idx].(*sql.NullString))
+		}
+
+		key := q.seriesKey(labelValues)
+		series, ok := q.series[key]
+		if !ok {
+			if len(q.series) >= s.MaxLabelValues {
+				if !q.overflow {
+					q.overflow = true
+					s.Warningf("query '%s': reached max label values limit (%d), new label values are dropped",
+						q.name, s.MaxLabelValues)
+				}
+				continue
+			}
+			series = q.newSeries(key, labelValues)
+			q.series[key] = series
+			s.addSeriesCharts(q, series)
+		}
+		seen[key] = true
+
+		for i, idx := range valueIdx {
+			v := values[idx].(*sql.NullString)
+			if !v.Valid {
+				continue
+			}
+			f, err := strconv.ParseFloat(v.String, 64)
+			if err != nil {
+				s.Debugf("query '%s': column '%s': can not parse value '%s': %v", q.name, q.values[i].Column, v.String, err)
+				continue
+			}
+			mx[q.seriesID(series, q.values[i])] = int64(f * precision)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for key, series := range q.series {
+		if !seen[key] {
+			delete(q.series, key)
+			q.overflow = false
+			s.removeSeriesCharts(q, series)
+		}
+	}
+
+	return mx, nil
+}
+
+func (s *SQL) openConnection() (*sql.DB, error) {
+	db, err := sql.Open(s.driverName, s.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("error on opening a connection with the database (driver '%s'): %v", s.Driver, err)
+	}
+
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(10 * time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.Timeout.Duration)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("error on pinging the database (driver '%s'): %v", s.Driver, err)
+	}
+
+	return db, nil
+}
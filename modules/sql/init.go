@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var reName = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
+// driverAliases maps user-friendly driver names to the names the drivers are registered with.
+var driverAliases = map[string]string{
+	"postgres":   "pgx",
+	"postgresql": "pgx",
+	"mariadb":    "mysql",
+}
+
+func (s *SQL) validateConfig() error {
+	if s.Driver == "" {
+		return errors.New("'driver' not set")
+	}
+	if s.DSN == "" {
+		return errors.New("'dsn' not set")
+	}
+	if len(s.Queries) == 0 {
+		return errors.New("'queries' not set")
+	}
+	if s.MaxLabelValues <= 0 {
+		return errors.New("'max_label_values' must be positive")
+	}
+	return nil
+}
+
+func (s *SQL) initDriverName() (string, error) {
+	name := s.Driver
+	if v, ok := driverAliases[name]; ok {
+		name = v
+	}
+
+	drivers := sql.Drivers()
+	for _, d := range drivers {
+		if d == name {
+			return name, nil
+		}
+	}
+	sort.Strings(drivers)
+	return "", fmt.Errorf("unknown driver '%s' (available: %s)", s.Driver, strings.Join(drivers, ", "))
+}
+
+func (s *SQL) initQueries() ([]*query, error) {
+	var queries []*query
+	seen := make(map[string]bool)
+
+	for i, cfg := range s.Queries {
+		if cfg.Name == "" {
+			return nil, fmt.Errorf("query %d: 'name' not set", i+1)
+		}
+		if !reName.MatchString(cfg.Name) {
+			return nil, fmt.Errorf("query '%s': 'name' must contain only letters, digits and underscores", cfg.Name)
+		}
+		if seen[cfg.Name] {
+			return nil, fmt.Errorf("query '%s': duplicate name", cfg.Name)
+		}
+		seen[cfg.Name] = true
+
+		q, err := newQuery(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("query '%s': %v", cfg.Name, err)
+		}
+		if q.timeout == 0 {
+			q.timeout = s.Timeout.Duration
+		}
+		s.Debugf("created query '%s' (%d value(s), labels %v, interval %s, timeout %s)",
+			q.name, len(q.values), q.labels, q.interval, q.timeout)
+		queries = append(queries, q)
+	}
+
+	return queries, nil
+}
+
+func newQuery(cfg queryConfig) (*query, error) {
+	if strings.TrimSpace(cfg.Query) == "" {
+		return nil, errors.New("'query' not set")
+	}
+	if len(cfg.Values) == 0 {
+		return nil, errors.New("'values' not set")
+	}
+
+	q := &query{
+		name:     cfg.Name,
+		query:    cfg.Query,
+		timeout:  cfg.Timeout.Duration,
+		interval: cfg.Interval.Duration,
+		labels:   cfg.Labels,
+		series:   make(map[string]*querySeries),
+	}
+
+	columns := make(map[string]bool)
+	for _, l := range cfg.Labels {
+		if l == "" {
+			return nil, errors.New("empty label column name")
+		}
+		columns[l] = true
+	}
+
+	names := make(map[string]bool)
+	for i, v := range cfg.Values {
+		if v.Column == "" {
+			return nil, fmt.Errorf("value %d: 'column' not set", i+1)
+		}
+		if columns[v.Column] {
+			return nil, fmt.Errorf("value '%s': column is used more than once", v.Column)
+		}
+		columns[v.Column] = true
+
+		if v.Name == "" {
+			v.Name = strings.ToLower(v.Column)
+		}
+		if !reName.MatchString(v.Name) {
+			return nil, fmt.Errorf("value '%s': 'name' must contain only letters, digits and underscores", v.Name)
+		}
+		if names[v.Name] {
+			return nil, fmt.Errorf("value '%s': duplicate name", v.Name)
+		}
+		names[v.Name] = true
+
+		switch v.Type {
+		case "":
+			v.Type = valueTypeGauge
+		case valueTypeGauge, valueTypeCounter:
+		default:
+			return nil, fmt.Errorf("value '%s': unknown type '%s'", v.Name, v.Type)
+		}
+
+		q.values = append(q.values, v)
+	}
+
+	return q, nil
+}
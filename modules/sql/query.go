@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package sql
+
+import (
+	"database/sql"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+)
+
+const (
+	valueTypeGauge   = "gauge"
+	valueTypeCounter = "counter"
+)
+
+const precision = 1000
+
+type (
+	query struct {
+		name     string
+		query    string
+		timeout  time.Duration
+		interval time.Duration
+		labels   []string
+		values   []valueConfig
+
+		lastRun  time.Time
+		duration time.Duration
+		mx       map[string]int64
+
+		series   map[string]*querySeries
+		overflow bool
+	}
+	querySeries struct {
+		key    string
+		labels []module.Label
+	}
+)
+
+func (q *query) isDue(now time.Time) bool {
+	return q.lastRun.IsZero() || now.Sub(q.lastRun) >= q.interval
+}
+
+// seriesKey encodes the label values unambiguously: every value is prefixed with its length.
+func (q *query) seriesKey(labelValues []string) string {
+	var sb strings.Builder
+	for _, v := range labelValues {
+		sb.WriteString(strconv.Itoa(len(v)))
+		sb.WriteByte(':')
+		sb.WriteString(v)
+	}
+	return sb.String()
+}
+
+func (q *query) newSeries(key string, labelValues []string) *querySeries {
+	s := &querySeries{}
+	if key != "" {
+		s.key = hashSeriesKey(key)
+	}
+	for i, name := range q.labels {
+		s.labels = append(s.labels, module.Label{Key: name, Value: labelValues[i]})
+	}
+	return s
+}
+
+func (q *query) seriesID(s *querySeries, v valueConfig) string {
+	id := q.name + "_" + v.Name
+	if s.key != "" {
+		id += "_" + s.key
+	}
+	return id
+}
+
+// columnIndexes returns the index of every label and value column in the result set columns.
+// Column names are compared case-insensitively, some databases change the case of unquoted identifiers.
+func (q *query) columnIndexes(columns []string) (labels, values []int, missing string) {
+	find := func(name string) int {
+		for i, c := range columns {
+			if strings.EqualFold(c, name) {
+				return i
+			}
+		}
+		return -1
+	}
+	for _, name := range q.labels {
+		idx := find(name)
+		if idx == -1 {
+			return nil, nil, name
+		}
+		labels = append(labels, idx)
+	}
+	for _, v := range q.values {
+		idx := find(v.Column)
+		if idx == -1 {
+			return nil, nil, v.Column
+		}
+		values = append(values, idx)
+	}
+	return labels, values, ""
+}
+
+func labelValue(v *sql.NullString) string {
+	if v.Valid && v.String != "" {
+		return v.String
+	}
+	return "unknown"
+}
+
+func makeValues(size int) []any {
+	vs := make([]any, size)
+	for i := range vs {
+		vs[i] = &sql.NullString{}
+	}
+	return vs
+}
+
+// hashSeriesKey returns the series ID suffix. The label values can't be used in IDs as is,
+// they may contain forbidden characters, and replacing them leads to collisions.
+func hashSeriesKey(key string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return strconv.FormatUint(h.Sum64(), 16)
+}
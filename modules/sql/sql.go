@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package sql
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/web"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/jackc/pgx/v4/stdlib"
+)
+
+func init() {
+	module.Register("sql", module.Creator{
+		Create: func() module.Module { return New() },
+	})
+}
+
+func New() *SQL {
+	return &SQL{
+		Config: Config{
+			Timeout:        web.Duration{Duration: time.Second * 2},
+			MaxLabelValues: 100,
+		},
+		charts: baseCharts.Copy(),
+		now:    time.Now,
+	}
+}
+
+type (
+	Config struct {
+		Driver         string        `yaml:"driver"`
+		DSN            string        `yaml:"dsn"`
+		Timeout        web.Duration  `yaml:"timeout"`
+		MaxLabelValues int           `yaml:"max_label_values"`
+		Queries        []queryConfig `yaml:"queries"`
+	}
+	queryConfig struct {
+		Name     string        `yaml:"name"`
+		Query    string        `yaml:"query"`
+		Timeout  web.Duration  `yaml:"timeout"`
+		Interval web.Duration  `yaml:"interval"`
+		Labels   []string      `yaml:"labels"`
+		Values   []valueConfig `yaml:"values"`
+	}
+	valueConfig struct {
+		Column string `yaml:"column"`
+		Name   string `yaml:"name"`
+		Type   string `yaml:"type"`
+		Title  string `yaml:"title"`
+		Units  string `yaml:"units"`
+	}
+)
+
+type SQL struct {
+	module.Base
+	Config `yaml:",inline"`
+
+	charts *module.Charts
+
+	db         *sql.DB
+	driverName string
+	queries    []*query
+
+	now func() time.Time
+}
+
+func (s *SQL) Init() bool {
+	if err := s.validateConfig(); err != nil {
+		s.Errorf("config validation: %v", err)
+		return false
+	}
+
+	driver, err := s.initDriverName()
+	if err != nil {
+		s.Errorf("init driver: %v", err)
+		return false
+	}
+	s.driverName = driver
+
+	queries, err := s.initQueries()
+	if err != nil {
+		s.Errorf("init queries: %v", err)
+		return false
+	}
+	s.queries = queries
+
+	s.addQueryExecutionTimeDims()
+
+	return true
+}
+
+func (s *SQL) Check() bool {
+	return len(s.Collect()) > 0
+}
+
+func (s *SQL) Charts() *module.Charts {
+	return s.charts
+}
+
+func (s *SQL) Collect() map[string]int64 {
+	mx, err := s.collect()
+	if err != nil {
+		s.Error(err)
+	}
+
+	if len(mx) == 0 {
+		return nil
+	}
+	return mx
+}
+
+func (s *SQL) Cleanup() {
+	if s.db == nil {
+		return
+	}
+	if err := s.db.Close(); err != nil {
+		s.Errorf("cleanup: error on closing the database connection: %v", err)
+	}
+	s.db = nil
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/metrics"
 )
 
 type (
@@ -28,6 +29,7 @@ const (
 	prioBandwidth
 
 	prioRespTime
+	prioRespTimeQuantile
 
 	prioCacheCode
 	prioCacheTransportTag
@@ -140,6 +142,14 @@ var (
 			{ID: "resp_time_avg", Name: "avg", Div: 1000},
 		},
 	}
+	respTimeQuantileChart = Chart{
+		ID:       "response_time_percentiles",
+		Title:    "Response Time Percentiles",
+		Units:    "milliseconds",
+		Fam:      "timings",
+		Ctx:      "squidlog.response_time_percentiles",
+		Priority: prioRespTimeQuantile,
+	}
 
 	// Clients
 	uniqClientsChart = Chart{
@@ -262,7 +272,7 @@ func (s *SquidLog) createCharts(line *logLine) error {
 		reqExcludedChart.Copy(),
 	}
 	if line.hasRespTime() {
-		if err := addRespTimeCharts(charts); err != nil {
+		if err := addRespTimeCharts(charts, s.Quantiles); err != nil {
 			return err
 		}
 	}
@@ -310,8 +320,21 @@ func (s *SquidLog) createCharts(line *logLine) error {
 	return nil
 }
 
-func addRespTimeCharts(charts *Charts) error {
-	return charts.Add(respTimeChart.Copy())
+func addRespTimeCharts(charts *Charts, quantiles []float64) error {
+	if err := charts.Add(respTimeChart.Copy()); err != nil {
+		return err
+	}
+	if len(quantiles) == 0 {
+		return nil
+	}
+	chart := respTimeQuantileChart.Copy()
+	for _, q := range quantiles {
+		name := metrics.QuantileName(q)
+		if err := chart.AddDim(&Dim{ID: "resp_time_quantile_" + name, Name: name, Div: 1000}); err != nil {
+			return err
+		}
+	}
+	return charts.Add(chart)
 }
 
 func addClientAddressCharts(charts *Charts) error {
@@ -92,6 +92,7 @@ func (s *SquidLog) collectRespTime() {
 		return
 	}
 	s.mx.RespTime.Observe(float64(s.line.respTime))
+	s.mx.RespTimeQntl.Observe(float64(s.line.respTime))
 }
 
 func (s *SquidLog) collectClientAddress() {
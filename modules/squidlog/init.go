@@ -14,19 +14,28 @@ func (s *SquidLog) createLogReader() error {
 	s.Cleanup()
 	s.Debug("starting log reader creating")
 
-	reader, err := logs.Open(s.Path, s.ExcludePath, s.Logger)
+	var reader logs.LogReader
+	var err error
+	switch s.Source {
+	case logs.SourceJournal:
+		reader, err = logs.OpenJournal(s.Journal, s.Logger)
+	case logs.SourceFile, "":
+		reader, err = logs.Open(s.Path, s.ExcludePath, s.Logger)
+	default:
+		err = fmt.Errorf("unknown source '%s'", s.Source)
+	}
 	if err != nil {
 		return fmt.Errorf("creating log reader: %v", err)
 	}
 
-	s.Debugf("created log reader, current file '%s'", reader.CurrentFilename())
+	s.Debugf("created log reader, %s", reader.Info())
 	s.file = reader
 	return nil
 }
 
 func (s *SquidLog) createParser() error {
 	s.Debug("starting parser creating")
-	lastLine, err := logs.ReadLastLine(s.file.CurrentFilename(), 0)
+	lastLine, err := s.file.LastLine()
 	if err != nil {
 		return fmt.Errorf("read last line: %v", err)
 	}
@@ -53,9 +53,10 @@ type metricsData struct {
 	ReqBad      metrics.Counter `stm:"req_type_bad"`
 	ReqError    metrics.Counter `stm:"req_type_error"`
 
-	BytesSent     metrics.Counter       `stm:"bytes_sent"`
-	RespTime      metrics.Summary       `stm:"resp_time,1000,1"`
-	UniqueClients metrics.UniqueCounter `stm:"uniq_clients"`
+	BytesSent     metrics.Counter                `stm:"bytes_sent"`
+	RespTime      metrics.Summary                `stm:"resp_time,1000,1"`
+	RespTimeQntl  metrics.OptionalQuantileSketch `stm:"resp_time_quantile,1000,1"`
+	UniqueClients metrics.UniqueCounter          `stm:"uniq_clients"`
 
 	ReqMethod              metrics.CounterVec `stm:"req_method"`
 	CacheCode              metrics.CounterVec `stm:"cache_result_code"`
@@ -71,12 +72,14 @@ type metricsData struct {
 
 func (m *metricsData) reset() {
 	m.RespTime.Reset()
+	m.RespTimeQntl.Reset()
 	m.UniqueClients.Reset()
 }
 
-func newMetricsData() *metricsData {
+func newMetricsData(config Config) *metricsData {
 	return &metricsData{
 		RespTime:               newSummary(),
+		RespTimeQntl:           metrics.NewOptionalQuantileSketch(config.Quantiles),
 		UniqueClients:          metrics.NewUniqueCounter(true),
 		HTTPRespCode:           metrics.NewCounterVec(),
 		ReqMethod:              metrics.NewCounterVec(),
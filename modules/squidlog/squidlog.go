@@ -4,6 +4,7 @@ package squidlog
 
 import (
 	"github.com/netdata/go.d.plugin/pkg/logs"
+	"github.com/netdata/go.d.plugin/pkg/metrics"
 
 	"github.com/netdata/go.d.plugin/agent/module"
 )
@@ -38,16 +39,19 @@ func New() *SquidLog {
 
 type (
 	Config struct {
-		Parser      logs.ParserConfig `yaml:",inline"`
-		Path        string            `yaml:"path"`
-		ExcludePath string            `yaml:"exclude_path"`
+		Parser      logs.ParserConfig  `yaml:",inline"`
+		Source      string             `yaml:"source"`
+		Path        string             `yaml:"path"`
+		ExcludePath string             `yaml:"exclude_path"`
+		Journal     logs.JournalConfig `yaml:"journal"`
+		Quantiles   []float64          `yaml:"quantiles"`
 	}
 
 	SquidLog struct {
 		module.Base
 		Config `yaml:",inline"`
 
-		file   *logs.Reader
+		file   logs.LogReader
 		parser logs.Parser
 		line   *logLine
 
@@ -57,8 +61,12 @@ type (
 )
 
 func (s *SquidLog) Init() bool {
+	if err := metrics.CheckQuantiles(s.Quantiles); err != nil {
+		s.Errorf("init failed: validate quantiles: %v", err)
+		return false
+	}
 	s.line = newEmptyLogLine()
-	s.mx = newMetricsData()
+	s.mx = newMetricsData(s.Config)
 	return true
 }
 
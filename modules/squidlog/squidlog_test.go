@@ -5,9 +5,12 @@ package squidlog
 import (
 	"bytes"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/netdata/go.d.plugin/pkg/logs"
+	"github.com/netdata/go.d.plugin/pkg/logs/logstest"
 	"github.com/netdata/go.d.plugin/pkg/metrics"
 
 	"github.com/netdata/go.d.plugin/agent/module"
@@ -33,6 +36,13 @@ func TestSquidLog_Init(t *testing.T) {
 	assert.True(t, squidlog.Init())
 }
 
+func TestSquidLog_Init_ErrorOnInvalidQuantiles(t *testing.T) {
+	squidlog := New()
+	squidlog.Quantiles = []float64{0.5, 99}
+
+	assert.False(t, squidlog.Init())
+}
+
 func TestSquidLog_Check(t *testing.T) {
 }
 
@@ -64,6 +74,26 @@ func TestSquid_Check_ErrorOnCreatingParserZeroKnownFields(t *testing.T) {
 	assert.False(t, squid.Check())
 }
 
+func TestSquidLog_Collect_JournalSource(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(string(nativeFormatAccessLog)), "\n")[:5]
+	squid := New()
+	defer squid.Cleanup()
+	squid.Source = logs.SourceJournal
+	dir := t.TempDir()
+	squid.Journal.JournalctlPath = logstest.PrepareFakeJournalctl(t, dir, logstest.JournalEntries(t, lines...))
+	squid.Journal.CursorFile = logstest.PrepareJournalCursorFile(t, dir, logstest.JournalStartCursor)
+	require.True(t, squid.Init())
+	require.True(t, squid.Check())
+
+	var mx map[string]int64
+	require.Eventually(t, func() bool {
+		mx = squid.Collect()
+		return mx["requests"] == int64(len(lines))
+	}, time.Second*5, time.Millisecond*100)
+	assert.Equal(t, int64(1), mx["unmatched"])
+	assert.Equal(t, int64(1), mx["req_method_HEAD"])
+}
+
 func TestSquidLog_Charts(t *testing.T) {
 	assert.Nil(t, New().Charts())
 
@@ -276,6 +306,38 @@ func ensureCollectedHasAllChartsDimsVarsIDs(t *testing.T, s *SquidLog, collected
 	}
 }
 
+func TestSquidLog_Collect_Quantiles(t *testing.T) {
+	squid := New()
+	squid.Path = "testdata/access.log"
+	squid.Quantiles = []float64{0.5, 0.9, 0.99}
+	require.True(t, squid.Init())
+	require.True(t, squid.Check())
+	defer squid.Cleanup()
+
+	p, err := logs.NewCSVParser(squid.Parser.CSV, bytes.NewReader(nativeFormatAccessLog))
+	require.NoError(t, err)
+	squid.parser = p
+
+	mx := squid.Collect()
+
+	assert.Equal(t, mx["resp_time_count"], mx["resp_time_quantile_count"])
+	assert.True(t, mx["resp_time_min"] <= mx["resp_time_quantile_p50"])
+	assert.True(t, mx["resp_time_quantile_p50"] <= mx["resp_time_quantile_p90"])
+	assert.True(t, mx["resp_time_quantile_p90"] <= mx["resp_time_quantile_p99"])
+	assert.True(t, mx["resp_time_quantile_p99"] <= mx["resp_time_max"])
+
+	chart := squid.Charts().Get(respTimeQuantileChart.ID)
+	require.NotNil(t, chart)
+	for _, dim := range chart.Dims {
+		_, ok := mx[dim.ID]
+		assert.Truef(t, ok, "collected metrics has no data for dim '%s' chart '%s'", dim.ID, chart.ID)
+	}
+
+	mx = squid.Collect()
+	assert.Equal(t, int64(0), mx["resp_time_quantile_count"])
+	assert.Equal(t, int64(0), mx["resp_time_quantile_p99"])
+}
+
 func prepareSquidCollect(t *testing.T) *SquidLog {
 	t.Helper()
 	squid := New()
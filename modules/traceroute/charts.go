@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package traceroute
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+)
+
+const (
+	prioHostHopCount = module.Priority + iota
+	prioHostPathChanges
+	prioHopRTT
+	prioHopPacketLoss
+)
+
+var hostChartsTmpl = module.Charts{
+	hostHopCountChartTmpl.Copy(),
+	hostPathChangesChartTmpl.Copy(),
+}
+
+var (
+	hostHopCountChartTmpl = module.Chart{
+		ID:       "host_%s_hop_count",
+		Title:    "Traceroute path hop count",
+		Units:    "hops",
+		Fam:      "path",
+		Ctx:      "traceroute.host_hop_count",
+		Priority: prioHostHopCount,
+		Dims: module.Dims{
+			{ID: "host_%s_hop_count", Name: "hops"},
+		},
+	}
+	hostPathChangesChartTmpl = module.Chart{
+		ID:       "host_%s_path_changes",
+		Title:    "Traceroute path changes",
+		Units:    "changes",
+		Fam:      "path",
+		Ctx:      "traceroute.host_path_changes",
+		Priority: prioHostPathChanges,
+		Dims: module.Dims{
+			{ID: "host_%s_path_changes", Name: "changes"},
+		},
+	}
+)
+
+var hopChartsTmpl = module.Charts{
+	hopRTTChartTmpl.Copy(),
+	hopPacketLossChartTmpl.Copy(),
+}
+
+var (
+	hopRTTChartTmpl = module.Chart{
+		ID:       "host_%s_hop_%d_rtt",
+		Title:    "Traceroute hop round-trip time",
+		Units:    "milliseconds",
+		Fam:      "hop latency",
+		Ctx:      "traceroute.hop_rtt",
+		Priority: prioHopRTT,
+		Type:     module.Area,
+		Dims: module.Dims{
+			{ID: "host_%s_hop_%d_min_rtt", Name: "min", Div: 1e3},
+			{ID: "host_%s_hop_%d_max_rtt", Name: "max", Div: 1e3},
+			{ID: "host_%s_hop_%d_avg_rtt", Name: "avg", Div: 1e3},
+		},
+	}
+	hopPacketLossChartTmpl = module.Chart{
+		ID:       "host_%s_hop_%d_packet_loss",
+		Title:    "Traceroute hop packet loss",
+		Units:    "percentage",
+		Fam:      "hop packet loss",
+		Ctx:      "traceroute.hop_packet_loss",
+		Priority: prioHopPacketLoss,
+		Dims: module.Dims{
+			{ID: "host_%s_hop_%d_packet_loss", Name: "loss", Div: 1000},
+		},
+	}
+)
+
+func newHostCharts(host string) *module.Charts {
+	charts := hostChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, strings.ReplaceAll(host, ".", "_"))
+		chart.Labels = []module.Label{
+			{Key: "host", Value: host},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, host)
+		}
+	}
+
+	return charts
+}
+
+func newHopCharts(host string, hop int, addr string) *module.Charts {
+	charts := hopChartsTmpl.Copy()
+
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, strings.ReplaceAll(host, ".", "_"), hop)
+		chart.Priority += hop * 2
+		chart.Labels = []module.Label{
+			{Key: "host", Value: host},
+			{Key: "hop", Value: strconv.Itoa(hop)},
+			{Key: "hop_address", Value: addr},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, host, hop)
+		}
+	}
+
+	return charts
+}
+
+func (tr *Traceroute) addHostCharts(host string) {
+	charts := newHostCharts(host)
+
+	if err := tr.Charts().Add(*charts...); err != nil {
+		tr.Warning(err)
+	}
+}
+
+func (tr *Traceroute) addHopCharts(host string, hop int, addr string) {
+	charts := newHopCharts(host, hop, addr)
+
+	if err := tr.Charts().Add(*charts...); err != nil {
+		tr.Warning(err)
+	}
+}
+
+func (tr *Traceroute) removeHopCharts(host string, hop int) {
+	px := fmt.Sprintf("host_%s_hop_%d_", strings.ReplaceAll(host, ".", "_"), hop)
+
+	for _, chart := range *tr.Charts() {
+		if strings.HasPrefix(chart.ID, px) {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
+
+func (tr *Traceroute) updateHopChartsAddress(host string, hop int, addr string) {
+	px := fmt.Sprintf("host_%s_hop_%d_", strings.ReplaceAll(host, ".", "_"), hop)
+
+	for _, chart := range *tr.Charts() {
+		if !strings.HasPrefix(chart.ID, px) || chart.Obsolete {
+			continue
+		}
+		for i, l := range chart.Labels {
+			if l.Key == "hop_address" {
+				chart.Labels[i].Value = addr
+			}
+		}
+		chart.MarkNotCreated()
+	}
+}
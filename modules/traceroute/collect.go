@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package traceroute
+
+import (
+	"fmt"
+	"sync"
+)
+
+type hostPath struct {
+	hops        []string // hop addresses, empty if the hop didn't respond
+	pathChanges int64
+}
+
+func (tr *Traceroute) collect() (map[string]int64, error) {
+	mu := &sync.Mutex{}
+	mx := make(map[string]int64)
+	var wg sync.WaitGroup
+
+	for _, v := range tr.Hosts {
+		wg.Add(1)
+		go func(v string) { defer wg.Done(); tr.traceHost(v, mx, mu) }(v)
+	}
+	wg.Wait()
+
+	return mx, nil
+}
+
+func (tr *Traceroute) traceHost(host string, mx map[string]int64, mu *sync.Mutex) {
+	res, err := tr.prober.trace(host)
+	if err != nil {
+		tr.Error(err)
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	path, ok := tr.hosts[host]
+	if !ok {
+		path = &hostPath{}
+		tr.hosts[host] = path
+		tr.addHostCharts(host)
+	}
+
+	tr.updatePath(host, path, res)
+
+	px := fmt.Sprintf("host_%s_", host)
+	mx[px+"hop_count"] = int64(len(res.hops))
+	mx[px+"path_changes"] = path.pathChanges
+
+	for i, hop := range res.hops {
+		px := fmt.Sprintf("host_%s_hop_%d_", host, i+1)
+		if hop.recv != 0 {
+			mx[px+"min_rtt"] = hop.minRTT.Microseconds()
+			mx[px+"max_rtt"] = hop.maxRTT.Microseconds()
+			mx[px+"avg_rtt"] = hop.avgRTT.Microseconds()
+		}
+		if hop.sent != 0 {
+			mx[px+"packet_loss"] = int64(float64(hop.sent-hop.recv) / float64(hop.sent) * 100 * 1000)
+		}
+	}
+}
+
+// updatePath counts the path change and syncs the hop charts with the traced path.
+func (tr *Traceroute) updatePath(host string, path *hostPath, res *traceResult) {
+	hops := make([]string, len(res.hops))
+	for i, hop := range res.hops {
+		hops[i] = hop.addr
+	}
+
+	if path.hops != nil && isPathChanged(path.hops, hops) {
+		tr.Debugf("host '%s' path changed: %v => %v", host, path.hops, hops)
+		path.pathChanges++
+	}
+
+	for i, addr := range hops {
+		switch {
+		case i >= len(path.hops):
+			tr.addHopCharts(host, i+1, addr)
+		case addr != "" && addr != path.hops[i]:
+			tr.updateHopChartsAddress(host, i+1, addr)
+		default:
+			// keep the last known address of a hop that didn't respond this time
+			hops[i] = path.hops[i]
+		}
+	}
+	for i := len(hops); i < len(path.hops); i++ {
+		tr.removeHopCharts(host, i+1)
+	}
+
+	path.hops = hops
+}
+
+// isPathChanged reports whether the hop count or any of the hops that responded on both traces is different.
+func isPathChanged(prev, curr []string) bool {
+	if len(prev) != len(curr) {
+		return true
+	}
+	for i := range prev {
+		if prev[i] != "" && curr[i] != "" && prev[i] != curr[i] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package traceroute
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+func (tr *Traceroute) validateConfig() error {
+	if len(tr.Hosts) == 0 {
+		return errors.New("'hosts' can't be empty")
+	}
+	if tr.Method != methodICMP && tr.Method != methodUDP {
+		return fmt.Errorf("'method' must be '%s' or '%s', got '%s'", methodICMP, methodUDP, tr.Method)
+	}
+	if tr.SendPackets <= 0 {
+		return errors.New("'packets' can't be <= 0")
+	}
+	if tr.MaxHops <= 0 || tr.MaxHops > 255 {
+		return errors.New("'max_hops' must be in the range 1-255")
+	}
+	if tr.Timeout.Duration <= 0 {
+		return errors.New("'timeout' can't be <= 0")
+	}
+	if seqs := tr.SendPackets * tr.MaxHops; seqs > 0xffff || (tr.Method == methodUDP && (tr.Port <= 0 || tr.Port+seqs > 0xffff)) {
+		return errors.New("too many probes for the 'packets', 'max_hops' and 'port' combination")
+	}
+	return nil
+}
+
+func (tr *Traceroute) initProber() (prober, error) {
+	if tr.UpdateEvery > 0 {
+		if d := time.Duration(tr.SendPackets) * tr.Timeout.Duration; d >= time.Duration(tr.UpdateEvery)*time.Second {
+			return nil, fmt.Errorf("'packets' * 'timeout' (%s) must be less than 'update_every' (%ds)", d, tr.UpdateEvery)
+		}
+	}
+
+	conf := tracerouteProberConfig{
+		method:     tr.Method,
+		privileged: tr.Privileged,
+		packets:    tr.SendPackets,
+		maxHops:    tr.MaxHops,
+		port:       tr.Port,
+		iface:      tr.Interface,
+		timeout:    tr.Timeout.Duration,
+	}
+
+	return tr.newProber(conf, tr.Logger), nil
+}
@@ -0,0 +1,256 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package traceroute
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"os"
+	"time"
+
+	"github.com/netdata/go.d.plugin/logger"
+)
+
+const (
+	methodICMP = "icmp"
+	methodUDP  = "udp"
+)
+
+func newTracerouteProber(conf tracerouteProberConfig, log *logger.Logger) prober {
+	var source net.IP
+	if conf.iface != "" {
+		if addr, err := getInterfaceIPAddress(conf.iface); err != nil {
+			log.Warningf("error getting interface '%s' IP address: %v", conf.iface, err)
+		} else {
+			log.Infof("interface '%s' IP address '%s', will use it as the source", conf.iface, addr)
+			source = net.ParseIP(addr)
+		}
+	}
+
+	return &tracerouteProber{
+		method:     conf.method,
+		privileged: conf.privileged,
+		packets:    conf.packets,
+		maxHops:    conf.maxHops,
+		port:       conf.port,
+		source:     source,
+		timeout:    conf.timeout,
+		Logger:     log,
+	}
+}
+
+type tracerouteProberConfig struct {
+	method     string
+	privileged bool
+	packets    int
+	maxHops    int
+	port       int
+	iface      string
+	timeout    time.Duration
+}
+
+type tracerouteProber struct {
+	method     string
+	privileged bool
+	packets    int
+	maxHops    int
+	port       int
+	source     net.IP
+	timeout    time.Duration
+	*logger.Logger
+}
+
+type (
+	// traceResult is the path to the host, hops[i] is the hop at TTL i+1.
+	traceResult struct {
+		reached bool
+		hops    []*hopStats
+	}
+	hopStats struct {
+		addr      string // empty if the hop didn't respond
+		sent      int
+		recv      int
+		minRTT    time.Duration
+		maxRTT    time.Duration
+		avgRTT    time.Duration
+		stdDevRTT time.Duration
+		rtts      []time.Duration
+	}
+)
+
+type (
+	// probeConn sends the probes with the given TTL and receives the replies, a probe is identified by its sequence number.
+	probeConn interface {
+		send(seq, ttl int) error
+		recv(deadline time.Time) (*probeReply, error)
+		close() error
+	}
+	probeConnConfig struct {
+		method     string
+		privileged bool
+		source     net.IP
+		dst        net.IP
+		port       int // the udp method destination base port
+		id         int // the icmp method echo identifier
+	}
+	probeReply struct {
+		seq     int
+		from    net.IP
+		reached bool // the reply came from the destination
+		at      time.Time
+	}
+)
+
+// trace sends 'packets' rounds of probes, every round probes all the hops at once.
+// After the destination is reached the following rounds probe only up to the destination hop.
+func (p *tracerouteProber) trace(host string) (*traceResult, error) {
+	ip, err := resolveIPv4(host)
+	if err != nil {
+		return nil, fmt.Errorf("DNS lookup '%s' : %v", host, err)
+	}
+
+	conn, err := newProbeConn(probeConnConfig{
+		method:     p.method,
+		privileged: p.privileged,
+		source:     p.source,
+		dst:        ip,
+		port:       p.port,
+		id:         rand.Intn(0xffff),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("tracing host '%s' (ip %s): %v", host, ip, err)
+	}
+	defer func() { _ = conn.close() }()
+
+	type probe struct {
+		ttl    int
+		sentAt time.Time
+	}
+
+	hops := make([]*hopStats, p.maxHops)
+	for i := range hops {
+		hops[i] = &hopStats{}
+	}
+	maxTTL, destTTL := p.maxHops, 0
+
+	for round := 0; round < p.packets; round++ {
+		sent := make(map[int]probe)
+
+		for ttl := 1; ttl <= maxTTL; ttl++ {
+			seq := round*p.maxHops + ttl
+			now := time.Now()
+			if err := conn.send(seq, ttl); err != nil {
+				return nil, fmt.Errorf("tracing host '%s' (ip %s): %v", host, ip, err)
+			}
+			sent[seq] = probe{ttl: ttl, sentAt: now}
+			hops[ttl-1].sent++
+		}
+
+		deadline := time.Now().Add(p.timeout)
+		for len(sent) > 0 {
+			reply, err := conn.recv(deadline)
+			if err != nil {
+				if errors.Is(err, os.ErrDeadlineExceeded) {
+					break
+				}
+				return nil, fmt.Errorf("tracing host '%s' (ip %s): %v", host, ip, err)
+			}
+
+			pr, ok := sent[reply.seq]
+			if !ok {
+				continue
+			}
+			delete(sent, reply.seq)
+
+			hop := hops[pr.ttl-1]
+			hop.recv++
+			hop.addr = reply.from.String()
+			hop.rtts = append(hop.rtts, reply.at.Sub(pr.sentAt))
+
+			if reply.reached && (destTTL == 0 || pr.ttl < destTTL) {
+				destTTL, maxTTL = pr.ttl, pr.ttl
+			}
+		}
+	}
+
+	res := &traceResult{reached: destTTL > 0}
+	if res.reached {
+		hops = hops[:destTTL]
+	} else {
+		// trim the trailing hops that didn't respond
+		for len(hops) > 0 && hops[len(hops)-1].recv == 0 {
+			hops = hops[:len(hops)-1]
+		}
+	}
+	for _, hop := range hops {
+		hop.calcRTTStats()
+	}
+	res.hops = hops
+
+	p.Debugf("traceroute for host '%s' (ip '%s'): reached %v, %d hops", host, ip, res.reached, len(res.hops))
+
+	return res, nil
+}
+
+func (h *hopStats) calcRTTStats() {
+	if len(h.rtts) == 0 {
+		return
+	}
+
+	var sum time.Duration
+	h.minRTT, h.maxRTT = h.rtts[0], h.rtts[0]
+	for _, v := range h.rtts {
+		sum += v
+		if v < h.minRTT {
+			h.minRTT = v
+		}
+		if v > h.maxRTT {
+			h.maxRTT = v
+		}
+	}
+	h.avgRTT = sum / time.Duration(len(h.rtts))
+
+	var sqSum float64
+	for _, v := range h.rtts {
+		d := float64(v - h.avgRTT)
+		sqSum += d * d
+	}
+	h.stdDevRTT = time.Duration(math.Sqrt(sqSum / float64(len(h.rtts))))
+}
+
+func resolveIPv4(host string) (net.IP, error) {
+	addr, err := net.ResolveIPAddr("ip4", host)
+	if err != nil {
+		return nil, err
+	}
+	return addr.IP.To4(), nil
+}
+
+func getInterfaceIPAddress(ifaceName string) (ipaddr string, err error) {
+	iface, err := net.InterfaceByName(ifaceName)
+	if err != nil {
+		return "", err
+	}
+
+	addresses, err := iface.Addrs()
+	if err != nil {
+		return "", err
+	}
+
+	var v4Addr string
+	for _, addr := range addresses {
+		if ipnet, ok := addr.(*net.IPNet); ok && ipnet.IP.To4() != nil {
+			v4Addr = ipnet.IP.To4().String()
+			break
+		}
+	}
+
+	if v4Addr == "" {
+		return "", errors.New("ipv4 addresses not found")
+	}
+
+	return v4Addr, nil
+}
@@ -0,0 +1,307 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build linux
+// +build linux
+
+package traceroute
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+)
+
+const (
+	icmpTypeEchoReply    = 0
+	icmpTypeDstUnreach   = 3
+	icmpTypeEcho         = 8
+	icmpTypeTimeExceeded = 11
+
+	soEEOriginICMP = 2
+)
+
+var udpPayload = []byte("netdata traceroute probe")
+
+// newProbeConn creates the socket for the method:
+//   - icmp privileged: raw ICMP socket, all the replies are read from the socket.
+//   - icmp unprivileged: ICMP datagram socket (net.ipv4.ping_group_range), the echo replies are read from the socket
+//     and the ICMP errors from the socket error queue (IP_RECVERR).
+//   - udp: UDP socket, needs no privileges, the ICMP errors are read from the socket error queue.
+func newProbeConn(cfg probeConnConfig) (probeConn, error) {
+	typ, proto := syscall.SOCK_DGRAM, syscall.IPPROTO_UDP
+	if cfg.method == methodICMP {
+		typ, proto = syscall.SOCK_DGRAM, syscall.IPPROTO_ICMP
+		if cfg.privileged {
+			typ = syscall.SOCK_RAW
+		}
+	}
+
+	fd, err := syscall.Socket(syscall.AF_INET, typ|syscall.SOCK_CLOEXEC, proto)
+	if err != nil {
+		return nil, fmt.Errorf("create socket: %v", err)
+	}
+
+	if cfg.source != nil {
+		sa := &syscall.SockaddrInet4{}
+		copy(sa.Addr[:], cfg.source.To4())
+		if err := syscall.Bind(fd, sa); err != nil {
+			_ = syscall.Close(fd)
+			return nil, fmt.Errorf("bind socket to '%s': %v", cfg.source, err)
+		}
+	}
+
+	errQueue := typ == syscall.SOCK_DGRAM
+	if errQueue {
+		if err := syscall.SetsockoptInt(fd, syscall.SOL_IP, syscall.IP_RECVERR, 1); err != nil {
+			_ = syscall.Close(fd)
+			return nil, fmt.Errorf("enable IP_RECVERR: %v", err)
+		}
+	}
+
+	f := os.NewFile(uintptr(fd), "traceroute")
+	pc, err := net.FilePacketConn(f)
+	_ = f.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	sc, ok := pc.(syscall.Conn)
+	if !ok {
+		_ = pc.Close()
+		return nil, errors.New("unexpected connection type")
+	}
+	rc, err := sc.SyscallConn()
+	if err != nil {
+		_ = pc.Close()
+		return nil, err
+	}
+
+	return &linuxProbeConn{
+		probeConnConfig: cfg,
+		conn:            pc,
+		rc:              rc,
+		raw:             typ == syscall.SOCK_RAW,
+		errQueue:        errQueue,
+		buf:             make([]byte, 1500),
+		oob:             make([]byte, 512),
+	}, nil
+}
+
+type linuxProbeConn struct {
+	probeConnConfig
+	conn     net.PacketConn
+	rc       syscall.RawConn
+	raw      bool
+	errQueue bool
+	buf      []byte
+	oob      []byte
+}
+
+func (c *linuxProbeConn) send(seq, ttl int) error {
+	var serr error
+	if err := c.rc.Control(func(fd uintptr) {
+		serr = syscall.SetsockoptInt(int(fd), syscall.IPPROTO_IP, syscall.IP_TTL, ttl)
+	}); err != nil {
+		return err
+	}
+	if serr != nil {
+		return fmt.Errorf("set TTL: %v", serr)
+	}
+
+	err := c.write(seq)
+	if c.errQueue && isPendingICMPError(err) {
+		// the socket reports the pending error of a previous probe instead of sending, it is in the error queue as well
+		err = c.write(seq)
+	}
+	return err
+}
+
+func (c *linuxProbeConn) write(seq int) error {
+	var err error
+	switch {
+	case c.method == methodUDP:
+		_, err = c.conn.WriteTo(udpPayload, &net.UDPAddr{IP: c.dst, Port: c.port + seq})
+	case c.raw:
+		_, err = c.conn.WriteTo(c.echo(seq), &net.IPAddr{IP: c.dst})
+	default:
+		_, err = c.conn.WriteTo(c.echo(seq), &net.UDPAddr{IP: c.dst})
+	}
+	return err
+}
+
+func isPendingICMPError(err error) bool {
+	return errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EHOSTUNREACH) ||
+		errors.Is(err, syscall.ENETUNREACH) ||
+		errors.Is(err, syscall.EPROTO)
+}
+
+func (c *linuxProbeConn) echo(seq int) []byte {
+	msg := icmp.Message{
+		Type: ipv4.ICMPTypeEcho,
+		Body: &icmp.Echo{ID: c.id, Seq: seq, Data: udpPayload},
+	}
+	bs, _ := msg.Marshal(nil)
+	return bs
+}
+
+func (c *linuxProbeConn) recv(deadline time.Time) (*probeReply, error) {
+	if err := c.conn.SetReadDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	for {
+		var reply *probeReply
+		var rerr error
+
+		err := c.rc.Read(func(fd uintptr) bool {
+			if c.errQueue {
+				reply, rerr = c.readErrQueue(int(fd))
+				if rerr != syscall.EAGAIN {
+					return true
+				}
+			}
+			reply, rerr = c.readSocket(int(fd))
+			return rerr != syscall.EAGAIN
+		})
+		if err != nil {
+			return nil, err
+		}
+		if rerr != nil {
+			if c.errQueue && isPendingICMPError(rerr) {
+				continue
+			}
+			return nil, rerr
+		}
+		if reply != nil {
+			reply.at = time.Now()
+			return reply, nil
+		}
+		// not a reply to our probes, read the next one
+	}
+}
+
+// readErrQueue reads an ICMP error, the payload is the original datagram and the name is the original destination.
+func (c *linuxProbeConn) readErrQueue(fd int) (*probeReply, error) {
+	n, oobn, _, from, err := syscall.Recvmsg(fd, c.buf, c.oob, syscall.MSG_ERRQUEUE)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs, err := syscall.ParseSocketControlMessage(c.oob[:oobn])
+	if err != nil {
+		return nil, nil
+	}
+
+	for _, m := range msgs {
+		if m.Header.Level != syscall.SOL_IP || m.Header.Type != syscall.IP_RECVERR || len(m.Data) < 16+8 {
+			continue
+		}
+		// struct sock_extended_err followed by the offender struct sockaddr_in
+		origin, typ, code := m.Data[4], m.Data[5], m.Data[6]
+		if origin != soEEOriginICMP {
+			continue
+		}
+		offender := net.IP(append([]byte(nil), m.Data[16+4:16+8]...))
+
+		seq := -1
+		switch c.method {
+		case methodUDP:
+			if sa, ok := from.(*syscall.SockaddrInet4); ok {
+				seq = sa.Port - c.port
+			}
+		default:
+			if n >= 8 && c.buf[0] == icmpTypeEcho {
+				seq = int(binary.BigEndian.Uint16(c.buf[6:8]))
+			}
+		}
+		if seq < 0 {
+			return nil, nil
+		}
+
+		reached := offender.Equal(c.dst) && typ == icmpTypeDstUnreach
+		if c.method == methodUDP {
+			// port unreachable
+			reached = reached && code == 3
+		}
+		return &probeReply{seq: seq, from: offender, reached: reached}, nil
+	}
+	return nil, nil
+}
+
+func (c *linuxProbeConn) readSocket(fd int) (*probeReply, error) {
+	n, from, err := syscall.Recvfrom(fd, c.buf, syscall.MSG_DONTWAIT)
+	if err != nil {
+		return nil, err
+	}
+	sa, ok := from.(*syscall.SockaddrInet4)
+	if !ok {
+		return nil, nil
+	}
+	fromIP := net.IP(append([]byte(nil), sa.Addr[:]...))
+
+	if c.method == methodUDP {
+		// the destination responded on the probed port
+		if !fromIP.Equal(c.dst) {
+			return nil, nil
+		}
+		return &probeReply{seq: sa.Port - c.port, from: fromIP, reached: true}, nil
+	}
+
+	b := c.buf[:n]
+	if c.raw {
+		if len(b) < 20 {
+			return nil, nil
+		}
+		b = b[int(b[0]&0x0f)*4:]
+	}
+	return parseICMPReply(b, fromIP, c.dst, c.id, c.raw), nil
+}
+
+// parseICMPReply parses an echo reply or an ICMP error that contains the original echo request.
+// The datagram ICMP sockets replies come without the IP header and the kernel replaces the echo identifier.
+func parseICMPReply(b []byte, from, dst net.IP, id int, checkID bool) *probeReply {
+	if len(b) < 8 {
+		return nil
+	}
+
+	switch b[0] {
+	case icmpTypeEchoReply:
+		if checkID && int(binary.BigEndian.Uint16(b[4:6])) != id {
+			return nil
+		}
+		return &probeReply{seq: int(binary.BigEndian.Uint16(b[6:8])), from: from, reached: from.Equal(dst)}
+	case icmpTypeTimeExceeded, icmpTypeDstUnreach:
+		// the original IP header and at least 8 bytes of the original datagram
+		inner := b[8:]
+		if len(inner) < 20 {
+			return nil
+		}
+		hl := int(inner[0]&0x0f) * 4
+		if inner[9] != syscall.IPPROTO_ICMP || len(inner) < hl+8 || !net.IP(inner[16:20]).Equal(dst) {
+			return nil
+		}
+		echo := inner[hl:]
+		if echo[0] != icmpTypeEcho || (checkID && int(binary.BigEndian.Uint16(echo[4:6])) != id) {
+			return nil
+		}
+		return &probeReply{
+			seq:     int(binary.BigEndian.Uint16(echo[6:8])),
+			from:    from,
+			reached: b[0] == icmpTypeDstUnreach && from.Equal(dst),
+		}
+	default:
+		return nil
+	}
+}
+
+func (c *linuxProbeConn) close() error {
+	return c.conn.Close()
+}
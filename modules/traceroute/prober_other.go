@@ -0,0 +1,15 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+//go:build !linux
+// +build !linux
+
+package traceroute
+
+import (
+	"fmt"
+	"runtime"
+)
+
+func newProbeConn(probeConnConfig) (probeConn, error) {
+	return nil, fmt.Errorf("traceroute is not supported on %s", runtime.GOOS)
+}
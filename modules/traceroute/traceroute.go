@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package traceroute
+
+import (
+	"time"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/logger"
+	"github.com/netdata/go.d.plugin/pkg/web"
+)
+
+func init() {
+	module.Register("traceroute", module.Creator{
+		Defaults: module.Defaults{
+			UpdateEvery: 30,
+		},
+		Create: func() module.Module { return New() },
+	})
+}
+
+func New() *Traceroute {
+	return &Traceroute{
+		Config: Config{
+			Method:      methodICMP,
+			Privileged:  true,
+			SendPackets: 3,
+			MaxHops:     30,
+			Port:        33434,
+			Timeout:     web.Duration{Duration: time.Second},
+		},
+
+		charts:    &module.Charts{},
+		hosts:     make(map[string]*hostPath),
+		newProber: newTracerouteProber,
+	}
+}
+
+type (
+	Config struct {
+		UpdateEvery int          `yaml:"update_every"`
+		Hosts       []string     `yaml:"hosts"`
+		Method      string       `yaml:"method"`
+		Privileged  bool         `yaml:"privileged"`
+		SendPackets int          `yaml:"packets"`
+		MaxHops     int          `yaml:"max_hops"`
+		Port        int          `yaml:"port"`
+		Timeout     web.Duration `yaml:"timeout"`
+		Interface   string       `yaml:"interface"`
+	}
+)
+
+type (
+	Traceroute struct {
+		module.Base
+		Config `yaml:",inline"`
+
+		charts *module.Charts
+
+		hosts map[string]*hostPath
+
+		newProber func(tracerouteProberConfig, *logger.Logger) prober
+		prober    prober
+	}
+	prober interface {
+		trace(host string) (*traceResult, error)
+	}
+)
+
+func (tr *Traceroute) Init() bool {
+	err := tr.validateConfig()
+	if err != nil {
+		tr.Errorf("config validation: %v", err)
+		return false
+	}
+
+	pr, err := tr.initProber()
+	if err != nil {
+		tr.Errorf("init prober: %v", err)
+		return false
+	}
+	tr.prober = pr
+
+	return true
+}
+
+func (tr *Traceroute) Check() bool {
+	return len(tr.Collect()) > 0
+}
+
+func (tr *Traceroute) Charts() *module.Charts {
+	return tr.charts
+}
+
+func (tr *Traceroute) Collect() map[string]int64 {
+	mx, err := tr.collect()
+	if err != nil {
+		tr.Error(err)
+	}
+
+	if len(mx) == 0 {
+		return nil
+	}
+	return mx
+}
+
+func (tr *Traceroute) Cleanup() {}
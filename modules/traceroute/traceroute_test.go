@@ -0,0 +1,282 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package traceroute
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/logger"
+	"github.com/netdata/go.d.plugin/pkg/web"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceroute_Init(t *testing.T) {
+	tests := map[string]struct {
+		wantFail bool
+		config   Config
+	}{
+		"fail with default": {
+			wantFail: true,
+			config:   New().Config,
+		},
+		"success when 'hosts' set": {
+			wantFail: false,
+			config: func() Config {
+				cfg := New().Config
+				cfg.Hosts = []string{"192.0.2.0"}
+				return cfg
+			}(),
+		},
+		"fail when 'method' is unknown": {
+			wantFail: true,
+			config: func() Config {
+				cfg := New().Config
+				cfg.Hosts = []string{"192.0.2.0"}
+				cfg.Method = "tcp"
+				return cfg
+			}(),
+		},
+		"fail when 'max_hops' is out of range": {
+			wantFail: true,
+			config: func() Config {
+				cfg := New().Config
+				cfg.Hosts = []string{"192.0.2.0"}
+				cfg.MaxHops = 256
+				return cfg
+			}(),
+		},
+		"fail when udp 'port' range overflows": {
+			wantFail: true,
+			config: func() Config {
+				cfg := New().Config
+				cfg.Hosts = []string{"192.0.2.0"}
+				cfg.Method = methodUDP
+				cfg.Port = 65500
+				return cfg
+			}(),
+		},
+		"fail when 'packets' * 'timeout' >= 'update_every'": {
+			wantFail: true,
+			config: func() Config {
+				cfg := New().Config
+				cfg.Hosts = []string{"192.0.2.0"}
+				cfg.UpdateEvery = 3
+				cfg.Timeout = web.Duration{Duration: time.Second}
+				return cfg
+			}(),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tr := New()
+			tr.Config = test.config
+			if tr.UpdateEvery == 0 {
+				tr.UpdateEvery = 30
+			}
+
+			if test.wantFail {
+				assert.False(t, tr.Init())
+			} else {
+				assert.True(t, tr.Init())
+			}
+		})
+	}
+}
+
+func TestTraceroute_Charts(t *testing.T) {
+	assert.NotNil(t, New().Charts())
+}
+
+func TestTraceroute_Cleanup(t *testing.T) {
+	assert.NotPanics(t, New().Cleanup)
+}
+
+func TestTraceroute_Check(t *testing.T) {
+	tests := map[string]struct {
+		wantFail bool
+		prepare  func(t *testing.T) *Traceroute
+	}{
+		"success when trace does not return an error": {
+			wantFail: false,
+			prepare:  caseTraceSuccess,
+		},
+		"fail when trace returns an error": {
+			wantFail: true,
+			prepare:  caseTraceError,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tr := test.prepare(t)
+
+			if test.wantFail {
+				assert.False(t, tr.Check())
+			} else {
+				assert.True(t, tr.Check())
+			}
+		})
+	}
+}
+
+func TestTraceroute_Collect(t *testing.T) {
+	tests := map[string]struct {
+		prepare       func(t *testing.T) *Traceroute
+		wantMetrics   map[string]int64
+		wantNumCharts int
+	}{
+		"success when trace does not return an error": {
+			prepare: caseTraceSuccess,
+			wantMetrics: map[string]int64{
+				"host_192.0.2.1_hop_1_avg_rtt":       1500,
+				"host_192.0.2.1_hop_1_max_rtt":       2000,
+				"host_192.0.2.1_hop_1_min_rtt":       1000,
+				"host_192.0.2.1_hop_1_packet_loss":   0,
+				"host_192.0.2.1_hop_2_packet_loss":   100000,
+				"host_192.0.2.1_hop_3_avg_rtt":       15000,
+				"host_192.0.2.1_hop_3_max_rtt":       20000,
+				"host_192.0.2.1_hop_3_min_rtt":       10000,
+				"host_192.0.2.1_hop_3_packet_loss":   33333,
+				"host_192.0.2.1_hop_count":           3,
+				"host_192.0.2.1_path_changes":        0,
+				"host_example.com_hop_1_avg_rtt":     1500,
+				"host_example.com_hop_1_max_rtt":     2000,
+				"host_example.com_hop_1_min_rtt":     1000,
+				"host_example.com_hop_1_packet_loss": 0,
+				"host_example.com_hop_2_packet_loss": 100000,
+				"host_example.com_hop_3_avg_rtt":     15000,
+				"host_example.com_hop_3_max_rtt":     20000,
+				"host_example.com_hop_3_min_rtt":     10000,
+				"host_example.com_hop_3_packet_loss": 33333,
+				"host_example.com_hop_count":         3,
+				"host_example.com_path_changes":      0,
+			},
+			wantNumCharts: 2*len(hostChartsTmpl) + 2*3*len(hopChartsTmpl),
+		},
+		"fail when trace returns an error": {
+			prepare:       caseTraceError,
+			wantMetrics:   nil,
+			wantNumCharts: 0,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tr := test.prepare(t)
+
+			mx := tr.Collect()
+
+			require.Equal(t, test.wantMetrics, mx)
+
+			if len(test.wantMetrics) > 0 {
+				assert.Len(t, *tr.Charts(), test.wantNumCharts)
+			}
+		})
+	}
+}
+
+func TestTraceroute_Collect_PathChanges(t *testing.T) {
+	tr := New()
+	tr.UpdateEvery = 30
+	tr.Hosts = []string{"192.0.2.1"}
+	mock := &mockProber{hops: []string{"10.0.0.1", "", "192.0.2.1"}}
+	tr.newProber = func(_ tracerouteProberConfig, _ *logger.Logger) prober { return mock }
+	require.True(t, tr.Init())
+
+	mx := tr.Collect()
+	require.NotNil(t, mx)
+	assert.Equal(t, int64(0), mx["host_192.0.2.1_path_changes"])
+
+	// a hop that didn't respond is not a change
+	mock.hops = []string{"10.0.0.1", "10.0.1.1", ""}
+	mx = tr.Collect()
+	assert.Equal(t, int64(0), mx["host_192.0.2.1_path_changes"])
+
+	// the hop address changed
+	mock.hops = []string{"10.0.0.2", "10.0.1.1", "192.0.2.1"}
+	mx = tr.Collect()
+	assert.Equal(t, int64(1), mx["host_192.0.2.1_path_changes"])
+	chart := tr.Charts().Get("host_192_0_2_1_hop_1_rtt")
+	require.NotNil(t, chart)
+	assert.Contains(t, chart.Labels, module.Label{Key: "hop_address", Value: "10.0.0.2"})
+
+	// the path got shorter
+	mock.hops = []string{"10.0.0.2", "192.0.2.1"}
+	mx = tr.Collect()
+	assert.Equal(t, int64(2), mx["host_192.0.2.1_path_changes"])
+	assert.Equal(t, int64(2), mx["host_192.0.2.1_hop_count"])
+	for _, chart := range *tr.Charts() {
+		if chart.ID == "host_192_0_2_1_hop_3_rtt" || chart.ID == "host_192_0_2_1_hop_3_packet_loss" {
+			assert.True(t, chart.Obsolete)
+		}
+	}
+}
+
+func TestHopStats_calcRTTStats(t *testing.T) {
+	hop := &hopStats{rtts: []time.Duration{time.Millisecond, 2 * time.Millisecond, 3 * time.Millisecond}}
+
+	hop.calcRTTStats()
+
+	assert.Equal(t, time.Millisecond, hop.minRTT)
+	assert.Equal(t, 3*time.Millisecond, hop.maxRTT)
+	assert.Equal(t, 2*time.Millisecond, hop.avgRTT)
+	assert.Equal(t, time.Duration(816496), hop.stdDevRTT)
+}
+
+func caseTraceSuccess(t *testing.T) *Traceroute {
+	tr := New()
+	tr.UpdateEvery = 30
+	tr.Hosts = []string{"192.0.2.1", "example.com"}
+	tr.newProber = func(_ tracerouteProberConfig, _ *logger.Logger) prober {
+		return &mockProber{hops: []string{"10.0.0.1", "", "192.0.2.1"}}
+	}
+	require.True(t, tr.Init())
+	return tr
+}
+
+func caseTraceError(t *testing.T) *Traceroute {
+	tr := New()
+	tr.UpdateEvery = 30
+	tr.Hosts = []string{"192.0.2.1", "example.com"}
+	tr.newProber = func(_ tracerouteProberConfig, _ *logger.Logger) prober {
+		return &mockProber{errOnTrace: true}
+	}
+	require.True(t, tr.Init())
+	return tr
+}
+
+type mockProber struct {
+	errOnTrace bool
+	hops       []string
+}
+
+func (m *mockProber) trace(string) (*traceResult, error) {
+	if m.errOnTrace {
+		return nil, errors.New("mock.trace() error")
+	}
+
+	res := &traceResult{}
+	for i, addr := range m.hops {
+		hop := &hopStats{addr: addr, sent: 3}
+		switch {
+		case addr == "":
+		case i == len(m.hops)-1:
+			res.reached = true
+			hop.recv = 2
+			hop.rtts = []time.Duration{time.Millisecond * 10, time.Millisecond * 20}
+		default:
+			hop.recv = 3
+			hop.rtts = []time.Duration{time.Millisecond, time.Millisecond * 2, time.Microsecond * 1500}
+		}
+		hop.calcRTTStats()
+		res.hops = append(res.hops, hop)
+	}
+
+	return res, nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/metrics"
 )
 
 type (
@@ -33,8 +34,10 @@ const (
 
 	prioReqProcTime
 	prioRespTimeHist
+	prioReqProcTimeQuantile
 	prioUpsRespTime
 	prioUpsRespTimeHist
+	prioUpsRespTimeQuantile
 
 	prioUniqIP
 
@@ -50,6 +53,7 @@ const (
 	prioReqCustomFieldPattern  // chart per custom field, alphabetical order
 	prioReqCustomTimeField     // chart per custom time field, alphabetical order
 	prioReqCustomTimeFieldHist // histogram chart per custom time field
+	prioReqCustomTimeFieldQntl // percentiles chart per custom time field
 	prioReqURLPattern
 	prioURLPatternStats // 3 charts per url pattern, alphabetical order
 )
@@ -214,6 +218,14 @@ var (
 		Ctx:      "web_log.requests_processing_time_histogram",
 		Priority: prioRespTimeHist,
 	}
+	reqProcTimeQuantile = Chart{
+		ID:       "request_processing_time_percentiles",
+		Title:    "Request Processing Time Percentiles",
+		Units:    "milliseconds",
+		Fam:      "timings",
+		Ctx:      "web_log.request_processing_time_percentiles",
+		Priority: prioReqProcTimeQuantile,
+	}
 )
 
 // Upstream
@@ -239,6 +251,14 @@ var (
 		Ctx:      "web_log.upstream_responses_time_histogram",
 		Priority: prioUpsRespTimeHist,
 	}
+	upsRespTimeQuantile = Chart{
+		ID:       "upstream_response_time_percentiles",
+		Title:    "Upstream Response Time Percentiles",
+		Units:    "milliseconds",
+		Fam:      "timings",
+		Ctx:      "web_log.upstream_response_time_percentiles",
+		Priority: prioUpsRespTimeQuantile,
+	}
 )
 
 // Clients
@@ -387,6 +407,14 @@ var (
 		Ctx:      "web_log.custom_time_field_histogram",
 		Priority: prioReqCustomTimeFieldHist,
 	}
+	reqByCustomTimeFieldQuantile = Chart{
+		ID:       "custom_time_field_%s_percentiles",
+		Title:    `Custom Time Field "%s" Percentiles`,
+		Units:    "milliseconds",
+		Fam:      "custom time field",
+		Ctx:      "web_log.custom_time_field_percentiles",
+		Priority: prioReqCustomTimeFieldQntl,
+	}
 )
 
 // URL pattern stats
@@ -459,6 +487,22 @@ func newReqProcTimeHistChart(histogram []float64) (*Chart, error) {
 	return chart, nil
 }
 
+func newQuantileChart(tmpl Chart, key string, quantiles []float64) (*Chart, error) {
+	chart := tmpl.Copy()
+	for _, q := range quantiles {
+		name := metrics.QuantileName(q)
+		dim := &Dim{
+			ID:   key + "_" + name,
+			Name: name,
+			Div:  1000,
+		}
+		if err := chart.AddDim(dim); err != nil {
+			return nil, err
+		}
+	}
+	return chart, nil
+}
+
 func newUpsRespTimeHistChart(histogram []float64) (*Chart, error) {
 	chart := upsRespTimeHist.Copy()
 	for i, v := range histogram {
@@ -572,6 +616,16 @@ func newCustomTimeFieldCharts(fields []customTimeField) (Charts, error) {
 		if err := charts.Add(chartTime); err != nil {
 			return nil, err
 		}
+		if len(f.Quantiles) > 0 {
+			chartQntl, err := newCustomTimeFieldQuantileChart(f)
+			if err != nil {
+				return nil, err
+			}
+			chartQntl.Priority += i
+			if err := charts.Add(chartQntl); err != nil {
+				return nil, err
+			}
+		}
 		if len(f.Histogram) < 1 {
 			continue
 		}
@@ -589,6 +643,17 @@ func newCustomTimeFieldCharts(fields []customTimeField) (Charts, error) {
 	return charts, nil
 }
 
+func newCustomTimeFieldQuantileChart(f customTimeField) (*Chart, error) {
+	key := fmt.Sprintf("custom_time_field_%s_time_quantile", f.Name)
+	chart, err := newQuantileChart(reqByCustomTimeFieldQuantile, key, f.Quantiles)
+	if err != nil {
+		return nil, err
+	}
+	chart.ID = fmt.Sprintf(chart.ID, f.Name)
+	chart.Title = fmt.Sprintf(chart.Title, f.Name)
+	return chart, nil
+}
+
 func newCustomTimeFieldChart(f customTimeField) (*Chart, error) {
 	chart := reqByCustomTimeField.Copy()
 	chart.ID = fmt.Sprintf(chart.ID, f.Name)
@@ -680,12 +745,12 @@ func (w *WebLog) createCharts(line *logLine) error {
 		}
 	}
 	if line.hasReqProcTime() {
-		if err := addReqProcTimeCharts(charts, w.Histogram, w.URLPatterns); err != nil {
+		if err := addReqProcTimeCharts(charts, w.Histogram, w.Quantiles, w.URLPatterns); err != nil {
 			return err
 		}
 	}
 	if line.hasUpsRespTime() {
-		if err := addUpstreamRespTimeCharts(charts, w.Histogram); err != nil {
+		if err := addUpstreamRespTimeCharts(charts, w.Histogram, w.Quantiles); err != nil {
 			return err
 		}
 	}
@@ -798,7 +863,7 @@ func addBandwidthCharts(charts *Charts, patterns []userPattern) error {
 	return nil
 }
 
-func addReqProcTimeCharts(charts *Charts, histogram []float64, patterns []userPattern) error {
+func addReqProcTimeCharts(charts *Charts, histogram, quantiles []float64, patterns []userPattern) error {
 	if err := charts.Add(reqProcTime.Copy()); err != nil {
 		return err
 	}
@@ -808,6 +873,15 @@ func addReqProcTimeCharts(charts *Charts, histogram []float64, patterns []userPa
 			return err
 		}
 	}
+	if len(quantiles) > 0 {
+		chart, err := newQuantileChart(reqProcTimeQuantile, "req_proc_time_quantile", quantiles)
+		if err != nil {
+			return err
+		}
+		if err := charts.Add(chart); err != nil {
+			return err
+		}
+	}
 	if len(histogram) == 0 {
 		return nil
 	}
@@ -818,10 +892,19 @@ func addReqProcTimeCharts(charts *Charts, histogram []float64, patterns []userPa
 	return charts.Add(chart)
 }
 
-func addUpstreamRespTimeCharts(charts *Charts, histogram []float64) error {
+func addUpstreamRespTimeCharts(charts *Charts, histogram, quantiles []float64) error {
 	if err := charts.Add(upsRespTime.Copy()); err != nil {
 		return err
 	}
+	if len(quantiles) > 0 {
+		chart, err := newQuantileChart(upsRespTimeQuantile, "upstream_resp_time_quantile", quantiles)
+		if err != nil {
+			return err
+		}
+		if err := charts.Add(chart); err != nil {
+			return err
+		}
+	}
 	if len(histogram) == 0 {
 		return nil
 	}
@@ -241,6 +241,7 @@ func (w *WebLog) collectReqProcTime() {
 		return
 	}
 	w.mx.ReqProcTime.Observe(w.line.reqProcTime)
+	w.mx.ReqProcTimeQntl.Observe(w.line.reqProcTime)
 	if w.mx.ReqProcTimeHist == nil {
 		return
 	}
@@ -252,6 +253,7 @@ func (w *WebLog) collectUpsRespTime() {
 		return
 	}
 	w.mx.UpsRespTime.Observe(w.line.upsRespTime)
+	w.mx.UpsRespTimeQntl.Observe(w.line.upsRespTime)
 	if w.mx.UpsRespTimeHist == nil {
 		return
 	}
@@ -344,6 +346,7 @@ func (w *WebLog) collectCustomFields() {
 				continue
 			}
 			v.Time.Observe(ctf)
+			v.TimeQntl.Observe(ctf * timeMultiplier(cv.value))
 			if histogram != nil {
 				v.TimeHist.Observe(ctf * timeMultiplier(cv.value))
 			}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/netdata/go.d.plugin/pkg/logs"
 	"github.com/netdata/go.d.plugin/pkg/matcher"
+	"github.com/netdata/go.d.plugin/pkg/metrics"
 )
 
 type pattern struct {
@@ -90,6 +91,18 @@ func (w *WebLog) createCustomTimeFields() error {
 	return nil
 }
 
+func (w *WebLog) validateQuantiles() error {
+	if err := metrics.CheckQuantiles(w.Quantiles); err != nil {
+		return fmt.Errorf("validate quantiles: %v", err)
+	}
+	for _, ctf := range w.CustomTimeFields {
+		if err := metrics.CheckQuantiles(ctf.Quantiles); err != nil {
+			return fmt.Errorf("validate custom time field '%s' quantiles: %v", ctf.Name, err)
+		}
+	}
+	return nil
+}
+
 func (w *WebLog) createLogLine() {
 	w.line = newEmptyLogLine()
 	for v := range w.customFields {
@@ -103,18 +116,27 @@ func (w *WebLog) createLogLine() {
 func (w *WebLog) createLogReader() error {
 	w.Cleanup()
 	w.Debug("starting log reader creating")
-	reader, err := logs.Open(w.Path, w.ExcludePath, w.Logger)
+	var reader logs.LogReader
+	var err error
+	switch w.Source {
+	case logs.SourceJournal:
+		reader, err = logs.OpenJournal(w.Journal, w.Logger)
+	case logs.SourceFile, "":
+		reader, err = logs.Open(w.Path, w.ExcludePath, w.Logger)
+	default:
+		err = fmt.Errorf("unknown source '%s'", w.Source)
+	}
 	if err != nil {
 		return fmt.Errorf("creating log reader: %v", err)
 	}
-	w.Debugf("created log reader, current file '%s'", reader.CurrentFilename())
+	w.Debugf("created log reader, %s", reader.Info())
 	w.file = reader
 	return nil
 }
 
 func (w *WebLog) createParser() error {
 	w.Debug("starting parser creating")
-	lastLine, err := logs.ReadLastLine(w.file.CurrentFilename(), 0)
+	lastLine, err := w.file.LastLine()
 	if err != nil {
 		return fmt.Errorf("read last line: %v", err)
 	}
@@ -42,14 +42,16 @@ type (
 		ReqBad      metrics.Counter `stm:"req_type_bad"`
 		ReqError    metrics.Counter `stm:"req_type_error"`
 
-		UniqueIPv4      metrics.UniqueCounter `stm:"uniq_ipv4"`
-		UniqueIPv6      metrics.UniqueCounter `stm:"uniq_ipv6"`
-		BytesSent       metrics.Counter       `stm:"bytes_sent"`
-		BytesReceived   metrics.Counter       `stm:"bytes_received"`
-		ReqProcTime     metrics.Summary       `stm:"req_proc_time"`
-		ReqProcTimeHist metrics.Histogram     `stm:"req_proc_time_hist"`
-		UpsRespTime     metrics.Summary       `stm:"upstream_resp_time"`
-		UpsRespTimeHist metrics.Histogram     `stm:"upstream_resp_time_hist"`
+		UniqueIPv4      metrics.UniqueCounter          `stm:"uniq_ipv4"`
+		UniqueIPv6      metrics.UniqueCounter          `stm:"uniq_ipv6"`
+		BytesSent       metrics.Counter                `stm:"bytes_sent"`
+		BytesReceived   metrics.Counter                `stm:"bytes_received"`
+		ReqProcTime     metrics.Summary                `stm:"req_proc_time"`
+		ReqProcTimeHist metrics.Histogram              `stm:"req_proc_time_hist"`
+		ReqProcTimeQntl metrics.OptionalQuantileSketch `stm:"req_proc_time_quantile"`
+		UpsRespTime     metrics.Summary                `stm:"upstream_resp_time"`
+		UpsRespTimeHist metrics.Histogram              `stm:"upstream_resp_time_hist"`
+		UpsRespTimeQntl metrics.OptionalQuantileSketch `stm:"upstream_resp_time_quantile"`
 
 		ReqVhost          metrics.CounterVec `stm:"req_vhost"`
 		ReqPort           metrics.CounterVec `stm:"req_port"`
@@ -69,8 +71,9 @@ type (
 		ReqCustomTimeField map[string]*customTimeFieldMetrics `stm:"custom_time_field"`
 	}
 	customTimeFieldMetrics struct {
-		Time     metrics.Summary   `stm:"time"`
-		TimeHist metrics.Histogram `stm:"time_hist"`
+		Time     metrics.Summary                `stm:"time"`
+		TimeHist metrics.Histogram              `stm:"time_hist"`
+		TimeQntl metrics.OptionalQuantileSketch `stm:"time_quantile"`
 	}
 	patternMetrics struct {
 		RespCode      metrics.CounterVec `stm:"resp_code"`
@@ -92,8 +95,10 @@ func newMetricsData(config Config) *metricsData {
 		ReqSSLCipherSuite:  metrics.NewCounterVec(),
 		ReqProcTime:        newWebLogSummary(),
 		ReqProcTimeHist:    metrics.NewHistogram(convHistOptionsToMicroseconds(config.Histogram)),
+		ReqProcTimeQntl:    metrics.NewOptionalQuantileSketch(config.Quantiles),
 		UpsRespTime:        newWebLogSummary(),
 		UpsRespTimeHist:    metrics.NewHistogram(convHistOptionsToMicroseconds(config.Histogram)),
+		UpsRespTimeQntl:    metrics.NewOptionalQuantileSketch(config.Quantiles),
 		UniqueIPv4:         metrics.NewUniqueCounter(true),
 		UniqueIPv6:         metrics.NewUniqueCounter(true),
 		ReqURLPattern:      newCounterVecFromPatterns(config.URLPatterns),
@@ -107,12 +112,15 @@ func (m *metricsData) reset() {
 	m.UniqueIPv4.Reset()
 	m.UniqueIPv6.Reset()
 	m.ReqProcTime.Reset()
+	m.ReqProcTimeQntl.Reset()
 	m.UpsRespTime.Reset()
+	m.UpsRespTimeQntl.Reset()
 	for _, v := range m.URLPatternStats {
 		v.ReqProcTime.Reset()
 	}
 	for _, v := range m.ReqCustomTimeField {
 		v.Time.Reset()
+		v.TimeQntl.Reset()
 	}
 }
 
@@ -150,6 +158,7 @@ func newReqCustomTimeField(fields []customTimeField) map[string]*customTimeField
 		cf[f.Name] = &customTimeFieldMetrics{
 			Time:     newWebLogSummary(),
 			TimeHist: metrics.NewHistogram(convHistOptionsToMicroseconds(f.Histogram)),
+			TimeQntl: metrics.NewOptionalQuantileSketch(f.Quantiles),
 		}
 	}
 	return cf
@@ -53,24 +53,28 @@ type (
 	customTimeField struct {
 		Name      string    `yaml:"name"`
 		Histogram []float64 `yaml:"histogram"`
+		Quantiles []float64 `yaml:"quantiles"`
 	}
 
 	Config struct {
-		Parser           logs.ParserConfig `yaml:",inline"`
-		Path             string            `yaml:"path"`
-		ExcludePath      string            `yaml:"exclude_path"`
-		URLPatterns      []userPattern     `yaml:"url_patterns"`
-		CustomFields     []customField     `yaml:"custom_fields"`
-		CustomTimeFields []customTimeField `yaml:"custom_time_fields"`
-		Histogram        []float64         `yaml:"histogram"`
-		GroupRespCodes   bool              `yaml:"group_response_codes"`
+		Parser           logs.ParserConfig  `yaml:",inline"`
+		Source           string             `yaml:"source"`
+		Path             string             `yaml:"path"`
+		ExcludePath      string             `yaml:"exclude_path"`
+		Journal          logs.JournalConfig `yaml:"journal"`
+		URLPatterns      []userPattern      `yaml:"url_patterns"`
+		CustomFields     []customField      `yaml:"custom_fields"`
+		CustomTimeFields []customTimeField  `yaml:"custom_time_fields"`
+		Histogram        []float64          `yaml:"histogram"`
+		Quantiles        []float64          `yaml:"quantiles"`
+		GroupRespCodes   bool               `yaml:"group_response_codes"`
 	}
 
 	WebLog struct {
 		module.Base
 		Config `yaml:",inline"`
 
-		file             *logs.Reader
+		file             logs.LogReader
 		parser           logs.Parser
 		line             *logLine
 		urlPatterns      []*pattern
@@ -98,6 +102,11 @@ func (w *WebLog) Init() bool {
 		return false
 	}
 
+	if err := w.validateQuantiles(); err != nil {
+		w.Error("init failed: ", err)
+		return false
+	}
+
 	w.createLogLine()
 	w.mx = newMetricsData(w.Config)
 	return true
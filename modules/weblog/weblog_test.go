@@ -10,8 +10,10 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/netdata/go.d.plugin/pkg/logs"
+	"github.com/netdata/go.d.plugin/pkg/logs/logstest"
 	"github.com/netdata/go.d.plugin/pkg/metrics"
 
 	"github.com/netdata/go.d.plugin/agent/module"
@@ -59,6 +61,23 @@ func TestWebLog_Init_ErrorOnCreatingCustomFields(t *testing.T) {
 	assert.False(t, weblog.Init())
 }
 
+func TestWebLog_Init_ErrorOnInvalidQuantiles(t *testing.T) {
+	tests := map[string]func(w *WebLog){
+		"quantile is 0":         func(w *WebLog) { w.Quantiles = []float64{0, 0.5} },
+		"quantile is 1":         func(w *WebLog) { w.Quantiles = []float64{0.5, 1} },
+		"custom time field > 1": func(w *WebLog) { w.CustomTimeFields = []customTimeField{{Name: "t", Quantiles: []float64{95}}} },
+	}
+
+	for name, prepare := range tests {
+		t.Run(name, func(t *testing.T) {
+			weblog := New()
+			prepare(weblog)
+
+			assert.False(t, weblog.Init())
+		})
+	}
+}
+
 func TestWebLog_Check(t *testing.T) {
 	weblog := New()
 	defer weblog.Cleanup()
@@ -97,6 +116,26 @@ func TestWebLog_Check_ErrorOnCreatingParserEmptyLine(t *testing.T) {
 	assert.False(t, weblog.Check())
 }
 
+func TestWebLog_Collect_JournalSource(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(string(testCommonLog)), "\n")[:9]
+	weblog := New()
+	defer weblog.Cleanup()
+	weblog.Source = logs.SourceJournal
+	dir := t.TempDir()
+	weblog.Journal.JournalctlPath = logstest.PrepareFakeJournalctl(t, dir, logstest.JournalEntries(t, lines...))
+	weblog.Journal.CursorFile = logstest.PrepareJournalCursorFile(t, dir, logstest.JournalStartCursor)
+	require.True(t, weblog.Init())
+	require.True(t, weblog.Check())
+
+	var mx map[string]int64
+	require.Eventually(t, func() bool {
+		mx = weblog.Collect()
+		return mx["requests"] == int64(len(lines))
+	}, time.Second*5, time.Millisecond*100)
+	assert.Equal(t, int64(1), mx["req_unmatched"])
+	assert.Equal(t, int64(5), mx["req_method_POST"])
+}
+
 func TestWebLog_Charts(t *testing.T) {
 	weblog := New()
 	defer weblog.Cleanup()
@@ -560,6 +599,48 @@ func TestWebLog_Collect_CustomTimeFieldsLogs(t *testing.T) {
 	testCharts(t, weblog, mx)
 }
 
+func TestWebLog_Collect_CustomTimeFieldsQuantiles(t *testing.T) {
+	weblog := New()
+	weblog.Config = prepareWebLogCollectCustomTimeFields(t).Config
+	weblog.CustomTimeFields = []customTimeField{
+		{Name: "time1", Quantiles: []float64{0.5, 0.9, 0.99}},
+		{Name: "time2", Quantiles: []float64{0.5, 0.9}},
+	}
+	require.True(t, weblog.Init())
+	require.True(t, weblog.Check())
+	defer weblog.Cleanup()
+
+	p, err := logs.NewCSVParser(weblog.Parser.CSV, bytes.NewReader(testCustomTimeFieldLog))
+	require.NoError(t, err)
+	weblog.parser = p
+
+	expected := map[string]int64{
+		"custom_time_field_time1_time_quantile_count": 72,
+		"custom_time_field_time1_time_quantile_p50":   121,
+		"custom_time_field_time1_time_quantile_p90":   431,
+		"custom_time_field_time1_time_quantile_p99":   431,
+		"custom_time_field_time2_time_quantile_count": 72,
+		"custom_time_field_time2_time_quantile_p50":   321,
+		"custom_time_field_time2_time_quantile_p90":   321,
+	}
+
+	mx := weblog.Collect()
+	for k, v := range expected {
+		assert.Equalf(t, v, mx[k], "metric '%s'", k)
+	}
+	for _, f := range weblog.CustomTimeFields {
+		id := fmt.Sprintf(reqByCustomTimeFieldQuantile.ID, f.Name)
+		chart := weblog.Charts().Get(id)
+		require.NotNilf(t, chart, "chart '%s' is not created", id)
+		assert.Len(t, chart.Dims, len(f.Quantiles))
+	}
+	testChartsDimIDs(t, weblog, mx)
+
+	mx = weblog.Collect()
+	assert.Equal(t, int64(0), mx["custom_time_field_time1_time_quantile_count"])
+	assert.Equal(t, int64(0), mx["custom_time_field_time1_time_quantile_p99"])
+}
+
 func TestWebLog_IISLogs(t *testing.T) {
 	weblog := prepareWebLogCollectIISFields(t)
 
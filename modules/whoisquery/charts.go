@@ -20,4 +20,30 @@ var baseCharts = module.Charts{
 			{ID: "days_until_expiration_critical"},
 		},
 	},
+	{
+		ID:    "domain_status",
+		Title: "Domain Status",
+		Units: "status",
+		Fam:   "status",
+		Ctx:   "whoisquery.domain_status",
+		Dims: module.Dims{
+			{ID: "status_client_hold", Name: "client_hold"},
+			{ID: "status_server_hold", Name: "server_hold"},
+			{ID: "status_redemption_period", Name: "redemption_period"},
+			{ID: "status_pending_delete", Name: "pending_delete"},
+			{ID: "status_client_transfer_prohibited", Name: "client_transfer_prohibited"},
+			{ID: "status_server_transfer_prohibited", Name: "server_transfer_prohibited"},
+		},
+	},
+	{
+		ID:    "domain_changes",
+		Title: "Domain Registration Recent Changes",
+		Units: "status",
+		Fam:   "changes",
+		Ctx:   "whoisquery.domain_changes",
+		Dims: module.Dims{
+			{ID: "nameservers_changed", Name: "nameservers"},
+			{ID: "registrar_changed", Name: "registrar"},
+		},
+	},
 }
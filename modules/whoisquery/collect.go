@@ -2,16 +2,33 @@
 
 package whoisquery
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// domainStatuses maps the normalized EPP (RFC 5731) and RDAP (RFC 8056) status values to the metric IDs.
+var domainStatuses = map[string]string{
+	"clienthold":               "status_client_hold",
+	"serverhold":               "status_server_hold",
+	"redemptionperiod":         "status_redemption_period",
+	"pendingdelete":            "status_pending_delete",
+	"clienttransferprohibited": "status_client_transfer_prohibited",
+	"servertransferprohibited": "status_server_transfer_prohibited",
+}
 
 func (w *WhoisQuery) collect() (map[string]int64, error) {
-	remainingTime, err := w.prov.remainingTime()
+	info, err := w.prov.domainInfo()
 	if err != nil {
 		return nil, fmt.Errorf("%v (source: %s)", err, w.Source)
 	}
 
 	mx := make(map[string]int64)
-	w.collectExpiration(mx, remainingTime)
+	w.collectExpiration(mx, info.remainingTime)
+	w.collectStatus(mx, info.statuses)
+	w.collectChanges(mx, info)
 
 	return mx, nil
 }
@@ -21,3 +38,85 @@ func (w *WhoisQuery) collectExpiration(mx map[string]int64, remainingTime float6
 	mx["days_until_expiration_warning"] = w.DaysUntilWarn
 	mx["days_until_expiration_critical"] = w.DaysUntilCrit
 }
+
+func (w *WhoisQuery) collectStatus(mx map[string]int64, statuses []string) {
+	if len(statuses) == 0 {
+		return
+	}
+
+	for _, id := range domainStatuses {
+		mx[id] = 0
+	}
+	for _, status := range statuses {
+		if id, ok := domainStatuses[normalizeStatus(status)]; ok {
+			mx[id] = 1
+		}
+	}
+}
+
+func (w *WhoisQuery) collectChanges(mx map[string]int64, info *domainInfo) {
+	now := time.Now()
+
+	// a change is detected only if both the previous and the current check of the same provider have the data
+	if nameservers := normalizeNameservers(info.nameservers); len(nameservers) > 0 {
+		prev := w.nameservers[info.provider]
+		if len(prev) > 0 && strings.Join(nameservers, ",") != strings.Join(prev, ",") {
+			w.Warningf("domain '%s' nameservers changed: %v => %v", w.Source, prev, nameservers)
+			w.nameserversChangedAt = now
+		}
+		w.nameservers[info.provider] = nameservers
+	}
+
+	if registrar := strings.TrimSpace(info.registrar); registrar != "" {
+		prev := w.registrar[info.provider]
+		if prev != "" && !strings.EqualFold(registrar, prev) {
+			w.Warningf("domain '%s' registrar changed: '%s' => '%s'", w.Source, prev, registrar)
+			w.registrarChangedAt = now
+		}
+		w.registrar[info.provider] = registrar
+	}
+
+	mx["nameservers_changed"] = w.changedWithinWindow(w.nameserversChangedAt, now)
+	mx["registrar_changed"] = w.changedWithinWindow(w.registrarChangedAt, now)
+}
+
+// changedWithinWindow keeps a change raised for 'change_window', so it is not missed between the checks.
+// The change is always reported on the check that detected it.
+func (w *WhoisQuery) changedWithinWindow(changedAt, now time.Time) int64 {
+	if changedAt.IsZero() {
+		return 0
+	}
+	if changedAt.Equal(now) || now.Sub(changedAt) < w.ChangeWindow.Duration {
+		return 1
+	}
+	return 0
+}
+
+// normalizeStatus handles both WHOIS ("clientTransferProhibited https://icann.org/epp#clientTransferProhibited")
+// and RDAP ("client transfer prohibited") status values.
+func normalizeStatus(status string) string {
+	status = strings.ToLower(status)
+	if i := strings.Index(status, "http"); i >= 0 {
+		status = status[:i]
+	}
+	if i := strings.Index(status, "("); i >= 0 {
+		status = status[:i]
+	}
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r
+		}
+		return -1
+	}, status)
+}
+
+func normalizeNameservers(nameservers []string) []string {
+	var ns []string
+	for _, v := range nameservers {
+		if v = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(v), ".")); v != "" {
+			ns = append(ns, v)
+		}
+	}
+	sort.Strings(ns)
+	return ns
+}
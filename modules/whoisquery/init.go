@@ -4,6 +4,7 @@ package whoisquery
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/netdata/go.d.plugin/agent/module"
 )
@@ -12,11 +13,16 @@ func (w *WhoisQuery) validateConfig() error {
 	if w.Source == "" {
 		return errors.New("source is not set")
 	}
+	switch w.Provider {
+	case "", providerAuto, providerRDAP, providerWhois:
+	default:
+		return fmt.Errorf("unknown provider '%s' (must be '%s', '%s' or '%s')", w.Provider, providerAuto, providerRDAP, providerWhois)
+	}
 	return nil
 }
 
 func (w *WhoisQuery) initProvider() (provider, error) {
-	return newProvider(w.Config)
+	return newProvider(w.Config, w.Logger)
 }
 
 func (w *WhoisQuery) initCharts() *module.Charts {
@@ -3,16 +3,33 @@
 package whoisquery
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
+	"github.com/netdata/go.d.plugin/logger"
+
 	"github.com/araddon/dateparse"
 	"github.com/likexian/whois"
 	whoisparser "github.com/likexian/whois-parser"
 )
 
+const (
+	providerAuto  = "auto"
+	providerRDAP  = "rdap"
+	providerWhois = "whois"
+)
+
 type provider interface {
-	remainingTime() (float64, error)
+	domainInfo() (*domainInfo, error)
+}
+
+type domainInfo struct {
+	provider      string // the provider that returned the data, "rdap" or "whois"
+	remainingTime float64
+	statuses      []string
+	nameservers   []string
+	registrar     string
 }
 
 type fromNet struct {
@@ -20,7 +37,26 @@ type fromNet struct {
 	client        *whois.Client
 }
 
-func newProvider(config Config) (provider, error) {
+func newProvider(config Config, log *logger.Logger) (provider, error) {
+	switch config.Provider {
+	case providerWhois:
+		return newWhoisProvider(config), nil
+	case providerRDAP:
+		return newRDAPProvider(config)
+	default:
+		rdap, err := newRDAPProvider(config)
+		if err != nil {
+			return nil, err
+		}
+		return &fromFallback{
+			primary:  rdap,
+			fallback: newWhoisProvider(config),
+			Logger:   log,
+		}, nil
+	}
+}
+
+func newWhoisProvider(config Config) *fromNet {
 	domain := config.Source
 	client := whois.NewClient()
 	client.SetTimeout(config.Timeout.Duration)
@@ -28,31 +64,71 @@ func newProvider(config Config) (provider, error) {
 	return &fromNet{
 		domainAddress: domain,
 		client:        client,
-	}, nil
+	}
 }
 
-func (f *fromNet) remainingTime() (float64, error) {
+func (f *fromNet) domainInfo() (*domainInfo, error) {
 	raw, err := f.client.Whois(f.domainAddress)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	result, err := whoisparser.Parse(raw)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
+	remainingTime, err := parseWhoisExpirationDate(result.Domain.ExpirationDate)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &domainInfo{
+		provider:      providerWhois,
+		remainingTime: remainingTime,
+		statuses:      result.Domain.Status,
+		nameservers:   result.Domain.NameServers,
+	}
+	if result.Registrar != nil {
+		info.registrar = result.Registrar.Name
+	}
+
+	return info, nil
+}
+
+func parseWhoisExpirationDate(date string) (float64, error) {
 	// https://community.netdata.cloud/t/whois-query-monitor-cannot-parse-expiration-time/3485
-	if strings.Contains(result.Domain.ExpirationDate, " ") {
-		if v, err := time.Parse("2006.01.02 15:04:05", result.Domain.ExpirationDate); err == nil {
+	if strings.Contains(date, " ") {
+		if v, err := time.Parse("2006.01.02 15:04:05", date); err == nil {
 			return time.Until(v).Seconds(), nil
 		}
 	}
 
-	expire, err := dateparse.ParseAny(result.Domain.ExpirationDate)
+	expire, err := dateparse.ParseAny(date)
 	if err != nil {
 		return 0, err
 	}
 
 	return time.Until(expire).Seconds(), nil
 }
+
+// fromFallback queries the primary provider (RDAP) and falls back to the secondary one (WHOIS) on error.
+type fromFallback struct {
+	primary  provider
+	fallback provider
+	*logger.Logger
+}
+
+func (f *fromFallback) domainInfo() (*domainInfo, error) {
+	info, err := f.primary.domainInfo()
+	if err == nil {
+		return info, nil
+	}
+	f.Debugf("RDAP query failed, falling back to WHOIS: %v", err)
+
+	info, ferr := f.fallback.domainInfo()
+	if ferr != nil {
+		return nil, fmt.Errorf("rdap: %v, whois: %v", err, ferr)
+	}
+	return info, nil
+}
@@ -0,0 +1,250 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package whoisquery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/netdata/go.d.plugin/pkg/web"
+)
+
+const (
+	defaultRDAPBootstrap = "https://data.iana.org/rdap/dns.json"
+
+	rdapBootstrapTTL = time.Hour * 24
+)
+
+// fromRDAP queries the domain registration data using RDAP (RFC 9082, RFC 9083).
+// The RDAP server is either set explicitly or taken from the IANA bootstrap file (RFC 9224).
+type fromRDAP struct {
+	domainAddress string
+	server        string
+	bootstrap     string
+	httpClient    *http.Client
+
+	mu          sync.Mutex
+	services    map[string]string // TLD => RDAP server base URL
+	refreshedAt time.Time
+}
+
+func newRDAPProvider(config Config) (*fromRDAP, error) {
+	client, err := web.NewHTTPClient(web.Client{Timeout: config.Timeout})
+	if err != nil {
+		return nil, err
+	}
+
+	bootstrap := config.RDAPBootstrap
+	if bootstrap == "" {
+		bootstrap = defaultRDAPBootstrap
+	}
+
+	return &fromRDAP{
+		domainAddress: strings.ToLower(strings.TrimSuffix(config.Source, ".")),
+		server:        config.RDAPServer,
+		bootstrap:     bootstrap,
+		httpClient:    client,
+	}, nil
+}
+
+type (
+	rdapDomain struct {
+		Status []string `json:"status"`
+		Events []struct {
+			Action string `json:"eventAction"`
+			Date   string `json:"eventDate"`
+		} `json:"events"`
+		Nameservers []struct {
+			LDHName string `json:"ldhName"`
+		} `json:"nameservers"`
+		Entities []rdapEntity `json:"entities"`
+	}
+	rdapEntity struct {
+		Roles      []string        `json:"roles"`
+		VCardArray json.RawMessage `json:"vcardArray"`
+		Entities   []rdapEntity    `json:"entities"`
+	}
+	rdapBootstrap struct {
+		Services [][][]string `json:"services"`
+	}
+)
+
+func (f *fromRDAP) domainInfo() (*domainInfo, error) {
+	server, err := f.serverURL()
+	if err != nil {
+		return nil, err
+	}
+
+	var domain rdapDomain
+	if err := f.doJSON(server+"domain/"+f.domainAddress, &domain); err != nil {
+		return nil, err
+	}
+
+	info := &domainInfo{
+		provider:  providerRDAP,
+		statuses:  domain.Status,
+		registrar: findRegistrarName(domain.Entities),
+	}
+	for _, ns := range domain.Nameservers {
+		info.nameservers = append(info.nameservers, ns.LDHName)
+	}
+
+	for _, e := range domain.Events {
+		if e.Action != "expiration" {
+			continue
+		}
+		expire, err := time.Parse(time.RFC3339, e.Date)
+		if err != nil {
+			return nil, fmt.Errorf("parse expiration date '%s': %v", e.Date, err)
+		}
+		info.remainingTime = time.Until(expire).Seconds()
+		return info, nil
+	}
+
+	return nil, errors.New("RDAP response has no expiration event")
+}
+
+func (f *fromRDAP) serverURL() (string, error) {
+	if f.server != "" {
+		return withTrailingSlash(f.server), nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.services == nil || time.Since(f.refreshedAt) > rdapBootstrapTTL {
+		services, err := f.loadBootstrap()
+		if err != nil {
+			if f.services == nil {
+				return "", fmt.Errorf("load RDAP bootstrap '%s': %v", f.bootstrap, err)
+			}
+			// keep using the previously loaded services
+		} else {
+			f.services = services
+		}
+		f.refreshedAt = time.Now()
+	}
+
+	// the longest match wins
+	labels := strings.Split(f.domainAddress, ".")
+	for i := 1; i < len(labels); i++ {
+		if server, ok := f.services[strings.Join(labels[i:], ".")]; ok {
+			return withTrailingSlash(server), nil
+		}
+	}
+
+	return "", fmt.Errorf("no RDAP server found for '%s'", f.domainAddress)
+}
+
+func (f *fromRDAP) loadBootstrap() (map[string]string, error) {
+	var bs rdapBootstrap
+
+	if strings.HasPrefix(f.bootstrap, "http://") || strings.HasPrefix(f.bootstrap, "https://") {
+		if err := f.doJSON(f.bootstrap, &bs); err != nil {
+			return nil, err
+		}
+	} else {
+		bytes, err := os.ReadFile(f.bootstrap)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(bytes, &bs); err != nil {
+			return nil, err
+		}
+	}
+
+	services := make(map[string]string)
+	for _, svc := range bs.Services {
+		if len(svc) < 2 || len(svc[1]) == 0 {
+			continue
+		}
+		server := svc[1][0]
+		// prefer https
+		for _, u := range svc[1] {
+			if strings.HasPrefix(u, "https://") {
+				server = u
+				break
+			}
+		}
+		for _, tld := range svc[0] {
+			services[strings.ToLower(tld)] = server
+		}
+	}
+
+	if len(services) == 0 {
+		return nil, errors.New("no services found")
+	}
+
+	return services, nil
+}
+
+func (f *fromRDAP) doJSON(url string, dst any) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/rdap+json, application/json")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("'%s' returned HTTP status code %d", url, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+		return fmt.Errorf("error on decoding response from '%s': %v", url, err)
+	}
+	return nil
+}
+
+// findRegistrarName returns the 'fn' property of the registrar entity jCard (RFC 7095).
+func findRegistrarName(entities []rdapEntity) string {
+	for _, e := range entities {
+		for _, role := range e.Roles {
+			if role != "registrar" {
+				continue
+			}
+			var vcard []any
+			if err := json.Unmarshal(e.VCardArray, &vcard); err != nil || len(vcard) < 2 {
+				continue
+			}
+			props, _ := vcard[1].([]any)
+			for _, p := range props {
+				if prop, ok := p.([]any); ok && len(prop) >= 4 && prop[0] == "fn" {
+					if name, ok := prop[3].(string); ok {
+						return name
+					}
+				}
+			}
+		}
+		if name := findRegistrarName(e.Entities); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+func withTrailingSlash(s string) string {
+	if strings.HasSuffix(s, "/") {
+		return s
+	}
+	return s + "/"
+}
+
+func closeBody(resp *http.Response) {
+	if resp != nil && resp.Body != nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}
+}
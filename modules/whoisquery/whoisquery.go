@@ -24,7 +24,12 @@ func New() *WhoisQuery {
 			Timeout:       web.Duration{Duration: time.Second * 5},
 			DaysUntilWarn: 90,
 			DaysUntilCrit: 30,
+			Provider:      providerAuto,
+			RDAPBootstrap: defaultRDAPBootstrap,
+			ChangeWindow:  web.Duration{Duration: time.Hour * 24},
 		},
+		nameservers: make(map[string][]string),
+		registrar:   make(map[string]string),
 	}
 }
 
@@ -33,6 +38,10 @@ type Config struct {
 	Timeout       web.Duration `yaml:"timeout"`
 	DaysUntilWarn int64        `yaml:"days_until_expiration_warning"`
 	DaysUntilCrit int64        `yaml:"days_until_expiration_critical"`
+	Provider      string       `yaml:"provider"`
+	RDAPServer    string       `yaml:"rdap_server"`
+	RDAPBootstrap string       `yaml:"rdap_bootstrap"`
+	ChangeWindow  web.Duration `yaml:"change_window"`
 }
 
 type WhoisQuery struct {
@@ -42,6 +51,12 @@ type WhoisQuery struct {
 	charts *module.Charts
 
 	prov provider
+
+	// the last seen data per provider, RDAP and WHOIS format it differently
+	nameservers          map[string][]string
+	registrar            map[string]string
+	nameserversChangedAt time.Time
+	registrarChangedAt   time.Time
 }
 
 func (w *WhoisQuery) Init() bool {
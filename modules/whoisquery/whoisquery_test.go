@@ -3,8 +3,14 @@
 package whoisquery
 
 import (
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -23,20 +29,36 @@ func TestWhoisQuery_Charts(t *testing.T) {
 }
 
 func TestWhoisQuery_Init(t *testing.T) {
-	const net = iota
+	const (
+		net = iota
+		rdap
+		fallback
+	)
 	tests := map[string]struct {
 		config       Config
 		providerType int
 		err          bool
 	}{
 		"ok from net": {
-			config:       Config{Source: "example.org"},
+			config:       Config{Source: "example.org", Provider: providerWhois},
 			providerType: net,
 		},
+		"ok from rdap": {
+			config:       Config{Source: "example.org", Provider: providerRDAP},
+			providerType: rdap,
+		},
+		"ok from rdap with whois fallback": {
+			config:       Config{Source: "example.org"},
+			providerType: fallback,
+		},
 		"empty source": {
 			config: Config{Source: ""},
 			err:    true,
 		},
+		"unknown provider": {
+			config: Config{Source: "example.org", Provider: "dns"},
+			err:    true,
+		},
 	}
 
 	for name, test := range tests {
@@ -50,8 +72,13 @@ func TestWhoisQuery_Init(t *testing.T) {
 				require.True(t, whoisquery.Init())
 
 				var typeOK bool
-				if test.providerType == net {
+				switch test.providerType {
+				case net:
 					_, typeOK = whoisquery.prov.(*fromNet)
+				case rdap:
+					_, typeOK = whoisquery.prov.(*fromRDAP)
+				case fallback:
+					_, typeOK = whoisquery.prov.(*fromFallback)
 				}
 
 				assert.True(t, typeOK)
@@ -83,9 +110,17 @@ func TestWhoisQuery_Collect(t *testing.T) {
 	collected := whoisquery.Collect()
 
 	expected := map[string]int64{
-		"expiry":                         12345,
-		"days_until_expiration_warning":  90,
-		"days_until_expiration_critical": 30,
+		"expiry":                            12345,
+		"days_until_expiration_warning":     90,
+		"days_until_expiration_critical":    30,
+		"status_client_hold":                0,
+		"status_client_transfer_prohibited": 1,
+		"status_pending_delete":             0,
+		"status_redemption_period":          0,
+		"status_server_hold":                0,
+		"status_server_transfer_prohibited": 1,
+		"nameservers_changed":               0,
+		"registrar_changed":                 0,
 	}
 
 	assert.NotZero(t, collected)
@@ -102,6 +137,242 @@ func TestWhoisQuery_Collect_ReturnsNilOnProviderError(t *testing.T) {
 	assert.Nil(t, whoisquery.Collect())
 }
 
+func TestWhoisQuery_Collect_DetectsChanges(t *testing.T) {
+	whoisquery := New()
+	whoisquery.Source = "example.com"
+	require.True(t, whoisquery.Init())
+	prov := &mockProvider{remTime: 12345}
+	whoisquery.prov = prov
+
+	mx := whoisquery.Collect()
+	require.NotNil(t, mx)
+	assert.Equal(t, int64(0), mx["nameservers_changed"])
+	assert.Equal(t, int64(0), mx["registrar_changed"])
+
+	// same nameservers in a different order and case
+	prov.nameservers = []string{"NS2.EXAMPLE.NET.", "ns1.example.net"}
+	mx = whoisquery.Collect()
+	assert.Equal(t, int64(0), mx["nameservers_changed"])
+
+	prov.nameservers = []string{"ns1.example.org", "ns2.example.org"}
+	prov.registrar = "Another Registrar"
+	mx = whoisquery.Collect()
+	assert.Equal(t, int64(1), mx["nameservers_changed"])
+	assert.Equal(t, int64(1), mx["registrar_changed"])
+
+	// the change stays reported within the change window
+	mx = whoisquery.Collect()
+	assert.Equal(t, int64(1), mx["nameservers_changed"])
+	assert.Equal(t, int64(1), mx["registrar_changed"])
+
+	whoisquery.nameserversChangedAt = time.Now().Add(-whoisquery.ChangeWindow.Duration)
+	whoisquery.registrarChangedAt = time.Now().Add(-whoisquery.ChangeWindow.Duration)
+	mx = whoisquery.Collect()
+	assert.Equal(t, int64(0), mx["nameservers_changed"])
+	assert.Equal(t, int64(0), mx["registrar_changed"])
+}
+
+func TestWhoisQuery_Collect_ComparesSameProviderOnly(t *testing.T) {
+	whoisquery := New()
+	whoisquery.Source = "example.com"
+	require.True(t, whoisquery.Init())
+	prov := &mockProvider{provider: providerRDAP, remTime: 12345}
+	whoisquery.prov = prov
+
+	_ = whoisquery.Collect()
+
+	// RDAP failed, WHOIS spells the registrar and nameservers differently
+	prov.provider = providerWhois
+	prov.registrar = "EXAMPLE REGISTRAR, INC."
+	prov.nameservers = []string{"ns1.example.net", "ns2.example.net", "ns3.example.net"}
+	mx := whoisquery.Collect()
+	assert.Equal(t, int64(0), mx["nameservers_changed"])
+	assert.Equal(t, int64(0), mx["registrar_changed"])
+
+	// RDAP is back with the same data
+	prov.provider = providerRDAP
+	prov.registrar = ""
+	prov.nameservers = nil
+	mx = whoisquery.Collect()
+	assert.Equal(t, int64(0), mx["nameservers_changed"])
+	assert.Equal(t, int64(0), mx["registrar_changed"])
+
+	// WHOIS again, the registrar changed since its previous check
+	prov.provider = providerWhois
+	prov.registrar = "Another Registrar"
+	prov.nameservers = []string{"ns1.example.net", "ns2.example.net", "ns3.example.net"}
+	mx = whoisquery.Collect()
+	assert.Equal(t, int64(0), mx["nameservers_changed"])
+	assert.Equal(t, int64(1), mx["registrar_changed"])
+}
+
+func TestWhoisQuery_Collect_ZeroChangeWindow(t *testing.T) {
+	whoisquery := New()
+	whoisquery.Source = "example.com"
+	whoisquery.ChangeWindow.Duration = 0
+	require.True(t, whoisquery.Init())
+	prov := &mockProvider{remTime: 12345}
+	whoisquery.prov = prov
+
+	_ = whoisquery.Collect()
+
+	prov.registrar = "Another Registrar"
+	mx := whoisquery.Collect()
+	assert.Equal(t, int64(1), mx["registrar_changed"])
+
+	mx = whoisquery.Collect()
+	assert.Equal(t, int64(0), mx["registrar_changed"])
+}
+
+func TestWhoisQuery_Collect_RDAP(t *testing.T) {
+	expiration := time.Now().Add(time.Hour * 24 * 100).UTC()
+	srv := newRDAPServer(t, expiration)
+	defer srv.Close()
+
+	tests := map[string]struct {
+		prepare func(w *WhoisQuery)
+		wantErr bool
+	}{
+		"bootstrap url": {
+			prepare: func(w *WhoisQuery) { w.RDAPBootstrap = srv.URL + "/bootstrap.json" },
+		},
+		"bootstrap file": {
+			prepare: func(w *WhoisQuery) {
+				resp, err := http.Get(srv.URL + "/bootstrap.json")
+				require.NoError(t, err)
+				defer func() { _ = resp.Body.Close() }()
+				var bs any
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&bs))
+				bytes, err := json.Marshal(bs)
+				require.NoError(t, err)
+				path := filepath.Join(t.TempDir(), "dns.json")
+				require.NoError(t, os.WriteFile(path, bytes, 0644))
+				w.RDAPBootstrap = path
+			},
+		},
+		"rdap server": {
+			prepare: func(w *WhoisQuery) { w.RDAPServer = srv.URL + "/rdap" },
+		},
+		"no server for TLD": {
+			prepare: func(w *WhoisQuery) {
+				w.Source = "example.org"
+				w.RDAPBootstrap = srv.URL + "/bootstrap.json"
+			},
+			wantErr: true,
+		},
+		"domain not found": {
+			prepare: func(w *WhoisQuery) {
+				w.Source = "missing.com"
+				w.RDAPServer = srv.URL + "/rdap"
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			whoisquery := New()
+			whoisquery.Source = "example.com"
+			whoisquery.Provider = providerRDAP
+			test.prepare(whoisquery)
+			require.True(t, whoisquery.Init())
+
+			mx := whoisquery.Collect()
+
+			if test.wantErr {
+				assert.Nil(t, mx)
+				return
+			}
+
+			require.NotNil(t, mx)
+			assert.InDelta(t, time.Until(expiration).Seconds(), mx["expiry"], 5)
+			assert.Equal(t, int64(1), mx["status_client_transfer_prohibited"])
+			assert.Equal(t, int64(1), mx["status_client_hold"])
+			assert.Equal(t, int64(0), mx["status_redemption_period"])
+			assert.Equal(t, []string{"a.iana-servers.net", "b.iana-servers.net"}, whoisquery.nameservers[providerRDAP])
+			assert.Equal(t, "Example Registrar, Inc.", whoisquery.registrar[providerRDAP])
+			ensureCollectedHasAllChartsDimsVarsIDs(t, whoisquery, mx)
+		})
+	}
+}
+
+func TestFromFallback_domainInfo(t *testing.T) {
+	whoisquery := New()
+	whoisquery.Source = "example.com"
+	require.True(t, whoisquery.Init())
+
+	prov := &fromFallback{
+		primary:  &mockProvider{err: true},
+		fallback: &mockProvider{remTime: 100},
+		Logger:   whoisquery.Logger,
+	}
+	info, err := prov.domainInfo()
+	require.NoError(t, err)
+	assert.Equal(t, float64(100), info.remainingTime)
+
+	prov.fallback = &mockProvider{err: true}
+	_, err = prov.domainInfo()
+	assert.Error(t, err)
+}
+
+func TestNormalizeStatus(t *testing.T) {
+	tests := map[string]string{
+		"clientTransferProhibited https://icann.org/epp#clientTransferProhibited":       "clienttransferprohibited",
+		"clientTransferProhibited (https://www.icann.org/epp#clientTransferProhibited)": "clienttransferprohibited",
+		"client transfer prohibited": "clienttransferprohibited",
+		"redemptionPeriod":           "redemptionperiod",
+		"redemption period":          "redemptionperiod",
+		"ok":                         "ok",
+	}
+
+	for status, want := range tests {
+		assert.Equal(t, want, normalizeStatus(status), status)
+	}
+}
+
+func newRDAPServer(t *testing.T, expiration time.Time) *httptest.Server {
+	domain := map[string]any{
+		"objectClassName": "domain",
+		"ldhName":         "EXAMPLE.COM",
+		"status":          []string{"client transfer prohibited", "client hold"},
+		"events": []map[string]string{
+			{"eventAction": "registration", "eventDate": "1995-08-14T04:00:00Z"},
+			{"eventAction": "expiration", "eventDate": expiration.Format(time.RFC3339)},
+		},
+		"nameservers": []map[string]string{
+			{"objectClassName": "nameserver", "ldhName": "B.IANA-SERVERS.NET"},
+			{"objectClassName": "nameserver", "ldhName": "A.IANA-SERVERS.NET"},
+		},
+		"entities": []any{
+			map[string]any{
+				"objectClassName": "entity",
+				"roles":           []string{"registrar"},
+				"vcardArray": []any{"vcard", []any{
+					[]any{"version", map[string]any{}, "text", "4.0"},
+					[]any{"fn", map[string]any{}, "text", "Example Registrar, Inc."},
+				}},
+			},
+		},
+	}
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bootstrap.json":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"version":  "1.0",
+				"services": [][][]string{{{"com", "net"}, {srv.URL + "/rdap/"}}},
+			})
+		case "/rdap/domain/example.com":
+			w.Header().Set("Content-Type", "application/rdap+json")
+			_ = json.NewEncoder(w).Encode(domain)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return srv
+}
+
 func ensureCollectedHasAllChartsDimsVarsIDs(t *testing.T, whoisquery *WhoisQuery, collected map[string]int64) {
 	for _, chart := range *whoisquery.Charts() {
 		for _, dim := range chart.Dims {
@@ -116,13 +387,32 @@ func ensureCollectedHasAllChartsDimsVarsIDs(t *testing.T, whoisquery *WhoisQuery
 }
 
 type mockProvider struct {
-	remTime float64
-	err     bool
+	provider    string
+	remTime     float64
+	err         bool
+	nameservers []string
+	registrar   string
 }
 
-func (m mockProvider) remainingTime() (float64, error) {
+func (m mockProvider) domainInfo() (*domainInfo, error) {
 	if m.err {
-		return 0, errors.New("mock remaining time error")
+		return nil, errors.New("mock domain info error")
+	}
+	info := &domainInfo{
+		provider:      m.provider,
+		remainingTime: m.remTime,
+		statuses: []string{
+			"clientTransferProhibited https://icann.org/epp#clientTransferProhibited",
+			"serverTransferProhibited https://icann.org/epp#serverTransferProhibited",
+		},
+		nameservers: []string{"ns1.example.net", "ns2.example.net"},
+		registrar:   "Example Registrar",
+	}
+	if m.nameservers != nil {
+		info.nameservers = m.nameservers
+	}
+	if m.registrar != "" {
+		info.registrar = m.registrar
 	}
-	return m.remTime, nil
+	return info, nil
 }
@@ -2,41 +2,139 @@
 
 package x509check
 
-import "github.com/netdata/go.d.plugin/agent/module"
+import (
+	"crypto/x509"
 
-var (
-	baseCharts = module.Charts{
-		timeUntilExpirationChart.Copy(),
-	}
-	withRevocationCharts = module.Charts{
-		timeUntilExpirationChart.Copy(),
-		revocationStatusChart.Copy(),
-	}
+	"github.com/netdata/go.d.plugin/agent/module"
+)
 
+const (
+	prioTimeUntilExpiration = module.Priority + iota
+	prioRevocationStatus
+	prioHostnameVerification
+	prioChainCertTimeUntilExpiration
+	prioChainCertWeakCrypto
+)
+
+var (
 	timeUntilExpirationChart = module.Chart{
-		ID:    "time_until_expiration",
-		Title: "Time Until Certificate Expiration",
-		Units: "seconds",
-		Fam:   "expiration time",
-		Ctx:   "x509check.time_until_expiration",
-		Opts:  module.Opts{StoreFirst: true},
+		ID:       "time_until_expiration",
+		Title:    "Time Until Certificate Expiration",
+		Units:    "seconds",
+		Fam:      "expiration time",
+		Ctx:      "x509check.time_until_expiration",
+		Priority: prioTimeUntilExpiration,
+		Opts:     module.Opts{StoreFirst: true},
 		Dims: module.Dims{
-			{ID: "expiry"},
+			{ID: "expiry", Name: "expiry"},
 		},
 		Vars: module.Vars{
-			{ID: "days_until_expiration_warning"},
-			{ID: "days_until_expiration_critical"},
+			{ID: "days_until_expiration_warning", Name: "days_until_expiration_warning"},
+			{ID: "days_until_expiration_critical", Name: "days_until_expiration_critical"},
 		},
 	}
 	revocationStatusChart = module.Chart{
-		ID:    "revocation_status",
-		Title: "Revocation Status",
-		Units: "boolean",
-		Fam:   "revocation",
-		Ctx:   "x509check.revocation_status",
-		Opts:  module.Opts{StoreFirst: true},
+		ID:       "revocation_status",
+		Title:    "Revocation Status",
+		Units:    "boolean",
+		Fam:      "revocation",
+		Ctx:      "x509check.revocation_status",
+		Priority: prioRevocationStatus,
+		Opts:     module.Opts{StoreFirst: true},
+		Dims: module.Dims{
+			{ID: "revoked", Name: "revoked"},
+		},
+	}
+	hostnameVerificationChart = module.Chart{
+		ID:       "hostname_verification",
+		Title:    "Certificate Hostname Mismatch",
+		Units:    "boolean",
+		Fam:      "hostname",
+		Ctx:      "x509check.hostname_verification",
+		Priority: prioHostnameVerification,
+		Opts:     module.Opts{StoreFirst: true},
+		Dims: module.Dims{
+			{ID: "hostname_mismatch", Name: "mismatch"},
+		},
+	}
+)
+
+var chainCertChartsTmpl = module.Charts{
+	chainCertTimeUntilExpirationChartTmpl.Copy(),
+	chainCertWeakCryptoChartTmpl.Copy(),
+}
+
+var (
+	chainCertTimeUntilExpirationChartTmpl = module.Chart{
+		ID:       "time_until_expiration",
+		Title:    "Time Until Chain Certificate Expiration",
+		Units:    "seconds",
+		Fam:      "chain",
+		Ctx:      "x509check.chain_certificate_time_until_expiration",
+		Priority: prioChainCertTimeUntilExpiration,
+		Opts:     module.Opts{StoreFirst: true},
+		Dims: module.Dims{
+			{ID: "expiry", Name: "expiry"},
+		},
+	}
+	chainCertWeakCryptoChartTmpl = module.Chart{
+		ID:       "weak_crypto",
+		Title:    "Chain Certificate Weak Key and Signature Algorithm",
+		Units:    "boolean",
+		Fam:      "chain",
+		Ctx:      "x509check.chain_certificate_weak_crypto",
+		Priority: prioChainCertWeakCrypto,
+		Opts:     module.Opts{StoreFirst: true},
 		Dims: module.Dims{
-			{ID: "revoked"},
+			{ID: "weak_key", Name: "weak_key"},
+			{ID: "weak_signature", Name: "weak_signature"},
 		},
 	}
 )
+
+func (x *X509Check) sourceChartsTmpl(hostname string) module.Charts {
+	charts := module.Charts{timeUntilExpirationChart.Copy()}
+	if x.CheckRevocation {
+		charts = append(charts, revocationStatusChart.Copy())
+	}
+	if hostname != "" {
+		charts = append(charts, hostnameVerificationChart.Copy())
+	}
+	return charts
+}
+
+func newSourceCharts(tmpl module.Charts, source, px string, leaf *x509.Certificate) *module.Charts {
+	charts := tmpl.Copy()
+	for _, chart := range *charts {
+		chart.ID = px + chart.ID
+		chart.Labels = certLabels(source, leaf)
+		for _, dim := range chart.Dims {
+			dim.ID = px + dim.ID
+		}
+		for _, v := range chart.Vars {
+			v.ID = px + v.ID
+		}
+	}
+	return charts
+}
+
+func newChainCertCharts(source, cpx string, cert *x509.Certificate, position string) *module.Charts {
+	charts := newSourceCharts(chainCertChartsTmpl, source, cpx, cert)
+	for _, chart := range *charts {
+		chart.Labels = append(chart.Labels, module.Label{Key: "chain_position", Value: position})
+	}
+	return charts
+}
+
+func certLabels(source string, cert *x509.Certificate) []module.Label {
+	issuer := cert.Issuer.CommonName
+	if issuer == "" {
+		issuer = cert.Issuer.String()
+	}
+	return []module.Label{
+		{Key: "source", Value: source},
+		{Key: "subject_cn", Value: cert.Subject.CommonName},
+		{Key: "issuer", Value: issuer},
+		{Key: "serial", Value: certSerial(cert)},
+	}
+}
@@ -3,50 +3,253 @@
 package x509check
 
 import (
+	"bytes"
+	"crypto/dsa" //nolint:staticcheck // used only to flag the weak keys
+	"crypto/ecdsa"
+	"crypto/rsa"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/cloudflare/cfssl/revoke"
 )
 
+// sourceCharts keeps track of the charts created for a certificate source.
+type sourceCharts struct {
+	parent string // the directory source the file belongs to
+	serial string
+	charts []string
+	certs  map[string][]string
+}
+
 func (x *X509Check) collect() (map[string]int64, error) {
-	certs, err := x.prov.certificates()
-	if err != nil {
-		return nil, err
+	sources := x.sources
+	if x.prov != nil {
+		sources = append([]*certSource{{name: x.Source, hostname: x.hostname, prov: x.prov}}, sources...)
+	}
+
+	mx := make(map[string]int64)
+
+	for _, src := range sources {
+		if err := x.collectSource(mx, src); err != nil {
+			if len(sources) == 1 {
+				return nil, err
+			}
+			x.Warning(err)
+		}
 	}
 
+	if len(mx) == 0 {
+		return nil, errors.New("no certificates were collected")
+	}
+	return mx, nil
+}
+
+func (x *X509Check) collectSource(mx map[string]int64, src *certSource) error {
+	if mp, ok := src.prov.(multiProvider); ok {
+		sets, err := mp.certificateSets()
+		if err != nil {
+			return err
+		}
+
+		seen := make(map[string]bool)
+		for path, certs := range sets {
+			name := "file://" + path
+			px := sourcePrefix(name)
+			seen[px] = true
+			x.collectCertificates(mx, name, px, "", certs)
+			x.seen[px].parent = src.name
+		}
+		for px, sc := range x.seen {
+			if sc.parent == src.name && !seen[px] {
+				x.removeSourceCharts(px)
+			}
+		}
+		return nil
+	}
+
+	certs, err := src.prov.certificates()
+	if err != nil {
+		return err
+	}
 	if len(certs) == 0 {
-		return nil, fmt.Errorf("no certificate was provided by '%s'", x.Config.Source)
+		return fmt.Errorf("no certificate was provided by '%s'", src.name)
 	}
 
-	mx := make(map[string]int64)
+	x.collectCertificates(mx, src.name, src.px, src.hostname, certs)
+
+	return nil
+}
 
-	x.collectExpiration(mx, certs)
+func (x *X509Check) collectCertificates(mx map[string]int64, name, px, hostname string, certs []*x509.Certificate) {
+	leaf := certs[0]
+	x.updateSourceCharts(name, px, hostname, certs)
+
+	x.collectExpiration(mx, px, certs)
 	if x.CheckRevocation {
-		x.collectRevocation(mx, certs)
+		x.collectRevocation(mx, px, certs)
+	}
+	if hostname != "" {
+		mx[px+"hostname_mismatch"] = boolToInt(leaf.VerifyHostname(hostname) != nil)
 	}
 
-	return mx, nil
+	for _, cert := range certs {
+		cpx := chainCertPrefix(px, cert)
+		mx[cpx+"expiry"] = int64(time.Until(cert.NotAfter).Seconds())
+		mx[cpx+"weak_key"] = boolToInt(isWeakKey(cert))
+		mx[cpx+"weak_signature"] = boolToInt(isWeakSignature(cert))
+	}
 }
 
-func (x *X509Check) collectExpiration(mx map[string]int64, certs []*x509.Certificate) {
+func (x *X509Check) collectExpiration(mx map[string]int64, px string, certs []*x509.Certificate) {
 	expiry := time.Until(certs[0].NotAfter).Seconds()
-	mx["expiry"] = int64(expiry)
-	mx["days_until_expiration_warning"] = x.DaysUntilWarn
-	mx["days_until_expiration_critical"] = x.DaysUntilCritical
+	mx[px+"expiry"] = int64(expiry)
+	mx[px+"days_until_expiration_warning"] = x.DaysUntilWarn
+	mx[px+"days_until_expiration_critical"] = x.DaysUntilCritical
 
 }
 
-func (x *X509Check) collectRevocation(mx map[string]int64, certs []*x509.Certificate) {
+func (x *X509Check) collectRevocation(mx map[string]int64, px string, certs []*x509.Certificate) {
 	rev, ok, err := revoke.VerifyCertificateError(certs[0])
 	if err != nil {
 		x.Debug(err)
 	}
 	switch {
 	case ok && rev:
-		mx["revoked"] = 1
+		mx[px+"revoked"] = 1
 	case ok && !rev:
-		mx["revoked"] = 0
+		mx[px+"revoked"] = 0
+	}
+}
+
+// updateSourceCharts adds the charts for the new source and chain certificates, removes the charts of the
+// certificates that are no longer in the chain and updates the source charts labels when the leaf is replaced.
+func (x *X509Check) updateSourceCharts(name, px, hostname string, certs []*x509.Certificate) {
+	leaf := certs[0]
+
+	sc, ok := x.seen[px]
+	if !ok {
+		sc = &sourceCharts{serial: certSerial(leaf), certs: make(map[string][]string)}
+		x.seen[px] = sc
+		charts := newSourceCharts(x.sourceChartsTmpl(hostname), name, px, leaf)
+		for _, chart := range *charts {
+			sc.charts = append(sc.charts, chart.ID)
+		}
+		if err := x.Charts().Add(*charts...); err != nil {
+			x.Warning(err)
+		}
+	} else if serial := certSerial(leaf); sc.serial != serial {
+		sc.serial = serial
+		for _, id := range sc.charts {
+			if chart := x.Charts().Get(id); chart != nil {
+				chart.Labels = certLabels(name, leaf)
+				chart.MarkNotCreated()
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i, cert := range certs {
+		cpx := chainCertPrefix(px, cert)
+		if seen[cpx] {
+			continue
+		}
+		seen[cpx] = true
+		if _, ok := sc.certs[cpx]; ok {
+			continue
+		}
+
+		charts := newChainCertCharts(name, cpx, cert, chainPosition(i, cert))
+		for _, chart := range *charts {
+			sc.certs[cpx] = append(sc.certs[cpx], chart.ID)
+		}
+		if err := x.Charts().Add(*charts...); err != nil {
+			x.Warning(err)
+		}
+	}
+
+	for cpx, ids := range sc.certs {
+		if !seen[cpx] {
+			delete(sc.certs, cpx)
+			x.removeCharts(ids)
+		}
+	}
+}
+
+func (x *X509Check) removeSourceCharts(px string) {
+	sc, ok := x.seen[px]
+	if !ok {
+		return
+	}
+	delete(x.seen, px)
+	x.removeCharts(sc.charts)
+	for _, ids := range sc.certs {
+		x.removeCharts(ids)
+	}
+}
+
+func (x *X509Check) removeCharts(ids []string) {
+	for _, id := range ids {
+		if chart := x.Charts().Get(id); chart != nil {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
+
+func chainCertPrefix(px string, cert *x509.Certificate) string {
+	return px + "chain_" + certSerial(cert) + "_"
+}
+
+func chainPosition(depth int, cert *x509.Certificate) string {
+	switch {
+	case depth == 0:
+		return "leaf"
+	case bytes.Equal(cert.RawIssuer, cert.RawSubject):
+		return "root"
+	default:
+		return "intermediate"
+	}
+}
+
+func certSerial(cert *x509.Certificate) string {
+	if cert.SerialNumber == nil {
+		return "0"
+	}
+	return cert.SerialNumber.Text(16)
+}
+
+func isWeakKey(cert *x509.Certificate) bool {
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return key.N.BitLen() < 2048
+	case *dsa.PublicKey:
+		return true
+	case *ecdsa.PublicKey:
+		return key.Curve.Params().BitSize < 256
+	default:
+		return false
+	}
+}
+
+// isWeakSignature reports MD2, MD5 and SHA-1 signatures, the self-signed roots signatures are not checked
+// because the roots are trusted as is.
+func isWeakSignature(cert *x509.Certificate) bool {
+	if len(cert.RawSubject) > 0 && bytes.Equal(cert.RawIssuer, cert.RawSubject) {
+		return false
+	}
+	switch cert.SignatureAlgorithm {
+	case x509.MD2WithRSA, x509.MD5WithRSA, x509.SHA1WithRSA, x509.DSAWithSHA1, x509.ECDSAWithSHA1:
+		return true
+	default:
+		return false
+	}
+}
+
+func boolToInt(v bool) int64 {
+	if v {
+		return 1
 	}
+	return 0
 }
@@ -4,14 +4,25 @@ package x509check
 
 import (
 	"errors"
-
-	"github.com/netdata/go.d.plugin/agent/module"
+	"fmt"
+	"net/url"
+	"strings"
 )
 
 func (x *X509Check) validateConfig() error {
-	if x.Source == "" {
+	if x.Source == "" && len(x.Sources) == 0 {
 		return errors.New("source is not set")
 	}
+	seen := map[string]bool{x.Source: x.Source != ""}
+	for _, src := range x.Sources {
+		if src == "" {
+			return errors.New("sources: empty source")
+		}
+		if seen[src] {
+			return fmt.Errorf("sources: duplicate source '%s'", src)
+		}
+		seen[src] = true
+	}
 	return nil
 }
 
@@ -19,20 +30,42 @@ func (x *X509Check) initProvider() (provider, error) {
 	return newProvider(x.Config)
 }
 
-func (x *X509Check) initCharts() *module.Charts {
-	var charts *module.Charts
-	if x.CheckRevocation {
-		charts = withRevocationCharts.Copy()
-	} else {
-		charts = baseCharts.Copy()
+func (x *X509Check) initSources() ([]*certSource, error) {
+	var sources []*certSource
+	for _, src := range x.Sources {
+		cfg := x.Config
+		cfg.Source = src
+		prov, err := newProvider(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("source '%s': %v", src, err)
+		}
+		sources = append(sources, &certSource{
+			name:     src,
+			px:       sourcePrefix(src),
+			hostname: sourceHostname(src),
+			prov:     prov,
+		})
 	}
+	return sources, nil
+}
 
-	for _, chart := range *charts {
-		chart.Labels = []module.Label{
-			{Key: "source", Value: x.Source},
-		}
+func sourceHostname(source string) string {
+	u, err := url.Parse(source)
+	if err != nil || u.Scheme == "file" {
+		return ""
 	}
+	return u.Hostname()
+}
 
-	return charts
+func sourcePrefix(source string) string {
+	return "source_" + cleanID(source) + "_"
+}
 
+func cleanID(s string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, s)
 }
@@ -11,6 +11,8 @@ import (
 	"net/smtp"
 	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/netdata/go.d.plugin/pkg/tlscfg"
@@ -20,10 +22,20 @@ type provider interface {
 	certificates() ([]*x509.Certificate, error)
 }
 
+// multiProvider is implemented by the providers that return several independent certificate sets.
+type multiProvider interface {
+	provider
+	certificateSets() (map[string][]*x509.Certificate, error)
+}
+
 type fromFile struct {
 	path string
 }
 
+type fromDir struct {
+	path string
+}
+
 type fromNet struct {
 	url       *url.URL
 	tlsConfig *tls.Config
@@ -36,6 +48,20 @@ type fromSMTP struct {
 	timeout   time.Duration
 }
 
+type fromStartTLS struct {
+	url       *url.URL
+	tlsConfig *tls.Config
+	timeout   time.Duration
+	proto     string
+}
+
+var certFileExtensions = map[string]bool{
+	".pem":  true,
+	".crt":  true,
+	".cer":  true,
+	".cert": true,
+}
+
 func newProvider(config Config) (provider, error) {
 	sourceURL, err := url.Parse(config.Source)
 	if err != nil {
@@ -54,6 +80,9 @@ func newProvider(config Config) (provider, error) {
 
 	switch sourceURL.Scheme {
 	case "file":
+		if fi, err := os.Stat(sourceURL.Path); err == nil && fi.IsDir() {
+			return &fromDir{path: sourceURL.Path}, nil
+		}
 		return &fromFile{path: sourceURL.Path}, nil
 	case "https", "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6":
 		if sourceURL.Scheme == "https" {
@@ -63,28 +92,87 @@ func newProvider(config Config) (provider, error) {
 	case "smtp":
 		sourceURL.Scheme = "tcp"
 		return &fromSMTP{url: sourceURL, tlsConfig: tlsCfg, timeout: config.Timeout.Duration}, nil
+	case "imap", "pop3", "ldap", "postgres", "postgresql", "mysql":
+		proto := sourceURL.Scheme
+		if proto == "postgresql" {
+			proto = "postgres"
+		}
+		sourceURL.Scheme = "tcp"
+		if sourceURL.Port() == "" {
+			sourceURL.Host = net.JoinHostPort(sourceURL.Hostname(), startTLSDefaultPorts[proto])
+		}
+		return &fromStartTLS{url: sourceURL, tlsConfig: tlsCfg, timeout: config.Timeout.Duration, proto: proto}, nil
 	default:
 		return nil, fmt.Errorf("unsupported scheme '%s'", sourceURL)
 	}
 }
 
 func (f fromFile) certificates() ([]*x509.Certificate, error) {
-	content, err := os.ReadFile(f.path)
+	return readCertificatesFile(f.path)
+}
+
+func (f fromDir) certificates() ([]*x509.Certificate, error) {
+	sets, err := f.certificateSets()
+	if err != nil {
+		return nil, err
+	}
+	var certs []*x509.Certificate
+	for _, v := range sets {
+		certs = append(certs, v...)
+	}
+	return certs, nil
+}
+
+// certificateSets returns the certificates of every file in the directory (not recursive) keyed by the file path.
+func (f fromDir) certificateSets() (map[string][]*x509.Certificate, error) {
+	entries, err := os.ReadDir(f.path)
 	if err != nil {
-		return nil, fmt.Errorf("error on reading '%s': %v", f.path, err)
+		return nil, fmt.Errorf("error on reading directory '%s': %v", f.path, err)
 	}
 
-	block, _ := pem.Decode(content)
-	if block == nil {
-		return nil, fmt.Errorf("error on decoding '%s': %v", f.path, err)
+	sets := make(map[string][]*x509.Certificate)
+	for _, e := range entries {
+		if e.IsDir() || !certFileExtensions[strings.ToLower(filepath.Ext(e.Name()))] {
+			continue
+		}
+		path := filepath.Join(f.path, e.Name())
+		certs, err := readCertificatesFile(path)
+		if err != nil {
+			continue
+		}
+		sets[path] = certs
 	}
+	return sets, nil
+}
 
-	cert, err := x509.ParseCertificate(block.Bytes)
+// readCertificatesFile returns all the PEM encoded certificates from the file, the first one is expected to be the leaf.
+func readCertificatesFile(path string) ([]*x509.Certificate, error) {
+	content, err := os.ReadFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("error on parsing certificate '%s': %v", f.path, err)
+		return nil, fmt.Errorf("error on reading '%s': %v", path, err)
+	}
+
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, content = pem.Decode(content)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error on parsing certificate '%s': %v", path, err)
+		}
+		certs = append(certs, cert)
 	}
 
-	return []*x509.Certificate{cert}, nil
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("error on decoding '%s': no PEM encoded certificates found", path)
+	}
+	return certs, nil
 }
 
 func (f fromNet) certificates() ([]*x509.Certificate, error) {
@@ -94,13 +182,18 @@ func (f fromNet) certificates() ([]*x509.Certificate, error) {
 	}
 	defer func() { _ = ipConn.Close() }()
 
-	conn := tls.Client(ipConn, f.tlsConfig.Clone())
+	_ = ipConn.SetDeadline(time.Now().Add(f.timeout))
+
+	conn := tls.Client(ipConn, handshakeTLSConfig(f.tlsConfig))
 	defer func() { _ = conn.Close() }()
 	if err := conn.Handshake(); err != nil {
 		return nil, fmt.Errorf("error on SSL handshake with '%s': %v", f.url, err)
 	}
 
 	certs := conn.ConnectionState().PeerCertificates
+	if err := verifyChain(f.tlsConfig, certs); err != nil {
+		return nil, fmt.Errorf("'%s': %v", f.url, err)
+	}
 	return certs, nil
 }
 
@@ -118,7 +211,7 @@ func (f fromSMTP) certificates() ([]*x509.Certificate, error) {
 	}
 	defer func() { _ = smtpClient.Quit() }()
 
-	err = smtpClient.StartTLS(f.tlsConfig.Clone())
+	err = smtpClient.StartTLS(handshakeTLSConfig(f.tlsConfig))
 	if err != nil {
 		return nil, fmt.Errorf("error on startTLS with '%s': %v", f.url, err)
 	}
@@ -127,5 +220,61 @@ func (f fromSMTP) certificates() ([]*x509.Certificate, error) {
 	if !ok {
 		return nil, fmt.Errorf("startTLS didn't succeed")
 	}
+	if err := verifyChain(f.tlsConfig, conn.PeerCertificates); err != nil {
+		return nil, fmt.Errorf("'%s': %v", f.url, err)
+	}
 	return conn.PeerCertificates, nil
 }
+
+func (f fromStartTLS) certificates() ([]*x509.Certificate, error) {
+	ipConn, err := net.DialTimeout(f.url.Scheme, f.url.Host, f.timeout)
+	if err != nil {
+		return nil, fmt.Errorf("error on dial to '%s': %v", f.url, err)
+	}
+	defer func() { _ = ipConn.Close() }()
+
+	_ = ipConn.SetDeadline(time.Now().Add(f.timeout))
+
+	if err := startTLS(ipConn, f.proto); err != nil {
+		return nil, fmt.Errorf("error on %s startTLS with '%s': %v", f.proto, f.url, err)
+	}
+
+	conn := tls.Client(ipConn, handshakeTLSConfig(f.tlsConfig))
+	defer func() { _ = conn.Close() }()
+	if err := conn.Handshake(); err != nil {
+		return nil, fmt.Errorf("error on SSL handshake with '%s': %v", f.url, err)
+	}
+
+	certs := conn.ConnectionState().PeerCertificates
+	if err := verifyChain(f.tlsConfig, certs); err != nil {
+		return nil, fmt.Errorf("'%s': %v", f.url, err)
+	}
+	return certs, nil
+}
+
+// handshakeTLSConfig disables the certificates verification during the handshake,
+// the chain is verified by verifyChain and the hostname mismatch is reported as a metric instead.
+func handshakeTLSConfig(cfg *tls.Config) *tls.Config {
+	cfg = cfg.Clone()
+	cfg.InsecureSkipVerify = true
+	return cfg
+}
+
+func verifyChain(cfg *tls.Config, certs []*x509.Certificate) error {
+	if cfg.InsecureSkipVerify || len(certs) == 0 {
+		return nil
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         cfg.RootCAs,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+
+	if _, err := certs[0].Verify(opts); err != nil {
+		return fmt.Errorf("error on verifying certificate chain: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package x509check
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+var startTLSDefaultPorts = map[string]string{
+	"imap":     "143",
+	"pop3":     "110",
+	"ldap":     "389",
+	"postgres": "5432",
+	"mysql":    "3306",
+}
+
+// startTLS upgrades the plain text connection to the protocol specific point where the TLS handshake can start.
+// Only unbuffered reads are made, so none of the TLS handshake bytes can be consumed by accident.
+func startTLS(conn net.Conn, proto string) error {
+	switch proto {
+	case "imap":
+		return startTLSIMAP(conn)
+	case "pop3":
+		return startTLSPOP3(conn)
+	case "ldap":
+		return startTLSLDAP(conn)
+	case "postgres":
+		return startTLSPostgres(conn)
+	case "mysql":
+		return startTLSMySQL(conn)
+	default:
+		return fmt.Errorf("unsupported protocol '%s'", proto)
+	}
+}
+
+func startTLSIMAP(conn net.Conn) error {
+	line, err := readLine(conn)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "* OK") {
+		return fmt.Errorf("unexpected greeting '%s'", line)
+	}
+
+	if _, err := io.WriteString(conn, "a001 STARTTLS\r\n"); err != nil {
+		return err
+	}
+	for {
+		if line, err = readLine(conn); err != nil {
+			return err
+		}
+		if strings.HasPrefix(line, "a001 ") {
+			break
+		}
+	}
+	if !strings.HasPrefix(line, "a001 OK") {
+		return fmt.Errorf("unexpected STARTTLS response '%s'", line)
+	}
+	return nil
+}
+
+func startTLSPOP3(conn net.Conn) error {
+	line, err := readLine(conn)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "+OK") {
+		return fmt.Errorf("unexpected greeting '%s'", line)
+	}
+
+	if _, err := io.WriteString(conn, "STLS\r\n"); err != nil {
+		return err
+	}
+	if line, err = readLine(conn); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "+OK") {
+		return fmt.Errorf("unexpected STLS response '%s'", line)
+	}
+	return nil
+}
+
+const ldapStartTLSOID = "1.3.6.1.4.1.1466.20037"
+
+// startTLSLDAP sends the StartTLS extended request (RFC 4511) and checks the extended response result code.
+func startTLSLDAP(conn net.Conn) error {
+	name := append([]byte{0x80, byte(len(ldapStartTLSOID))}, ldapStartTLSOID...)
+	op := append([]byte{0x77, byte(len(name))}, name...)
+	msgID := []byte{0x02, 0x01, 0x01}
+	req := append([]byte{0x30, byte(len(msgID) + len(op))}, msgID...)
+	req = append(req, op...)
+
+	if _, err := conn.Write(req); err != nil {
+		return err
+	}
+
+	tag, msg, err := readBER(conn)
+	if err != nil {
+		return err
+	}
+	if tag != 0x30 {
+		return fmt.Errorf("unexpected LDAP message tag 0x%x", tag)
+	}
+
+	// messageID
+	if _, _, msg, err = parseBERElement(msg); err != nil {
+		return err
+	}
+	tag, resp, _, err := parseBERElement(msg)
+	if err != nil {
+		return err
+	}
+	if tag != 0x78 {
+		return fmt.Errorf("unexpected LDAP protocol operation tag 0x%x", tag)
+	}
+	tag, code, _, err := parseBERElement(resp)
+	if err != nil {
+		return err
+	}
+	if tag != 0x0a || len(code) != 1 {
+		return errors.New("invalid LDAP extended response")
+	}
+	if code[0] != 0 {
+		return fmt.Errorf("LDAP extended response result code %d", code[0])
+	}
+	return nil
+}
+
+// startTLSPostgres sends the SSLRequest message, the server responds with a single 'S' byte if it supports SSL.
+func startTLSPostgres(conn net.Conn) error {
+	req := make([]byte, 8)
+	binary.BigEndian.PutUint32(req[0:4], 8)
+	binary.BigEndian.PutUint32(req[4:8], 80877103)
+
+	if _, err := conn.Write(req); err != nil {
+		return err
+	}
+
+	resp := make([]byte, 1)
+	if _, err := io.ReadFull(conn, resp); err != nil {
+		return err
+	}
+	if resp[0] != 'S' {
+		return errors.New("server does not support SSL")
+	}
+	return nil
+}
+
+const (
+	mysqlClientProtocol41       = 0x00000200
+	mysqlClientSSL              = 0x00000800
+	mysqlClientSecureConnection = 0x00008000
+)
+
+// startTLSMySQL reads the initial handshake packet and responds with the SSLRequest packet.
+func startTLSMySQL(conn net.Conn) error {
+	hdr := make([]byte, 4)
+	if _, err := io.ReadFull(conn, hdr); err != nil {
+		return err
+	}
+	payload := make([]byte, int(hdr[0])|int(hdr[1])<<8|int(hdr[2])<<16)
+	if _, err := io.ReadFull(conn, payload); err != nil {
+		return err
+	}
+
+	if len(payload) > 0 && payload[0] == 0xff {
+		return errors.New("server responded with an error packet")
+	}
+	if len(payload) == 0 || payload[0] != 10 {
+		return errors.New("unsupported handshake protocol version")
+	}
+	// protocol version, server version (null terminated), connection id, auth plugin data part 1, filler
+	idx := strings.IndexByte(string(payload[1:]), 0)
+	if idx < 0 {
+		return errors.New("invalid handshake packet")
+	}
+	pos := 1 + idx + 1 + 4 + 8 + 1
+	if len(payload) < pos+2 {
+		return errors.New("invalid handshake packet")
+	}
+	if binary.LittleEndian.Uint16(payload[pos:pos+2])&mysqlClientSSL == 0 {
+		return errors.New("server does not support SSL")
+	}
+
+	req := make([]byte, 4+32)
+	req[0] = 32
+	req[3] = hdr[3] + 1
+	binary.LittleEndian.PutUint32(req[4:8], mysqlClientSSL|mysqlClientProtocol41|mysqlClientSecureConnection)
+	binary.LittleEndian.PutUint32(req[8:12], 1<<24)
+	req[12] = 33 // utf8_general_ci
+
+	_, err := conn.Write(req)
+	return err
+}
+
+// readLine reads byte by byte until the line feed.
+func readLine(r io.Reader) (string, error) {
+	var line []byte
+	b := make([]byte, 1)
+	for {
+		if _, err := io.ReadFull(r, b); err != nil {
+			return "", err
+		}
+		if b[0] == '\n' {
+			return strings.TrimRight(string(line), "\r"), nil
+		}
+		if line = append(line, b[0]); len(line) > 8192 {
+			return "", errors.New("line is too long")
+		}
+	}
+}
+
+const maxBERLength = 1 << 16
+
+// readBER reads a single BER element from the connection and returns its tag and contents.
+func readBER(r io.Reader) (byte, []byte, error) {
+	hdr := make([]byte, 2)
+	if _, err := io.ReadFull(r, hdr); err != nil {
+		return 0, nil, err
+	}
+
+	length := int(hdr[1])
+	if length&0x80 != 0 {
+		n := length & 0x7f
+		if n == 0 || n > 4 {
+			return 0, nil, errors.New("unsupported BER length")
+		}
+		bs := make([]byte, n)
+		if _, err := io.ReadFull(r, bs); err != nil {
+			return 0, nil, err
+		}
+		length = 0
+		for _, b := range bs {
+			length = length<<8 | int(b)
+		}
+	}
+	if length > maxBERLength {
+		return 0, nil, errors.New("BER element is too long")
+	}
+
+	contents := make([]byte, length)
+	if _, err := io.ReadFull(r, contents); err != nil {
+		return 0, nil, err
+	}
+	return hdr[0], contents, nil
+}
+
+func parseBERElement(data []byte) (tag byte, contents, rest []byte, err error) {
+	if len(data) < 2 {
+		return 0, nil, nil, errors.New("truncated BER element")
+	}
+	tag, length, pos := data[0], int(data[1]), 2
+	if length&0x80 != 0 {
+		n := length & 0x7f
+		if n == 0 || n > 4 || len(data) < 2+n {
+			return 0, nil, nil, errors.New("unsupported BER length")
+		}
+		length = 0
+		for _, b := range data[2 : 2+n] {
+			length = length<<8 | int(b)
+		}
+		pos += n
+	}
+	if len(data) < pos+length {
+		return 0, nil, nil, errors.New("truncated BER element")
+	}
+	return tag, data[pos : pos+length], data[pos+length:], nil
+}
@@ -29,11 +29,14 @@ func New() *X509Check {
 			DaysUntilWarn:     14,
 			DaysUntilCritical: 7,
 		},
+		charts: &module.Charts{},
+		seen:   make(map[string]*sourceCharts),
 	}
 }
 
 type Config struct {
 	Source            string
+	Sources           []string `yaml:"sources"`
 	Timeout           web.Duration
 	tlscfg.TLSConfig  `yaml:",inline"`
 	DaysUntilWarn     int64 `yaml:"days_until_expiration_warning"`
@@ -45,7 +48,18 @@ type X509Check struct {
 	module.Base
 	Config `yaml:",inline"`
 	charts *module.Charts
-	prov   provider
+
+	prov     provider
+	hostname string
+	sources  []*certSource
+	seen     map[string]*sourceCharts
+}
+
+type certSource struct {
+	name     string
+	px       string
+	hostname string // the network sources server name, used for the hostname verification
+	prov     provider
 }
 
 func (x *X509Check) Init() bool {
@@ -54,14 +68,22 @@ func (x *X509Check) Init() bool {
 		return false
 	}
 
-	prov, err := x.initProvider()
+	if x.Source != "" {
+		prov, err := x.initProvider()
+		if err != nil {
+			x.Errorf("certificate provider init: %v", err)
+			return false
+		}
+		x.prov = prov
+		x.hostname = sourceHostname(x.Source)
+	}
+
+	sources, err := x.initSources()
 	if err != nil {
 		x.Errorf("certificate provider init: %v", err)
 		return false
 	}
-	x.prov = prov
-
-	x.charts = x.initCharts()
+	x.sources = sources
 
 	return true
 }
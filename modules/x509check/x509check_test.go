@@ -3,10 +3,27 @@
 package x509check
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/netdata/go.d.plugin/agent/module"
 	"github.com/netdata/go.d.plugin/pkg/tlscfg"
 
 	"github.com/stretchr/testify/assert"
@@ -29,6 +46,8 @@ func TestX509Check_Init(t *testing.T) {
 		file = iota
 		net
 		smtp
+		startTLS
+		dir
 	)
 	tests := map[string]struct {
 		config       Config
@@ -51,6 +70,26 @@ func TestX509Check_Init(t *testing.T) {
 			config:       Config{Source: "smtp://smtp.my_mail.org:587"},
 			providerType: smtp,
 		},
+		"ok from imap": {
+			config:       Config{Source: "imap://mail.my_mail.org"},
+			providerType: startTLS,
+		},
+		"ok from postgres": {
+			config:       Config{Source: "postgres://db.my_org.org:5432"},
+			providerType: startTLS,
+		},
+		"ok from dir": {
+			config:       Config{Source: "file:///"},
+			providerType: dir,
+		},
+		"ok from sources": {
+			config:       Config{Sources: []string{"https://example.org", "file:///"}},
+			providerType: -1,
+		},
+		"duplicate sources": {
+			config: Config{Source: "https://example.org", Sources: []string{"https://example.org"}},
+			err:    true,
+		},
 		"empty source": {
 			config: Config{Source: ""},
 			err:    true},
@@ -82,6 +121,12 @@ func TestX509Check_Init(t *testing.T) {
 					_, typeOK = x509Check.prov.(*fromNet)
 				case smtp:
 					_, typeOK = x509Check.prov.(*fromSMTP)
+				case startTLS:
+					_, typeOK = x509Check.prov.(*fromStartTLS)
+				case dir:
+					_, typeOK = x509Check.prov.(*fromDir)
+				default:
+					typeOK = x509Check.prov == nil && len(x509Check.sources) == len(test.config.Sources)
 				}
 
 				assert.True(t, typeOK)
@@ -131,6 +176,207 @@ func TestX509Check_Collect_ReturnsNilOnZeroCertificates(t *testing.T) {
 	assert.Nil(t, mx)
 }
 
+func TestX509Check_Collect_StartTLS(t *testing.T) {
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0644))
+
+	tests := map[string]struct {
+		negotiate    func(conn net.Conn) error
+		host         string
+		wantMismatch int64
+	}{
+		"imap": {
+			negotiate: func(conn net.Conn) error {
+				return testDialog(conn, "* OK IMAP4rev1 ready\r\n", "a001 STARTTLS", "a001 OK Begin TLS negotiation now\r\n")
+			},
+		},
+		"pop3": {
+			negotiate: func(conn net.Conn) error {
+				return testDialog(conn, "+OK POP3 ready\r\n", "STLS", "+OK Begin TLS negotiation\r\n")
+			},
+			host:         "localhost",
+			wantMismatch: 1,
+		},
+		"ldap": {
+			negotiate: func(conn net.Conn) error {
+				if _, _, err := readBER(conn); err != nil {
+					return err
+				}
+				_, err := conn.Write([]byte{0x30, 0x0c, 0x02, 0x01, 0x01, 0x78, 0x07, 0x0a, 0x01, 0x00, 0x04, 0x00, 0x04, 0x00})
+				return err
+			},
+		},
+		"postgres": {
+			negotiate: func(conn net.Conn) error {
+				if _, err := io.ReadFull(conn, make([]byte, 8)); err != nil {
+					return err
+				}
+				_, err := conn.Write([]byte{'S'})
+				return err
+			},
+		},
+		"mysql": {
+			negotiate: func(conn net.Conn) error {
+				payload := append([]byte{10}, "8.0.34\x00"...)
+				payload = append(payload, make([]byte, 4+8+1)...)
+				payload = append(payload, 0x00, 0x8a) // CLIENT_SSL | CLIENT_PROTOCOL_41 | CLIENT_SECURE_CONNECTION
+				payload = append(payload, make([]byte, 16)...)
+				pkt := append([]byte{byte(len(payload)), 0, 0, 0}, payload...)
+				if _, err := conn.Write(pkt); err != nil {
+					return err
+				}
+				_, err := io.ReadFull(conn, make([]byte, 4+32))
+				return err
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			defer func() { _ = ln.Close() }()
+
+			go func() {
+				conn, err := ln.Accept()
+				if err != nil {
+					return
+				}
+				defer func() { _ = conn.Close() }()
+				if test.negotiate(conn) != nil {
+					return
+				}
+				tlsConn := tls.Server(conn, &tls.Config{Certificates: srv.TLS.Certificates})
+				_ = tlsConn.Handshake()
+			}()
+
+			host := test.host
+			if host == "" {
+				host = "127.0.0.1"
+			}
+			_, port, _ := net.SplitHostPort(ln.Addr().String())
+
+			x509Check := New()
+			x509Check.Source = name + "://" + net.JoinHostPort(host, port)
+			x509Check.TLSCA = caFile
+			require.True(t, x509Check.Init())
+
+			mx := x509Check.Collect()
+			require.NotNil(t, mx)
+
+			leaf := srv.Certificate()
+			assert.Greater(t, mx["expiry"], int64(0))
+			assert.Equal(t, test.wantMismatch, mx["hostname_mismatch"])
+			assert.Equal(t, int64(0), mx["chain_"+certSerial(leaf)+"_weak_key"])
+			ensureCollectedHasAllChartsDimsVarsIDs(t, x509Check, mx)
+
+			chart := x509Check.Charts().Get("time_until_expiration")
+			require.NotNil(t, chart)
+			assert.Contains(t, chart.Labels, module.Label{Key: "serial", Value: certSerial(leaf)})
+			assert.Contains(t, chart.Labels, module.Label{Key: "issuer", Value: leaf.Issuer.String()})
+		})
+	}
+}
+
+func TestX509Check_Collect_Directory(t *testing.T) {
+	rootKey, root := newTestCert(t, "Test Root CA", nil, nil)
+	_, leaf1 := newTestCert(t, "one.example.com", root, rootKey)
+	_, leaf2 := newTestCert(t, "two.example.com", root, rootKey)
+
+	dir := t.TempDir()
+	writeCerts := func(name string, certs ...*x509.Certificate) {
+		var bs []byte
+		for _, cert := range certs {
+			bs = append(bs, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+		}
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), bs, 0644))
+	}
+	writeCerts("one.pem", leaf1, root)
+	writeCerts("two.crt", leaf2)
+	writeCerts("notes.txt", leaf2)
+
+	x509Check := New()
+	x509Check.Sources = []string{"file://" + dir}
+	require.True(t, x509Check.Init())
+
+	mx := x509Check.Collect()
+	require.NotNil(t, mx)
+	ensureCollectedHasAllChartsDimsVarsIDs(t, x509Check, mx)
+
+	px1 := sourcePrefix("file://" + filepath.Join(dir, "one.pem"))
+	px2 := sourcePrefix("file://" + filepath.Join(dir, "two.crt"))
+
+	assert.Contains(t, mx, px1+"expiry")
+	assert.Contains(t, mx, px1+"chain_"+certSerial(leaf1)+"_expiry")
+	assert.Contains(t, mx, px1+"chain_"+certSerial(root)+"_expiry")
+	assert.Contains(t, mx, px2+"expiry")
+	assert.NotContains(t, mx, px1+"hostname_mismatch")
+	assert.Len(t, *x509Check.Charts(), 1+len(chainCertChartsTmpl)*2+1+len(chainCertChartsTmpl))
+
+	rootChart := x509Check.Charts().Get(px1 + "chain_" + certSerial(root) + "_time_until_expiration")
+	require.NotNil(t, rootChart)
+	assert.Contains(t, rootChart.Labels, module.Label{Key: "chain_position", Value: "root"})
+	assert.Contains(t, rootChart.Labels, module.Label{Key: "subject_cn", Value: "Test Root CA"})
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "two.crt")))
+
+	mx = x509Check.Collect()
+	require.NotNil(t, mx)
+	assert.NotContains(t, mx, px2+"expiry")
+	assert.True(t, x509Check.Charts().Get(px2+"time_until_expiration").Obsolete)
+	assert.False(t, x509Check.Charts().Get(px1+"time_until_expiration").Obsolete)
+}
+
+func TestX509Check_Collect_WeakCrypto(t *testing.T) {
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	x509Check := New()
+	x509Check.Source = "https://example.com"
+	require.True(t, x509Check.Init())
+	x509Check.prov = &mockProvider{certs: []*x509.Certificate{
+		{
+			SerialNumber:       big.NewInt(1),
+			PublicKey:          &weakKey.PublicKey,
+			SignatureAlgorithm: x509.SHA1WithRSA,
+			RawSubject:         []byte("leaf"),
+			RawIssuer:          []byte("intermediate"),
+			DNSNames:           []string{"example.com"},
+		},
+		{
+			SerialNumber:       big.NewInt(2),
+			PublicKey:          &rsa.PublicKey{N: new(big.Int).Lsh(big.NewInt(1), 4095), E: 65537},
+			SignatureAlgorithm: x509.SHA256WithRSA,
+			RawSubject:         []byte("intermediate"),
+			RawIssuer:          []byte("root"),
+		},
+		{
+			SerialNumber:       big.NewInt(3),
+			SignatureAlgorithm: x509.SHA1WithRSA,
+			RawSubject:         []byte("root"),
+			RawIssuer:          []byte("root"),
+		},
+	}}
+
+	mx := x509Check.Collect()
+	require.NotNil(t, mx)
+	ensureCollectedHasAllChartsDimsVarsIDs(t, x509Check, mx)
+
+	assert.Equal(t, int64(0), mx["hostname_mismatch"])
+	assert.Equal(t, int64(1), mx["chain_1_weak_key"])
+	assert.Equal(t, int64(1), mx["chain_1_weak_signature"])
+	assert.Equal(t, int64(0), mx["chain_2_weak_key"])
+	assert.Equal(t, int64(0), mx["chain_2_weak_signature"])
+	assert.Equal(t, int64(0), mx["chain_3_weak_signature"])
+
+	chart := x509Check.Charts().Get("chain_2_weak_crypto")
+	require.NotNil(t, chart)
+	assert.Contains(t, chart.Labels, module.Label{Key: "chain_position", Value: "intermediate"})
+}
+
 func ensureCollectedHasAllChartsDimsVarsIDs(t *testing.T, x509Check *X509Check, collected map[string]int64) {
 	for _, chart := range *x509Check.Charts() {
 		for _, dim := range chart.Dims {
@@ -144,6 +390,49 @@ func ensureCollectedHasAllChartsDimsVarsIDs(t *testing.T, x509Check *X509Check,
 	}
 }
 
+func testDialog(conn net.Conn, greeting, command, response string) error {
+	if _, err := io.WriteString(conn, greeting); err != nil {
+		return err
+	}
+	line, err := readLine(conn)
+	if err != nil {
+		return err
+	}
+	if line != command {
+		return fmt.Errorf("unexpected command '%s'", line)
+	}
+	_, err = io.WriteString(conn, response)
+	return err
+}
+
+func newTestCert(t *testing.T, cn string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*ecdsa.PrivateKey, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour * 24 * 30),
+		IsCA:                  parent == nil,
+		BasicConstraintsValid: true,
+		DNSNames:              []string{cn},
+	}
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return key, cert
+}
+
 type mockProvider struct {
 	certs []*x509.Certificate
 	err   bool
@@ -0,0 +1,326 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package logs
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/netdata/go.d.plugin/logger"
+
+	"github.com/valyala/fastjson"
+)
+
+const (
+	SourceFile    = "file"
+	SourceJournal = "journal"
+)
+
+const journalQueueSize = 1024
+
+// JournalConfig describes which systemd journal entries the JournalReader reads.
+type JournalConfig struct {
+	Units          []string `yaml:"units"`
+	Identifiers    []string `yaml:"identifiers"`
+	Matches        []string `yaml:"matches"`
+	Directory      string   `yaml:"directory"`
+	CursorFile     string   `yaml:"cursor_file"`
+	JournalctlPath string   `yaml:"journalctl_path"`
+}
+
+type journalEntry struct {
+	line   []byte
+	cursor string
+}
+
+// JournalReader reads the MESSAGE field of systemd journal entries, one entry per line.
+// It follows the journal using 'journalctl -o json --follow' and, like Reader, returns io.EOF when there is no new data.
+// The cursor of the last read entry is persisted in JournalConfig.CursorFile (if set) to resume after a restart.
+type JournalReader struct {
+	config JournalConfig
+	log    *logger.Logger
+
+	cmd     *exec.Cmd
+	entries chan journalEntry
+	wg      sync.WaitGroup
+
+	pending     []byte
+	pendingCur  string
+	cursor      string
+	savedCursor string
+}
+
+// OpenJournal starts following the journal.
+// It starts from the persisted cursor if there is one, otherwise from the journal tail.
+func OpenJournal(config JournalConfig, log *logger.Logger) (*JournalReader, error) {
+	if config.JournalctlPath == "" {
+		config.JournalctlPath = "journalctl"
+	}
+	path, err := exec.LookPath(config.JournalctlPath)
+	if err != nil {
+		return nil, fmt.Errorf("journalctl lookup: %v", err)
+	}
+	config.JournalctlPath = path
+
+	for _, m := range config.Matches {
+		if !strings.Contains(m, "=") {
+			return nil, fmt.Errorf("bad journal match '%s', expected 'FIELD=value'", m)
+		}
+	}
+
+	r := &JournalReader{
+		config: config,
+		log:    log,
+	}
+
+	if r.cursor, err = r.loadCursor(); err != nil {
+		return nil, err
+	}
+	r.savedCursor = r.cursor
+
+	if err := r.start(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Read reads already received journal entries messages, it doesn't wait for new entries.
+func (r *JournalReader) Read(p []byte) (n int, err error) {
+	for n < len(p) {
+		if len(r.pending) == 0 {
+			if r.pendingCur != "" {
+				r.cursor = r.pendingCur
+				r.pendingCur = ""
+			}
+			if !r.next() {
+				break
+			}
+		}
+		c := copy(p[n:], r.pending)
+		r.pending = r.pending[c:]
+		n += c
+	}
+
+	if len(r.pending) == 0 && r.pendingCur != "" {
+		r.cursor = r.pendingCur
+		r.pendingCur = ""
+	}
+
+	if n > 0 {
+		return n, nil
+	}
+
+	r.saveCursor()
+
+	if r.entries == nil {
+		// journalctl exited, start it again from the last read entry
+		if err := r.restart(); err != nil {
+			return 0, err
+		}
+	}
+	return 0, io.EOF
+}
+
+func (r *JournalReader) next() bool {
+	if r.entries == nil {
+		return false
+	}
+	select {
+	case e, ok := <-r.entries:
+		if !ok {
+			r.entries = nil
+			r.wait()
+			return false
+		}
+		r.pending = e.line
+		r.pendingCur = e.cursor
+		return true
+	default:
+		return false
+	}
+}
+
+// LastLine returns the message of the last journal entry that matches the filters.
+func (r *JournalReader) LastLine() ([]byte, error) {
+	args := append([]string{"--output=json", "--no-pager", "--lines=1"}, r.filterArgs()...)
+	bs, err := exec.Command(r.config.JournalctlPath, args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("error on '%s %s': %v", r.config.JournalctlPath, strings.Join(args, " "), err)
+	}
+
+	var last []byte
+	sc := bufio.NewScanner(bytes.NewReader(bs))
+	sc.Buffer(make([]byte, 0, DefaultMaxLineWidth), 1024*1024)
+	for sc.Scan() {
+		if e, ok := parseJournalEntry(sc.Bytes()); ok {
+			last = e.line
+		}
+	}
+	return bytes.TrimRight(last, "\n"), nil
+}
+
+func (r *JournalReader) Info() string {
+	return fmt.Sprintf("journal %s", strings.Join(r.filterArgs(), " "))
+}
+
+func (r *JournalReader) Close() error {
+	if r == nil {
+		return nil
+	}
+	r.saveCursor()
+	r.stop()
+	return nil
+}
+
+func (r *JournalReader) start() error {
+	args := []string{"--output=json", "--no-pager", "--follow"}
+	if r.cursor != "" {
+		args = append(args, "--after-cursor="+r.cursor)
+	} else {
+		args = append(args, "--lines=0")
+	}
+	args = append(args, r.filterArgs()...)
+
+	r.log.Debugf("starting '%s %s'", r.config.JournalctlPath, strings.Join(args, " "))
+
+	cmd := exec.Command(r.config.JournalctlPath, args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("error on starting journalctl: %v", err)
+	}
+
+	entries := make(chan journalEntry, journalQueueSize)
+	r.cmd = cmd
+	r.entries = entries
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer close(entries)
+
+		sc := bufio.NewScanner(stdout)
+		sc.Buffer(make([]byte, 0, DefaultMaxLineWidth), 1024*1024)
+		for sc.Scan() {
+			if e, ok := parseJournalEntry(sc.Bytes()); ok {
+				entries <- e
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (r *JournalReader) stop() {
+	if r.cmd == nil {
+		return
+	}
+	if r.cmd.Process != nil {
+		_ = r.cmd.Process.Kill()
+	}
+	// unblock the reading goroutine if the queue is full
+	if r.entries != nil {
+		go func(ch chan journalEntry) {
+			for range ch {
+			}
+		}(r.entries)
+	}
+	r.wait()
+	r.entries = nil
+}
+
+func (r *JournalReader) wait() {
+	r.wg.Wait()
+	if r.cmd != nil {
+		_ = r.cmd.Wait()
+		r.cmd = nil
+	}
+}
+
+func (r *JournalReader) restart() error {
+	r.log.Debug("journalctl exited, restarting")
+	r.stop()
+	return r.start()
+}
+
+func (r *JournalReader) filterArgs() []string {
+	var args []string
+	if r.config.Directory != "" {
+		args = append(args, "--directory="+r.config.Directory)
+	}
+	for _, v := range r.config.Units {
+		args = append(args, "--unit="+v)
+	}
+	for _, v := range r.config.Identifiers {
+		args = append(args, "--identifier="+v)
+	}
+	return append(args, r.config.Matches...)
+}
+
+func (r *JournalReader) loadCursor() (string, error) {
+	if r.config.CursorFile == "" {
+		return "", nil
+	}
+	bs, err := os.ReadFile(r.config.CursorFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("read journal cursor file: %v", err)
+	}
+	return strings.TrimSpace(string(bs)), nil
+}
+
+func (r *JournalReader) saveCursor() {
+	if r.config.CursorFile == "" || r.cursor == "" || r.cursor == r.savedCursor {
+		return
+	}
+
+	dir := filepath.Dir(r.config.CursorFile)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		r.log.Warningf("create journal cursor file directory: %v", err)
+		return
+	}
+
+	tmp := r.config.CursorFile + ".tmp"
+	if err := os.WriteFile(tmp, []byte(r.cursor), 0644); err != nil {
+		r.log.Warningf("write journal cursor file: %v", err)
+		return
+	}
+	if err := os.Rename(tmp, r.config.CursorFile); err != nil {
+		r.log.Warningf("write journal cursor file: %v", err)
+		return
+	}
+	r.savedCursor = r.cursor
+}
+
+func parseJournalEntry(data []byte) (journalEntry, bool) {
+	var p fastjson.Parser
+	v, err := p.ParseBytes(data)
+	if err != nil {
+		return journalEntry{}, false
+	}
+
+	cursor := string(v.GetStringBytes("__CURSOR"))
+
+	// MESSAGE is an array of bytes if it contains non-printable characters or non-UTF8 sequences
+	msg := v.Get("MESSAGE")
+	if msg == nil || msg.Type() != fastjson.TypeString {
+		return journalEntry{cursor: cursor}, cursor != ""
+	}
+
+	b := msg.GetStringBytes()
+	line := make([]byte, 0, len(b)+1)
+	line = append(append(line, b...), '\n')
+	return journalEntry{line: line, cursor: cursor}, true
+}
@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package logs
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/netdata/go.d.plugin/pkg/logs/logstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fakeJournalEntries = `{"__CURSOR":"c1","_SYSTEMD_UNIT":"nginx.service","MESSAGE":"first"}
+{"__CURSOR":"c2","_SYSTEMD_UNIT":"nginx.service","MESSAGE":"second"}
+{"__CURSOR":"c3","_SYSTEMD_UNIT":"nginx.service","MESSAGE":[98,105,110]}
+{"__CURSOR":"c4","_SYSTEMD_UNIT":"nginx.service","MESSAGE":"fourth"}
+`
+
+func TestOpenJournal(t *testing.T) {
+	tests := map[string]struct {
+		config  func(dir string) JournalConfig
+		wantErr bool
+	}{
+		"journalctl exists": {
+			config: func(dir string) JournalConfig {
+				return JournalConfig{JournalctlPath: filepath.Join(dir, "journalctl")}
+			},
+		},
+		"journalctl not exists": {
+			wantErr: true,
+			config: func(dir string) JournalConfig {
+				return JournalConfig{JournalctlPath: filepath.Join(dir, "not_exists")}
+			},
+		},
+		"bad match": {
+			wantErr: true,
+			config: func(dir string) JournalConfig {
+				return JournalConfig{
+					JournalctlPath: filepath.Join(dir, "journalctl"),
+					Matches:        []string{"_SYSTEMD_UNIT"},
+				}
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := prepareFakeJournalctl(t)
+
+			r, err := OpenJournal(test.config(dir), nil)
+
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.NoError(t, r.Close())
+			}
+		})
+	}
+}
+
+func TestJournalReader_Read_FromTail(t *testing.T) {
+	dir := prepareFakeJournalctl(t)
+
+	r, err := OpenJournal(JournalConfig{JournalctlPath: filepath.Join(dir, "journalctl")}, nil)
+	require.NoError(t, err)
+	defer func() { _ = r.Close() }()
+
+	n, err := r.Read(make([]byte, 100))
+	assert.Equal(t, 0, n)
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestJournalReader_Read_ResumesFromCursor(t *testing.T) {
+	dir := prepareFakeJournalctl(t)
+	cursorFile := filepath.Join(dir, "state", "cursor")
+	require.NoError(t, os.MkdirAll(filepath.Dir(cursorFile), 0755))
+	require.NoError(t, os.WriteFile(cursorFile, []byte("c1\n"), 0644))
+
+	cfg := JournalConfig{
+		JournalctlPath: filepath.Join(dir, "journalctl"),
+		CursorFile:     cursorFile,
+		Units:          []string{"nginx.service"},
+	}
+	r, err := OpenJournal(cfg, nil)
+	require.NoError(t, err)
+
+	var data []byte
+	buf := make([]byte, 3)
+	assert.Eventually(t, func() bool {
+		for {
+			n, err := r.Read(buf)
+			data = append(data, buf[:n]...)
+			if err != nil {
+				return string(data) == "second\nfourth\n"
+			}
+		}
+	}, time.Second*5, time.Millisecond*50)
+
+	require.NoError(t, r.Close())
+
+	bs, err := os.ReadFile(cursorFile)
+	require.NoError(t, err)
+	assert.Equal(t, "c4", string(bs))
+}
+
+func TestJournalReader_LastLine(t *testing.T) {
+	dir := prepareFakeJournalctl(t)
+
+	r, err := OpenJournal(JournalConfig{JournalctlPath: filepath.Join(dir, "journalctl")}, nil)
+	require.NoError(t, err)
+	defer func() { _ = r.Close() }()
+
+	line, err := r.LastLine()
+	require.NoError(t, err)
+	assert.Equal(t, "fourth", string(line))
+}
+
+func Test_parseJournalEntry(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		wantOK   bool
+		expected journalEntry
+	}{
+		"string message": {
+			input:    `{"__CURSOR":"c1","MESSAGE":"hello"}`,
+			wantOK:   true,
+			expected: journalEntry{line: []byte("hello\n"), cursor: "c1"},
+		},
+		"binary message": {
+			input:    `{"__CURSOR":"c1","MESSAGE":[104,105]}`,
+			wantOK:   true,
+			expected: journalEntry{cursor: "c1"},
+		},
+		"no cursor no message": {
+			input: `{"PRIORITY":"6"}`,
+		},
+		"not json": {
+			input: `hello`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			e, ok := parseJournalEntry([]byte(test.input))
+
+			assert.Equal(t, test.wantOK, ok)
+			if test.wantOK {
+				assert.Equal(t, test.expected, e)
+			}
+		})
+	}
+}
+
+func prepareFakeJournalctl(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	logstest.PrepareFakeJournalctl(t, dir, fakeJournalEntries)
+	return dir
+}
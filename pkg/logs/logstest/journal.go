@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package logstest provides helpers to test the log sources.
+package logstest
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeJournalctl emulates 'journalctl -o json' over the entries file:
+// '--lines=N' prints the last N entries, '--follow' prints entries after '--after-cursor' (or nothing if '--lines=0') and waits.
+const fakeJournalctl = `#!/bin/sh
+after=""
+follow=0
+lines=""
+for a in "$@"; do
+  case "$a" in
+    --after-cursor=*) after="${a#--after-cursor=}" ;;
+    --follow) follow=1 ;;
+    --lines=*) lines="${a#--lines=}" ;;
+  esac
+done
+entries="$(dirname "$0")/entries.json"
+if [ "$follow" = "0" ]; then
+  tail -n "$lines" "$entries"
+  exit 0
+fi
+if [ -n "$after" ]; then
+  awk -v c="\"__CURSOR\":\"$after\"" 'found { print } index($0, c) { found = 1 }' "$entries"
+fi
+exec sleep 60
+`
+
+// JournalStartCursor is the cursor of the entry that precedes the messages in JournalEntries.
+const JournalStartCursor = "c0"
+
+// PrepareFakeJournalctl writes a fake journalctl and its journal entries (JSON, one per line) to dir
+// and returns the journalctl path.
+func PrepareFakeJournalctl(t *testing.T, dir, entries string) string {
+	t.Helper()
+	path := filepath.Join(dir, "journalctl")
+	require.NoError(t, os.WriteFile(path, []byte(fakeJournalctl), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "entries.json"), []byte(entries), 0644))
+	return path
+}
+
+// PrepareJournalCursorFile writes the cursor file that makes the journal reader start after the cursor
+// and returns its path.
+func PrepareJournalCursorFile(t *testing.T, dir, cursor string) string {
+	t.Helper()
+	path := filepath.Join(dir, "cursor")
+	require.NoError(t, os.WriteFile(path, []byte(cursor+"\n"), 0644))
+	return path
+}
+
+// JournalEntries returns the journal entries with the messages, cursors are "c1", "c2" and so on.
+// The entries are preceded by an entry without a message, its cursor is JournalStartCursor.
+func JournalEntries(t *testing.T, messages ...string) string {
+	t.Helper()
+	entries := []string{`{"__CURSOR":"` + JournalStartCursor + `"}`}
+	for i, msg := range messages {
+		bs, err := json.Marshal(map[string]string{"__CURSOR": "c" + strconv.Itoa(i+1), "MESSAGE": msg})
+		require.NoError(t, err)
+		entries = append(entries, string(bs))
+	}
+	return strings.Join(entries, "\n") + "\n"
+}
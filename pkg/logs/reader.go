@@ -21,6 +21,20 @@ var (
 	ErrNoMatchedFile = errors.New("no matched files")
 )
 
+// LogReader is a source of log lines consumed by parsers.
+type LogReader interface {
+	io.ReadCloser
+	// LastLine returns the most recent log line, it is used to detect and verify the log format.
+	LastLine() ([]byte, error)
+	// Info returns a short description of the source.
+	Info() string
+}
+
+var (
+	_ LogReader = (*Reader)(nil)
+	_ LogReader = (*JournalReader)(nil)
+)
+
 // Reader is a log rotate aware Reader
 // TODO: better reopen algorithm
 // TODO: handle truncate
@@ -64,6 +78,15 @@ func (r *Reader) CurrentFilename() string {
 	return r.file.Name()
 }
 
+// LastLine returns the last line of the current opened file.
+func (r *Reader) LastLine() ([]byte, error) {
+	return ReadLastLine(r.CurrentFilename(), 0)
+}
+
+func (r *Reader) Info() string {
+	return fmt.Sprintf("file '%s'", r.CurrentFilename())
+}
+
 func (r *Reader) open() error {
 	path := r.findFile()
 	if path == "" {
@@ -47,3 +47,14 @@ func ExampleNew_regexp_format() {
 	m.MatchString("1a") // => true
 	m.MatchString("a")  // => false
 }
+
+func ExampleParseLabelExpr() {
+	// create a label matcher, which matches key/value sets
+	m, err := matcher.ParseLabelExpr(`namespace=prod && name=~"^api-" && !label[team]=infra && age<1h`)
+	if err != nil {
+		panic(err)
+	}
+	m.MatchLabels(map[string]string{"namespace": "prod", "name": "api-1", "age": "10m"})                        // => true
+	m.MatchLabels(map[string]string{"namespace": "prod", "name": "api-1", "age": "2h"})                         // => false
+	m.MatchLabels(map[string]string{"namespace": "prod", "name": "api-1", "age": "10m", "label.team": "infra"}) // => false
+}
@@ -0,0 +1,390 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package matcher
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type (
+	// LabelMatcher is an interface that wraps MatchLabels method.
+	LabelMatcher interface {
+		// MatchLabels performs match against given key/value set
+		MatchLabels(labels map[string]string) bool
+	}
+
+	labelTrueMatcher  struct{}
+	labelAndMatcher   struct{ lhs, rhs LabelMatcher }
+	labelOrMatcher    struct{ lhs, rhs LabelMatcher }
+	labelNegMatcher   struct{ LabelMatcher }
+	labelExistMatcher struct{ key string }
+	labelValueMatcher struct {
+		key string
+		Matcher
+	}
+	labelNumberMatcher struct {
+		key   string
+		op    string
+		value float64
+	}
+)
+
+// ParseLabelExpr parses the expression and returns a matcher of key/value sets (labels).
+// An empty expression matches any set.
+//
+// Syntax
+//
+//	<expr>      ::= <and> { '||' <and> }
+//	<and>       ::= <unary> { '&&' <unary> }
+//	<unary>     ::= '!' <unary> | '(' <expr> ')' | <cond>
+//	<cond>      ::= <key> [ <op> <value> ]
+//	                  key without operator and value means the key exists
+//	<key>       ::= <ident> [ '[' ( <ident> | <quoted> ) ']' ]
+//	                  'label[team]' is the same as 'label.team'
+//	<op>        ::= '=' | '==' | '!='
+//	                  string (not) equal
+//	              | '=~' | '!~'
+//	                  regexp (not) match
+//	              | ':'
+//	                  value is a matcher line in any supported format (see Parse)
+//	              | '<' | '<=' | '>' | '>='
+//	                  numeric comparison, value and label value may have a size (B, KiB, MB, ...)
+//	                  or duration (ms, s, m, h, d, w) suffix
+//	<value>     ::= <quoted> | any string without spaces and '(', ')', '&', '|'
+//	<quoted>    ::= '"' Go escaped string '"' | "'" raw string "'"
+//
+// A missing key has an empty value for the string operators, numeric comparisons are false for missing
+// keys and values that are not numbers.
+func ParseLabelExpr(expr string) (LabelMatcher, error) {
+	p := &labelExprParser{input: expr}
+	p.skipSpaces()
+	if p.eof() {
+		return labelTrueMatcher{}, nil
+	}
+	m, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("parse label expression '%s': %v", expr, err)
+	}
+	if p.skipSpaces(); !p.eof() {
+		return nil, fmt.Errorf("parse label expression '%s': %v", expr, p.errorf("unexpected '%s'", p.rest()))
+	}
+	return m, nil
+}
+
+// MustParseLabelExpr is like ParseLabelExpr but panics if the expression cannot be parsed.
+func MustParseLabelExpr(expr string) LabelMatcher {
+	m, err := ParseLabelExpr(expr)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func (labelTrueMatcher) MatchLabels(map[string]string) bool { return true }
+
+func (m labelAndMatcher) MatchLabels(labels map[string]string) bool {
+	return m.lhs.MatchLabels(labels) && m.rhs.MatchLabels(labels)
+}
+
+func (m labelOrMatcher) MatchLabels(labels map[string]string) bool {
+	return m.lhs.MatchLabels(labels) || m.rhs.MatchLabels(labels)
+}
+
+func (m labelNegMatcher) MatchLabels(labels map[string]string) bool {
+	return !m.LabelMatcher.MatchLabels(labels)
+}
+
+func (m labelExistMatcher) MatchLabels(labels map[string]string) bool {
+	_, ok := labels[m.key]
+	return ok
+}
+
+func (m labelValueMatcher) MatchLabels(labels map[string]string) bool {
+	return m.MatchString(labels[m.key])
+}
+
+func (m labelNumberMatcher) MatchLabels(labels map[string]string) bool {
+	s, ok := labels[m.key]
+	if !ok {
+		return false
+	}
+	v, ok := parseNumber(s)
+	if !ok {
+		return false
+	}
+	switch m.op {
+	case "<":
+		return v < m.value
+	case "<=":
+		return v <= m.value
+	case ">":
+		return v > m.value
+	case ">=":
+		return v >= m.value
+	}
+	return false
+}
+
+var numberUnits = map[string]float64{
+	// sizes
+	"B": 1,
+	"K": 1e3, "KB": 1e3, "Ki": 1 << 10, "KiB": 1 << 10,
+	"M": 1e6, "MB": 1e6, "Mi": 1 << 20, "MiB": 1 << 20,
+	"G": 1e9, "GB": 1e9, "Gi": 1 << 30, "GiB": 1 << 30,
+	"T": 1e12, "TB": 1e12, "Ti": 1 << 40, "TiB": 1 << 40,
+	// durations, in seconds
+	"ns": 1e-9, "us": 1e-6, "ms": 1e-3, "s": 1, "m": 60, "h": 3600, "d": 86400, "w": 604800,
+}
+
+// parseNumber parses a number with an optional size or duration suffix: "10", "1.5", "10KiB", "2GB", "500ms", "7d".
+// Sizes are returned in bytes, durations in seconds.
+func parseNumber(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	i := len(s)
+	for i > 0 && unicode.IsLetter(rune(s[i-1])) {
+		i--
+	}
+	num, unit := strings.TrimSpace(s[:i]), s[i:]
+	if num == "" {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil || math.IsNaN(v) {
+		return 0, false
+	}
+	if unit == "" {
+		return v, true
+	}
+	mul, ok := numberUnits[unit]
+	if !ok {
+		return 0, false
+	}
+	return v * mul, true
+}
+
+type labelExprParser struct {
+	input string
+	pos   int
+}
+
+func (p *labelExprParser) parseOr() (LabelMatcher, error) {
+	lhs, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.consume("||") {
+		rhs, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		lhs = labelOrMatcher{lhs, rhs}
+	}
+	return lhs, nil
+}
+
+func (p *labelExprParser) parseAnd() (LabelMatcher, error) {
+	lhs, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.consume("&&") {
+		rhs, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		lhs = labelAndMatcher{lhs, rhs}
+	}
+	return lhs, nil
+}
+
+func (p *labelExprParser) parseUnary() (LabelMatcher, error) {
+	p.skipSpaces()
+	switch {
+	case p.eof():
+		return nil, p.errorf("unexpected end of expression")
+	case p.consume("!"):
+		m, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return labelNegMatcher{m}, nil
+	case p.consume("("):
+		m, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			return nil, p.errorf("missing ')'")
+		}
+		return m, nil
+	default:
+		return p.parseCond()
+	}
+}
+
+func (p *labelExprParser) parseCond() (LabelMatcher, error) {
+	key, err := p.parseKey()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaces()
+	op := p.parseOp()
+	if op == "" {
+		return labelExistMatcher{key: key}, nil
+	}
+
+	p.skipSpaces()
+	pos := p.pos
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+
+	var m Matcher
+	switch op {
+	case "=", "==", "!=":
+		m, err = NewStringMatcher(value, true, true)
+	case "=~", "!~":
+		m, err = NewRegExpMatcher(value)
+	case ":":
+		m, err = Parse(value)
+	case "<", "<=", ">", ">=":
+		v, ok := parseNumber(value)
+		if !ok {
+			p.pos = pos
+			return nil, p.errorf("'%s' is not a number", value)
+		}
+		return labelNumberMatcher{key: key, op: op, value: v}, nil
+	}
+	if err != nil {
+		p.pos = pos
+		return nil, p.errorf("key '%s' value '%s': %v", key, value, err)
+	}
+	if op == "!=" || op == "!~" {
+		m = Not(m)
+	}
+	return labelValueMatcher{key: key, Matcher: m}, nil
+}
+
+func (p *labelExprParser) parseKey() (string, error) {
+	key := p.parseIdent()
+	if key == "" {
+		return "", p.errorf("expected key, got '%s'", p.rest())
+	}
+	if !p.consume("[") {
+		return key, nil
+	}
+	p.skipSpaces()
+	var sub string
+	if p.peek() == '"' || p.peek() == '\'' {
+		s, err := p.parseQuoted()
+		if err != nil {
+			return "", err
+		}
+		sub = s
+	} else {
+		sub = p.parseIdent()
+	}
+	if sub == "" {
+		return "", p.errorf("empty '%s[]' key", key)
+	}
+	if !p.consume("]") {
+		return "", p.errorf("missing ']'")
+	}
+	return key + "." + sub, nil
+}
+
+func (p *labelExprParser) parseIdent() string {
+	start := p.pos
+	for !p.eof() {
+		c := rune(p.input[p.pos])
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && !strings.ContainsRune("_.-/", c) {
+			break
+		}
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *labelExprParser) parseOp() string {
+	for _, op := range []string{"==", "!=", "=~", "!~", "<=", ">=", "=", "<", ">", ":"} {
+		if strings.HasPrefix(p.rest(), op) {
+			p.pos += len(op)
+			return op
+		}
+	}
+	return ""
+}
+
+func (p *labelExprParser) parseValue() (string, error) {
+	if p.peek() == '"' || p.peek() == '\'' {
+		return p.parseQuoted()
+	}
+	start := p.pos
+	for !p.eof() && !strings.ContainsRune(" \t\r\n()&|", rune(p.input[p.pos])) {
+		p.pos++
+	}
+	if start == p.pos {
+		return "", p.errorf("expected value")
+	}
+	return p.input[start:p.pos], nil
+}
+
+func (p *labelExprParser) parseQuoted() (string, error) {
+	quote := p.input[p.pos]
+	for i := p.pos + 1; i < len(p.input); i++ {
+		switch p.input[i] {
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+		case quote:
+			s := p.input[p.pos : i+1]
+			if quote == '\'' {
+				p.pos = i + 1
+				return s[1 : len(s)-1], nil
+			}
+			v, err := strconv.Unquote(s)
+			if err != nil {
+				return "", p.errorf("bad quoted string %s: %v", s, err)
+			}
+			p.pos = i + 1
+			return v, nil
+		}
+	}
+	return "", p.errorf("unterminated quoted string")
+}
+
+func (p *labelExprParser) consume(s string) bool {
+	p.skipSpaces()
+	if !strings.HasPrefix(p.rest(), s) {
+		return false
+	}
+	p.pos += len(s)
+	return true
+}
+
+func (p *labelExprParser) skipSpaces() {
+	for !p.eof() && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *labelExprParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *labelExprParser) rest() string { return p.input[p.pos:] }
+func (p *labelExprParser) eof() bool    { return p.pos >= len(p.input) }
+
+func (p *labelExprParser) errorf(format string, a ...interface{}) error {
+	return fmt.Errorf("position %d: %s", p.pos+1, fmt.Sprintf(format, a...))
+}
@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package matcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLabelExpr(t *testing.T) {
+	tests := map[string]struct {
+		expr    string
+		wantErr bool
+	}{
+		"empty":                   {expr: ""},
+		"spaces":                  {expr: "  "},
+		"exists":                  {expr: "name"},
+		"string":                  {expr: "namespace=prod"},
+		"quoted":                  {expr: `name="a b" && name='a\b'`},
+		"regexp":                  {expr: `name=~"api-.*"`},
+		"matcher line":            {expr: `name:"glob:api-*" || name:"* *-api"`},
+		"bracket key":             {expr: `label[team]=infra && label["app.kubernetes.io/name"]!=nginx`},
+		"numeric":                 {expr: "size>=10MiB && age<1h"},
+		"nested":                  {expr: "!(a=1 || (b=2 && !c))"},
+		"missing value":           {expr: "name=", wantErr: true},
+		"missing rhs":             {expr: "a=1 &&", wantErr: true},
+		"missing paren":           {expr: "(a=1", wantErr: true},
+		"unexpected paren":        {expr: "a=1)", wantErr: true},
+		"bad regexp":              {expr: "name=~'[a'", wantErr: true},
+		"bad matcher line":        {expr: "name:'x:y'", wantErr: true},
+		"not a number":            {expr: "size>big", wantErr: true},
+		"unknown unit":            {expr: "size>10XB", wantErr: true},
+		"unterminated quote":      {expr: `name="api`, wantErr: true},
+		"empty bracket key":       {expr: `label[]=a`, wantErr: true},
+		"no key":                  {expr: "=a", wantErr: true},
+		"single ampersand":        {expr: "a=1 & b=2", wantErr: true},
+		"unterminated bracket":    {expr: "label[team=a", wantErr: true},
+		"regexp without brackets": {expr: "name=~a|b", wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			m, err := ParseLabelExpr(test.expr)
+
+			if test.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, m)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, m)
+			}
+		})
+	}
+}
+
+func TestLabelMatcher_MatchLabels(t *testing.T) {
+	labels := map[string]string{
+		"namespace":                    "prod",
+		"name":                         "api-server-1",
+		"label.team":                   "core",
+		"label.app.kubernetes.io/name": "api",
+		"size":                         "20971520",
+		"age":                          "30m",
+		"restarts":                     "3",
+		"status":                       "n/a",
+	}
+
+	tests := map[string]struct {
+		expr     string
+		expected bool
+	}{
+		"empty":                   {expr: "", expected: true},
+		"exists":                  {expr: "namespace", expected: true},
+		"not exists":              {expr: "!node", expected: true},
+		"string equal":            {expr: "namespace=prod", expected: true},
+		"string double equal":     {expr: "namespace==prod", expected: true},
+		"string not equal":        {expr: "namespace!=prod", expected: false},
+		"missing key not equal":   {expr: "node!=a", expected: true},
+		"missing key equal empty": {expr: `node=""`, expected: true},
+		"regexp":                  {expr: `name=~"^api-.*"`, expected: true},
+		"regexp not match":        {expr: `name!~"-[0-9]+$"`, expected: false},
+		"regexp alternation":      {expr: `name=~"^(web|api)-"`, expected: true},
+		"glob matcher line":       {expr: `name:"glob:api-*"`, expected: true},
+		"short matcher line":      {expr: `name:"* *-1"`, expected: true},
+		"negative matcher line":   {expr: `name:"!~ ^api"`, expected: false},
+		"simple patterns":         {expr: `name:"simple_patterns:!*-1 *"`, expected: false},
+		"bracket key":             {expr: "label[team]=core", expected: true},
+		"quoted bracket key":      {expr: `label["app.kubernetes.io/name"]=api`, expected: true},
+		"negated bracket key":     {expr: "!label[team]=infra", expected: true},
+		"size greater":            {expr: "size>10MiB", expected: true},
+		"size less or equal":      {expr: "size<=20MB", expected: false},
+		"age less":                {expr: "age<1h", expected: true},
+		"age greater or equal":    {expr: "age>=1800", expected: true},
+		"plain number":            {expr: "restarts>2 && restarts<4", expected: true},
+		"numeric on not a number": {expr: "status>0", expected: false},
+		"numeric on missing key":  {expr: "node<1", expected: false},
+		"and":                     {expr: `namespace=prod && name=~"api-.*" && !label[team]=infra`, expected: true},
+		"and false":               {expr: "namespace=prod && restarts>5", expected: false},
+		"or":                      {expr: "namespace=dev || restarts>2", expected: true},
+		"precedence":              {expr: "namespace=dev && restarts>5 || name", expected: true},
+		"parens":                  {expr: "namespace=dev && (restarts>5 || name)", expected: false},
+		"double negation":         {expr: "!!namespace", expected: true},
+		"spaces":                  {expr: "  namespace = prod  &&  ( restarts > 2 )  ", expected: true},
+		"escaped quoted value":    {expr: `status="n\x2fa" && status='n/a'`, expected: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			m, err := ParseLabelExpr(test.expr)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, m.MatchLabels(labels))
+		})
+	}
+}
+
+func Test_parseNumber(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected float64
+		wantOK   bool
+	}{
+		"integer":        {input: "10", expected: 10, wantOK: true},
+		"float":          {input: "1.5", expected: 1.5, wantOK: true},
+		"negative":       {input: "-3", expected: -3, wantOK: true},
+		"exponent":       {input: "1e3", expected: 1000, wantOK: true},
+		"bytes":          {input: "512B", expected: 512, wantOK: true},
+		"kibibytes":      {input: "2KiB", expected: 2048, wantOK: true},
+		"megabytes":      {input: "3 MB", expected: 3e6, wantOK: true},
+		"gibibytes":      {input: "1Gi", expected: 1 << 30, wantOK: true},
+		"milliseconds":   {input: "500ms", expected: 0.5, wantOK: true},
+		"minutes":        {input: "2m", expected: 120, wantOK: true},
+		"days":           {input: "7d", expected: 604800, wantOK: true},
+		"empty":          {input: ""},
+		"only unit":      {input: "MiB"},
+		"unknown unit":   {input: "10XB"},
+		"not a number":   {input: "abc1"},
+		"NaN":            {input: "NaN"},
+		"go duration":    {input: "1h30m"},
+		"trailing space": {input: "10 ", expected: 10, wantOK: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			v, ok := parseNumber(test.input)
+
+			assert.Equal(t, test.wantOK, ok)
+			if test.wantOK {
+				assert.Equal(t, test.expected, v)
+			}
+		})
+	}
+}
+
+func TestMustParseLabelExpr(t *testing.T) {
+	assert.NotPanics(t, func() { MustParseLabelExpr("a=1") })
+	assert.Panics(t, func() { MustParseLabelExpr("a=") })
+}
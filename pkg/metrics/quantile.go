@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/netdata/go.d.plugin/pkg/stm"
+
+	"github.com/beorn7/perks/quantile"
+)
+
+type (
+	// A QuantileSketch estimates quantiles (percentiles) of an event or sample stream
+	// without storing all the observations. It uses the CKMS targeted quantiles algorithm,
+	// the error of every quantile q is bounded by (1-q)/10 (e.g. p99 is accurate within 0.1% of rank).
+	//
+	// Unlike Histogram it doesn't require pre-defined buckets. Like Summary it describes the
+	// observations since the last Reset call, call Reset before every scrape loop.
+	//
+	// To create quantile sketch instances, use NewQuantileSketch.
+	QuantileSketch interface {
+		Observer
+		Reset()
+	}
+
+	quantileSketch struct {
+		quantiles []float64
+		stream    *quantile.Stream
+	}
+)
+
+var (
+	_ stm.Value = quantileSketch{}
+	_ stm.Value = OptionalQuantileSketch{}
+)
+
+// DefQuantiles are the default quantiles of a QuantileSketch.
+var DefQuantiles = []float64{0.5, 0.9, 0.95, 0.99}
+
+// NewQuantileSketch creates a new QuantileSketch.
+//
+// The function panics if any of the quantiles is not in the (0, 1) range.
+func NewQuantileSketch(quantiles []float64) QuantileSketch {
+	if len(quantiles) == 0 {
+		quantiles = DefQuantiles
+	}
+	if err := CheckQuantiles(quantiles); err != nil {
+		panic(err)
+	}
+
+	qs := append([]float64(nil), quantiles...)
+	sort.Float64s(qs)
+
+	targets := make(map[float64]float64, len(qs))
+	for _, q := range qs {
+		targets[q] = (1 - q) / 10
+	}
+
+	return &quantileSketch{
+		quantiles: qs,
+		stream:    quantile.NewTargeted(targets),
+	}
+}
+
+// CheckQuantiles returns an error if any of the quantiles is not in the (0, 1) range.
+func CheckQuantiles(quantiles []float64) error {
+	for _, q := range quantiles {
+		if q <= 0 || q >= 1 {
+			return fmt.Errorf("quantile %v is out of the (0, 1) range", q)
+		}
+	}
+	return nil
+}
+
+// QuantileName returns the quantile name used as key suffix by QuantileSketch.WriteTo:
+// 0.5 => "p50", 0.99 => "p99", 0.999 => "p99_9".
+func QuantileName(q float64) string {
+	// round to avoid the floating point artifacts: 0.57*100 => 56.99999999999999
+	s := strconv.FormatFloat(math.Round(q*1e6)/1e4, 'f', -1, 64)
+	return "p" + strings.ReplaceAll(s, ".", "_")
+}
+
+// WriteTo writes its values into given map.
+// It adds those key-value pairs:
+//
+//	${key}_count      counter, for count of it's observed values from last Reset calls
+//	${key}_p50        gauge, for 0.5 quantile of it's observed values from last Reset calls (only exists if count > 0)
+//	...
+//	${key}_pN         gauge, for every configured quantile (only exists if count > 0)
+func (s quantileSketch) WriteTo(rv map[string]int64, key string, mul, div int) {
+	count := s.stream.Count()
+	rv[key+"_count"] = int64(count)
+	for _, q := range s.quantiles {
+		name := key + "_" + QuantileName(q)
+		if count > 0 {
+			rv[name] = int64(s.stream.Query(q) * float64(mul) / float64(div))
+		} else {
+			delete(rv, name)
+		}
+	}
+}
+
+// Reset resets all of its observations.
+// Call it before every scrape loop.
+func (s *quantileSketch) Reset() {
+	s.stream.Reset()
+}
+
+// Observe observes a value
+func (s *quantileSketch) Observe(v float64) {
+	s.stream.Insert(v)
+}
+
+// OptionalQuantileSketch is a QuantileSketch that is disabled (observes and writes nothing)
+// if no quantiles are configured. Unlike QuantileSketch it writes zero quantiles if there are no observations.
+//
+// To create optional quantile sketch instances, use NewOptionalQuantileSketch.
+type OptionalQuantileSketch struct {
+	sketch    QuantileSketch
+	quantiles []float64
+}
+
+// NewOptionalQuantileSketch creates a new OptionalQuantileSketch.
+//
+// The function panics if any of the quantiles is not in the (0, 1) range.
+func NewOptionalQuantileSketch(quantiles []float64) OptionalQuantileSketch {
+	if len(quantiles) == 0 {
+		return OptionalQuantileSketch{}
+	}
+	return OptionalQuantileSketch{
+		sketch:    NewQuantileSketch(quantiles),
+		quantiles: quantiles,
+	}
+}
+
+// Enabled returns true if the quantiles are configured.
+func (q OptionalQuantileSketch) Enabled() bool {
+	return q.sketch != nil
+}
+
+// Observe observes a value
+func (q OptionalQuantileSketch) Observe(v float64) {
+	if q.Enabled() {
+		q.sketch.Observe(v)
+	}
+}
+
+// Reset resets all of its observations.
+func (q OptionalQuantileSketch) Reset() {
+	if q.Enabled() {
+		q.sketch.Reset()
+	}
+}
+
+// WriteTo writes its values into given map, see QuantileSketch.WriteTo.
+// The quantiles are written as zeros if there are no observations.
+func (q OptionalQuantileSketch) WriteTo(rv map[string]int64, key string, mul, div int) {
+	if !q.Enabled() {
+		return
+	}
+	q.sketch.WriteTo(rv, key, mul, div)
+	for _, v := range q.quantiles {
+		name := key + "_" + QuantileName(v)
+		if _, ok := rv[name]; !ok {
+			rv[name] = 0
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package metrics
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewQuantileSketch(t *testing.T) {
+	s := NewQuantileSketch(nil).(*quantileSketch)
+	assert.Equal(t, DefQuantiles, s.quantiles)
+
+	s = NewQuantileSketch([]float64{0.99, 0.5}).(*quantileSketch)
+	assert.Equal(t, []float64{0.5, 0.99}, s.quantiles)
+
+	assert.Panics(t, func() { NewQuantileSketch([]float64{0.5, 1}) })
+	assert.Panics(t, func() { NewQuantileSketch([]float64{0}) })
+}
+
+func TestQuantileName(t *testing.T) {
+	tests := map[float64]string{
+		0.5:   "p50",
+		0.9:   "p90",
+		0.99:  "p99",
+		0.999: "p99_9",
+		0.25:  "p25",
+		0.57:  "p57",
+		0.58:  "p58",
+		0.29:  "p29",
+		0.07:  "p7",
+	}
+	for q, name := range tests {
+		assert.Equal(t, name, QuantileName(q))
+	}
+}
+
+func TestQuantileSketch_WriteTo(t *testing.T) {
+	s := NewQuantileSketch([]float64{0.5, 0.9, 0.99})
+
+	m1 := map[string]int64{}
+	s.WriteTo(m1, "time", 1, 1)
+	assert.Equal(t, map[string]int64{"time_count": 0}, m1)
+
+	for i := 1; i <= 100; i++ {
+		s.Observe(float64(i))
+	}
+
+	m2 := map[string]int64{}
+	s.WriteTo(m1, "time", 10, 1)
+	s.WriteTo(m2, "time", 10, 1)
+	assert.Equal(t, m1, m2)
+	assert.Len(t, m1, 4)
+	assert.EqualValues(t, 100, m1["time_count"])
+	assert.EqualValues(t, 500, m1["time_p50"])
+	assert.EqualValues(t, 900, m1["time_p90"])
+	assert.EqualValues(t, 990, m1["time_p99"])
+
+	s.Reset()
+	s.WriteTo(m1, "time", 10, 1)
+	assert.Equal(t, map[string]int64{"time_count": 0}, m1)
+}
+
+func TestOptionalQuantileSketch_WriteTo(t *testing.T) {
+	disabled := NewOptionalQuantileSketch(nil)
+	disabled.Observe(1)
+	m := map[string]int64{}
+	disabled.WriteTo(m, "time", 1, 1)
+	assert.False(t, disabled.Enabled())
+	assert.Empty(t, m)
+
+	s := NewOptionalQuantileSketch([]float64{0.5, 0.9})
+	assert.True(t, s.Enabled())
+	s.WriteTo(m, "time", 1, 1)
+	assert.Equal(t, map[string]int64{"time_count": 0, "time_p50": 0, "time_p90": 0}, m)
+
+	for i := 1; i <= 100; i++ {
+		s.Observe(float64(i))
+	}
+	s.WriteTo(m, "time", 1, 1)
+	assert.EqualValues(t, 100, m["time_count"])
+	assert.EqualValues(t, 50, m["time_p50"])
+	assert.EqualValues(t, 90, m["time_p90"])
+
+	s.Reset()
+	s.WriteTo(m, "time", 1, 1)
+	assert.Equal(t, map[string]int64{"time_count": 0, "time_p50": 0, "time_p90": 0}, m)
+}
+
+func TestQuantileSketch_Observe_ErrorIsBounded(t *testing.T) {
+	s := NewQuantileSketch([]float64{0.5, 0.9, 0.99})
+	r := rand.New(rand.NewSource(1))
+
+	const n = 100000
+	perm := r.Perm(n)
+	for _, v := range perm {
+		s.Observe(float64(v))
+	}
+
+	m := map[string]int64{}
+	s.WriteTo(m, "v", 1, 1)
+	assert.EqualValues(t, n, m["v_count"])
+	assert.InDelta(t, 0.5*n, m["v_p50"], 0.05*n)
+	assert.InDelta(t, 0.9*n, m["v_p90"], 0.01*n)
+	assert.InDelta(t, 0.99*n, m["v_p99"], 0.001*n)
+}
+
+func BenchmarkQuantileSketch_Observe(b *testing.B) {
+	s := NewQuantileSketch(nil)
+	for i := 0; i < b.N; i++ {
+		s.Observe(float64(i % 1000))
+	}
+}
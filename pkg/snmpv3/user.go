@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package snmpv3 provides the SNMPv3 user (USM) configuration shared by the SNMP modules.
+package snmpv3
+
+import (
+	"fmt"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// User is an SNMPv3 user configuration.
+type User struct {
+	Name          string `yaml:"name"`
+	SecurityLevel string `yaml:"level"`
+	AuthProto     string `yaml:"auth_proto"`
+	AuthKey       string `yaml:"auth_key"`
+	PrivProto     string `yaml:"priv_proto"`
+	PrivKey       string `yaml:"priv_key"`
+}
+
+// ParseSecurityLevel parses the user security level, an empty level means 'noAuthNoPriv'.
+func ParseSecurityLevel(level string) (gosnmp.SnmpV3MsgFlags, error) {
+	switch level {
+	case "1", "none", "noAuthNoPriv", "":
+		return gosnmp.NoAuthNoPriv, nil
+	case "2", "authNoPriv":
+		return gosnmp.AuthNoPriv, nil
+	case "3", "authPriv":
+		return gosnmp.AuthPriv, nil
+	default:
+		return gosnmp.NoAuthNoPriv, fmt.Errorf("invalid snmpv3 user security level value (%s)", level)
+	}
+}
+
+// ParseAuthProtocol parses the user authentication protocol, an empty protocol means 'noAuth'.
+func ParseAuthProtocol(protocol string) (gosnmp.SnmpV3AuthProtocol, error) {
+	switch protocol {
+	case "1", "none", "noAuth", "":
+		return gosnmp.NoAuth, nil
+	case "2", "md5":
+		return gosnmp.MD5, nil
+	case "3", "sha":
+		return gosnmp.SHA, nil
+	case "4", "sha224":
+		return gosnmp.SHA224, nil
+	case "5", "sha256":
+		return gosnmp.SHA256, nil
+	case "6", "sha384":
+		return gosnmp.SHA384, nil
+	case "7", "sha512":
+		return gosnmp.SHA512, nil
+	default:
+		return gosnmp.NoAuth, fmt.Errorf("invalid snmpv3 user auth protocol value (%s)", protocol)
+	}
+}
+
+// ParsePrivProtocol parses the user privacy protocol, an empty protocol means 'noPriv'.
+func ParsePrivProtocol(protocol string) (gosnmp.SnmpV3PrivProtocol, error) {
+	switch protocol {
+	case "1", "none", "noPriv", "":
+		return gosnmp.NoPriv, nil
+	case "2", "des":
+		return gosnmp.DES, nil
+	case "3", "aes":
+		return gosnmp.AES, nil
+	case "4", "aes192":
+		return gosnmp.AES192, nil
+	case "5", "aes256":
+		return gosnmp.AES256, nil
+	case "6", "aes192c":
+		return gosnmp.AES192C, nil
+	case "7", "aes256c":
+		return gosnmp.AES256C, nil
+	default:
+		return gosnmp.NoPriv, fmt.Errorf("invalid snmpv3 user priv protocol value (%s)", protocol)
+	}
+}
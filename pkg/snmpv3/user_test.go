@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmpv3
+
+import (
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSecurityLevel(t *testing.T) {
+	tests := map[string]struct {
+		wantErr  bool
+		expected gosnmp.SnmpV3MsgFlags
+	}{
+		"":           {expected: gosnmp.NoAuthNoPriv},
+		"authNoPriv": {expected: gosnmp.AuthNoPriv},
+		"3":          {expected: gosnmp.AuthPriv},
+		"authpriv":   {wantErr: true, expected: gosnmp.NoAuthNoPriv},
+	}
+
+	for level, test := range tests {
+		t.Run(level, func(t *testing.T) {
+			v, err := ParseSecurityLevel(level)
+
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expected, v)
+		})
+	}
+}
+
+func TestParseAuthProtocol(t *testing.T) {
+	tests := map[string]struct {
+		wantErr  bool
+		expected gosnmp.SnmpV3AuthProtocol
+	}{
+		"":        {expected: gosnmp.NoAuth},
+		"md5":     {expected: gosnmp.MD5},
+		"7":       {expected: gosnmp.SHA512},
+		"sha1024": {wantErr: true, expected: gosnmp.NoAuth},
+	}
+
+	for protocol, test := range tests {
+		t.Run(protocol, func(t *testing.T) {
+			v, err := ParseAuthProtocol(protocol)
+
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expected, v)
+		})
+	}
+}
+
+func TestParsePrivProtocol(t *testing.T) {
+	tests := map[string]struct {
+		wantErr  bool
+		expected gosnmp.SnmpV3PrivProtocol
+	}{
+		"":       {expected: gosnmp.NoPriv},
+		"aes":    {expected: gosnmp.AES},
+		"7":      {expected: gosnmp.AES256C},
+		"aes512": {wantErr: true, expected: gosnmp.NoPriv},
+	}
+
+	for protocol, test := range tests {
+		t.Run(protocol, func(t *testing.T) {
+			v, err := ParsePrivProtocol(protocol)
+
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expected, v)
+		})
+	}
+}